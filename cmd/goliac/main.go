@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Alayacare/goliac/internal"
 	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/engine"
+	"github.com/Alayacare/goliac/internal/entity"
 	"github.com/Alayacare/goliac/internal/notification"
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/schollz/progressbar/v3"
@@ -20,6 +23,10 @@ var dryrunParameter bool
 var forceParameter bool
 var repositoryParameter string
 var branchParameter string
+var refParameter string
+var sinceParameter string
+var baseParameter string
+var diffParameter string
 var noProgressbar bool
 var goliacAdminTeamnameParameter string
 var usersOnly bool
@@ -56,6 +63,14 @@ func (p *ProgressBar) LoadingAsset(entity string, nb int) {
 	p.bar.Add(nb)
 }
 
+// writePlanDiff writes the plan to a file, as a unified-diff-like text grouped by entity
+func writePlanDiff(filename string, operations []config.GoliacOperation) {
+	diff := engine.RenderDriftDiff(engine.GroupDrift(operations))
+	if err := os.WriteFile(filename, []byte(diff), 0644); err != nil {
+		logrus.Fatalf("Failed to write the plan to %s: %v", filename, err)
+	}
+}
+
 func main() {
 	verifyCmd := &cobra.Command{
 		Use:   "verify <path>",
@@ -76,12 +91,16 @@ func main() {
 	}
 
 	planCmd := &cobra.Command{
-		Use:   "plan [--repository https_team_repository_url] [--branch branch]",
+		Use:   "plan [--repository https_team_repository_url] [--branch branch] [--ref git_ref [--base git_ref]] [--since git_ref] [--diff file]",
 		Short: "Check the validity of IAC directory structure against a Github organization",
 		Long: `Check the validity of IAC directory structure against a Github organization.
 repository: a remote repository in the form https://github.com/...
 repository can be passed by parameter or by defining GOLIAC_SERVER_GIT_REPOSITORY env variable
-branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env variable`,
+branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env variable
+ref: plan the teams repository at a git ref (a branch, a tag or a commit sha), like a PR branch, instead of the branch
+base: with ref, only print the operations the ref adds to (+) or removes from (-) the plan of the base git ref
+since: only plan the teams and repositories changed since a git ref, like 'goliac' (the tag of the last applied commit)
+diff: also write the plan to a file, as a unified-diff-like text grouped by entity (for a code review)`,
 		Run: func(cmd *cobra.Command, args []string) {
 			repo := repositoryParameter
 			branch := branchParameter
@@ -113,24 +132,80 @@ branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env va
 
 			ctx := context.Background()
 			fs := osfs.New("/")
-			err, _, _, _ = goliac.Apply(ctx, fs, true, repo, branch)
+			if refParameter != "" && baseParameter != "" {
+				if diffParameter != "" {
+					logrus.Fatalf("--diff is not supported with --base")
+				}
+				err, errs, _, comparison := goliac.ComparePlans(ctx, fs, repo, baseParameter, refParameter)
+				for _, e := range errs {
+					logrus.Error(e)
+				}
+				if err != nil {
+					logrus.Fatalf("Failed to compare the plans of %s and %s: %v", baseParameter, refParameter, err)
+				}
+				for _, o := range comparison.Added {
+					fmt.Printf("+ %s: %s\n", o.Command, o.Detail)
+				}
+				for _, o := range comparison.Removed {
+					fmt.Printf("- %s: %s\n", o.Command, o.Detail)
+				}
+				return
+			}
+			if baseParameter != "" {
+				logrus.Fatalf("--base requires --ref")
+			}
+			if refParameter != "" {
+				err, errs, _, operations := goliac.PlanFromRef(ctx, fs, repo, refParameter)
+				for _, e := range errs {
+					logrus.Error(e)
+				}
+				if err != nil {
+					logrus.Fatalf("Failed to plan %s: %v", refParameter, err)
+				}
+				for _, o := range operations {
+					if o.Skipped {
+						fmt.Printf("%s: %s (skipped)\n", o.Command, o.Detail)
+					} else if o.Simulated {
+						fmt.Printf("%s: %s (simulated)\n", o.Command, o.Detail)
+					} else {
+						fmt.Printf("%s: %s\n", o.Command, o.Detail)
+					}
+				}
+				if diffParameter != "" {
+					writePlanDiff(diffParameter, operations)
+				}
+				return
+			}
+			// the refused operations (like a guarded ruleset enforcement) are also part of the plan
+			changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+			ctx = context.WithValue(ctx, config.ContextKeyChanges, &changes)
+			err, _, _, _ = goliac.ApplySince(ctx, fs, true, repo, branch, sinceParameter)
 			if err != nil {
 				logrus.Errorf("Failed to plan: %v", err)
+				return
+			}
+			if diffParameter != "" {
+				writePlanDiff(diffParameter, changes.Operations)
 			}
 		},
 	}
 
 	planCmd.Flags().StringVarP(&repositoryParameter, "repository", "r", config.Config.ServerGitRepository, "repository (default env variable GOLIAC_SERVER_GIT_REPOSITORY)")
 	planCmd.Flags().StringVarP(&branchParameter, "branch", "b", config.Config.ServerGitBranch, "branch (default env variable GOLIAC_SERVER_GIT_BRANCH)")
+	planCmd.Flags().StringVarP(&refParameter, "ref", "", "", "git ref (branch, tag or commit sha) to plan, instead of the branch")
+	planCmd.Flags().StringVarP(&baseParameter, "base", "", "", "git ref (branch, tag or commit sha): only print the difference between the plans of the base and of the ref")
+	planCmd.Flags().StringVarP(&sinceParameter, "since", "", "", "git ref (tag or commit sha): only plan the teams and repositories changed since it (incremental mode)")
+	planCmd.Flags().StringVarP(&diffParameter, "diff", "", "", "file to write the plan to, as a unified-diff-like text grouped by entity")
 	planCmd.Flags().BoolVarP(&noProgressbar, "noprogressbar", "p", false, "display a progress bar")
 
 	applyCmd := &cobra.Command{
-		Use:   "apply [--repository https_team_repository_url] [--branch branch]",
+		Use:   "apply [--repository https_team_repository_url] [--branch branch] [--since git_ref]",
 		Short: "Verify and apply a IAC directory structure to a Github organization",
 		Long: `Apply a IAC directory structure to a Github organization.
 repository: a remote repository in the form https://github.com/...
 repository can be passed by parameter or by defining GOLIAC_SERVER_GIT_REPOSITORY env variable
-branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env variable`,
+branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env variable
+since: only apply the teams and repositories changed since a git ref, like 'goliac' (the tag of the last applied commit)`,
 		Run: func(cmd *cobra.Command, args []string) {
 			repo := repositoryParameter
 			branch := branchParameter
@@ -160,7 +235,7 @@ branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env va
 
 			ctx := context.Background()
 			fs := osfs.New("/")
-			err, _, _, _ = goliac.Apply(ctx, fs, false, repo, branch)
+			err, _, _, _ = goliac.ApplySince(ctx, fs, false, repo, branch, sinceParameter)
 			if err != nil {
 				logrus.Errorf("Failed to apply: %v", err)
 			}
@@ -169,6 +244,7 @@ branch can be passed by parameter or by defining GOLIAC_SERVER_GIT_BRANCH env va
 	applyCmd.Flags().StringVarP(&repositoryParameter, "repository", "r", config.Config.ServerGitRepository, "repository (default env variable GOLIAC_SERVER_GIT_REPOSITORY)")
 	applyCmd.Flags().StringVarP(&branchParameter, "branch", "b", config.Config.ServerGitBranch, "branch (default env variable GOLIAC_SERVER_GIT_BRANCH)")
 	applyCmd.Flags().BoolVarP(&noProgressbar, "noprogressbar", "p", false, "display a progress bar")
+	applyCmd.Flags().StringVarP(&sinceParameter, "since", "", "", "git ref (tag or commit sha): only apply the teams and repositories changed since it (incremental mode)")
 
 	postSyncUsersCmd := &cobra.Command{
 		Use:   "syncusers [--repository https_team_repository_url] [--branch branch] [--dryrun] [--force]",
@@ -295,6 +371,28 @@ any changes from the teams Git repository to Github.`,
 		},
 	}
 
+	schemacmd := &cobra.Command{
+		Use:   "schema <directory>",
+		Short: "Write the JSON schemas of the teams repository files",
+		Long: `Write the JSON schema of each kind of file of the teams repository
+(User, Team, Repository, Ruleset) in the directory, as <kind>.schema.json,
+to be used by editors or CI to validate the files`,
+		Args: cobra.MatchAll(cobra.MinimumNArgs(1), cobra.OnlyValidArgs),
+		Run: func(cmd *cobra.Command, args []string) {
+			directory := args[0]
+			for _, kind := range entity.SchemaKinds() {
+				schema, err := entity.JSONSchema(kind)
+				if err != nil {
+					logrus.Fatalf("failed to generate the %s schema: %s", kind, err)
+				}
+				filename := filepath.Join(directory, strings.ToLower(kind)+".schema.json")
+				if err := os.WriteFile(filename, schema, 0644); err != nil {
+					logrus.Fatalf("failed to write %s: %s", filename, err)
+				}
+			}
+		},
+	}
+
 	versioncmd := &cobra.Command{
 		Use:   "version",
 		Short: "Return the version of the goliac CLI",
@@ -317,6 +415,7 @@ Either local directory, or remote git repository`,
 	rootCmd.AddCommand(postSyncUsersCmd)
 	rootCmd.AddCommand(scaffoldcmd)
 	rootCmd.AddCommand(servecmd)
+	rootCmd.AddCommand(schemacmd)
 	rootCmd.AddCommand(versioncmd)
 
 	// if the team app is not set, use the app github app settings
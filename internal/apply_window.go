@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var applyWindowDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ApplyWindow is a range of hours (end excluded, in minutes since midnight) on some days of the week
+type ApplyWindow struct {
+	days  [7]bool
+	start int
+	end   int
+}
+
+/*
+ApplyWindows are the windows during which Goliac is allowed to apply the
+changes to Github (the plan can be computed at any time)
+*/
+type ApplyWindows struct {
+	windows  []ApplyWindow
+	location *time.Location
+}
+
+/*
+ParseApplyWindows parses the apply windows, like "mon-fri 09:00-17:00", in
+the given timezone (like "America/Toronto"). It returns nil if there is no
+window (the changes can be applied at any time)
+*/
+func ParseApplyWindows(windows []string, timezone string) (*ApplyWindows, error) {
+	applyWindows := &ApplyWindows{}
+	for _, w := range windows {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		window, err := parseApplyWindow(w)
+		if err != nil {
+			return nil, err
+		}
+		applyWindows.windows = append(applyWindows.windows, window)
+	}
+	if len(applyWindows.windows) == 0 {
+		return nil, nil
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apply window timezone %s: %v", timezone, err)
+	}
+	applyWindows.location = location
+	return applyWindows, nil
+}
+
+// parseApplyWindow parses a "<day>[-<day>] HH:MM-HH:MM" (or "* HH:MM-HH:MM" for every day) window
+func parseApplyWindow(w string) (ApplyWindow, error) {
+	window := ApplyWindow{}
+	fields := strings.Fields(w)
+	if len(fields) != 2 {
+		return window, fmt.Errorf("invalid apply window %s: expected <days> <HH:MM-HH:MM>, like mon-fri 09:00-17:00", w)
+	}
+
+	days := strings.ToLower(fields[0])
+	if days == "*" {
+		for i := range window.days {
+			window.days[i] = true
+		}
+	} else {
+		first, last, isRange := strings.Cut(days, "-")
+		if !isRange {
+			last = first
+		}
+		firstDay, ok := applyWindowDays[first]
+		if !ok {
+			return window, fmt.Errorf("invalid apply window %s: unknown day %s", w, first)
+		}
+		lastDay, ok := applyWindowDays[last]
+		if !ok {
+			return window, fmt.Errorf("invalid apply window %s: unknown day %s", w, last)
+		}
+		// a range can wrap around the end of the week (like fri-mon)
+		for day := firstDay; ; day = (day + 1) % 7 {
+			window.days[day] = true
+			if day == lastDay {
+				break
+			}
+		}
+	}
+
+	start, end, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return window, fmt.Errorf("invalid apply window %s: expected a HH:MM-HH:MM hours range", w)
+	}
+	var err error
+	if window.start, err = parseApplyWindowTime(start); err != nil {
+		return window, fmt.Errorf("invalid apply window %s: %v", w, err)
+	}
+	if window.end, err = parseApplyWindowTime(end); err != nil {
+		return window, fmt.Errorf("invalid apply window %s: %v", w, err)
+	}
+	if window.end <= window.start {
+		return window, fmt.Errorf("invalid apply window %s: the end must be after the start (split a window crossing midnight in 2 windows)", w)
+	}
+	return window, nil
+}
+
+// parseApplyWindowTime parses a HH:MM time (24:00 is the end of the day) into minutes since midnight
+func parseApplyWindowTime(t string) (int, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(t, "%d:%d", &hours, &minutes); err != nil || len(t) != 5 {
+		return 0, fmt.Errorf("invalid time %s (expected HH:MM)", t)
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > 24*60 {
+		return 0, fmt.Errorf("invalid time %s (expected HH:MM)", t)
+	}
+	return hours*60 + minutes, nil
+}
+
+/*
+Contains tells if the changes can be applied at t
+*/
+func (a *ApplyWindows) Contains(t time.Time) bool {
+	t = t.In(a.location)
+	minutes := t.Hour()*60 + t.Minute()
+	for _, w := range a.windows {
+		if w.days[t.Weekday()] && minutes >= w.start && minutes < w.end {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Next returns the start of the next window after t
+*/
+func (a *ApplyWindows) Next(t time.Time) time.Time {
+	t = t.In(a.location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, a.location)
+	var next time.Time
+	for i := 0; i <= 7; i++ {
+		day := midnight.AddDate(0, 0, i)
+		for _, w := range a.windows {
+			if !w.days[day.Weekday()] {
+				continue
+			}
+			start := day.Add(time.Duration(w.start) * time.Minute)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyWindow(t *testing.T) {
+	// 2024-01-05 is a friday
+	toronto, err := time.LoadLocation("America/Toronto")
+	assert.Nil(t, err)
+
+	t.Run("happy path: no window", func(t *testing.T) {
+		applyWindows, err := ParseApplyWindows([]string{}, "UTC")
+		assert.Nil(t, err)
+		assert.Nil(t, applyWindows)
+	})
+
+	t.Run("happy path: business hours", func(t *testing.T) {
+		applyWindows, err := ParseApplyWindows([]string{"mon-fri 09:00-17:00"}, "America/Toronto")
+		assert.Nil(t, err)
+
+		assert.True(t, applyWindows.Contains(time.Date(2024, 1, 5, 9, 0, 0, 0, toronto)))
+		assert.True(t, applyWindows.Contains(time.Date(2024, 1, 5, 16, 59, 0, 0, toronto)))
+		assert.False(t, applyWindows.Contains(time.Date(2024, 1, 5, 17, 0, 0, 0, toronto)))
+		assert.False(t, applyWindows.Contains(time.Date(2024, 1, 6, 10, 0, 0, 0, toronto)))
+		// the timezone of the window is used (14:30 UTC is 09:30 in Toronto)
+		assert.True(t, applyWindows.Contains(time.Date(2024, 1, 5, 14, 30, 0, 0, time.UTC)))
+		assert.False(t, applyWindows.Contains(time.Date(2024, 1, 5, 13, 30, 0, 0, time.UTC)))
+
+		// the friday evening, the next window is the monday morning
+		assert.Equal(t, time.Date(2024, 1, 8, 9, 0, 0, 0, toronto), applyWindows.Next(time.Date(2024, 1, 5, 18, 0, 0, 0, toronto)))
+		// the morning, it is the same day
+		assert.Equal(t, time.Date(2024, 1, 5, 9, 0, 0, 0, toronto), applyWindows.Next(time.Date(2024, 1, 5, 7, 0, 0, 0, toronto)))
+	})
+
+	t.Run("happy path: several windows, wrapping around the week", func(t *testing.T) {
+		applyWindows, err := ParseApplyWindows([]string{"sat-sun 10:00-12:00", "* 22:00-24:00"}, "")
+		assert.Nil(t, err)
+
+		assert.True(t, applyWindows.Contains(time.Date(2024, 1, 7, 11, 0, 0, 0, time.UTC)))
+		assert.False(t, applyWindows.Contains(time.Date(2024, 1, 8, 11, 0, 0, 0, time.UTC)))
+		assert.True(t, applyWindows.Contains(time.Date(2024, 1, 8, 23, 59, 0, 0, time.UTC)))
+		assert.Equal(t, time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC), applyWindows.Next(time.Date(2024, 1, 6, 1, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("not happy path: invalid windows", func(t *testing.T) {
+		for _, w := range []string{
+			"mon-fri",
+			"monday 09:00-17:00",
+			"mon-fri 9:00-17:00",
+			"mon-fri 09:00-25:00",
+			"mon-fri 17:00-09:00",
+			"mon-fri 09:00",
+		} {
+			_, err := ParseApplyWindows([]string{w}, "UTC")
+			assert.NotNil(t, err, w)
+		}
+
+		_, err := ParseApplyWindows([]string{"mon-fri 09:00-17:00"}, "Mars/Olympus")
+		assert.NotNil(t, err)
+	})
+}
@@ -1,13 +1,87 @@
 package config
 
+import (
+	"context"
+	"time"
+)
+
 type contextKey string
 
 const (
 	// ContextKeyConfig is the key used to store the configuration in the context.
 	ContextKeyStatistics contextKey = "githubStatistics"
+	// ContextKeyChanges is the key used to collect the operations applied during a reconciliation
+	ContextKeyChanges contextKey = "changes"
+	// KeyAuthor is the key used to store the author of the changes being applied
+	KeyAuthor contextKey = "author"
+	// KeyReason is the key used to store the reason given (in the commit message) for the changes being applied
+	KeyReason contextKey = "reason"
+	// KeyOrganization is the key used to store the Github organization being reconciled
+	KeyOrganization contextKey = "organization"
 )
 
 type GoliacStatistics struct {
 	GithubApiCalls  int
 	GithubThrottled int
 }
+
+type GoliacOperation struct {
+	Command string `json:"command"`
+	Detail  string `json:"detail"`
+	// Destructive is set for the operations deleting a team or a repository
+	Destructive bool   `json:"destructive,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	// Changes lists the field-level differences (only for some operations, like the rulesets update)
+	Changes []string `json:"changes,omitempty"`
+	// Organization is the Github organization the operation applies to
+	Organization string `json:"organization,omitempty"`
+	// Simulated is set for the operations of a category only simulated (dryrun_operations in goliac.yaml)
+	Simulated bool `json:"simulated,omitempty"`
+	// Skipped is set for the operations Goliac refused to apply (only reported if ReportSkipped is set)
+	Skipped bool `json:"skipped,omitempty"`
+	// Timestamp is when the operation was recorded
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type GoliacChanges struct {
+	Author     string
+	Operations []GoliacOperation
+	Dryrun     bool // the operations were only planned (observe mode), not applied
+	// the warnings raised by the reconciliation (like a visibility flip-flop)
+	Warnings []string
+	// the skipped operations are also reported (like in the plan of a git ref)
+	ReportSkipped bool
+}
+
+/*
+GetAuthor returns the author of the changes (stored in the context)
+or "unknown" if not set
+*/
+func GetAuthor(ctx context.Context) string {
+	if author, ok := ctx.Value(KeyAuthor).(string); ok && author != "" {
+		return author
+	}
+	return "unknown"
+}
+
+/*
+GetReason returns the reason given for the changes (stored in the context)
+or an empty string if not set
+*/
+func GetReason(ctx context.Context) string {
+	if reason, ok := ctx.Value(KeyReason).(string); ok {
+		return reason
+	}
+	return ""
+}
+
+/*
+GetOrganization returns the Github organization being reconciled (stored in the context)
+or the main organization (GOLIAC_GITHUB_APP_ORGANIZATION) if not set
+*/
+func GetOrganization(ctx context.Context) string {
+	if organization, ok := ctx.Value(KeyOrganization).(string); ok && organization != "" {
+		return organization
+	}
+	return Config.GithubAppOrganization
+}
@@ -17,6 +17,17 @@ var Config = struct {
 	GithubTeamAppPrivateKeyFile string `env:"GOLIAC_GITHUB_TEAM_APP_PRIVATE_KEY_FILE"`
 	GoliacEmail                 string `env:"GOLIAC_EMAIL" envDefault:"goliac@alayacare.com"`
 	GoliacTeamOwnerSuffix       string `env:"GOLIAC_TEAM_OWNER_SUFFIX" envDefault:"-goliac-owners"`
+	// applied to the Github name of the Goliac teams (and of their owners teams)
+	// only the Github teams matching them are managed by Goliac
+	GoliacTeamNamePrefix string `env:"GOLIAC_TEAM_NAME_PREFIX" envDefault:""`
+	GoliacTeamNameSuffix string `env:"GOLIAC_TEAM_NAME_SUFFIX" envDefault:""`
+
+	// additional organizations reconciled with the same teams repository (the Github app must be installed on each)
+	GithubAppOrganizations []string `env:"GOLIAC_GITHUB_APP_ORGANIZATIONS" envDefault:"" envSeparator:","`
+
+	// authentication to Github: "app" (the Github App, recommended) or "pat" (a personal access token)
+	GithubAuth                string `env:"GOLIAC_GITHUB_AUTH" envDefault:"app"`
+	GithubPersonalAccessToken string `env:"GOLIAC_GITHUB_PERSONAL_ACCESS_TOKEN" envDefault:""`
 
 	GithubConcurrentThreads int64 `env:"GOLIAC_GITHUB_CONCURRENT_THREADS" envDefault:"5"`
 	GithubCacheTTL          int64 `env:"GOLIAC_GITHUB_CACHE_TTL" envDefault:"86400"`
@@ -24,6 +35,32 @@ var Config = struct {
 	ServerApplyInterval int64  `env:"GOLIAC_SERVER_APPLY_INTERVAL" envDefault:"600"`
 	ServerGitRepository string `env:"GOLIAC_SERVER_GIT_REPOSITORY" envDefault:""`
 	ServerGitBranch     string `env:"GOLIAC_SERVER_GIT_BRANCH" envDefault:"main"`
+	// number of past runs (with changes) kept in memory for the /changes endpoint
+	ServerChangesHistory int `env:"GOLIAC_SERVER_CHANGES_HISTORY" envDefault:"10"`
+	// optional jsonl file where each run with changes is appended (for the /history endpoint)
+	ServerHistoryFile string `env:"GOLIAC_SERVER_HISTORY_FILE" envDefault:""`
+	// optional hooks POSTed to before and after each apply
+	ServerPreApplyHookURL  string `env:"GOLIAC_SERVER_PRE_APPLY_HOOK_URL" envDefault:""`
+	ServerPostApplyHookURL string `env:"GOLIAC_SERVER_POST_APPLY_HOOK_URL" envDefault:""`
+	// if the pre-apply hook fails (or doesn't answer a 2xx), the apply is aborted
+	ServerPreApplyHookVeto bool  `env:"GOLIAC_SERVER_PRE_APPLY_HOOK_VETO" envDefault:"true"`
+	ServerApplyHookTimeout int64 `env:"GOLIAC_SERVER_APPLY_HOOK_TIMEOUT" envDefault:"30"`
+	// start the server with the reconciliation paused (until /resume is called)
+	ServerPaused bool `env:"GOLIAC_SERVER_PAUSED" envDefault:"false"`
+	// how long (in seconds) to wait for the in-flight apply when stopping the server
+	ServerShutdownTimeout int64 `env:"GOLIAC_SERVER_SHUTDOWN_TIMEOUT" envDefault:"300"`
+	// how long (in seconds) the /apply endpoint waits for the apply to finish
+	ServerSyncApplyTimeout int64 `env:"GOLIAC_SERVER_SYNC_APPLY_TIMEOUT" envDefault:"600"`
+	// number of consecutive failed applies before stopping the automatic applies (0 to disable)
+	ServerCircuitBreakerThreshold int64 `env:"GOLIAC_SERVER_CIRCUIT_BREAKER_THRESHOLD" envDefault:"5"`
+	// how long (in seconds) the same sync error is not notified again (0 to notify each failed sync)
+	ServerErrorNotificationCooldown int64 `env:"GOLIAC_SERVER_ERROR_NOTIFICATION_COOLDOWN" envDefault:"3600"`
+	// only report the drift (plan) without ever applying it to Github
+	ServerObserveOnly bool `env:"GOLIAC_SERVER_OBSERVE_ONLY" envDefault:"false"`
+	// windows (like "mon-fri 09:00-17:00") outside which the changes are only planned
+	// (and notified), and applied at the next window (empty: always applied)
+	ServerApplyWindows         []string `env:"GOLIAC_SERVER_APPLY_WINDOWS" envDefault:"" envSeparator:","`
+	ServerApplyWindowsTimezone string   `env:"GOLIAC_SERVER_APPLY_WINDOWS_TIMEZONE" envDefault:"UTC"`
 	// the name of the CI validating each PR on the teams repsotiry. See scaffold.go for the Github action
 	ServerGitBranchProtectionRequiredCheck string `env:"GOLIAC_SERVER_PR_REQUIRED_CHECK" envDefault:"validate"`
 
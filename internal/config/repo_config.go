@@ -1,32 +1,268 @@
 package config
 
 import (
+	"strings"
+
 	"gopkg.in/yaml.v3"
 )
 
 type RepositoryConfig struct {
 	AdminTeam           string `yaml:"admin_team"`
 	EveryoneTeamEnabled bool   `yaml:"everyone_team_enabled"`
+	// notifications_disabled (default) prevents to mass-mention the "everyone" team (empty: left untouched)
+	EveryoneTeamNotificationSetting string `yaml:"everyone_team_notification_setting"`
 
 	Rulesets []struct {
+		Pattern  string
+		Ruleset  string
+		Priority int    // optional: rulesets are created by ascending priority
+		Topic    string // optional: only the repositories (matching the pattern) carrying this Github topic
+	}
+	// label sets applied to the repositories matching the pattern
+	// (a label defined on a repository overrides the one of the same name)
+	Labels []struct {
 		Pattern string
-		Ruleset string
+		Labels  []Label
 	}
-	MaxChangesets           int `yaml:"max_changesets"`
-	GithubConcurrentThreads int `yaml:"github_concurrent_threads"`
-	UserSync                struct {
+	MaxChangesets int `yaml:"max_changesets"`
+	// maximum number of destructive operations (deletions/removals) applied in one run (0: no limit)
+	MaxDestructiveOperations int `yaml:"max_destructive_operations"`
+	GithubConcurrentThreads  int `yaml:"github_concurrent_threads"`
+	UserSync                 struct {
 		Plugin string `yaml:"plugin"`
 		Path   string `yaml:"path"`
 	}
-	ArchiveOnDelete       bool `yaml:"archive_on_delete"`
+	ArchiveOnDelete          bool     `yaml:"archive_on_delete"`
+	RulesetsEnforcementGuard bool     `yaml:"rulesets_enforcement_guard"`
+	AllowVisibilityReduction bool     `yaml:"allow_visibility_reduction"`
+	RequiredFiles            []string `yaml:"required_files"`
+
+	// number of days a repository archived on delete is kept before being deleted (0: never deleted)
+	RepositoryDeletionGracePeriod int `yaml:"repository_deletion_grace_period"`
+
+	// number of days a pending organization invitation is kept before being cancelled (0: never cancelled)
+	PendingInvitationsMaxAge int `yaml:"pending_invitations_max_age"`
+
+	// permission given to the "<team>-goliac-owners" teams on the teams repository
+	TeamsRepositoryOwnersPermission string `yaml:"teams_repository_owners_permission"`
+
+	// hardened ruleset Goliac attaches to the teams repository itself (opt-in)
+	TeamsRepositoryProtection struct {
+		Enabled                      bool     `yaml:"enabled"`
+		RequiredApprovingReviewCount int      `yaml:"required_approving_review_count"`
+		RequiredStatusChecks         []string `yaml:"required_status_checks"`
+		// Github Apps (slugs) allowed to bypass it, on top of the Goliac ones
+		BypassApps []string `yaml:"bypass_apps"`
+	} `yaml:"teams_repository_protection"`
+
+	// regular expressions the (whole) repositories and teams names must match (empty disables the check)
+	RepositoryNamePattern string `yaml:"repository_name_pattern"`
+	TeamNamePattern       string `yaml:"team_name_pattern"`
+
+	// remote teams and repositories (glob patterns, like "legacy-*") Goliac never touches
+	ExcludedTeams        []string `yaml:"excluded_teams"`
+	ExcludedRepositories []string `yaml:"excluded_repositories"`
+
+	// minimum number of owners per team (0 disables the check)
+	TeamMinimumOwners struct {
+		Count       int    `yaml:"count"`
+		Enforcement string `yaml:"enforcement"` // warn or fail
+	} `yaml:"team_minimum_owners"`
+
+	// org-wide defaults merged into the spec of every repository owned by a team
+	// (teams/_defaults.yaml and the repository definition win over them)
+	RepositoryDefaults map[string]interface{} `yaml:"repository_defaults"`
+
+	// org-wide merge strategies seeding the spec of every repository owned by a team
+	// (unset values are not managed). A repository deviating from them must set
+	// merge_strategy_override
+	DefaultMergeStrategy struct {
+		AllowSquashMerge *bool `yaml:"allow_squash_merge"`
+		AllowMergeCommit *bool `yaml:"allow_merge_commit"`
+		AllowRebaseMerge *bool `yaml:"allow_rebase_merge"`
+	} `yaml:"default_merge_strategy"`
+
+	// organization policies enforced by Goliac (unset values are not managed)
+	OrganizationPolicies OrganizationPolicies `yaml:"organization_policies"`
+
+	// organization profile fields managed by Goliac (unset fields are not managed)
+	OrgProfile OrgProfile `yaml:"org_profile"`
+
+	// teams required on the repositories carrying a Github topic (or a custom property value),
+	// added to them unless the repository definition explicitly gives them a weaker permission
+	RequiredTeams []RequiredTeams `yaml:"required_teams"`
+
+	// organization webhooks managed by Goliac (identified by their url)
+	OrgWebhooks []OrgWebhook `yaml:"org_webhooks"`
+
+	// teams (by name) having the security manager role in the organization
+	// (not managed if unset)
+	SecurityManagerTeams []string `yaml:"security_manager_teams"`
+
+	// org-level settings of the additional Github organizations (GOLIAC_GITHUB_APP_ORGANIZATIONS),
+	// by organization: organization_policies, org_profile, org_webhooks and
+	// security_manager_teams (above) only apply to the main organization
+	Organizations map[string]OrganizationSettings `yaml:"organizations"`
+
+	// categories of operations only simulated (logged, but not sent to Github)
+	// while the other ones are applied
+	DryrunOperations struct {
+		DryrunOrganization bool `yaml:"organization"` // organization settings, actions settings, webhooks and security managers
+		DryrunUsers        bool `yaml:"users"`        // organization members and outside collaborators
+		DryrunTeams        bool `yaml:"teams"`
+		DryrunRepositories bool `yaml:"repositories"` // repositories, their accesses, CODEOWNERS, labels and Dependabot settings
+		DryrunRulesets     bool `yaml:"rulesets"`
+	} `yaml:"dryrun_operations"`
+
 	DestructiveOperations struct {
-		AllowDestructiveRepositories bool `yaml:"repositories"`
-		AllowDestructiveTeams        bool `yaml:"teams"`
-		AllowDestructiveUsers        bool `yaml:"users"`
-		AllowDestructiveRulesets     bool `yaml:"rulesets"`
+		AllowDestructiveRepositories         bool `yaml:"repositories"`
+		AllowDestructiveTeams                bool `yaml:"teams"`
+		AllowDestructiveUsers                bool `yaml:"users"`
+		AllowDestructiveOutsideCollaborators bool `yaml:"outside_collaborators"`
+		AllowDestructiveRulesets             bool `yaml:"rulesets"`
+		AllowDestructiveRulesetsEnforcement  bool `yaml:"rulesets_enforcement"`
+		AllowDestructiveLabels               bool `yaml:"labels"`
+		AllowDestructiveOrgWebhooks          bool `yaml:"org_webhooks"`
+		AllowDestructiveSecurityManagers     bool `yaml:"security_manager_teams"`
 	} `yaml:"destructive_operations"`
 }
 
+type OrganizationPolicies struct {
+	MembersCanCreatePublicRepos   *bool `yaml:"members_can_create_public_repositories"`
+	MembersCanCreatePrivateRepos  *bool `yaml:"members_can_create_private_repositories"`
+	MembersCanCreateInternalRepos *bool `yaml:"members_can_create_internal_repositories"` // Github Enterprise only
+	// default branch name of the repositories created (outside of Goliac) in the organization
+	DefaultBranchName string `yaml:"default_branch_name"`
+
+	// Github Actions permissions: they apply to every repository, so they are only
+	// managed if ManageActionsPermissions is set
+	ManageActionsPermissions        bool   `yaml:"manage_actions_permissions"`
+	DefaultWorkflowTokenPermissions string `yaml:"default_workflow_token_permissions"` // read or write
+	RequireApprovalForForkPRs       *bool  `yaml:"require_approval_for_fork_prs"`      // private repositories fork PR workflows
+
+	// Github Actions allowed in the organization: when manage_allowed_actions is set, the
+	// organization only allows the selected actions (the allowed_actions allowlist)
+	ManageAllowedActions bool `yaml:"manage_allowed_actions"`
+	AllowedActions       struct {
+		GithubOwnedAllowed bool     `yaml:"github_owned_allowed"`
+		VerifiedAllowed    bool     `yaml:"verified_allowed"` // actions of the Marketplace verified creators
+		PatternsAllowed    []string `yaml:"patterns_allowed"` // like "monalisa/octocat@v2" or "monalisa/*"
+	} `yaml:"allowed_actions"`
+}
+
+type OrgProfile struct {
+	BillingEmail    *string `yaml:"billing_email"`
+	Company         *string `yaml:"company"`
+	Description     *string `yaml:"description"`
+	Blog            *string `yaml:"blog"` // url
+	TwitterUsername *string `yaml:"twitter_username"`
+	// an empty field clears the Github value only if allow_clearing is set (else it is skipped)
+	AllowClearing bool `yaml:"allow_clearing"`
+}
+
+// OrganizationSettings are the org-level settings of an additional Github organization
+type OrganizationSettings struct {
+	OrganizationPolicies OrganizationPolicies `yaml:"organization_policies"`
+	OrgProfile           OrgProfile           `yaml:"org_profile"`
+	OrgWebhooks          []OrgWebhook         `yaml:"org_webhooks"`
+	SecurityManagerTeams []string             `yaml:"security_manager_teams"`
+}
+
+type Label struct {
+	Name        string `yaml:"name"`
+	Color       string `yaml:"color"` // hexadecimal color, like d73a4a (the leading # is optional)
+	Description string `yaml:"description,omitempty"`
+}
+
+type OrgWebhook struct {
+	Url         string   `yaml:"url"`
+	Events      []string `yaml:"events"`       // default: push
+	Active      *bool    `yaml:"active"`       // default: true
+	ContentType string   `yaml:"content_type"` // json (default) or form
+	// name of the environment variable holding the webhook secret
+	// (the secret itself must never be stored in the teams repository)
+	SecretEnv string `yaml:"secret_env"`
+}
+
+type RequiredTeams struct {
+	Topic          string `yaml:"topic"` // Github topic (not managed by Goliac)
+	CustomProperty struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"custom_property"` // custom property (of the repository definition)
+	Readers []string `yaml:"readers"`
+	Writers []string `yaml:"writers"`
+}
+
+// Condition describes what makes the teams required (like "topic pii")
+func (rt *RequiredTeams) Condition() string {
+	conditions := []string{}
+	if rt.Topic != "" {
+		conditions = append(conditions, "topic "+rt.Topic)
+	}
+	if rt.CustomProperty.Name != "" {
+		conditions = append(conditions, "custom property "+rt.CustomProperty.Name+"="+rt.CustomProperty.Value)
+	}
+	return strings.Join(conditions, " and ")
+}
+
+/*
+ForOrganization returns the config reconciling an additional Github organization:
+its org-level settings (organization_policies, org_profile, org_webhooks and
+security_manager_teams) are the ones of its organizations entry (none are
+managed without an entry), the other settings are the ones of the main organization
+*/
+func (rc *RepositoryConfig) ForOrganization(organization string) *RepositoryConfig {
+	orgConfig := *rc
+	settings := OrganizationSettings{}
+	for name, s := range rc.Organizations {
+		if strings.EqualFold(name, organization) {
+			settings = s
+			break
+		}
+	}
+	orgConfig.OrganizationPolicies = settings.OrganizationPolicies
+	orgConfig.OrgProfile = settings.OrgProfile
+	orgConfig.OrgWebhooks = settings.OrgWebhooks
+	orgConfig.SecurityManagerTeams = settings.SecurityManagerTeams
+	orgConfig.Organizations = nil
+	return &orgConfig
+}
+
+/*
+RepositorySpecDefaults returns the org-wide defaults merged into the spec of
+every repository owned by a team: the repository_defaults, and the
+default_merge_strategy (that wins over them)
+*/
+func (rc *RepositoryConfig) RepositorySpecDefaults() map[string]interface{} {
+	defaults := make(map[string]interface{}, len(rc.RepositoryDefaults)+3)
+	for k, v := range rc.RepositoryDefaults {
+		defaults[k] = v
+	}
+	for k, v := range rc.MergeStrategyDefaults() {
+		defaults[k] = v
+	}
+	return defaults
+}
+
+/*
+MergeStrategyDefaults returns the merge strategies set in default_merge_strategy
+(indexed by their repository spec name)
+*/
+func (rc *RepositoryConfig) MergeStrategyDefaults() map[string]bool {
+	defaults := make(map[string]bool)
+	for name, value := range map[string]*bool{
+		"allow_squash_merge": rc.DefaultMergeStrategy.AllowSquashMerge,
+		"allow_merge_commit": rc.DefaultMergeStrategy.AllowMergeCommit,
+		"allow_rebase_merge": rc.DefaultMergeStrategy.AllowRebaseMerge,
+	} {
+		if value != nil {
+			defaults[name] = *value
+		}
+	}
+	return defaults
+}
+
 // set default values
 func (rc *RepositoryConfig) UnmarshalYAML(value *yaml.Node) error {
 	type myStructAlias RepositoryConfig // Create a new alias type to avoid recursion
@@ -36,6 +272,11 @@ func (rc *RepositoryConfig) UnmarshalYAML(value *yaml.Node) error {
 	x.GithubConcurrentThreads = 4
 	x.UserSync.Plugin = "noop"
 	x.ArchiveOnDelete = true
+	x.TeamsRepositoryOwnersPermission = "push"
+	x.EveryoneTeamNotificationSetting = "notifications_disabled"
+	x.TeamsRepositoryProtection.RequiredApprovingReviewCount = 1
+	x.TeamMinimumOwners.Count = 2
+	x.TeamMinimumOwners.Enforcement = "warn"
 
 	if err := value.Decode(x); err != nil {
 		return err
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Alayacare/goliac/internal/github"
+)
+
+// number of repositories checked per GraphQL query
+const COMPLIANCE_REPOSITORIES_PER_QUERY = 50
+
+type GraphQLComplianceFiles struct {
+	Data   map[string]map[string]*struct{ Id string } `json:"data"`
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+buildComplianceQuery builds a GraphQL query checking, for each repository,
+the presence of each file on the default branch, like
+
+	query checkFiles($orgLogin: String!, $r0: String!, $f0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    f0: object(expression: $f0) { id }
+	  }
+	}
+*/
+func buildComplianceQuery(nbRepositories int, nbFiles int) string {
+	var query strings.Builder
+	query.WriteString("query checkFiles($orgLogin: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	for j := 0; j < nbFiles; j++ {
+		fmt.Fprintf(&query, ", $f%d: String!", j)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		for j := 0; j < nbFiles; j++ {
+			fmt.Fprintf(&query, "    f%d: object(expression: $f%d) { id }\n", j, j)
+		}
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
+}
+
+/*
+MissingRequiredFiles checks (read-only) the presence of the required files
+on the default branch of each repository of the Github organization.
+It returns, for each repository, the list of missing files
+(repositories with all the required files, or not found on Github, are not returned)
+*/
+func MissingRequiredFiles(ctx context.Context, client github.GitHubClient, organization string, repositories []string, requiredFiles []string) (map[string][]string, error) {
+	missing := make(map[string][]string)
+	if len(requiredFiles) == 0 {
+		return missing, nil
+	}
+
+	for start := 0; start < len(repositories); start += COMPLIANCE_REPOSITORIES_PER_QUERY {
+		end := start + COMPLIANCE_REPOSITORIES_PER_QUERY
+		if end > len(repositories) {
+			end = len(repositories)
+		}
+		batch := repositories[start:end]
+
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = organization
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+		for j, file := range requiredFiles {
+			variables[fmt.Sprintf("f%d", j)] = "HEAD:" + file
+		}
+
+		data, err := client.QueryGraphQLAPI(ctx, buildComplianceQuery(len(batch), len(requiredFiles)), variables)
+		if err != nil {
+			return missing, err
+		}
+		var gResult GraphQLComplianceFiles
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return missing, err
+		}
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return missing, fmt.Errorf("graphql error on MissingRequiredFiles: %v (%v)", e.Message, e.Path)
+			}
+		}
+
+		for i, reponame := range batch {
+			files, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if !ok || files == nil {
+				continue
+			}
+			for j, file := range requiredFiles {
+				if f := files[fmt.Sprintf("f%d", j)]; f == nil {
+					missing[reponame] = append(missing[reponame], file)
+				}
+			}
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type GitHubClientComplianceMock struct {
+	result    []byte
+	variables map[string]interface{}
+}
+
+func (g *GitHubClientComplianceMock) QueryGraphQLAPI(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	g.variables = variables
+	return g.result, nil
+}
+func (g *GitHubClientComplianceMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	return nil, nil
+}
+func (g *GitHubClientComplianceMock) GetAccessToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (g *GitHubClientComplianceMock) GetAppSlug() string {
+	return ""
+}
+
+func TestMissingRequiredFiles(t *testing.T) {
+	t.Run("happy path: no required files", func(t *testing.T) {
+		client := &GitHubClientComplianceMock{}
+		missing, err := MissingRequiredFiles(context.TODO(), client, "myorg", []string{"repo1"}, []string{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(missing))
+		assert.Nil(t, client.variables)
+	})
+
+	t.Run("happy path: missing files", func(t *testing.T) {
+		client := &GitHubClientComplianceMock{
+			result: []byte(`{
+				"data": {
+					"r0": {"f0": {"id": "a"}, "f1": {"id": "b"}},
+					"r1": {"f0": null, "f1": {"id": "c"}},
+					"r2": null
+				},
+				"errors": [
+					{"type": "NOT_FOUND", "path": ["r2"], "message": "Could not resolve to a Repository with the name 'repo3'."}
+				]
+			}`),
+		}
+		missing, err := MissingRequiredFiles(context.TODO(), client, "myorg", []string{"repo1", "repo2", "repo3"}, []string{".github/CODEOWNERS", "LICENSE"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]string{
+			"repo2": {".github/CODEOWNERS"},
+		}, missing)
+		assert.Equal(t, "repo2", client.variables["r1"])
+		assert.Equal(t, "HEAD:LICENSE", client.variables["f1"])
+	})
+
+	t.Run("not happy path: graphql error", func(t *testing.T) {
+		client := &GitHubClientComplianceMock{
+			result: []byte(`{"data": null, "errors": [{"type": "FORBIDDEN", "message": "forbidden"}]}`),
+		}
+		_, err := MissingRequiredFiles(context.TODO(), client, "myorg", []string{"repo1"}, []string{"LICENSE"})
+		assert.NotNil(t, err)
+	})
+}
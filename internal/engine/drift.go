@@ -0,0 +1,346 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/entity"
+)
+
+/*
+RepositoryDriftField is a repository field that differs between
+the repository definition (local) and Github (remote)
+*/
+type RepositoryDriftField struct {
+	Name   string
+	Local  string
+	Remote string
+}
+
+/*
+RepositoryDrift compares (read-only) a repository definition with its
+(cached) Github state, and returns the differing fields:
+- visibility
+- teams access (admins, maintainers, writers, triagers, readers)
+- external users (externalUserReaders, externalUserWriters)
+Like in the reconciliation, the access inherited from a parent team is ignored,
+and the "<team>-goliac-owners" teams are expected on the teams repository (teamsreponame).
+*/
+func RepositoryDrift(ctx context.Context, local GoliacLocalResources, remote GoliacRemote, repoconfig *config.RepositoryConfig, teamsreponame string, reponame string) ([]RepositoryDriftField, error) {
+	lRepo, ok := local.Repositories()[reponame]
+	if !ok && teamsreponame != "" && reponame == teamsreponame {
+		lRepo = (&GoliacReconciliatorImpl{repoconfig: repoconfig}).teamsRepository(teamsreponame)
+		ok = true
+	}
+	if !ok {
+		return nil, fmt.Errorf("repository %s not found", reponame)
+	}
+
+	drift := []RepositoryDriftField{}
+	// an immutable repository is not reconciled
+	if lRepo.Immutable {
+		return drift, nil
+	}
+
+	rRepo, ok := remote.Repositories(ctx)[reponame]
+	if !ok {
+		drift = append(drift, RepositoryDriftField{
+			Name:   "repository",
+			Local:  "present",
+			Remote: "absent",
+		})
+		return drift, nil
+	}
+
+	// visibility
+	lVisibility := "private"
+	if lRepo.Spec.IsPublic {
+		lVisibility = "public"
+	}
+	rVisibility := "public"
+	if rRepo.BoolProperties["private"] {
+		rVisibility = "private"
+	}
+	if lVisibility != rVisibility {
+		drift = append(drift, RepositoryDriftField{
+			Name:   "visibility",
+			Local:  lVisibility,
+			Remote: rVisibility,
+		})
+	}
+
+	// teams access (built like in the reconciliation)
+	lPermissions, _ := expectedTeamsPermissions(repoconfig, newSlugCache(), local.Teams(), teamsreponame, reponame, lRepo, rRepo)
+
+	rTeams := remote.Teams(ctx, false)
+	rTeamsById := teamsByIdIndex(rTeams)
+	rTeamsRepos := remote.TeamRepositories(ctx)
+	rPermissions := make(map[string]string)
+	rInherited := make(map[string]string)
+	for teamslug, repos := range rTeamsRepos {
+		if p, ok := repos[reponame]; ok {
+			_, declared := lPermissions[teamslug]
+			if isTeamRepoAccessInherited(rTeams, rTeamsById, rTeamsRepos, teamslug, reponame, p.Permission, declared) {
+				rInherited[teamslug] = teamRepoRestPermission(p.Permission)
+				continue
+			}
+			rPermissions[teamslug] = teamRepoRestPermission(p.Permission)
+		}
+	}
+	// a local access already inherited from a parent team is not a drift
+	for teamslug, permission := range lPermissions {
+		if _, ok := rPermissions[teamslug]; !ok && rInherited[teamslug] == permission {
+			delete(lPermissions, teamslug)
+		}
+	}
+
+	for _, bucket := range []struct {
+		name       string
+		permission string
+	}{
+		{"admins", "admin"},
+		{"maintainers", "maintain"},
+		{"writers", "push"},
+		{"triagers", "triage"},
+		{"readers", "pull"},
+	} {
+		lTeams := teamsWithPermission(lPermissions, bucket.permission)
+		rTeams := teamsWithPermission(rPermissions, bucket.permission)
+		if res, _, _ := entity.StringArrayEquivalent(lTeams, rTeams); !res {
+			drift = append(drift, RepositoryDriftField{
+				Name:   bucket.name,
+				Local:  strings.Join(lTeams, ","),
+				Remote: strings.Join(rTeams, ","),
+			})
+		}
+	}
+
+	// external users
+	lExternalReaders, lExternalWriters, lExternalAdmins := repositoryExternalUsers(local.ExternalUsers(), externalUsersRepoPatterns(local.ExternalUsers()), reponame, lRepo)
+	rExternalReaders := []string{}
+	rExternalWriters := []string{}
+	rExternalAdmins := []string{}
+	for githubid, permission := range rRepo.ExternalUsers {
+		switch permission {
+		case "ADMIN":
+			rExternalAdmins = append(rExternalAdmins, githubid)
+		case "WRITE":
+			rExternalWriters = append(rExternalWriters, githubid)
+		default:
+			rExternalReaders = append(rExternalReaders, githubid)
+		}
+	}
+	sort.Strings(lExternalReaders)
+	sort.Strings(lExternalWriters)
+	sort.Strings(lExternalAdmins)
+	sort.Strings(rExternalReaders)
+	sort.Strings(rExternalWriters)
+	sort.Strings(rExternalAdmins)
+
+	if res, _, _ := entity.StringArrayEquivalentFold(lExternalReaders, rExternalReaders); !res {
+		drift = append(drift, RepositoryDriftField{
+			Name:   "externalUserReaders",
+			Local:  strings.Join(lExternalReaders, ","),
+			Remote: strings.Join(rExternalReaders, ","),
+		})
+	}
+	if res, _, _ := entity.StringArrayEquivalentFold(lExternalWriters, rExternalWriters); !res {
+		drift = append(drift, RepositoryDriftField{
+			Name:   "externalUserWriters",
+			Local:  strings.Join(lExternalWriters, ","),
+			Remote: strings.Join(rExternalWriters, ","),
+		})
+	}
+	if res, _, _ := entity.StringArrayEquivalentFold(lExternalAdmins, rExternalAdmins); !res {
+		drift = append(drift, RepositoryDriftField{
+			Name:   "externalUserAdmins",
+			Local:  strings.Join(lExternalAdmins, ","),
+			Remote: strings.Join(rExternalAdmins, ","),
+		})
+	}
+
+	return drift, nil
+}
+
+/*
+teamsWithPermission returns the (sorted) teams having exactly the permission
+*/
+func teamsWithPermission(permissions map[string]string, permission string) []string {
+	teams := []string{}
+	for teamslug, p := range permissions {
+		if p == permission {
+			teams = append(teams, teamslug)
+		}
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+/*
+EntityDrift is a managed entity (a team, a repository, a user, ...) that
+differs from the teams repository, with the operations reconciling it
+*/
+type EntityDrift struct {
+	EntityType string
+	Name       string
+	Operations []config.GoliacOperation
+}
+
+// the entity type of an operation, by the first key of its detail
+var driftEntityTypes = map[string]string{
+	"repositoryname": "repository",
+	"repository":     "repository",
+	"teamname":       "team",
+	"teamslug":       "team",
+	"ghuserid":       "user",
+	"ruleset":        "ruleset",
+	"ruleset id":     "ruleset",
+	"url":            "org_webhook",
+	"setting":        "organization",
+}
+
+/*
+GroupDrift groups the operations of a reconciliation (dry-run) by entity,
+sorted by entity type and name
+*/
+func GroupDrift(operations []config.GoliacOperation) []EntityDrift {
+	drifts := []EntityDrift{}
+	index := make(map[string]int)
+	for _, o := range operations {
+		entityType, name := driftEntity(o)
+		key := entityType + "/" + name
+		i, ok := index[key]
+		if !ok {
+			i = len(drifts)
+			index[key] = i
+			drifts = append(drifts, EntityDrift{
+				EntityType: entityType,
+				Name:       name,
+			})
+		}
+		drifts[i].Operations = append(drifts[i].Operations, o)
+	}
+	sort.SliceStable(drifts, func(i, j int) bool {
+		if drifts[i].EntityType != drifts[j].EntityType {
+			return drifts[i].EntityType < drifts[j].EntityType
+		}
+		return drifts[i].Name < drifts[j].Name
+	})
+	return drifts
+}
+
+/*
+driftEntity returns the entity (type and name) an operation applies to,
+from its detail (like "repositoryname: myrepo, teamslug: myteam, ...")
+*/
+func driftEntity(o config.GoliacOperation) (string, string) {
+	// the security manager teams are an organization setting
+	if strings.HasSuffix(o.Command, "_security_manager_team") {
+		return "organization", "security_manager_teams"
+	}
+	key, value, ok := strings.Cut(o.Detail, ":")
+	if !ok {
+		return "organization", ""
+	}
+	entityType, ok := driftEntityTypes[strings.TrimSpace(key)]
+	if !ok {
+		return "organization", ""
+	}
+	name := strings.TrimSpace(value)
+	if i := strings.IndexAny(name, " ,"); i >= 0 {
+		name = name[:i]
+	}
+	return entityType, name
+}
+
+/*
+RenderDriftDiff renders the drift as a unified-diff-like text (easier to
+review than JSON), with a block per entity and a line per operation:
+  - "+" for the operations adding something (create, add, link)
+  - "-" for the operations removing something (delete, remove, unlink, cancel)
+  - "~" for the other updates, followed by their field-level changes (if any)
+*/
+func RenderDriftDiff(drifts []EntityDrift) string {
+	var sb strings.Builder
+	for i, d := range drifts {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if d.Name == "" {
+			fmt.Fprintf(&sb, "%s:\n", d.EntityType)
+		} else {
+			fmt.Fprintf(&sb, "%s %s:\n", d.EntityType, d.Name)
+		}
+		for _, o := range d.Operations {
+			line := o.Command
+			if detail := driftDetail(o); detail != "" {
+				line += " " + detail
+			}
+			if o.Skipped {
+				line += " (skipped)"
+			} else if o.Simulated {
+				line += " (simulated)"
+			}
+			fmt.Fprintf(&sb, "%s %s\n", driftSign(o.Command), line)
+			for _, change := range o.Changes {
+				for _, l := range driftChangeLines(change) {
+					fmt.Fprintf(&sb, "  %s\n", l)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+// driftSign returns the diff sign of an operation, by its command
+func driftSign(command string) string {
+	for _, prefix := range []string{"create_", "add_", "link_"} {
+		if strings.HasPrefix(command, prefix) || strings.Contains(command, "_"+prefix) {
+			return "+"
+		}
+	}
+	for _, prefix := range []string{"delete_", "remove_", "unlink_", "cancel_"} {
+		if strings.HasPrefix(command, prefix) || strings.Contains(command, "_"+prefix) {
+			return "-"
+		}
+	}
+	return "~"
+}
+
+// driftDetail returns the detail of an operation, without its entity (already in the block header)
+func driftDetail(o config.GoliacOperation) string {
+	entityType, name := driftEntity(o)
+	key, value, ok := strings.Cut(o.Detail, ":")
+	if !ok || name == "" || driftEntityTypes[strings.TrimSpace(key)] != entityType {
+		return o.Detail
+	}
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, name) {
+		return o.Detail
+	}
+	return strings.TrimLeft(value[len(name):], ", ")
+}
+
+/*
+driftChangeLines renders a field-level change:
+  - "field: old -> new" as "- field: old" and "+ field: new"
+  - "field added: ..." as "+ field added: ..." (and "- field removed: ..." for the removals)
+*/
+func driftChangeLines(change string) []string {
+	field, values, ok := strings.Cut(change, ": ")
+	if ok {
+		if before, after, ok := strings.Cut(values, " -> "); ok {
+			return []string{"- " + field + ": " + before, "+ " + field + ": " + after}
+		}
+		if strings.HasSuffix(field, " added") {
+			return []string{"+ " + change}
+		}
+		if strings.HasSuffix(field, " removed") {
+			return []string{"- " + change}
+		}
+	}
+	return []string{"~ " + change}
+}
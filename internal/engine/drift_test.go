@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepositoryDrift(t *testing.T) {
+	newFixture := func() (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users:     make(map[string]*entity.User),
+			externals: make(map[string]*entity.User),
+			teams:     make(map[string]*entity.Team),
+			repos:     make(map[string]*entity.Repository),
+		}
+		outside := &entity.User{}
+		outside.Name = "outside"
+		outside.Spec.GithubID = "outside_githubid"
+		local.externals["outside"] = outside
+
+		owner := "owner"
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Owner = &owner
+		lRepo.Spec.Readers = []string{"reader"}
+		lRepo.Spec.ExternalUserReaders = []string{"outside"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+		}
+		remote.teams["owner"] = &GithubTeam{Name: "owner", Slug: "owner", Id: 1}
+		remote.teams["reader"] = &GithubTeam{Name: "reader", Slug: "reader", Id: 2}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private": true,
+			},
+			ExternalUsers: map[string]string{
+				"outside_githubid": "READ",
+			},
+		}
+		remote.teamsrepos["owner"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "WRITE"},
+		}
+		remote.teamsrepos["reader"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "READ"},
+		}
+		return &local, &remote
+	}
+
+	t.Run("happy path: repository in sync", func(t *testing.T) {
+		local, remote := newFixture()
+
+		drift, err := RepositoryDrift(context.TODO(), local, remote, &config.RepositoryConfig{}, "teams", "myrepo")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(drift))
+	})
+
+	t.Run("happy path: team required by a topic is not a drift", func(t *testing.T) {
+		local, remote := newFixture()
+		local.teams["security"] = &entity.Team{}
+		local.teams["security"].Name = "security"
+		remote.teams["security"] = &GithubTeam{Name: "security", Slug: "security", Id: 3}
+		remote.repos["myrepo"].Topics = []string{"pii"}
+		remote.teamsrepos["security"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "READ"},
+		}
+
+		repoconfig := &config.RepositoryConfig{
+			RequiredTeams: []config.RequiredTeams{
+				{Topic: "pii", Readers: []string{"security"}},
+			},
+		}
+		drift, err := RepositoryDrift(context.TODO(), local, remote, repoconfig, "teams", "myrepo")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(drift))
+	})
+
+	t.Run("happy path: visibility, teams and external users drift", func(t *testing.T) {
+		local, remote := newFixture()
+		remote.repos["myrepo"].BoolProperties["private"] = false
+		remote.repos["myrepo"].ExternalUsers["outside_githubid"] = "WRITE"
+		remote.teamsrepos["reader"]["myrepo"].Permission = "WRITE"
+
+		drift, err := RepositoryDrift(context.TODO(), local, remote, &config.RepositoryConfig{}, "teams", "myrepo")
+		assert.Nil(t, err)
+		assert.Equal(t, []RepositoryDriftField{
+			{Name: "visibility", Local: "private", Remote: "public"},
+			{Name: "writers", Local: "owner", Remote: "owner,reader"},
+			{Name: "readers", Local: "reader", Remote: ""},
+			{Name: "externalUserReaders", Local: "outside_githubid", Remote: ""},
+			{Name: "externalUserWriters", Local: "", Remote: "outside_githubid"},
+		}, drift)
+	})
+
+	t.Run("happy path: the owners teams are expected on the teams repository", func(t *testing.T) {
+		local, remote := newFixture()
+		local.teams["owner"] = &entity.Team{}
+		local.teams["owner"].Name = "owner"
+		remote.teams["admin"] = &GithubTeam{Name: "admin", Slug: "admin", Id: 3}
+		remote.teams["owner-goliac-owners"] = &GithubTeam{Name: "owner-goliac-owners", Slug: "owner-goliac-owners", Id: 4}
+		remote.repos["teams"] = &GithubRepository{
+			Name: "teams",
+			BoolProperties: map[string]bool{
+				"private": true,
+			},
+			ExternalUsers: map[string]string{},
+		}
+		remote.teamsrepos["admin"] = map[string]*GithubTeamRepo{
+			"teams": {Name: "teams", Permission: "WRITE"},
+		}
+		remote.teamsrepos["owner-goliac-owners"] = map[string]*GithubTeamRepo{
+			"teams": {Name: "teams", Permission: "WRITE"},
+		}
+
+		drift, err := RepositoryDrift(context.TODO(), local, remote, &config.RepositoryConfig{AdminTeam: "admin"}, "teams", "teams")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(drift))
+	})
+
+	t.Run("not happy path: unknown repository", func(t *testing.T) {
+		local, remote := newFixture()
+
+		_, err := RepositoryDrift(context.TODO(), local, remote, &config.RepositoryConfig{}, "teams", "unknown")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGroupDrift(t *testing.T) {
+	t.Run("happy path: the operations are grouped by entity", func(t *testing.T) {
+		operations := []config.GoliacOperation{
+			{Command: "update_team_add_member", Detail: "teamslug: team1, ghuserid: user1, role: member"},
+			{Command: "update_repository_update_bool_property", Detail: "repositoryname: repo2 archived:false"},
+			{Command: "update_repository_add_team", Detail: "repositoryname: repo1, teamslug: team1, permission: push"},
+			{Command: "update_team_remove_member", Detail: "teamslug: team1, ghuserid: user2"},
+			{Command: "update_org_setting", Detail: "setting: members_can_create_public_repositories true -> false"},
+			{Command: "add_security_manager_team", Detail: "teamslug: appsec"},
+			{Command: "delete_ruleset", Detail: "ruleset id:3"},
+		}
+
+		drifts := GroupDrift(operations)
+
+		assert.Equal(t, 6, len(drifts))
+		assert.Equal(t, "organization", drifts[0].EntityType)
+		assert.Equal(t, "members_can_create_public_repositories", drifts[0].Name)
+		assert.Equal(t, "security_manager_teams", drifts[1].Name)
+		assert.Equal(t, "repository", drifts[2].EntityType)
+		assert.Equal(t, "repo1", drifts[2].Name)
+		assert.Equal(t, "repo2", drifts[3].Name)
+		assert.Equal(t, "ruleset", drifts[4].EntityType)
+		assert.Equal(t, "3", drifts[4].Name)
+		assert.Equal(t, "team", drifts[5].EntityType)
+		assert.Equal(t, "team1", drifts[5].Name)
+		assert.Equal(t, 2, len(drifts[5].Operations))
+		assert.Equal(t, "update_team_remove_member", drifts[5].Operations[1].Command)
+	})
+
+	t.Run("happy path: no operation, no drift", func(t *testing.T) {
+		assert.Equal(t, 0, len(GroupDrift(nil)))
+	})
+}
+
+func TestRenderDriftDiff(t *testing.T) {
+	t.Run("happy path: the drift is rendered as a diff", func(t *testing.T) {
+		operations := []config.GoliacOperation{
+			{Command: "update_team_add_member", Detail: "teamslug: platform, ghuserid: alice, role: member"},
+			{Command: "update_team_remove_member", Detail: "teamslug: platform, ghuserid: bob"},
+			{Command: "update_repository_update_bool_property", Detail: "repositoryname: repo1 archived:false", Simulated: true},
+			{Command: "update_org_setting", Detail: "setting: members_can_create_public_repositories true -> false"},
+			{Command: "add_security_manager_team", Detail: "teamslug: appsec"},
+			{Command: "update_ruleset", Detail: "ruleset: default (id: 3) enforcement: active", Changes: []string{
+				"enforcement: evaluate -> active",
+				"bypass teams added: owners",
+				"conditions changed",
+			}},
+		}
+
+		diff := RenderDriftDiff(GroupDrift(operations))
+
+		assert.Equal(t, `organization members_can_create_public_repositories:
+~ update_org_setting true -> false
+
+organization security_manager_teams:
++ add_security_manager_team teamslug: appsec
+
+repository repo1:
+~ update_repository_update_bool_property archived:false (simulated)
+
+ruleset default:
+~ update_ruleset (id: 3) enforcement: active
+  - enforcement: evaluate
+  + enforcement: active
+  + bypass teams added: owners
+  ~ conditions changed
+
+team platform:
++ update_team_add_member ghuserid: alice, role: member
+- update_team_remove_member ghuserid: bob
+`, diff)
+	})
+
+	t.Run("happy path: no drift, empty diff", func(t *testing.T) {
+		assert.Equal(t, "", RenderDriftDiff(nil))
+	})
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Alayacare/goliac/internal/entity"
+)
+
+/*
+ExternalAccessExpiration is the (time-boxed) access of an external user to a
+repository
+*/
+type ExternalAccessExpiration struct {
+	Repository string
+	Username   string // external user name
+	GithubID   string
+	Permission string // read, write or admin
+	ExpiresAt  time.Time
+	Expired    bool
+}
+
+/*
+ExpiringExternalAccesses returns the accesses of the external users to the
+(non archived) repositories expiring before a date, including the ones already
+expired but still declared. They are sorted by expiration date
+*/
+func ExpiringExternalAccesses(repositories map[string]*entity.Repository, externalUsers map[string]*entity.User, before time.Time, now time.Time) []ExternalAccessExpiration {
+	expirations := make([]ExternalAccessExpiration, 0)
+	for reponame, repo := range repositories {
+		if repo.Archived {
+			continue
+		}
+		for permission, usernames := range map[string][]string{
+			"read":  repo.Spec.ExternalUserReaders,
+			"write": repo.Spec.ExternalUserWriters,
+			"admin": repo.Spec.ExternalUserAdmins,
+		} {
+			for _, username := range usernames {
+				expiresAt := repo.ExternalUserExpiresAt(username)
+				if expiresAt == nil || !expiresAt.Before(before) {
+					continue
+				}
+				expiration := ExternalAccessExpiration{
+					Repository: reponame,
+					Username:   username,
+					Permission: permission,
+					ExpiresAt:  *expiresAt,
+					Expired:    !now.Before(*expiresAt),
+				}
+				if user, ok := externalUsers[username]; ok {
+					expiration.GithubID = user.Spec.GithubID
+				}
+				expirations = append(expirations, expiration)
+			}
+		}
+	}
+
+	sort.Slice(expirations, func(i, j int) bool {
+		if !expirations[i].ExpiresAt.Equal(expirations[j].ExpiresAt) {
+			return expirations[i].ExpiresAt.Before(expirations[j].ExpiresAt)
+		}
+		if expirations[i].Repository != expirations[j].Repository {
+			return expirations[i].Repository < expirations[j].Repository
+		}
+		return expirations[i].Username < expirations[j].Username
+	})
+	return expirations
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiringExternalAccesses(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newRepo := func(name string) *entity.Repository {
+		repo := &entity.Repository{}
+		repo.Name = name
+		return repo
+	}
+
+	t.Run("happy path: expired and upcoming expirations", func(t *testing.T) {
+		repo1 := newRepo("repo1")
+		repo1.Spec.ExternalUserReaders = []string{"partner1", "partner2"}
+		repo1.Spec.ExternalUserAdmins = []string{"partner3"}
+		repo1.Spec.ExternalUsersExpiresAt = map[string]string{
+			"partner1": "2024-06-10",
+			"partner2": "2025-01-01", // outside of the window
+			"partner3": "2024-05-01",
+		}
+		repo2 := newRepo("repo2")
+		repo2.Spec.ExternalUserWriters = []string{"partner1"}
+		repo2.Spec.ExternalUsersExpiresAt = map[string]string{"partner1": "2024-06-10"}
+		repo3 := newRepo("repo3")
+		repo3.Spec.ExternalUserWriters = []string{"partner1"} // never expires
+
+		partner1 := &entity.User{}
+		partner1.Spec.GithubID = "partner1_gh"
+
+		expirations := ExpiringExternalAccesses(
+			map[string]*entity.Repository{"repo1": repo1, "repo2": repo2, "repo3": repo3},
+			map[string]*entity.User{"partner1": partner1},
+			now.Add(30*24*time.Hour), now)
+
+		assert.Equal(t, []ExternalAccessExpiration{
+			{Repository: "repo1", Username: "partner3", Permission: "admin", ExpiresAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Expired: true},
+			{Repository: "repo1", Username: "partner1", GithubID: "partner1_gh", Permission: "read", ExpiresAt: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)},
+			{Repository: "repo2", Username: "partner1", GithubID: "partner1_gh", Permission: "write", ExpiresAt: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)},
+		}, expirations)
+	})
+
+	t.Run("happy path: archived repositories are ignored", func(t *testing.T) {
+		repo1 := newRepo("repo1")
+		repo1.Archived = true
+		repo1.Spec.ExternalUserReaders = []string{"partner1"}
+		repo1.Spec.ExternalUsersExpiresAt = map[string]string{"partner1": "2024-06-10"}
+
+		expirations := ExpiringExternalAccesses(map[string]*entity.Repository{"repo1": repo1}, map[string]*entity.User{}, now.Add(30*24*time.Hour), now)
+		assert.Equal(t, 0, len(expirations))
+	})
+}
@@ -3,13 +3,17 @@ package engine
 import (
 	"context"
 	"fmt"
+	"os"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/entity"
 	"github.com/Alayacare/goliac/internal/utils"
-	"github.com/gosimple/slug"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,19 +23,31 @@ type UnmanagedResources struct {
 	Teams                  map[string]bool
 	Repositories           map[string]bool
 	RuleSets               map[string]bool
+	OutsideCollaborators   map[string]bool // outside collaborators not declared in any repository
 }
 
 /*
  * GoliacReconciliator is here to sync the local state to the remote state
  */
 type GoliacReconciliator interface {
-	Reconciliate(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamreponame string, dryrun bool, goliacAdminSlug string, reposToArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository) (*UnmanagedResources, error)
+	// teamreponame is empty when the teams repository is not part of the reconciled organization
+	Reconciliate(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamreponame string, dryrun bool, goliacAdminSlug string, reposToArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository, reposToDelete map[string]bool) (*UnmanagedResources, error)
+	// sync only one team (its members, and its repositories access)
+	ReconciliateTeam(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamreponame string, dryrun bool, teamslug string) error
+	// restrict the teams and repositories reconciliated by Reconciliate (nil: everything)
+	SetScope(scope *ReconciliationScope)
+	// remember the repositories visibility changes across the reconciliations (nil: no flip-flop detection)
+	SetVisibilityGuard(guard *VisibilityGuard)
 }
 
 type GoliacReconciliatorImpl struct {
 	executor   ReconciliatorExecutor
 	repoconfig *config.RepositoryConfig
 	unmanaged  *UnmanagedResources
+	slugs      *slugCache
+	simulated  bool // the operations of the current phase are only simulated (dryrun_operations)
+	scope      *ReconciliationScope
+	visibility *VisibilityGuard
 }
 
 func NewGoliacReconciliatorImpl(executor ReconciliatorExecutor, repoconfig *config.RepositoryConfig) GoliacReconciliator {
@@ -39,48 +55,814 @@ func NewGoliacReconciliatorImpl(executor ReconciliatorExecutor, repoconfig *conf
 		executor:   executor,
 		repoconfig: repoconfig,
 		unmanaged:  nil,
+		slugs:      newSlugCache(),
 	}
 }
 
-func (r *GoliacReconciliatorImpl) Reconciliate(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamsreponame string, dryrun bool, goliacAdminSlug string, reposToArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository) (*UnmanagedResources, error) {
+func (r *GoliacReconciliatorImpl) SetScope(scope *ReconciliationScope) {
+	r.scope = scope
+}
+
+func (r *GoliacReconciliatorImpl) SetVisibilityGuard(guard *VisibilityGuard) {
+	r.visibility = guard
+}
+
+func (r *GoliacReconciliatorImpl) Reconciliate(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamsreponame string, dryrun bool, goliacAdminSlug string, reposToArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository, reposToDelete map[string]bool) (*UnmanagedResources, error) {
 	rremote := NewMutableGoliacRemoteImpl(ctx, remote)
 	r.Begin(ctx, dryrun)
+	r.slugs = newSlugCache()
 	unmanaged := &UnmanagedResources{
 		Users:                  make(map[string]bool),
 		ExternallyManagedTeams: make(map[string]bool),
 		Teams:                  make(map[string]bool),
 		Repositories:           make(map[string]bool),
 		RuleSets:               make(map[string]bool),
+		OutsideCollaborators:   make(map[string]bool),
 	}
 	r.unmanaged = unmanaged
+	if r.visibility != nil {
+		r.visibility.begin()
+	}
 
-	err := r.reconciliateUsers(ctx, local, rremote, dryrun)
+	dryrunOperations := r.repoconfig.DryrunOperations
+
+	orgDryrun := r.phaseDryrun(ctx, dryrun, "organization", dryrunOperations.DryrunOrganization)
+	r.reconciliateOrgSettings(ctx, rremote, orgDryrun)
+	r.reconciliateOrgDefaultBranchName(ctx, remote, orgDryrun)
+	r.reconciliateOrgProfile(ctx, remote, orgDryrun)
+	r.reconciliateOrgActionsSettings(ctx, remote, orgDryrun)
+	r.reconciliateOrgAllowedActions(ctx, remote, orgDryrun)
+	r.reconciliateOrgWebhooks(ctx, remote, orgDryrun)
+
+	// users must be reconciliated before the teams: removing a user from the organization
+	// also removes it from its teams
+	err := r.reconciliateUsers(ctx, local, rremote, r.phaseDryrun(ctx, dryrun, "users", dryrunOperations.DryrunUsers))
 	if err != nil {
 		r.Rollback(ctx, dryrun, err)
 		return nil, err
 	}
 
-	err = r.reconciliateTeams(ctx, local, rremote, dryrun)
+	err = r.reconciliateTeams(ctx, local, rremote, r.phaseDryrun(ctx, dryrun, "teams", dryrunOperations.DryrunTeams), "")
 	if err != nil {
 		r.Rollback(ctx, dryrun, err)
 		return nil, err
 	}
 
-	err = r.reconciliateRepositories(ctx, local, rremote, teamsreponame, dryrun, reposToArchive, reposToRename)
+	// the security manager teams must exist: they are reconciliated after the teams
+	r.reconciliateOrgSecurityManagerTeams(ctx, local, remote, r.phaseDryrun(ctx, dryrun, "organization", dryrunOperations.DryrunOrganization))
+
+	err = r.reconciliateRepositories(ctx, local, rremote, teamsreponame, r.phaseDryrun(ctx, dryrun, "repositories", dryrunOperations.DryrunRepositories), reposToArchive, reposToRename, reposToDelete)
 	if err != nil {
 		r.Rollback(ctx, dryrun, err)
 		return nil, err
 	}
 
-	if remote.IsEnterprise() {
-		err = r.reconciliateRulesets(ctx, local, rremote, teamsreponame, r.repoconfig, dryrun)
-		if err != nil {
-			r.Rollback(ctx, dryrun, err)
-			return nil, err
+	r.reconciliateOutsideCollaborators(ctx, local, rremote, r.phaseDryrun(ctx, dryrun, "users", dryrunOperations.DryrunUsers))
+
+	reposDryrun := r.phaseDryrun(ctx, dryrun, "repositories", dryrunOperations.DryrunRepositories)
+	r.reconciliateCodeowners(ctx, local, remote, reposDryrun)
+
+	r.reconciliateLabels(ctx, local, remote, reposDryrun)
+
+	r.reconciliateDependabot(ctx, local, remote, reposDryrun)
+
+	r.reconciliateProjects(ctx, local, remote, reposDryrun)
+
+	if remote.IsEnterprise() {
+		err = r.reconciliateRulesets(ctx, local, rremote, teamsreponame, r.repoconfig, r.phaseDryrun(ctx, dryrun, "rulesets", dryrunOperations.DryrunRulesets))
+		if err != nil {
+			r.Rollback(ctx, dryrun, err)
+			return nil, err
+		}
+	}
+	r.simulated = false
+
+	err = r.Commit(ctx, dryrun)
+	if err == nil && r.visibility != nil {
+		r.visibility.end(dryrun, r.scope == nil)
+	}
+	return r.unmanaged, err
+}
+
+func (r *GoliacReconciliatorImpl) ReconciliateTeam(ctx context.Context, local GoliacLocal, remote GoliacRemote, teamsreponame string, dryrun bool, teamslug string) error {
+	rremote := NewMutableGoliacRemoteImpl(ctx, remote)
+	r.Begin(ctx, dryrun)
+	r.slugs = newSlugCache()
+	r.unmanaged = &UnmanagedResources{
+		Users:                  make(map[string]bool),
+		ExternallyManagedTeams: make(map[string]bool),
+		Teams:                  make(map[string]bool),
+		Repositories:           make(map[string]bool),
+		RuleSets:               make(map[string]bool),
+		OutsideCollaborators:   make(map[string]bool),
+	}
+
+	err := r.reconciliateTeams(ctx, local, rremote, r.phaseDryrun(ctx, dryrun, "teams", r.repoconfig.DryrunOperations.DryrunTeams), teamslug)
+	if err != nil {
+		r.Rollback(ctx, dryrun, err)
+		return err
+	}
+
+	r.reconciliateTeamRepositories(ctx, local, rremote, teamsreponame, r.phaseDryrun(ctx, dryrun, "repositories", r.repoconfig.DryrunOperations.DryrunRepositories), teamslug)
+	r.simulated = false
+
+	return r.Commit(ctx, dryrun)
+}
+
+/*
+phaseDryrun returns the dryrun of a reconciliation phase: a category of
+operations can be only simulated (dryrun_operations in goliac.yaml) while
+the other ones are applied. The operations of a simulated category are
+marked as such in the plan
+*/
+func (r *GoliacReconciliatorImpl) phaseDryrun(ctx context.Context, dryrun bool, category string, simulated bool) bool {
+	r.simulated = simulated
+	if simulated {
+		logrus.WithFields(map[string]interface{}{"dryrun": true, "category": category}).Debugf("%s operations are only simulated (dryrun_operations.%s)", category, category)
+	}
+	return dryrun || simulated
+}
+
+/*
+ * This function reports (and removes from the organization, if
+ * destructive_operations.outside_collaborators is set) the outside
+ * collaborators not declared as external user of any repository
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOutsideCollaborators(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, dryrun bool) {
+	externalUsers := local.ExternalUsers()
+	repoPatterns := externalUsersRepoPatterns(externalUsers)
+	declared := make(map[string]bool)
+	for reponame, repo := range local.Repositories() {
+		eReaders, eWriters, eAdmins := repositoryExternalUsers(externalUsers, repoPatterns, reponame, repo)
+		for _, ghuserid := range append(append(eReaders, eWriters...), eAdmins...) {
+			declared[ghuserid] = true
+		}
+	}
+
+	undeclared := []string{}
+	for ghuserid := range remote.OutsideCollaborators() {
+		if !declared[strings.ToLower(ghuserid)] {
+			undeclared = append(undeclared, ghuserid)
+		}
+	}
+	sort.Strings(undeclared)
+
+	for _, ghuserid := range undeclared {
+		// reported, even if they are removed
+		r.unmanaged.OutsideCollaborators[ghuserid] = true
+		r.RemoveOutsideCollaboratorFromOrg(ctx, dryrun, remote, ghuserid)
+	}
+}
+
+/*
+ * This function commits a .github/CODEOWNERS file, granting the review to the
+ * owner team, in the repositories with manageCodeowners set.
+ * The file is written only if its content differs (to avoid needless commits)
+ */
+func (r *GoliacReconciliatorImpl) reconciliateCodeowners(ctx context.Context, local GoliacLocal, remote GoliacRemote, dryrun bool) {
+	expected := make(map[string]string)
+	reponames := []string{}
+	for reponame, repo := range local.Repositories() {
+		// a renamed repository is handled once renamed (and the ones out of the incremental scope are skipped)
+		if !repo.Spec.ManageCodeowners || repo.Archived || repo.Immutable || repo.Owner == nil || repo.RenameTo != "" || !r.scope.HasRepository(reponame) {
+			continue
+		}
+		expected[reponame] = codeownersContent(config.GetOrganization(ctx), r.slugs.Team(*repo.Owner))
+		reponames = append(reponames, reponame)
+	}
+	if len(reponames) == 0 {
+		return
+	}
+	sort.Strings(reponames)
+
+	files, err := remote.RepositoriesFile(ctx, reponames, CODEOWNERS_FILENAME)
+	if err != nil {
+		logrus.Warnf("not able to fetch the %s files (skipping them): %v", CODEOWNERS_FILENAME, err)
+		return
+	}
+
+	for _, reponame := range reponames {
+		sha := ""
+		if file, ok := files[reponame]; ok {
+			if file.Content == expected[reponame] {
+				continue
+			}
+			sha = file.Sha
+		}
+		r.UpdateRepositoryFile(ctx, dryrun, reponame, CODEOWNERS_FILENAME, expected[reponame], sha)
+	}
+}
+
+/*
+ * This function sync the labels of the repositories: the label sets of goliac.yaml
+ * (applied by pattern) overridden by the labels of each repository.
+ * Repositories without any expected label are not managed, and a repository not
+ * yet created is handled at the next run (Github creates it with its default labels)
+ */
+func (r *GoliacReconciliatorImpl) reconciliateLabels(ctx context.Context, local GoliacLocal, remote GoliacRemote, dryrun bool) {
+	patterns := make([]*regexp.Regexp, len(r.repoconfig.Labels))
+	for i, ls := range r.repoconfig.Labels {
+		match, err := regexp.Compile(ls.Pattern)
+		if err != nil {
+			logrus.Warnf("not able to parse labels regular expression %s (skipping the labels): %v", ls.Pattern, err)
+			return
+		}
+		patterns[i] = match
+	}
+
+	rRepositories := remote.Repositories(ctx)
+	rRepositoriesLowerCase := make(map[string]*GithubRepository)
+	for name, rRepo := range rRepositories {
+		rRepositoriesLowerCase[strings.ToLower(name)] = rRepo
+	}
+
+	// key is the reponame, then the lowercase label name
+	expected := make(map[string]map[string]*GithubLabel)
+	reponames := []string{}
+	for reponame, lRepo := range local.Repositories() {
+		// a renamed repository is handled once renamed (and the ones out of the incremental scope are skipped)
+		if lRepo.Archived || lRepo.Immutable || lRepo.RenameTo != "" || !r.scope.HasRepository(reponame) {
+			continue
+		}
+		rRepo := findRemoteRepository(rRepositories, rRepositoriesLowerCase, reponame)
+		if rRepo == nil || rRepo.BoolProperties["archived"] {
+			continue
+		}
+
+		labels := make(map[string]*GithubLabel)
+		for i, ls := range r.repoconfig.Labels {
+			if !patterns[i].MatchString(reponame) {
+				continue
+			}
+			for _, l := range ls.Labels {
+				labels[strings.ToLower(l.Name)] = githubLabel(l)
+			}
+		}
+		for _, l := range lRepo.Spec.Labels {
+			labels[strings.ToLower(l.Name)] = githubLabel(l)
+		}
+		if len(labels) == 0 {
+			continue
+		}
+		expected[rRepo.Name] = labels
+		reponames = append(reponames, rRepo.Name)
+	}
+	if len(reponames) == 0 {
+		return
+	}
+	sort.Strings(reponames)
+
+	rLabels, err := remote.RepositoriesLabels(ctx, reponames)
+	if err != nil {
+		logrus.Warnf("not able to fetch the repositories labels (skipping them): %v", err)
+		return
+	}
+
+	for _, reponame := range reponames {
+		rRepoLabels := rLabels[reponame]
+
+		names := make([]string, 0, len(expected[reponame]))
+		for name := range expected[reponame] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			lLabel := expected[reponame][name]
+			rLabel, ok := rRepoLabels[name]
+			if !ok {
+				r.AddRepositoryLabel(ctx, dryrun, reponame, lLabel)
+				continue
+			}
+			if rLabel.Name != lLabel.Name ||
+				normalizeLabelColor(rLabel.Color) != lLabel.Color ||
+				rLabel.Description != lLabel.Description {
+				r.UpdateRepositoryLabel(ctx, dryrun, reponame, rLabel.Name, lLabel)
+			}
+		}
+
+		extras := []string{}
+		for name, rLabel := range rRepoLabels {
+			if _, ok := expected[reponame][name]; !ok {
+				extras = append(extras, rLabel.Name)
+			}
+		}
+		sort.Strings(extras)
+		for _, labelname := range extras {
+			r.DeleteRepositoryLabel(ctx, dryrun, reponame, labelname)
+		}
+	}
+}
+
+/*
+ * This function sync the Dependabot settings (vulnerability alerts and security updates)
+ * of the repositories setting them. Archived repositories are skipped.
+ * The security updates need the vulnerability alerts: they are disabled before
+ * the alerts, and enabled after them
+ */
+func (r *GoliacReconciliatorImpl) reconciliateDependabot(ctx context.Context, local GoliacLocal, remote GoliacRemote, dryrun bool) {
+	rRepositories := remote.Repositories(ctx)
+	rRepositoriesLowerCase := make(map[string]*GithubRepository)
+	for name, rRepo := range rRepositories {
+		rRepositoriesLowerCase[strings.ToLower(name)] = rRepo
+	}
+
+	lRepos := make(map[string]*entity.Repository)
+	reponames := []string{}
+	for reponame, lRepo := range local.Repositories() {
+		// a renamed repository is handled once renamed (and the ones out of the incremental scope are skipped)
+		if lRepo.Archived || lRepo.Immutable || lRepo.RenameTo != "" || !r.scope.HasRepository(reponame) {
+			continue
+		}
+		if lRepo.Spec.DependabotAlerts == nil && lRepo.Spec.DependabotSecurityUpdates == nil {
+			continue
+		}
+		rRepo := findRemoteRepository(rRepositories, rRepositoriesLowerCase, reponame)
+		if rRepo == nil || rRepo.BoolProperties["archived"] {
+			continue
+		}
+		lRepos[rRepo.Name] = lRepo
+		reponames = append(reponames, rRepo.Name)
+	}
+	if len(reponames) == 0 {
+		return
+	}
+	sort.Strings(reponames)
+
+	rDependabot, err := remote.RepositoriesDependabot(ctx, reponames)
+	if err != nil {
+		logrus.Warnf("not able to fetch the repositories dependabot settings (skipping them): %v", err)
+		return
+	}
+
+	for _, reponame := range reponames {
+		current, ok := rDependabot[reponame]
+		if !ok {
+			continue
+		}
+		spec := lRepos[reponame].Spec
+
+		alerts := current.Alerts
+		if spec.DependabotAlerts != nil {
+			alerts = *spec.DependabotAlerts
+		}
+		securityUpdates := current.SecurityUpdates
+		if spec.DependabotSecurityUpdates != nil {
+			securityUpdates = *spec.DependabotSecurityUpdates
+		}
+		if spec.DependabotSecurityUpdates != nil && *spec.DependabotSecurityUpdates {
+			alerts = true
+		} else if !alerts {
+			securityUpdates = false
+		}
+
+		if current.SecurityUpdates && !securityUpdates {
+			r.UpdateRepositoryDependabotSecurityUpdates(ctx, dryrun, reponame, current.SecurityUpdates, false)
+		}
+		if current.Alerts != alerts {
+			r.UpdateRepositoryDependabotAlerts(ctx, dryrun, reponame, current.Alerts, alerts)
+		}
+		if !current.SecurityUpdates && securityUpdates {
+			r.UpdateRepositoryDependabotSecurityUpdates(ctx, dryrun, reponame, current.SecurityUpdates, true)
+		}
+	}
+}
+
+/*
+ * This function sync the (v2) organization projects linked to the repositories
+ * setting them (an empty list unlinks all of them). Archived repositories are skipped.
+ * The projects not found in the organization are rejected by ValidateProjects
+ * before the reconciliation: here they are only skipped
+ */
+func (r *GoliacReconciliatorImpl) reconciliateProjects(ctx context.Context, local GoliacLocal, remote GoliacRemote, dryrun bool) {
+	rRepositories := remote.Repositories(ctx)
+	rRepositoriesLowerCase := make(map[string]*GithubRepository)
+	for name, rRepo := range rRepositories {
+		rRepositoriesLowerCase[strings.ToLower(name)] = rRepo
+	}
+
+	lRepos := make(map[string]*entity.Repository)
+	reponames := []string{}
+	for reponame, lRepo := range local.Repositories() {
+		// a renamed repository is handled once renamed (and the ones out of the incremental scope are skipped)
+		if lRepo.Archived || lRepo.Immutable || lRepo.RenameTo != "" || !r.scope.HasRepository(reponame) {
+			continue
+		}
+		if lRepo.Spec.Projects == nil {
+			continue
+		}
+		rRepo := findRemoteRepository(rRepositories, rRepositoriesLowerCase, reponame)
+		if rRepo == nil || rRepo.BoolProperties["archived"] {
+			continue
+		}
+		lRepos[rRepo.Name] = lRepo
+		reponames = append(reponames, rRepo.Name)
+	}
+	if len(reponames) == 0 {
+		return
+	}
+	sort.Strings(reponames)
+
+	rProjects, err := remote.RepositoriesProjects(ctx, reponames)
+	if err != nil {
+		logrus.Warnf("not able to fetch the repositories projects (skipping them): %v", err)
+		return
+	}
+	orgProjects, err := remote.OrgProjects(ctx)
+	if err != nil {
+		logrus.Warnf("not able to fetch the organization projects (skipping the repositories projects): %v", err)
+		return
+	}
+
+	for _, reponame := range reponames {
+		linked, ok := rProjects[reponame]
+		if !ok {
+			continue
+		}
+		expected := make(map[string]bool)
+		for _, title := range lRepos[reponame].Spec.Projects {
+			expected[title] = true
+			if _, ok := linked[title]; ok {
+				continue
+			}
+			project, ok := orgProjects[title]
+			if !ok {
+				logrus.Warnf("project %s (of repository %s) not found in the organization, skipping it", title, reponame)
+				continue
+			}
+			r.LinkRepositoryProject(ctx, dryrun, reponame, project)
+		}
+
+		extras := []string{}
+		for title := range linked {
+			if !expected[title] {
+				extras = append(extras, title)
+			}
+		}
+		sort.Strings(extras)
+		for _, title := range extras {
+			r.UnlinkRepositoryProject(ctx, dryrun, reponame, linked[title])
+		}
+	}
+}
+
+func githubLabel(label config.Label) *GithubLabel {
+	return &GithubLabel{
+		Name:        label.Name,
+		Color:       normalizeLabelColor(label.Color),
+		Description: label.Description,
+	}
+}
+
+/*
+normalizeLabelColor returns the color as Github expects it:
+lowercase, without the leading #
+*/
+func normalizeLabelColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(color, "#"))
+}
+
+const CODEOWNERS_FILENAME = ".github/CODEOWNERS"
+
+func codeownersContent(organization string, teamslug string) string {
+	return fmt.Sprintf("# managed by Goliac (manageCodeowners), do not edit\n* @%s/%s\n", organization, teamslug)
+}
+
+/*
+ * This function sync the organization policies (defined in goliac.yaml)
+ * Unset policies are not managed
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgSettings(ctx context.Context, remote *MutableGoliacRemoteImpl, dryrun bool) {
+	policies := r.repoconfig.OrganizationPolicies
+	settings := map[string]*bool{
+		"members_can_create_public_repositories":  policies.MembersCanCreatePublicRepos,
+		"members_can_create_private_repositories": policies.MembersCanCreatePrivateRepos,
+	}
+	// internal repositories only exist on Github Enterprise
+	if remote.IsEnterprise() {
+		settings["members_can_create_internal_repositories"] = policies.MembersCanCreateInternalRepos
+	} else if policies.MembersCanCreateInternalRepos != nil {
+		logrus.Warn("members_can_create_internal_repositories is only supported on Github Enterprise, ignoring it")
+	}
+
+	rSettings := remote.OrgSettings()
+	if rSettings == nil {
+		// we don't know the Github values: don't overwrite them blindly
+		logrus.Warn("the organization settings couldn't be loaded, not reconciling them")
+		return
+	}
+	for _, name := range []string{
+		"members_can_create_public_repositories",
+		"members_can_create_private_repositories",
+		"members_can_create_internal_repositories",
+	} {
+		value := settings[name]
+		if value == nil {
+			continue
+		}
+		rValue, ok := rSettings[name]
+		if !ok {
+			// unknown Github value (not returned): don't update it on every run
+			logrus.Warnf("the organization setting %s couldn't be loaded, not reconciling it", name)
+			continue
+		}
+		if rValue != *value {
+			r.UpdateOrgSetting(ctx, dryrun, remote, name, rValue, *value)
+		}
+	}
+}
+
+/*
+ * This function sync the default branch name of the repositories created in the
+ * organization (defined in goliac.yaml). It is not managed if unset
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgDefaultBranchName(ctx context.Context, remote GoliacRemote, dryrun bool) {
+	branchName := r.repoconfig.OrganizationPolicies.DefaultBranchName
+	if branchName == "" {
+		return
+	}
+	rBranchName := remote.OrgDefaultBranchName(ctx)
+	if rBranchName == "" {
+		// loaded with the organization settings
+		logrus.Warn("the organization default branch name couldn't be loaded, not reconciling it")
+		return
+	}
+	if rBranchName != branchName {
+		r.UpdateOrgDefaultBranchName(ctx, dryrun, rBranchName, branchName)
+	}
+}
+
+/*
+ * This function sync the organization profile (defined in goliac.yaml)
+ * Unset fields are not managed, and an empty field only clears the Github value
+ * if allow_clearing is set
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgProfile(ctx context.Context, remote GoliacRemote, dryrun bool) {
+	profile := r.repoconfig.OrgProfile
+	fields := map[string]*string{
+		"billing_email":    profile.BillingEmail,
+		"company":          profile.Company,
+		"description":      profile.Description,
+		"blog":             profile.Blog,
+		"twitter_username": profile.TwitterUsername,
+	}
+
+	rProfile := remote.OrgProfile(ctx)
+	if rProfile == nil {
+		// we don't know the Github values: don't overwrite them blindly
+		logrus.Warn("the organization profile couldn't be loaded, not reconciling it")
+		return
+	}
+	for _, name := range []string{
+		"billing_email",
+		"company",
+		"description",
+		"blog",
+		"twitter_username",
+	} {
+		value := fields[name]
+		if value == nil {
+			continue
+		}
+		rValue, ok := rProfile[name]
+		if ok && rValue == *value {
+			continue
+		}
+		if *value == "" && !profile.AllowClearing {
+			r.logSkippedCommand(ctx, dryrun, "update_org_profile", "setting: %s %q -> %q, not clearing the organization profile field (allow_clearing is not set)", name, rValue, *value)
+			continue
+		}
+		r.UpdateOrgProfileField(ctx, dryrun, name, rValue, *value)
+	}
+}
+
+/*
+ * This function sync the Github Actions permissions of the organization (defined in goliac.yaml)
+ * They apply to every repository, so they are only managed if manage_actions_permissions is set
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgActionsSettings(ctx context.Context, remote GoliacRemote, dryrun bool) {
+	policies := r.repoconfig.OrganizationPolicies
+	if !policies.ManageActionsPermissions {
+		return
+	}
+
+	settings := map[string]string{}
+	if policies.DefaultWorkflowTokenPermissions != "" {
+		settings["default_workflow_permissions"] = policies.DefaultWorkflowTokenPermissions
+	}
+	if policies.RequireApprovalForForkPRs != nil {
+		settings["require_approval_for_fork_pr_workflows"] = strconv.FormatBool(*policies.RequireApprovalForForkPRs)
+	}
+
+	rSettings := remote.OrgActionsSettings(ctx)
+	for _, name := range []string{
+		"default_workflow_permissions",
+		"require_approval_for_fork_pr_workflows",
+	} {
+		value, ok := settings[name]
+		if !ok {
+			continue
+		}
+		if rValue, ok := rSettings[name]; !ok || rValue != value {
+			r.UpdateOrgActionsSetting(ctx, dryrun, name, rValue, value)
+		}
+	}
+}
+
+/*
+ * This function sync the Github Actions allowed in the organization (defined in goliac.yaml)
+ * They are only managed if manage_allowed_actions is set: the organization then only allows
+ * the selected actions
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgAllowedActions(ctx context.Context, remote GoliacRemote, dryrun bool) {
+	policies := r.repoconfig.OrganizationPolicies
+	if !policies.ManageAllowedActions {
+		return
+	}
+
+	lAllowedActions := &GithubOrgAllowedActions{
+		AllowedActions:     "selected",
+		GithubOwnedAllowed: policies.AllowedActions.GithubOwnedAllowed,
+		VerifiedAllowed:    policies.AllowedActions.VerifiedAllowed,
+		PatternsAllowed:    append([]string{}, policies.AllowedActions.PatternsAllowed...),
+	}
+	sort.Strings(lAllowedActions.PatternsAllowed)
+
+	rAllowedActions := remote.OrgAllowedActions(ctx)
+	if rAllowedActions == nil {
+		logrus.Warn("not able to get the Github Actions allowed in the organization, skipping them")
+		return
+	}
+
+	changes := []string{}
+	if rAllowedActions.AllowedActions != lAllowedActions.AllowedActions {
+		changes = append(changes, fmt.Sprintf("allowed_actions: %s -> %s", rAllowedActions.AllowedActions, lAllowedActions.AllowedActions))
+	}
+	if rAllowedActions.GithubOwnedAllowed != lAllowedActions.GithubOwnedAllowed {
+		changes = append(changes, fmt.Sprintf("github_owned_allowed: %v -> %v", rAllowedActions.GithubOwnedAllowed, lAllowedActions.GithubOwnedAllowed))
+	}
+	if rAllowedActions.VerifiedAllowed != lAllowedActions.VerifiedAllowed {
+		changes = append(changes, fmt.Sprintf("verified_allowed: %v -> %v", rAllowedActions.VerifiedAllowed, lAllowedActions.VerifiedAllowed))
+	}
+	if strings.Join(rAllowedActions.PatternsAllowed, ",") != strings.Join(lAllowedActions.PatternsAllowed, ",") {
+		changes = append(changes, fmt.Sprintf("patterns_allowed: %v -> %v", rAllowedActions.PatternsAllowed, lAllowedActions.PatternsAllowed))
+	}
+	if len(changes) > 0 {
+		r.UpdateOrgAllowedActions(ctx, dryrun, lAllowedActions, changes)
+	}
+}
+
+/*
+ * This function sync the organization webhooks (defined in goliac.yaml), identified by their url
+ * They are only managed if org_webhooks is set (an empty list removes all of them)
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgWebhooks(ctx context.Context, remote GoliacRemote, dryrun bool) {
+	if r.repoconfig.OrgWebhooks == nil {
+		return
+	}
+
+	expected := make(map[string]*GithubOrgWebhook)
+	urls := []string{}
+	for _, w := range r.repoconfig.OrgWebhooks {
+		webhook, err := githubOrgWebhook(w)
+		if err != nil {
+			logrus.Warnf("not able to manage the org webhook %s (skipping it): %v", w.Url, err)
+			// don't remove it either
+			expected[w.Url] = nil
+			continue
+		}
+		if _, ok := expected[w.Url]; ok {
+			logrus.Warnf("the org webhook %s is defined several times, only the first one is managed", w.Url)
+			continue
+		}
+		expected[w.Url] = webhook
+		urls = append(urls, w.Url)
+	}
+	sort.Strings(urls)
+
+	rWebhooks := remote.OrgWebhooks(ctx)
+	if rWebhooks == nil {
+		// we don't know the Github webhooks: don't add them again
+		logrus.Warn("the organization webhooks couldn't be loaded, not reconciling them")
+		return
+	}
+	for _, url := range urls {
+		lWebhook := expected[url]
+		rWebhook, ok := rWebhooks[url]
+		if !ok {
+			r.AddOrgWebhook(ctx, dryrun, lWebhook)
+			continue
+		}
+
+		changes := []string{}
+		if strings.Join(rWebhook.Events, ",") != strings.Join(lWebhook.Events, ",") {
+			changes = append(changes, fmt.Sprintf("events: %v -> %v", rWebhook.Events, lWebhook.Events))
+		}
+		if rWebhook.Active != lWebhook.Active {
+			changes = append(changes, fmt.Sprintf("active: %v -> %v", rWebhook.Active, lWebhook.Active))
+		}
+		if rWebhook.ContentType != lWebhook.ContentType {
+			changes = append(changes, fmt.Sprintf("content_type: %s -> %s", rWebhook.ContentType, lWebhook.ContentType))
+		}
+		// Github never returns the secret: only its presence can be compared
+		if rWebhook.HasSecret != (lWebhook.Secret != "") {
+			changes = append(changes, fmt.Sprintf("secret: %v -> %v", rWebhook.HasSecret, lWebhook.Secret != ""))
+		}
+		if len(changes) > 0 {
+			lWebhook.Id = rWebhook.Id
+			r.UpdateOrgWebhook(ctx, dryrun, lWebhook, changes)
+		}
+	}
+
+	extras := []string{}
+	for url := range rWebhooks {
+		if _, ok := expected[url]; !ok {
+			extras = append(extras, url)
+		}
+	}
+	sort.Strings(extras)
+	for _, url := range extras {
+		r.DeleteOrgWebhook(ctx, dryrun, rWebhooks[url])
+	}
+}
+
+/*
+ * This function sync the teams having the security manager role in the organization (defined in goliac.yaml)
+ * They are only managed if security_manager_teams is set (an empty list removes all of them)
+ */
+func (r *GoliacReconciliatorImpl) reconciliateOrgSecurityManagerTeams(ctx context.Context, local GoliacLocal, remote GoliacRemote, dryrun bool) {
+	if r.repoconfig.SecurityManagerTeams == nil {
+		return
+	}
+
+	lTeams := local.Teams()
+	expected := make(map[string]bool)
+	for _, teamname := range r.repoconfig.SecurityManagerTeams {
+		if _, ok := lTeams[teamname]; !ok {
+			logrus.Warnf("the security manager team %s is not defined in the teams repository (skipping it)", teamname)
+			continue
+		}
+		expected[r.slugs.Team(teamname)] = true
+	}
+
+	rTeams := remote.OrgSecurityManagerTeams(ctx)
+
+	toAdd := []string{}
+	for teamslug := range expected {
+		if !rTeams[teamslug] {
+			toAdd = append(toAdd, teamslug)
+		}
+	}
+	sort.Strings(toAdd)
+	for _, teamslug := range toAdd {
+		r.AddOrgSecurityManagerTeam(ctx, dryrun, teamslug)
+	}
+
+	toRemove := []string{}
+	for teamslug := range rTeams {
+		if !expected[teamslug] {
+			toRemove = append(toRemove, teamslug)
 		}
 	}
+	sort.Strings(toRemove)
+	for _, teamslug := range toRemove {
+		r.RemoveOrgSecurityManagerTeam(ctx, dryrun, teamslug)
+	}
+}
 
-	return r.unmanaged, r.Commit(ctx, dryrun)
+/*
+githubOrgWebhook converts an org webhook of goliac.yaml, with its default
+values, and resolves its secret (from the environment variable it references)
+*/
+func githubOrgWebhook(w config.OrgWebhook) (*GithubOrgWebhook, error) {
+	if w.Url == "" {
+		return nil, fmt.Errorf("the url is not set")
+	}
+	webhook := &GithubOrgWebhook{
+		Url:         w.Url,
+		Events:      append([]string{}, w.Events...),
+		Active:      true,
+		ContentType: w.ContentType,
+	}
+	if len(webhook.Events) == 0 {
+		webhook.Events = []string{"push"}
+	}
+	sort.Strings(webhook.Events)
+	if w.Active != nil {
+		webhook.Active = *w.Active
+	}
+	if webhook.ContentType == "" {
+		webhook.ContentType = "json"
+	}
+	if webhook.ContentType != "json" && webhook.ContentType != "form" {
+		return nil, fmt.Errorf("invalid content_type %s (json or form expected)", webhook.ContentType)
+	}
+	if w.SecretEnv != "" {
+		webhook.Secret = os.Getenv(w.SecretEnv)
+		if webhook.Secret == "" {
+			return nil, fmt.Errorf("the secret environment variable %s is not set", w.SecretEnv)
+		}
+	}
+	return webhook, nil
 }
 
 /*
@@ -89,20 +871,86 @@ func (r *GoliacReconciliatorImpl) Reconciliate(ctx context.Context, local Goliac
 func (r *GoliacReconciliatorImpl) reconciliateUsers(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, dryrun bool) error {
 	ghUsers := remote.Users()
 
+	// Github logins are case insensitive: the key is the lowercase login
 	rUsers := make(map[string]string)
 	for u := range ghUsers {
-		rUsers[u] = u
+		rUsers[strings.ToLower(u)] = u
+	}
+
+	nbAdmins := 0
+	for _, role := range ghUsers {
+		if role == "ADMIN" {
+			nbAdmins++
+		}
+	}
+	promoted := []string{}
+	demoted := []string{}
+
+	// a pending invitation satisfies the membership (the user is not invited again),
+	// unless it is older than pending_invitations_max_age: it is cancelled (and sent again)
+	invitations := make(map[string]*GithubOrgInvitation)
+	for login, invitation := range remote.OrgInvitations() {
+		invitations[strings.ToLower(login)] = invitation
+	}
+	if r.repoconfig.PendingInvitationsMaxAge > 0 {
+		maxAge := time.Duration(r.repoconfig.PendingInvitationsMaxAge) * 24 * time.Hour
+		logins := make([]string, 0, len(invitations))
+		for login := range invitations {
+			logins = append(logins, login)
+		}
+		sort.Strings(logins)
+		for _, login := range logins {
+			if time.Since(invitations[login].CreatedAt) > maxAge {
+				r.CancelOrgInvitation(ctx, dryrun, remote, invitations[login])
+				delete(invitations, login)
+			}
+		}
 	}
 
 	for _, lUser := range local.Users() {
-		user, ok := rUsers[lUser.Spec.GithubID]
+		user, ok := rUsers[strings.ToLower(lUser.Spec.GithubID)]
 
 		if !ok {
+			if _, invited := invitations[strings.ToLower(lUser.Spec.GithubID)]; invited {
+				logrus.Debugf("user %s has a pending invitation to the organization", lUser.Spec.GithubID)
+				continue
+			}
 			// deal with non existing remote user
 			r.AddUserToOrg(ctx, dryrun, remote, lUser.Spec.GithubID)
+			if lUser.GetOrgRole() == "admin" {
+				promoted = append(promoted, lUser.Spec.GithubID)
+			}
 		} else {
-			delete(rUsers, user)
+			delete(rUsers, strings.ToLower(user))
+
+			rRole := "member"
+			if ghUsers[user] == "ADMIN" {
+				rRole = "admin"
+			}
+			if rRole != lUser.GetOrgRole() {
+				if lUser.GetOrgRole() == "admin" {
+					promoted = append(promoted, user)
+				} else {
+					demoted = append(demoted, user)
+				}
+			}
+		}
+	}
+
+	// promote first, to not demote the last owner of the organization
+	sort.Strings(promoted)
+	for _, user := range promoted {
+		r.UpdateUserOrgRole(ctx, dryrun, remote, user, "admin")
+		nbAdmins++
+	}
+	sort.Strings(demoted)
+	for _, user := range demoted {
+		if nbAdmins <= 1 {
+			r.logSkippedCommand(ctx, dryrun, "update_user_org_role", "ghuserid: %s is the last owner of the organization and cannot be demoted", user)
+			continue
 		}
+		r.UpdateUserOrgRole(ctx, dryrun, remote, user, "member")
+		nbAdmins--
 	}
 
 	// remaining (GH) users (aka not found locally)
@@ -114,17 +962,20 @@ func (r *GoliacReconciliatorImpl) reconciliateUsers(ctx context.Context, local G
 }
 
 type GithubTeamComparable struct {
-	Name        string
-	Slug        string
-	Members     []string
-	Maintainers []string
-	ParentTeam  *string
+	Name                string
+	Slug                string
+	Members             []string
+	Maintainers         []string
+	ParentTeam          *string
+	ExternallyManaged   bool                        // members synchronized from the identity provider (not reconciled)
+	ReviewAssignment    *GithubTeamReviewAssignment // nil: disabled remotely, not managed locally
+	NotificationSetting string                      // notifications_enabled, notifications_disabled (empty: not managed locally)
 }
 
 /*
 This function sync teams and team's members,
 */
-func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, dryrun bool) error {
+func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, dryrun bool, scope string) error {
 	ghTeams := remote.Teams()
 	rUsers := remote.Users()
 
@@ -135,25 +986,34 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 
 	rTeams := make(map[string]*GithubTeamComparable)
 	for k, v := range ghTeams {
-		members := make([]string, len(v.Members))
-		copy(members, v.Members)
+		// teams without the GOLIAC_TEAM_NAME_PREFIX/SUFFIX are not managed by Goliac
+		// (they are neither updated nor deleted)
+		if k != "everyone" && !isManagedTeamSlug(k) {
+			continue
+		}
+		if isExcluded(r.repoconfig.ExcludedTeams, k) {
+			continue
+		}
+		members := githubLogins(v.Members)
 		maintainers := []string{}
 
 		// let's filter admin from the maintainers
 		for _, m := range v.Maintainers {
 			if rUsers[m] == "ADMIN" {
-				members = append(members, m)
+				members = append(members, strings.ToLower(m))
 			} else {
-				maintainers = append(maintainers, m)
+				maintainers = append(maintainers, strings.ToLower(m))
 			}
 		}
 
 		team := &GithubTeamComparable{
-			Name:        v.Name,
-			Slug:        v.Slug,
-			Members:     members,
-			Maintainers: maintainers,
-			ParentTeam:  nil,
+			Name:                v.Name,
+			Slug:                v.Slug,
+			Members:             members,
+			Maintainers:         maintainers,
+			ParentTeam:          nil,
+			ReviewAssignment:    v.ReviewAssignment,
+			NotificationSetting: v.NotificationSetting,
 		}
 		if v.ParentTeam != nil {
 			if parent, ok := ghTeamsPerId[*v.ParentTeam]; ok {
@@ -171,10 +1031,10 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 	lUsers := local.Users()
 
 	for teamname, teamvalue := range lTeams {
-		teamslug := slug.Make(teamname)
+		teamslug := r.slugs.Team(teamname)
 
-		// if the team is externally managed, we don't want to touch it
-		// we just remove it from the list
+		// if the team is externally managed (team synchronization with an
+		// identity provider), we manage its existence but not its members
 		if teamvalue.Spec.ExternallyManaged {
 			// let's add it to the special -goliac-owners
 			membersOwners := []string{}
@@ -191,8 +1051,27 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 			}
 			slugTeams[teamslug+config.Config.GoliacTeamOwnerSuffix] = team
 
+			// the team itself keeps its remote members
+			team = &GithubTeamComparable{
+				Name:                GithubTeamName(teamname),
+				Slug:                teamslug,
+				Members:             []string{},
+				Maintainers:         []string{},
+				ExternallyManaged:   true,
+				ReviewAssignment:    teamReviewAssignment(teamvalue),
+				NotificationSetting: teamvalue.Spec.NotificationSetting,
+			}
+			if rt, ok := rTeams[teamslug]; ok {
+				team.Members = append(team.Members, rt.Members...)
+				team.Maintainers = append(team.Maintainers, rt.Maintainers...)
+			}
+			if teamvalue.ParentTeam != nil {
+				parentTeam := r.slugs.Team(*teamvalue.ParentTeam)
+				team.ParentTeam = &parentTeam
+			}
+			slugTeams[teamslug] = team
+
 			r.unmanaged.ExternallyManagedTeams[teamslug] = true
-			delete(rTeams, teamslug)
 			continue
 		}
 
@@ -201,23 +1080,25 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 		// teamvalue.Spec.Members are not github id
 		for _, m := range teamvalue.Spec.Members {
 			if u, ok := lUsers[m]; ok {
-				members = append(members, u.Spec.GithubID)
+				members = append(members, strings.ToLower(u.Spec.GithubID))
 			}
 		}
 		for _, m := range teamvalue.Spec.Owners {
 			if u, ok := lUsers[m]; ok {
-				members = append(members, u.Spec.GithubID)
-				membersOwners = append(membersOwners, u.Spec.GithubID)
+				members = append(members, strings.ToLower(u.Spec.GithubID))
+				membersOwners = append(membersOwners, strings.ToLower(u.Spec.GithubID))
 			}
 		}
 
 		team := &GithubTeamComparable{
-			Name:    teamname,
-			Slug:    teamslug,
-			Members: members,
+			Name:                GithubTeamName(teamname),
+			Slug:                teamslug,
+			Members:             members,
+			ReviewAssignment:    teamReviewAssignment(teamvalue),
+			NotificationSetting: teamvalue.Spec.NotificationSetting,
 		}
 		if teamvalue.ParentTeam != nil {
-			parentTeam := slug.Make(*teamvalue.ParentTeam)
+			parentTeam := r.slugs.Team(*teamvalue.ParentTeam)
 			team.ParentTeam = &parentTeam
 		}
 		slugTeams[teamslug] = team
@@ -235,9 +1116,10 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 	// adding the "everyone" team
 	if r.repoconfig.EveryoneTeamEnabled {
 		everyone := GithubTeamComparable{
-			Name:    "everyone",
-			Slug:    "everyone",
-			Members: []string{},
+			Name:                "everyone",
+			Slug:                "everyone",
+			Members:             []string{},
+			NotificationSetting: r.repoconfig.EveryoneTeamNotificationSetting,
 		}
 		for u := range local.Users() {
 			everyone.Members = append(everyone.Members, u)
@@ -245,13 +1127,50 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 		slugTeams["everyone"] = &everyone
 	}
 
+	// the users with a pending organization invitation are not yet listed as team
+	// members by Github: they are left out of the teams until they accept the
+	// invitation (else the teams would never converge, and they would be added
+	// again at each reconciliation). Only the users invited by this reconciliation
+	// are reported, the other ones are already pending
+	pendingUsers := make(map[string]bool)
+	for login := range remote.OrgInvitations() {
+		pendingUsers[strings.ToLower(login)] = true
+	}
+	if len(pendingUsers) > 0 {
+		teamslugs := make([]string, 0, len(slugTeams))
+		for teamslug := range slugTeams {
+			teamslugs = append(teamslugs, teamslug)
+		}
+		sort.Strings(teamslugs)
+		for _, teamslug := range teamslugs {
+			team := slugTeams[teamslug]
+			// the members of an externally managed team are the remote ones
+			if team.ExternallyManaged {
+				continue
+			}
+			activeMembers := []string{}
+			for _, m := range team.Members {
+				if !pendingUsers[strings.ToLower(m)] {
+					activeMembers = append(activeMembers, m)
+					continue
+				}
+				if remote.Invited(m) {
+					r.logSkippedCommand(ctx, dryrun, "update_team_add_member", "teamslug: %s ghuserid: %s, the user has a pending invitation to the organization (added to the team once the invitation is accepted)", teamslug, m)
+				} else {
+					logrus.Debugf("teamslug: %s ghuserid: %s, the user has a pending invitation to the organization", teamslug, m)
+				}
+			}
+			team.Members = activeMembers
+		}
+	}
+
 	// now we compare local (slugTeams) and remote (rTeams)
 
 	compareTeam := func(teamname string, lTeam *GithubTeamComparable, rTeam *GithubTeamComparable) bool {
-		if res, _, _ := entity.StringArrayEquivalent(lTeam.Members, rTeam.Members); !res {
+		if res, _, _ := entity.StringArrayEquivalentFold(lTeam.Members, rTeam.Members); !res {
 			return false
 		}
-		if res, _, _ := entity.StringArrayEquivalent(lTeam.Maintainers, rTeam.Maintainers); !res {
+		if res, _, _ := entity.StringArrayEquivalentFold(lTeam.Maintainers, rTeam.Maintainers); !res {
 			return false
 		}
 		if (lTeam.ParentTeam == nil && rTeam.ParentTeam != nil) ||
@@ -259,6 +1178,12 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 			(lTeam.ParentTeam != nil && rTeam.ParentTeam != nil && *lTeam.ParentTeam != *rTeam.ParentTeam) {
 			return false
 		}
+		if !sameReviewAssignment(lTeam.ReviewAssignment, rTeam.ReviewAssignment) {
+			return false
+		}
+		if lTeam.NotificationSetting != "" && lTeam.NotificationSetting != rTeam.NotificationSetting {
+			return false
+		}
 
 		return true
 	}
@@ -272,58 +1197,96 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 			parentTeam = &ghTeams[*lTeam.ParentTeam].Id
 		}
 		r.CreateTeam(ctx, dryrun, remote, lTeam.Name, lTeam.Name, parentTeam, lTeam.Members)
+		if lTeam.ReviewAssignment != nil {
+			r.UpdateTeamReviewAssignment(ctx, dryrun, remote, lTeam.Slug, lTeam.ReviewAssignment)
+		}
+		if lTeam.NotificationSetting != "" {
+			r.UpdateTeamNotificationSetting(ctx, dryrun, remote, lTeam.Slug, lTeam.NotificationSetting)
+		}
 	}
 
 	onRemoved := func(key string, lTeam *GithubTeamComparable, rTeam *GithubTeamComparable) {
 		// DELETE team
-		r.DeleteTeam(ctx, dryrun, remote, rTeam.Slug)
+		r.DeleteTeam(ctx, dryrun, remote, rTeam.Slug, "")
 	}
 
+	// last owner (github id) kept per team slug
+	lastOwners := make(map[string]string)
+
 	onChanged := func(slugTeam string, lTeam *GithubTeamComparable, rTeam *GithubTeamComparable) {
-		// change membership from maintainers to members
+		// the members of an externally managed team are never touched
+		// (reported only when the team changes and has members Goliac would otherwise remove)
+		if lTeam.ExternallyManaged {
+			if len(rTeam.Members)+len(rTeam.Maintainers) > 0 {
+				r.logSkippedCommand(ctx, dryrun, "update_team_members", "teamslug: %s, %d members managed externally (externallyManaged)", slugTeam, len(rTeam.Members)+len(rTeam.Maintainers))
+			}
+		} else {
+			// change membership from maintainers to members
 
-		rmaintainers := make([]string, len(rTeam.Maintainers))
-		copy(rmaintainers, rTeam.Maintainers)
+			rmaintainers := make([]string, len(rTeam.Maintainers))
+			copy(rmaintainers, rTeam.Maintainers)
 
-		for _, r_maintainer := range rmaintainers {
-			found := false
-			for _, l_maintainer := range lTeam.Maintainers {
-				if r_maintainer == l_maintainer {
-					found = true
-					break
-				}
-			}
-			if !found {
-				// let's downgrade the maintainer to member
-				r.UpdateTeamChangeMaintainerToMember(ctx, dryrun, remote, slugTeam, r_maintainer)
-				for i, m := range rTeam.Maintainers {
-					if m == r_maintainer {
-						rTeam.Maintainers = append(rTeam.Maintainers[:i], rTeam.Maintainers[i+1:]...)
+			for _, r_maintainer := range rmaintainers {
+				found := false
+				for _, l_maintainer := range lTeam.Maintainers {
+					if r_maintainer == l_maintainer {
+						found = true
 						break
 					}
 				}
-				rTeam.Members = append(rTeam.Members, r_maintainer)
+				if !found {
+					// let's downgrade the maintainer to member
+					r.UpdateTeamChangeMaintainerToMember(ctx, dryrun, remote, slugTeam, r_maintainer)
+					for i, m := range rTeam.Maintainers {
+						if m == r_maintainer {
+							rTeam.Maintainers = append(rTeam.Maintainers[:i], rTeam.Maintainers[i+1:]...)
+							break
+						}
+					}
+					rTeam.Members = append(rTeam.Members, r_maintainer)
+				}
 			}
-		}
 
-		// membership change
-		if res, _, _ := entity.StringArrayEquivalent(lTeam.Members, rTeam.Members); !res {
-			localMembers := make(map[string]bool)
-			for _, m := range lTeam.Members {
-				localMembers[m] = true
-			}
+			// membership change (the deltas are flushed in one call per team)
+			if res, _, _ := entity.StringArrayEquivalentFold(lTeam.Members, rTeam.Members); !res {
+				localMembers := make(map[string]bool)
+				for _, m := range lTeam.Members {
+					localMembers[m] = true
+				}
 
-			for _, m := range rTeam.Members {
-				if _, ok := localMembers[m]; !ok {
-					// REMOVE team member
-					r.UpdateTeamRemoveMember(ctx, dryrun, remote, slugTeam, m)
-				} else {
-					delete(localMembers, m)
+				membersToRemove := []string{}
+				for _, m := range rTeam.Members {
+					if _, ok := localMembers[m]; !ok {
+						membersToRemove = append(membersToRemove, m)
+					} else {
+						delete(localMembers, m)
+					}
+				}
+				membersToAdd := []string{}
+				for m := range localMembers {
+					membersToAdd = append(membersToAdd, m)
+				}
+				sort.Strings(membersToAdd)
+
+				// the last owner of the team is kept
+				if lastOwner, ok := lastOwners[slugTeam]; ok {
+					for i, m := range membersToRemove {
+						if m == lastOwner {
+							membersToRemove = append(membersToRemove[:i], membersToRemove[i+1:]...)
+							r.logSkippedCommand(ctx, dryrun, "update_team_remove_members", "teamslug: %s ghuserid: %s, refusing to remove the last owner of the team (a team must keep at least one owner)", slugTeam, lastOwner)
+							break
+						}
+					}
+				}
+
+				// REMOVE team members
+				if len(membersToRemove) > 0 {
+					r.UpdateTeamRemoveMembers(ctx, dryrun, remote, slugTeam, membersToRemove)
+				}
+				// ADD team members
+				if len(membersToAdd) > 0 {
+					r.UpdateTeamAddMembers(ctx, dryrun, remote, slugTeam, membersToAdd, "member")
 				}
-			}
-			for m := range localMembers {
-				// ADD team member
-				r.UpdateTeamAddMember(ctx, dryrun, remote, slugTeam, m, "member")
 			}
 		}
 
@@ -341,6 +1304,61 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 
 			r.UpdateTeamSetParent(ctx, dryrun, remote, slugTeam, parentTeam, parentTeamName)
 		}
+
+		// code review assignment change
+		if !sameReviewAssignment(lTeam.ReviewAssignment, rTeam.ReviewAssignment) {
+			r.UpdateTeamReviewAssignment(ctx, dryrun, remote, slugTeam, lTeam.ReviewAssignment)
+		}
+
+		// notification setting change (left untouched if not set locally)
+		if lTeam.NotificationSetting != "" && lTeam.NotificationSetting != rTeam.NotificationSetting {
+			r.UpdateTeamNotificationSetting(ctx, dryrun, remote, slugTeam, lTeam.NotificationSetting)
+		}
+	}
+
+	// only reconciliate one team (and its owners team)
+	if scope != "" {
+		for _, teams := range []map[string]*GithubTeamComparable{slugTeams, rTeams} {
+			for teamslug := range teams {
+				if teamslug != scope && teamslug != scope+config.Config.GoliacTeamOwnerSuffix {
+					delete(teams, teamslug)
+				}
+			}
+		}
+	}
+
+	// only reconciliate the teams of the incremental scope (and their owners teams)
+	if r.scope != nil {
+		scopeSlugs := make(map[string]bool)
+		for teamname := range r.scope.Teams {
+			scopeSlugs[r.slugs.Team(teamname)] = true
+			scopeSlugs[r.slugs.Team(teamname)+config.Config.GoliacTeamOwnerSuffix] = true
+		}
+		for _, teams := range []map[string]*GithubTeamComparable{slugTeams, rTeams} {
+			for teamslug := range teams {
+				if !scopeSlugs[teamslug] {
+					delete(teams, teamslug)
+				}
+			}
+		}
+	}
+
+	// a team must keep at least one owner: the removal of its last owner (or
+	// maintainer) is refused, unless the team itself is deleted
+	for teamslug, lTeam := range slugTeams {
+		if !strings.HasSuffix(teamslug, config.Config.GoliacTeamOwnerSuffix) || len(lTeam.Members) > 0 {
+			continue
+		}
+		if rTeam, ok := rTeams[teamslug]; ok {
+			owners := append(append([]string{}, rTeam.Members...), rTeam.Maintainers...)
+			if len(owners) == 0 {
+				continue
+			}
+			sort.Strings(owners)
+			lastOwners[teamslug] = owners[0]
+			// the owner stays a member of the team itself
+			lastOwners[strings.TrimSuffix(teamslug, config.Config.GoliacTeamOwnerSuffix)] = owners[0]
+		}
 	}
 
 	CompareEntities(slugTeams, rTeams, compareTeam, onAdded, onRemoved, onChanged)
@@ -348,27 +1366,356 @@ func (r *GoliacReconciliatorImpl) reconciliateTeams(ctx context.Context, local G
 	return nil
 }
 
+/*
+teamReviewAssignment returns the code review assignment of a team
+(in the Github format), or nil if the team doesn't define it
+*/
+func teamReviewAssignment(team *entity.Team) *GithubTeamReviewAssignment {
+	if team.Spec.ReviewAssignment == nil {
+		return nil
+	}
+	return &GithubTeamReviewAssignment{
+		Algorithm:        strings.ToUpper(team.Spec.ReviewAssignment.Algorithm),
+		TeamMembersCount: team.Spec.ReviewAssignment.TeamMembersCount,
+		NotifyTeam:       team.Spec.ReviewAssignment.NotifyTeam,
+	}
+}
+
+/*
+sameReviewAssignment tells if the remote code review assignment matches
+the local one. A team without local review assignment is not managed
+(the remote setting is kept as is)
+*/
+func sameReviewAssignment(lReviewAssignment *GithubTeamReviewAssignment, rReviewAssignment *GithubTeamReviewAssignment) bool {
+	if lReviewAssignment == nil {
+		return true
+	}
+	return rReviewAssignment != nil && *lReviewAssignment == *rReviewAssignment
+}
+
+/*
+mergeCommitStrategies lists, per merge strategy, the property enabling it
+and its commit title and message properties
+*/
+var mergeCommitStrategies = []struct {
+	allowed string
+	title   string
+	message string
+}{
+	{"allow_squash_merge", "squash_merge_commit_title", "squash_merge_commit_message"},
+	{"allow_merge_commit", "merge_commit_title", "merge_commit_message"},
+}
+
+/*
+mergeStrategies returns the merge strategies set locally (directly or through
+the default_merge_strategy of goliac.yaml), the other ones are left untouched
+*/
+func mergeStrategies(lRepo *entity.Repository) map[string]bool {
+	strategies := map[string]bool{}
+	for property, value := range map[string]*bool{
+		"allow_squash_merge": lRepo.Spec.AllowSquashMerge,
+		"allow_merge_commit": lRepo.Spec.AllowMergeCommit,
+		"allow_rebase_merge": lRepo.Spec.AllowRebaseMerge,
+	} {
+		if value != nil {
+			strategies[property] = *value
+		}
+	}
+	return strategies
+}
+
+/*
+mergeCommitProperties returns the merge commit titles and messages set locally.
+They are only reconciled when the merge strategy is enabled on the (existing)
+repository, or is being enabled, else Github rejects them
+*/
+func (r *GoliacReconciliatorImpl) mergeCommitProperties(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, lRepo *entity.Repository) map[string]string {
+	lProperties := map[string]string{
+		"squash_merge_commit_title":   lRepo.Spec.SquashMergeCommitTitle,
+		"squash_merge_commit_message": lRepo.Spec.SquashMergeCommitMessage,
+		"merge_commit_title":          lRepo.Spec.MergeCommitTitle,
+		"merge_commit_message":        lRepo.Spec.MergeCommitMessage,
+	}
+	properties := map[string]string{}
+
+	rRepo, ok := remote.Repositories()[utils.GithubAnsiString(reponame)]
+	if !ok {
+		return properties
+	}
+	lStrategies := mergeStrategies(lRepo)
+	for _, strategy := range mergeCommitStrategies {
+		allowed, ok := lStrategies[strategy.allowed]
+		if !ok {
+			allowed = rRepo.BoolProperties[strategy.allowed]
+		}
+		for _, property := range []string{strategy.title, strategy.message} {
+			lv := lProperties[property]
+			if lv == "" {
+				continue
+			}
+			if allowed {
+				properties[property] = lv
+			} else if rRepo.StringProperties[property] != lv {
+				r.logSkippedCommand(ctx, dryrun, "update_repository_update_string_properties", "repositoryname: %s %s:%s, %s is not enabled", reponame, property, lv, strategy.allowed)
+			}
+		}
+	}
+	return properties
+}
+
 type GithubRepoComparable struct {
-	BoolProperties      map[string]bool
-	Writers             []string
-	Readers             []string
-	ExternalUserReaders []string // githubids
-	ExternalUserWriters []string // githubids
-	InternalUsers       []string // githubids
-	Rulesets            map[string]*GithubRuleSet
+	BoolProperties           map[string]bool   // has_issues, has_wiki, has_projects and allow_forking only if set locally
+	StringProperties         map[string]string // merge commit titles and messages, only if set locally (and the merge strategy is enabled)
+	Writers                  []string          // teams with push permission
+	Readers                  []string          // teams with pull permission
+	Admins                   []string          // teams with admin permission
+	Maintainers              []string          // teams with maintain permission
+	Triagers                 []string          // teams with triage permission
+	ExternalUserReaders      []string          // githubids
+	ExternalUserWriters      []string          // githubids
+	ExternalUserAdmins       []string          // githubids
+	InternalUsers            []string          // githubids
+	Rulesets                 map[string]*GithubRuleSet
+	CustomProperties         map[string]string // Enterprise only
+	AllowVisibilityReduction bool              // allow to go from public to private (forks are detached)
+	InheritedTeams           map[string]string // remote only: teams access inherited from a parent team (teamslug -> permission)
+	ForkOf                   string            // local only: upstream repository (owner/repo) to fork when creating the repository
+	NoInheritedAccess        bool              // local only: the teams accesses inherited from a parent team are not wanted (allowInheritedAccess: false)
+}
+
+/*
+TeamsPermissions returns the teams permission (admin, maintain, push, triage, pull)
+on the repository, by team slug
+*/
+func (c *GithubRepoComparable) TeamsPermissions() map[string]string {
+	permissions := make(map[string]string)
+	for _, t := range c.Readers {
+		permissions[t] = "pull"
+	}
+	for _, t := range c.Triagers {
+		permissions[t] = "triage"
+	}
+	for _, t := range c.Writers {
+		permissions[t] = "push"
+	}
+	for _, t := range c.Maintainers {
+		permissions[t] = "maintain"
+	}
+	for _, t := range c.Admins {
+		permissions[t] = "admin"
+	}
+	return permissions
+}
+
+/*
+TeamsPermissionsChanges returns the teams permissions to apply on the remote repository
+to match c (the local repository):
+- the teams to add (teamslug -> permission)
+- the teams to update (teamslug -> permission)
+- the teams to remove
+Accesses inherited from a parent team are not managed here (but through the parent team)
+*/
+func (c *GithubRepoComparable) TeamsPermissionsChanges(remote *GithubRepoComparable) (map[string]string, map[string]string, []string) {
+	toAdd := make(map[string]string)
+	toUpdate := make(map[string]string)
+	toRemove := []string{}
+
+	lPermissions := c.TeamsPermissions()
+	rPermissions := remote.TeamsPermissions()
+	for teamSlug, lPermission := range lPermissions {
+		if rPermission, ok := rPermissions[teamSlug]; ok {
+			if rPermission != lPermission {
+				toUpdate[teamSlug] = lPermission
+			}
+		} else if remote.InheritedTeams[teamSlug] != lPermission {
+			toAdd[teamSlug] = lPermission
+		}
+	}
+	for teamSlug := range rPermissions {
+		if _, ok := lPermissions[teamSlug]; !ok {
+			toRemove = append(toRemove, teamSlug)
+		}
+	}
+	return toAdd, toUpdate, toRemove
+}
+
+/*
+ExternalUsersPermissions returns the external users permission (admin, push, pull)
+on the repository, by githubid
+*/
+func (c *GithubRepoComparable) ExternalUsersPermissions() map[string]string {
+	permissions := make(map[string]string)
+	for _, u := range c.ExternalUserReaders {
+		permissions[u] = "pull"
+	}
+	for _, u := range c.ExternalUserWriters {
+		permissions[u] = "push"
+	}
+	for _, u := range c.ExternalUserAdmins {
+		permissions[u] = "admin"
+	}
+	return permissions
+}
+
+/*
+ExternalUsersPermissionsChanges returns the external users permissions to apply
+on the remote repository to match c (the local repository):
+- the external users to set, added or with a different permission (githubid -> permission)
+- the external users to remove
+*/
+func (c *GithubRepoComparable) ExternalUsersPermissionsChanges(remote *GithubRepoComparable) (map[string]string, []string) {
+	toSet := make(map[string]string)
+	toRemove := []string{}
+
+	lPermissions := c.ExternalUsersPermissions()
+	rPermissions := remote.ExternalUsersPermissions()
+	for githubid, lPermission := range lPermissions {
+		if rPermissions[githubid] != lPermission {
+			toSet[githubid] = lPermission
+		}
+	}
+	for githubid := range rPermissions {
+		if _, ok := lPermissions[githubid]; !ok {
+			toRemove = append(toRemove, githubid)
+		}
+	}
+	sort.Strings(toRemove)
+	return toSet, toRemove
+}
+
+/*
+repositoryExternalUsers returns the githubids of the external users reading, writing
+and administrating a repository:
+- the ones listed in the repository definition
+- the ones with a default access on the repositories matching their repoPattern
+An external user listed in the repository definition overrides its default access
+(the admin access can only be granted explicitly). An expired access (see
+externalUsersExpiresAt) is ignored, as if the external user was not listed.
+The githubids are returned in lowercase (Github logins are case insensitive).
+The repoPatterns are the ones compiled by externalUsersRepoPatterns.
+*/
+func repositoryExternalUsers(externalUsers map[string]*entity.User, repoPatterns map[string]*regexp.Regexp, reponame string, lRepo *entity.Repository) ([]string, []string, []string) {
+	eReaders := make([]string, 0)
+	eWriters := make([]string, 0)
+	eAdmins := make([]string, 0)
+	explicit := make(map[string]bool)
+	now := time.Now()
+	for _, r := range lRepo.Spec.ExternalUserReaders {
+		if lRepo.IsExternalUserExpired(r, now) {
+			continue
+		}
+		if user, ok := externalUsers[r]; ok {
+			eReaders = append(eReaders, user.Spec.GithubID)
+			explicit[r] = true
+		}
+	}
+	for _, w := range lRepo.Spec.ExternalUserWriters {
+		if lRepo.IsExternalUserExpired(w, now) {
+			continue
+		}
+		if user, ok := externalUsers[w]; ok {
+			eWriters = append(eWriters, user.Spec.GithubID)
+			explicit[w] = true
+		}
+	}
+	for _, a := range lRepo.Spec.ExternalUserAdmins {
+		if lRepo.IsExternalUserExpired(a, now) {
+			continue
+		}
+		if user, ok := externalUsers[a]; ok {
+			eAdmins = append(eAdmins, user.Spec.GithubID)
+			explicit[a] = true
+		}
+	}
+
+	usernames := make([]string, 0, len(externalUsers))
+	for username := range externalUsers {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	for _, username := range usernames {
+		user := externalUsers[username]
+		if explicit[username] || user.Spec.DefaultAccess == "" {
+			continue
+		}
+		match, ok := repoPatterns[username]
+		if !ok || !match.MatchString(reponame) {
+			continue
+		}
+		if user.Spec.DefaultAccess == "write" {
+			eWriters = append(eWriters, user.Spec.GithubID)
+		} else {
+			eReaders = append(eReaders, user.Spec.GithubID)
+		}
+	}
+	return githubLogins(eReaders), githubLogins(eWriters), githubLogins(eAdmins)
+}
+
+/*
+externalUsersRepoPatterns compiles (once per run) the repoPattern of the external
+users having a default access, by username. An invalid pattern is reported and skipped.
+*/
+func externalUsersRepoPatterns(externalUsers map[string]*entity.User) map[string]*regexp.Regexp {
+	repoPatterns := make(map[string]*regexp.Regexp)
+	for username, user := range externalUsers {
+		if user.Spec.DefaultAccess == "" || user.Spec.RepoPattern == "" {
+			continue
+		}
+		match, err := regexp.Compile(user.Spec.RepoPattern)
+		if err != nil {
+			logrus.Warnf("not able to parse the repoPattern %s of the external user %s: %v", user.Spec.RepoPattern, username, err)
+			continue
+		}
+		repoPatterns[username] = match
+	}
+	return repoPatterns
+}
+
+/*
+RepositoryExternalUsersPermissions returns the permission (pull, push or admin)
+of the external users on a repository, by (lowercase) githubid
+*/
+func RepositoryExternalUsersPermissions(externalUsers map[string]*entity.User, reponame string, lRepo *entity.Repository) map[string]string {
+	eReaders, eWriters, eAdmins := repositoryExternalUsers(externalUsers, externalUsersRepoPatterns(externalUsers), reponame, lRepo)
+	c := GithubRepoComparable{
+		ExternalUserReaders: eReaders,
+		ExternalUserWriters: eWriters,
+		ExternalUserAdmins:  eAdmins,
+	}
+	return c.ExternalUsersPermissions()
+}
+
+/*
+githubLogins returns a lowercase copy of the logins: Github logins are case
+insensitive, but Github returns them with their own case
+*/
+func githubLogins(logins []string) []string {
+	lowercase := make([]string, len(logins))
+	for i, login := range logins {
+		lowercase[i] = strings.ToLower(login)
+	}
+	return lowercase
 }
 
 /*
  * This function sync repositories and team's repositories permissions
  * It returns the list of deleted repos that must not be deleted but archived
+ * and the list of archived repos that were deleted (once their grace period elapsed)
  */
-func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, teamsreponame string, dryrun bool, toArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository) error {
+func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, teamsreponame string, dryrun bool, toArchive map[string]*GithubRepoComparable, reposToRename map[string]*entity.Repository, toDelete map[string]bool) error {
 
 	// let's start with the local cloned github-teams repo
 	lRepos := make(map[string]*GithubRepoComparable)
 
 	localRepositories := make(map[string]*entity.Repository)
+	// immutable repositories (lowercase) are neither created, updated nor deleted
+	immutableRepositories := make(map[string]bool)
 	for reponame, repo := range local.Repositories() {
+		if repo.Immutable {
+			immutableRepositories[strings.ToLower(reponame)] = true
+			continue
+		}
 
 		// we rename the repository before we start to reconciliate
 		if repo.RenameTo != "" {
@@ -387,29 +1734,68 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 		localRepositories[reponame] = repo
 	}
 
+	// repositories archived on delete are deleted once their grace period elapsed
+	if r.repoconfig.RepositoryDeletionGracePeriod > 0 && r.repoconfig.DestructiveOperations.AllowDestructiveRepositories {
+		gracePeriod := time.Duration(r.repoconfig.RepositoryDeletionGracePeriod) * 24 * time.Hour
+		for reponame, repo := range localRepositories {
+			if !repo.Archived || repo.ArchivedAt == nil || time.Since(*repo.ArchivedAt) < gracePeriod {
+				continue
+			}
+			delete(localRepositories, reponame)
+			if _, ok := remote.Repositories()[reponame]; ok {
+				// the archived definition is kept until a later run confirms the
+				// repository is gone (the Github deletion may fail)
+				r.DeleteRepository(ctx, dryrun, remote, reponame, repo.DeletionReason)
+				continue
+			}
+			// in the post action we have to also remove it from the git repository
+			toDelete[reponame] = true
+		}
+	}
+
 	// let's get the remote now
 	rRepos := make(map[string]*GithubRepoComparable)
 
 	ghRepos := remote.Repositories()
 	for k, v := range ghRepos {
+		// excluded repositories are neither updated nor deleted
+		if isExcluded(r.repoconfig.ExcludedRepositories, k) || immutableRepositories[strings.ToLower(k)] {
+			continue
+		}
 		repo := &GithubRepoComparable{
 			BoolProperties:      map[string]bool{},
+			StringProperties:    map[string]string{},
 			Writers:             []string{},
 			Readers:             []string{},
+			Admins:              []string{},
+			Maintainers:         []string{},
+			Triagers:            []string{},
 			ExternalUserReaders: []string{},
 			ExternalUserWriters: []string{},
+			ExternalUserAdmins:  []string{},
 			InternalUsers:       []string{},
 			Rulesets:            v.RuleSets,
+			CustomProperties:    map[string]string{},
+			InheritedTeams:      map[string]string{},
 		}
 		for pk, pv := range v.BoolProperties {
 			repo.BoolProperties[pk] = pv
 		}
+		for pk, pv := range v.StringProperties {
+			repo.StringProperties[pk] = pv
+		}
+		for pk, pv := range v.CustomProperties {
+			repo.CustomProperties[pk] = pv
+		}
 
 		for cGithubid, cPermission := range v.ExternalUsers {
-			if cPermission == "WRITE" {
-				repo.ExternalUserWriters = append(repo.ExternalUserWriters, cGithubid)
-			} else {
-				repo.ExternalUserReaders = append(repo.ExternalUserReaders, cGithubid)
+			switch cPermission {
+			case "ADMIN":
+				repo.ExternalUserAdmins = append(repo.ExternalUserAdmins, strings.ToLower(cGithubid))
+			case "WRITE":
+				repo.ExternalUserWriters = append(repo.ExternalUserWriters, strings.ToLower(cGithubid))
+			default:
+				repo.ExternalUserReaders = append(repo.ExternalUserReaders, strings.ToLower(cGithubid))
 			}
 		}
 
@@ -421,69 +1807,43 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 		rRepos[k] = repo
 	}
 
-	// on the remote object, I have teams->repos, and I need repos->teams
-	for t, repos := range remote.TeamRepositories() {
-		for r, p := range repos {
-			if rr, ok := rRepos[r]; ok {
-				if p.Permission == "ADMIN" || p.Permission == "WRITE" {
-					rr.Writers = append(rr.Writers, t)
-				} else {
-					rr.Readers = append(rr.Readers, t)
-				}
-			}
-		}
+	// adding the teams repo (if it lives in this organization)
+	if teamsreponame != "" {
+		localRepositories[teamsreponame] = r.teamsRepository(teamsreponame)
 	}
 
-	// adding the teams repo
-	teamsRepo := &entity.Repository{}
-	teamsRepo.ApiVersion = "v1"
-	teamsRepo.Kind = "Repository"
-	teamsRepo.Name = teamsreponame
-	teamsRepo.Spec.Writers = []string{r.repoconfig.AdminTeam}
-	teamsRepo.Spec.Readers = []string{}
-	teamsRepo.Spec.IsPublic = false
-	teamsRepo.Spec.DeleteBranchOnMerge = true
-	localRepositories[teamsreponame] = teamsRepo
-
+	externalUsers := local.ExternalUsers()
+	repoPatterns := externalUsersRepoPatterns(externalUsers)
 	for reponame, lRepo := range localRepositories {
-		writers := make([]string, 0)
-		for _, w := range lRepo.Spec.Writers {
-			writers = append(writers, slug.Make(w))
-		}
-		// add the team owner's name ;-)
-		if lRepo.Owner != nil {
-			writers = append(writers, slug.Make(*lRepo.Owner))
-		}
-		readers := make([]string, 0)
-		for _, r := range lRepo.Spec.Readers {
-			readers = append(readers, slug.Make(r))
-		}
-
-		// special case for the Goliac "teams" repo
-		if reponame == teamsreponame {
-			for teamname := range local.Teams() {
-				writers = append(writers, slug.Make(teamname)+config.Config.GoliacTeamOwnerSuffix)
-			}
-		}
-
-		// adding the "everyone" team to each repository
-		if r.repoconfig.EveryoneTeamEnabled {
-			readers = append(readers, "everyone")
-		}
+		permissions := r.repositoryTeamsPermissions(ctx, dryrun, local, remote, teamsreponame, reponame, lRepo)
 
-		// adding exernal reader/writer
-		eReaders := make([]string, 0)
-		for _, r := range lRepo.Spec.ExternalUserReaders {
-			if user, ok := local.ExternalUsers()[r]; ok {
-				eReaders = append(eReaders, user.Spec.GithubID)
+		admins := make([]string, 0)
+		maintainers := make([]string, 0)
+		writers := make([]string, 0)
+		triagers := make([]string, 0)
+		readers := make([]string, 0)
+		for teamslug, permission := range permissions {
+			switch permission {
+			case "admin":
+				admins = append(admins, teamslug)
+			case "maintain":
+				maintainers = append(maintainers, teamslug)
+			case "push":
+				writers = append(writers, teamslug)
+			case "triage":
+				triagers = append(triagers, teamslug)
+			default:
+				readers = append(readers, teamslug)
 			}
 		}
 
+		// adding exernal reader/writer/admin
+		eReaders := make([]string, 0)
 		eWriters := make([]string, 0)
-		for _, w := range lRepo.Spec.ExternalUserWriters {
-			if user, ok := local.ExternalUsers()[w]; ok {
-				eWriters = append(eWriters, user.Spec.GithubID)
-			}
+		eAdmins := make([]string, 0)
+		// (no external user on the special Goliac "teams" repo)
+		if reponame != teamsreponame {
+			eReaders, eWriters, eAdmins = repositoryExternalUsers(externalUsers, repoPatterns, reponame, lRepo)
 		}
 
 		rulesets := make(map[string]*GithubRuleSet)
@@ -492,6 +1852,7 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 				Name:        rs.Name,
 				Enforcement: rs.Enforcement,
 				BypassApps:  map[string]string{},
+				BypassTeams: map[string]string{},
 				OnInclude:   rs.Conditions.Include,
 				OnExclude:   rs.Conditions.Exclude,
 				Rules:       map[string]entity.RuleSetParameters{},
@@ -499,26 +1860,123 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 			for _, b := range rs.BypassApps {
 				ruleset.BypassApps[b.AppName] = b.Mode
 			}
+			// the owner team administers the repository: it can bypass its rulesets
+			if lRepo.Owner != nil && (rs.OwnerBypass == nil || *rs.OwnerBypass) {
+				ruleset.BypassTeams[r.slugs.Team(*lRepo.Owner)] = "always"
+			}
 			for _, r := range rs.Rules {
 				ruleset.Rules[r.Ruletype] = r.Parameters
 			}
+			r.resolveStatusChecksIntegrations(ctx, remote, ruleset.Rules, []string{reponame})
 			rulesets[rs.Name] = &ruleset
 		}
+		if reponame == teamsreponame {
+			ruleset, err := r.teamsRepositoryRuleset(remote)
+			if err != nil {
+				// a lookup failure must not remove the protection: the Github one is kept as is
+				logrus.Warnf("%v, the teams repository protection is left unchanged", err)
+				if rRepo, ok := remote.Repositories()[utils.GithubAnsiString(reponame)]; ok {
+					if rRuleset, ok := rRepo.RuleSets[TEAMS_REPOSITORY_RULESET]; ok {
+						rulesets[TEAMS_REPOSITORY_RULESET] = rRuleset
+					}
+				}
+			} else if ruleset != nil {
+				rulesets[ruleset.Name] = ruleset
+			}
+		}
+
+		// custom properties are only available for Enterprise
+		customProperties := make(map[string]string)
+		if remote.IsEnterprise() {
+			for k, v := range lRepo.Spec.CustomProperties {
+				customProperties[k] = v
+			}
+		}
+
+		boolProperties := map[string]bool{
+			"private":                !lRepo.Spec.IsPublic,
+			"archived":               lRepo.Archived,
+			"allow_auto_merge":       lRepo.Spec.AllowAutoMerge,
+			"delete_branch_on_merge": lRepo.Spec.DeleteBranchOnMerge,
+			"allow_update_branch":    lRepo.Spec.AllowUpdateBranch,
+		}
+		// features not set locally are left untouched
+		if lRepo.Spec.HasIssues != nil {
+			boolProperties["has_issues"] = *lRepo.Spec.HasIssues
+		}
+		if lRepo.Spec.HasWiki != nil {
+			boolProperties["has_wiki"] = *lRepo.Spec.HasWiki
+		}
+		if lRepo.Spec.HasProjects != nil {
+			boolProperties["has_projects"] = *lRepo.Spec.HasProjects
+		}
+		for property, value := range mergeStrategies(lRepo) {
+			boolProperties[property] = value
+		}
+		// the fork policy of a public repository is forced by Github
+		if lRepo.Spec.AllowForking != nil {
+			if !lRepo.Spec.IsPublic {
+				boolProperties["allow_forking"] = *lRepo.Spec.AllowForking
+			} else if rRepo, ok := remote.Repositories()[utils.GithubAnsiString(reponame)]; ok && rRepo.BoolProperties["allow_forking"] != *lRepo.Spec.AllowForking {
+				r.logSkippedCommand(ctx, dryrun, "update_repository_update_bool_property", "repositoryname: %s allow_forking:%v, the fork policy of a public repository is forced by Github", reponame, *lRepo.Spec.AllowForking)
+			}
+		}
 
 		lRepos[utils.GithubAnsiString(reponame)] = &GithubRepoComparable{
-			BoolProperties: map[string]bool{
-				"private":                !lRepo.Spec.IsPublic,
-				"archived":               lRepo.Archived,
-				"allow_auto_merge":       lRepo.Spec.AllowAutoMerge,
-				"delete_branch_on_merge": lRepo.Spec.DeleteBranchOnMerge,
-				"allow_update_branch":    lRepo.Spec.AllowUpdateBranch,
-			},
-			Readers:             readers,
-			Writers:             writers,
-			ExternalUserReaders: eReaders,
-			ExternalUserWriters: eWriters,
-			InternalUsers:       []string{},
-			Rulesets:            rulesets,
+			BoolProperties:           boolProperties,
+			StringProperties:         r.mergeCommitProperties(ctx, dryrun, remote, reponame, lRepo),
+			Readers:                  readers,
+			Writers:                  writers,
+			Admins:                   admins,
+			Maintainers:              maintainers,
+			Triagers:                 triagers,
+			ExternalUserReaders:      eReaders,
+			ExternalUserWriters:      eWriters,
+			ExternalUserAdmins:       eAdmins,
+			InternalUsers:            []string{},
+			Rulesets:                 rulesets,
+			CustomProperties:         customProperties,
+			AllowVisibilityReduction: lRepo.Spec.AllowVisibilityReduction,
+			ForkOf:                   lRepo.Spec.ForkOf,
+			NoInheritedAccess:        lRepo.Spec.AllowInheritedAccess != nil && !*lRepo.Spec.AllowInheritedAccess,
+		}
+	}
+
+	// on the remote object, I have teams->repos, and I need repos->teams
+	// (once the local repositories are known, to tell the direct grants from the inherited ones)
+	rTeamRepositories := remote.TeamRepositories()
+	rTeams := remote.Teams()
+	rTeamsById := teamsByIdIndex(rTeams)
+	lTeamsPermissions := make(map[string]map[string]string, len(lRepos))
+	for reponame, lRepo := range lRepos {
+		lTeamsPermissions[reponame] = lRepo.TeamsPermissions()
+	}
+	for t, repos := range rTeamRepositories {
+		// the accesses of the excluded teams are left untouched
+		if isExcluded(r.repoconfig.ExcludedTeams, t) {
+			continue
+		}
+		for r, p := range repos {
+			if rr, ok := rRepos[r]; ok {
+				// access inherited from a parent team is managed through the parent team
+				_, declared := lTeamsPermissions[r][t]
+				if isTeamRepoAccessInherited(rTeams, rTeamsById, rTeamRepositories, t, r, p.Permission, declared) {
+					rr.InheritedTeams[t] = teamRepoRestPermission(p.Permission)
+					continue
+				}
+				switch p.Permission {
+				case "ADMIN":
+					rr.Admins = append(rr.Admins, t)
+				case "MAINTAIN":
+					rr.Maintainers = append(rr.Maintainers, t)
+				case "WRITE":
+					rr.Writers = append(rr.Writers, t)
+				case "TRIAGE":
+					rr.Triagers = append(rr.Triagers, t)
+				default:
+					rr.Readers = append(rr.Readers, t)
+				}
+			}
 		}
 	}
 
@@ -538,8 +1996,11 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 		}
 		onRulesetChange := func(rulename string, lRuleset *GithubRuleSet, rRuleset *GithubRuleSet) {
 			// UPDATE ruleset
+			if r.guardRulesetEnforcement(ctx, dryrun, lRuleset, rRuleset) {
+				return
+			}
 			lRuleset.Id = rRuleset.Id
-			r.UpdateRepositoryRuleset(ctx, dryrun, reponame, lRuleset)
+			r.UpdateRepositoryRuleset(ctx, dryrun, reponame, lRuleset, diffRulesets(lRuleset, rRuleset))
 		}
 		CompareEntities(lRepo.Rulesets, rRepo.Rulesets, compareRulesets, onRulesetAdded, onRulesetRemoved, onRulesetChange)
 
@@ -552,23 +2013,21 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 			}
 		}
 
-		if res, _, _ := entity.StringArrayEquivalent(lRepo.Readers, rRepo.Readers); !res {
-			return false
-		}
-
-		if res, _, _ := entity.StringArrayEquivalent(lRepo.Writers, rRepo.Writers); !res {
-			return false
-		}
-
-		if len(rRepo.InternalUsers) != 0 {
-			return false
+		for lk, lv := range lRepo.StringProperties {
+			if rRepo.StringProperties[lk] != lv {
+				return false
+			}
 		}
 
-		if res, _, _ := entity.StringArrayEquivalent(lRepo.ExternalUserReaders, rRepo.ExternalUserReaders); !res {
-			return false
+		// only the custom properties declared locally are managed
+		for lk, lv := range lRepo.CustomProperties {
+			if rv, ok := rRepo.CustomProperties[lk]; (lv == "" && ok) || (lv != "" && rv != lv) {
+				return false
+			}
 		}
 
-		if res, _, _ := entity.StringArrayEquivalent(lRepo.ExternalUserWriters, rRepo.ExternalUserWriters); !res {
+		// the accesses of an archived repository are not reconciled
+		if !lRepo.BoolProperties["archived"] && !sameRepositoryAccesses(lRepo, rRepo) {
 			return false
 		}
 
@@ -579,26 +2038,86 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 		// reconciliate repositories boolean properties
 		for lk, lv := range lRepo.BoolProperties {
 			if rv, ok := rRepo.BoolProperties[lk]; !ok || rv != lv {
+				// going from public to private detaches (or deletes) the existing forks
+				if lk == "private" && lv && ok && !lRepo.AllowVisibilityReduction && !r.repoconfig.AllowVisibilityReduction {
+					r.logSkippedCommand(ctx, dryrun, "update_repository_update_bool_property", "repositoryname: %s private:true, not changing the visibility from public to private (allow_visibility_reduction is not set)", reponame)
+					continue
+				}
+				// a visibility changed back right after being changed is held (flip-flop)
+				if lk == "private" && r.visibility != nil {
+					allowed, flipflop, held := r.visibility.check(reponame, lv)
+					if flipflop {
+						r.logWarning(ctx, "visibility flip-flop on the repository %s: the last reconciliation set private:%v, and it is changed back to private:%v. Holding private:%v, check the definitions of the repository", reponame, held, lv, held)
+					}
+					if !allowed {
+						r.logSkippedCommand(ctx, dryrun, "update_repository_update_bool_property", "repositoryname: %s private:%v, holding the visibility private:%v (visibility flip-flop)", reponame, lv, held)
+						continue
+					}
+				}
 				r.UpdateRepositoryUpdateBoolProperty(ctx, dryrun, remote, reponame, lk, lv)
+				if lk == "private" && r.visibility != nil && !dryrun {
+					r.visibility.record(reponame, lv)
+				}
 			}
 		}
 
-		if res, readToRemove, readToAdd := entity.StringArrayEquivalent(lRepo.Readers, rRepo.Readers); !res {
-			for _, teamSlug := range readToAdd {
-				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, "pull")
+		// reconciliate the merge commit messages
+		// (a title and its message are updated together)
+		stringProperties := map[string]string{}
+		for _, strategy := range mergeCommitStrategies {
+			changed := false
+			for _, property := range []string{strategy.title, strategy.message} {
+				if lv, ok := lRepo.StringProperties[property]; ok && rRepo.StringProperties[property] != lv {
+					changed = true
+				}
+			}
+			if !changed {
+				continue
 			}
-			for _, teamSlug := range readToRemove {
-				r.UpdateRepositoryRemoveTeamAccess(ctx, dryrun, remote, reponame, teamSlug)
+			for _, property := range []string{strategy.title, strategy.message} {
+				if lv, ok := lRepo.StringProperties[property]; ok {
+					stringProperties[property] = lv
+				} else if rv := rRepo.StringProperties[property]; rv != "" {
+					stringProperties[property] = rv
+				}
 			}
 		}
+		if len(stringProperties) > 0 {
+			r.UpdateRepositoryUpdateStringProperties(ctx, dryrun, remote, reponame, stringProperties)
+		}
 
-		if res, writeToRemove, writeToAdd := entity.StringArrayEquivalent(lRepo.Writers, rRepo.Writers); !res {
-			for _, teamSlug := range writeToAdd {
-				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, "push")
+		// reconciliate repositories custom properties
+		// (an empty value means the property must be removed)
+		for lk, lv := range lRepo.CustomProperties {
+			rv, ok := rRepo.CustomProperties[lk]
+			if lv == "" && ok {
+				r.UpdateRepositoryRemoveCustomProperty(ctx, dryrun, remote, reponame, lk)
+			} else if lv != "" && rv != lv {
+				r.UpdateRepositorySetCustomProperty(ctx, dryrun, remote, reponame, lk, lv)
 			}
-			for _, teamSlug := range writeToRemove {
-				r.UpdateRepositoryRemoveTeamAccess(ctx, dryrun, remote, reponame, teamSlug)
+		}
+
+		// the accesses of an archived repository are left untouched (nothing is
+		// lost): they are reconciled again, from the repository definition, once
+		// the repository is unarchived
+		if lRepo.BoolProperties["archived"] {
+			if !sameRepositoryAccesses(lRepo, rRepo) {
+				r.logSkippedCommand(ctx, dryrun, "update_repository_accesses", "repositoryname: %s is archived, its accesses are reconciled once unarchived", reponame)
 			}
+			return
+		}
+
+		// teams permissions
+		toAdd, toUpdate, toRemove := lRepo.TeamsPermissionsChanges(rRepo)
+		toUpdate, toRemove = r.guardLastWriterTeam(ctx, dryrun, reponame, rRepo, toAdd, toUpdate, toRemove)
+		for teamSlug, permission := range toAdd {
+			r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, permission)
+		}
+		for teamSlug, permission := range toUpdate {
+			r.UpdateRepositoryUpdateTeamAccess(ctx, dryrun, remote, reponame, teamSlug, permission)
+		}
+		for _, teamSlug := range toRemove {
+			r.UpdateRepositoryRemoveTeamAccess(ctx, dryrun, remote, reponame, teamSlug)
 		}
 
 		// internal users
@@ -607,47 +2126,18 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 		}
 
 		// external users
-		resEreader, ereaderToRemove, ereaderToAdd := entity.StringArrayEquivalent(lRepo.ExternalUserReaders, rRepo.ExternalUserReaders)
-		resEWriter, ewriteToRemove, ewriteToAdd := entity.StringArrayEquivalent(lRepo.ExternalUserWriters, rRepo.ExternalUserWriters)
-
-		if !resEreader {
-			for _, eReader := range ereaderToRemove {
-				// check if it is added in the writers
-				found := false
-				for _, eWriter := range ewriteToAdd {
-					if eWriter == eReader {
-						found = true
-						break
-					}
-				}
-				if !found {
-					r.UpdateRepositoryRemoveExternalUser(ctx, dryrun, remote, reponame, eReader)
-				}
-			}
-			for _, eReader := range ereaderToAdd {
-				r.UpdateRepositorySetExternalUser(ctx, dryrun, remote, reponame, eReader, "pull")
-			}
+		toSet, toRemoveExternal := lRepo.ExternalUsersPermissionsChanges(rRepo)
+		githubids := make([]string, 0, len(toSet))
+		for githubid := range toSet {
+			githubids = append(githubids, githubid)
 		}
-
-		if !resEWriter {
-			for _, eWriter := range ewriteToRemove {
-				// check if it is added in the writers
-				found := false
-				for _, eReader := range ereaderToAdd {
-					if eReader == eWriter {
-						found = true
-						break
-					}
-				}
-				if !found {
-					r.UpdateRepositoryRemoveExternalUser(ctx, dryrun, remote, reponame, eWriter)
-				}
-			}
-			for _, eWriter := range ewriteToAdd {
-				r.UpdateRepositorySetExternalUser(ctx, dryrun, remote, reponame, eWriter, "push")
-			}
+		sort.Strings(githubids)
+		for _, githubid := range githubids {
+			r.UpdateRepositorySetExternalUser(ctx, dryrun, remote, reponame, githubid, toSet[githubid])
+		}
+		for _, githubid := range toRemoveExternal {
+			r.UpdateRepositoryRemoveExternalUser(ctx, dryrun, remote, reponame, githubid)
 		}
-
 	}
 
 	onAdded := func(reponame string, lRepo *GithubRepoComparable, rRepo *GithubRepoComparable) {
@@ -660,7 +2150,25 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 			// calling onChanged to update the repository permissions
 			onChanged(reponame, aRepo, rRepo)
 		} else {
-			r.CreateRepository(ctx, dryrun, remote, reponame, reponame, lRepo.Writers, lRepo.Readers, lRepo.BoolProperties)
+			if lRepo.ForkOf != "" {
+				r.CreateRepositoryFork(ctx, dryrun, remote, reponame, lRepo.ForkOf, lRepo.Writers, lRepo.Readers, lRepo.BoolProperties)
+			} else {
+				r.CreateRepository(ctx, dryrun, remote, reponame, reponame, lRepo.Writers, lRepo.Readers, lRepo.BoolProperties)
+			}
+			for _, teamSlug := range lRepo.Triagers {
+				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, "triage")
+			}
+			for _, teamSlug := range lRepo.Maintainers {
+				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, "maintain")
+			}
+			for _, teamSlug := range lRepo.Admins {
+				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, teamSlug, "admin")
+			}
+			for lk, lv := range lRepo.CustomProperties {
+				if lv != "" {
+					r.UpdateRepositorySetCustomProperty(ctx, dryrun, remote, reponame, lk, lv)
+				}
+			}
 		}
 	}
 
@@ -676,53 +2184,559 @@ func (r *GoliacReconciliatorImpl) reconciliateRepositories(ctx context.Context,
 				r.unmanaged.Repositories[reponame] = true
 			}
 		} else {
-			r.DeleteRepository(ctx, dryrun, remote, reponame)
+			r.DeleteRepository(ctx, dryrun, remote, reponame, "")
+		}
+	}
+
+	// only reconciliate the repositories of the incremental scope (and the teams repository,
+	// whose accesses depend on the teams)
+	if r.scope != nil {
+		scopeRepos := make(map[string]bool)
+		for reponame := range r.scope.Repositories {
+			scopeRepos[reponame] = true
+			// a renamed repository is reconciliated under its new name
+			if lRepo, ok := local.Repositories()[reponame]; ok && lRepo.RenameTo != "" {
+				scopeRepos[lRepo.RenameTo] = true
+			}
+		}
+		scopeRepos[teamsreponame] = true
+		for _, repos := range []map[string]*GithubRepoComparable{lRepos, rRepos} {
+			for reponame := range repos {
+				if !scopeRepos[reponame] {
+					delete(repos, reponame)
+				}
+			}
+		}
+	}
+
+	r.reportInheritedTeamsAccesses(ctx, dryrun, lRepos, rRepos)
+
+	CompareEntities(lRepos, rRepos, compareRepos, onAdded, onRemoved, onChanged)
+
+	return nil
+}
+
+/*
+reportInheritedTeamsAccesses reports (as skipped commands) the teams accessing a
+repository through a parent team, when the repository doesn't want the inherited
+accesses (allowInheritedAccess: false) and the team is not granted (at least) the
+same permission in the repository definition.
+Github doesn't allow to prevent a child team from inheriting the access of its
+parent team, so these accesses can't be removed: only a stronger permission can
+be granted to the child team
+*/
+func (r *GoliacReconciliatorImpl) reportInheritedTeamsAccesses(ctx context.Context, dryrun bool, lRepos map[string]*GithubRepoComparable, rRepos map[string]*GithubRepoComparable) {
+	reponames := []string{}
+	for reponame, lRepo := range lRepos {
+		// the accesses of an archived repository are not reconciled
+		if lRepo.NoInheritedAccess && !lRepo.BoolProperties["archived"] {
+			reponames = append(reponames, reponame)
+		}
+	}
+	sort.Strings(reponames)
+
+	for _, reponame := range reponames {
+		rRepo, ok := rRepos[reponame]
+		if !ok {
+			continue
+		}
+		lPermissions := lRepos[reponame].TeamsPermissions()
+
+		teamslugs := make([]string, 0, len(rRepo.InheritedTeams))
+		for teamslug := range rRepo.InheritedTeams {
+			teamslugs = append(teamslugs, teamslug)
+		}
+		sort.Strings(teamslugs)
+
+		for _, teamslug := range teamslugs {
+			inherited := rRepo.InheritedTeams[teamslug]
+			if lPermission, ok := lPermissions[teamslug]; ok && teamRepoPermissionLevels[lPermission] >= teamRepoPermissionLevels[inherited] {
+				continue
+			}
+			r.logSkippedCommand(ctx, dryrun, "update_repository_remove_team", "repositoryname: %s, teamslug: %s, the %s access inherited from a parent team can't be removed (allowInheritedAccess is false, but Github doesn't allow to prevent the inheritance)", reponame, teamslug, inherited)
+		}
+	}
+}
+
+/*
+isExcluded returns true if the name (of a team or a repository) matches
+one of the (case insensitive) glob patterns, like "legacy-*"
+*/
+func isExcluded(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if match, err := path.Match(strings.ToLower(pattern), strings.ToLower(name)); err == nil && match {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+sameRepositoryAccesses checks if the teams, internal and external users
+accesses of a repository are the expected ones
+*/
+func sameRepositoryAccesses(lRepo *GithubRepoComparable, rRepo *GithubRepoComparable) bool {
+	if toAdd, toUpdate, toRemove := lRepo.TeamsPermissionsChanges(rRepo); len(toAdd) > 0 || len(toUpdate) > 0 || len(toRemove) > 0 {
+		return false
+	}
+
+	if len(rRepo.InternalUsers) != 0 {
+		return false
+	}
+
+	if toSet, toRemove := lRepo.ExternalUsersPermissionsChanges(rRepo); len(toSet) > 0 || len(toRemove) > 0 {
+		return false
+	}
+
+	return true
+}
+
+/*
+ * This function sync the repositories access of one team (and of its owners team).
+ * Repositories not (yet) existing in Github are left to the full reconciliation
+ */
+func (r *GoliacReconciliatorImpl) reconciliateTeamRepositories(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, teamsreponame string, dryrun bool, teamslug string) {
+	localRepositories := make(map[string]*entity.Repository)
+	for reponame, repo := range local.Repositories() {
+		// renamed repositories are left to the full reconciliation
+		// (and the accesses of the archived and immutable repositories are left untouched)
+		if repo.RenameTo == "" && !repo.Archived && !repo.Immutable {
+			localRepositories[utils.GithubAnsiString(reponame)] = repo
+		}
+	}
+	if teamsreponame != "" {
+		localRepositories[teamsreponame] = r.teamsRepository(teamsreponame)
+	}
+
+	rRepos := remote.Repositories()
+	rTeams := remote.Teams()
+	rTeamsById := teamsByIdIndex(rTeams)
+	rTeamsRepos := remote.TeamRepositories()
+
+	for reponame, lRepo := range localRepositories {
+		if _, ok := rRepos[reponame]; !ok {
+			continue
+		}
+		lPermissions := r.repositoryTeamsPermissions(ctx, dryrun, local, remote, teamsreponame, reponame, lRepo)
+
+		for _, slug := range []string{teamslug, teamslug + config.Config.GoliacTeamOwnerSuffix} {
+			lPermission, lok := lPermissions[slug]
+			rPermission := ""
+			inherited := false
+			if rTeamRepo, ok := rTeamsRepos[slug][reponame]; ok {
+				rPermission = teamRepoRestPermission(rTeamRepo.Permission)
+				inherited = isTeamRepoAccessInherited(rTeams, rTeamsById, rTeamsRepos, slug, reponame, rTeamRepo.Permission, lok)
+			}
+
+			switch {
+			case lok && rPermission == "":
+				r.UpdateRepositoryAddTeamAccess(ctx, dryrun, remote, reponame, slug, lPermission)
+			case lok && rPermission != lPermission:
+				r.UpdateRepositoryUpdateTeamAccess(ctx, dryrun, remote, reponame, slug, lPermission)
+			case !lok && rPermission != "" && !inherited:
+				// access inherited from a parent team is managed through the parent team
+				r.UpdateRepositoryRemoveTeamAccess(ctx, dryrun, remote, reponame, slug)
+			}
+		}
+	}
+}
+
+/*
+teamsRepository returns the definition of the Goliac "teams" repository
+*/
+func (r *GoliacReconciliatorImpl) teamsRepository(teamsreponame string) *entity.Repository {
+	teamsRepo := &entity.Repository{}
+	teamsRepo.ApiVersion = "v1"
+	teamsRepo.Kind = "Repository"
+	teamsRepo.Name = teamsreponame
+	teamsRepo.Spec.Writers = []string{r.repoconfig.AdminTeam}
+	teamsRepo.Spec.Readers = []string{}
+	teamsRepo.Spec.IsPublic = false
+	teamsRepo.Spec.DeleteBranchOnMerge = true
+	return teamsRepo
+}
+
+// name of the ruleset protecting the teams repository (teams_repository_protection)
+const TEAMS_REPOSITORY_RULESET = "goliac-teams-repository"
+
+/*
+teamsRepositoryRuleset returns the hardened ruleset of the teams repository
+(nil if teams_repository_protection is not enabled). The Goliac Github Apps
+bypass it, else Goliac could not commit (codeowners, users sync) anymore.
+It returns an error if the Goliac Github App installation is not found
+*/
+func (r *GoliacReconciliatorImpl) teamsRepositoryRuleset(remote *MutableGoliacRemoteImpl) (*GithubRuleSet, error) {
+	protection := r.repoconfig.TeamsRepositoryProtection
+	if !protection.Enabled {
+		return nil, nil
+	}
+
+	bypassApps := map[string]string{}
+	for slug, appId := range remote.AppIds() {
+		if int64(appId) == config.Config.GithubAppID || int64(appId) == config.Config.GithubTeamAppID {
+			bypassApps[slug] = "always"
+		}
+	}
+	if len(bypassApps) == 0 {
+		return nil, fmt.Errorf("not able to find the Goliac Github App installation")
+	}
+	for _, slug := range protection.BypassApps {
+		bypassApps[slug] = "always"
+	}
+
+	ruleset := &GithubRuleSet{
+		Name:        TEAMS_REPOSITORY_RULESET,
+		Enforcement: "active",
+		BypassApps:  bypassApps,
+		OnInclude:   []string{"~DEFAULT_BRANCH"},
+		Rules: map[string]entity.RuleSetParameters{
+			"pull_request": {
+				RequiredApprovingReviewCount: protection.RequiredApprovingReviewCount,
+				DismissStaleReviewsOnPush:    true,
+				RequireLastPushApproval:      true,
+			},
+			"non_fast_forward": {},
+			"deletion":         {},
+		},
+	}
+	if len(protection.RequiredStatusChecks) > 0 {
+		ruleset.Rules["required_status_checks"] = entity.RuleSetParameters{
+			RequiredStatusChecks: protection.RequiredStatusChecks,
+		}
+	}
+	return ruleset, nil
+}
+
+/*
+repositoryTeamsPermissions returns the teams permission (admin, maintain, push, triage, pull)
+expected on a repository, by team slug (see expectedTeamsPermissions). The required
+teams the repository definition gives a weaker permission are reported
+*/
+func (r *GoliacReconciliatorImpl) repositoryTeamsPermissions(ctx context.Context, dryrun bool, local GoliacLocal, remote *MutableGoliacRemoteImpl, teamsreponame string, reponame string, lRepo *entity.Repository) map[string]string {
+	rRepo := remote.Repositories()[utils.GithubAnsiString(reponame)]
+	permissions, conflicts := expectedTeamsPermissions(r.repoconfig, r.slugs, local.Teams(), teamsreponame, reponame, lRepo, rRepo)
+	for _, c := range conflicts {
+		r.logSkippedCommand(ctx, dryrun, "update_repository_update_team", "repositoryname: %s, teamslug: %s, the %s requires the %s permission, but the repository definition gives %s", reponame, c.teamslug, c.condition, c.required, c.permission)
+	}
+	return permissions
+}
+
+/*
+used to compare org rulesets but also repo rulesets
+(the priority is only used for the creation order)
+*/
+func compareRulesets(rulesetname string, lrs *GithubRuleSet, rrs *GithubRuleSet) bool {
+	return len(diffRulesets(lrs, rrs)) == 0
+}
+
+/*
+diffRulesets returns the differences between the local and the remote ruleset
+(enforcement, bypass apps, include/exclude conditions, rules and repositories),
+like "rule pull_request: requiredApprovingReviewCount: 1 -> 2"
+*/
+func diffRulesets(lrs *GithubRuleSet, rrs *GithubRuleSet) []string {
+	diff := []string{}
+
+	// stringArrayDiff reports the elements added and removed
+	stringArrayDiff := func(name string, local []string, remote []string) {
+		res, removed, added := entity.StringArrayEquivalent(local, remote)
+		if res {
+			return
+		}
+		sort.Strings(added)
+		sort.Strings(removed)
+		if len(added) > 0 {
+			diff = append(diff, fmt.Sprintf("%s added: %s", name, strings.Join(added, ",")))
+		}
+		if len(removed) > 0 {
+			diff = append(diff, fmt.Sprintf("%s removed: %s", name, strings.Join(removed, ",")))
+		}
+		if len(added) == 0 && len(removed) == 0 {
+			diff = append(diff, fmt.Sprintf("%s changed", name))
+		}
+	}
+
+	if lrs.Enforcement != rrs.Enforcement {
+		diff = append(diff, fmt.Sprintf("enforcement: %s -> %s", rrs.Enforcement, lrs.Enforcement))
+	}
+
+	// bypassDiff reports the bypass actors (apps or teams) added, removed or changed
+	bypassDiff := func(kind string, local map[string]string, remote map[string]string) {
+		actors := []string{}
+		for actor := range local {
+			actors = append(actors, actor)
+		}
+		for actor := range remote {
+			if _, ok := local[actor]; !ok {
+				actors = append(actors, actor)
+			}
+		}
+		sort.Strings(actors)
+		for _, actor := range actors {
+			lMode, lok := local[actor]
+			rMode, rok := remote[actor]
+			switch {
+			case !rok:
+				diff = append(diff, fmt.Sprintf("bypass %s %s added (%s)", kind, actor, lMode))
+			case !lok:
+				diff = append(diff, fmt.Sprintf("bypass %s %s removed", kind, actor))
+			case lMode != rMode:
+				diff = append(diff, fmt.Sprintf("bypass %s %s: %s -> %s", kind, actor, rMode, lMode))
+			}
+		}
+	}
+	bypassDiff("app", lrs.BypassApps, rrs.BypassApps)
+	bypassDiff("team", lrs.BypassTeams, rrs.BypassTeams)
+
+	stringArrayDiff("include", lrs.OnInclude, rrs.OnInclude)
+	stringArrayDiff("exclude", lrs.OnExclude, rrs.OnExclude)
+
+	ruletypes := []string{}
+	for ruletype := range lrs.Rules {
+		ruletypes = append(ruletypes, ruletype)
+	}
+	for ruletype := range rrs.Rules {
+		if _, ok := lrs.Rules[ruletype]; !ok {
+			ruletypes = append(ruletypes, ruletype)
+		}
+	}
+	sort.Strings(ruletypes)
+	for _, ruletype := range ruletypes {
+		lRule, lok := lrs.Rules[ruletype]
+		rRule, rok := rrs.Rules[ruletype]
+		switch {
+		case !rok:
+			diff = append(diff, fmt.Sprintf("rule %s added", ruletype))
+		case !lok:
+			diff = append(diff, fmt.Sprintf("rule %s removed", ruletype))
+		default:
+			for _, d := range entity.DiffRulesetParameters(ruletype, lRule, rRule) {
+				diff = append(diff, fmt.Sprintf("rule %s: %s", ruletype, d))
+			}
 		}
 	}
 
-	CompareEntities(lRepos, rRepos, compareRepos, onAdded, onRemoved, onChanged)
+	stringArrayDiff("repositories", lrs.Repositories, rrs.Repositories)
+
+	return diff
+}
+
+// teamRepoPermissionLevels orders the team repository permissions, from the weakest to the strongest
+var teamRepoPermissionLevels = map[string]int{
+	"pull":     0,
+	"triage":   1,
+	"push":     2,
+	"maintain": 3,
+	"admin":    4,
+}
+
+/*
+TeamRepoPermissionLevel returns the level of a team repository permission (admin,
+maintain, push, triage, pull), from 0 (pull) to 4 (admin). It returns -1 for no
+(or an unknown) permission
+*/
+func TeamRepoPermissionLevel(permission string) int {
+	if level, ok := teamRepoPermissionLevels[permission]; ok {
+		return level
+	}
+	return -1
+}
+
+/*
+teamsRepositoryOwnersPermission returns the permission given to the
+"<team>-goliac-owners" teams on the teams repository (push by default)
+*/
+func teamsRepositoryOwnersPermission(repoconfig *config.RepositoryConfig) string {
+	permission := repoconfig.TeamsRepositoryOwnersPermission
+	if _, ok := teamRepoPermissionLevels[permission]; !ok {
+		return "push"
+	}
+	return permission
+}
+
+/*
+teamRepoRestPermission converts a GraphQL team repository permission (ADMIN, MAINTAIN, WRITE, TRIAGE, READ)
+into the REST one (admin, maintain, push, triage, pull). It is the reverse of teamRepoPermission
+*/
+func teamRepoRestPermission(permission string) string {
+	switch permission {
+	case "ADMIN":
+		return "admin"
+	case "MAINTAIN":
+		return "maintain"
+	case "WRITE":
+		return "push"
+	case "TRIAGE":
+		return "triage"
+	default:
+		return "pull"
+	}
+}
+
+// teamsByIdIndex indexes the teams by id (to walk up their parent teams)
+func teamsByIdIndex(teams map[string]*GithubTeam) map[int]*GithubTeam {
+	teamsById := make(map[int]*GithubTeam, len(teams))
+	for _, t := range teams {
+		teamsById[t.Id] = t
+	}
+	return teamsById
+}
+
+/*
+isTeamRepoAccessInherited returns true if the team's access to the repository
+comes from one of its parent teams: a parent team has at least the same
+permission, and the team has no direct grant of its own. Github doesn't tell
+a direct grant from an inherited one, so an access declared for the team in the
+teams repository (granted directly by Goliac) is a direct grant.
+teamsById is the index of the teams (see teamsByIdIndex)
+*/
+func isTeamRepoAccessInherited(teams map[string]*GithubTeam, teamsById map[int]*GithubTeam, teamsRepos map[string]map[string]*GithubTeamRepo, teamslug string, reponame string, permission string, declared bool) bool {
+	if declared {
+		return false
+	}
+	level := teamRepoPermissionLevels[teamRepoRestPermission(permission)]
+
+	team := teams[teamslug]
+	// (we limit the depth in case of a loop)
+	for depth := 0; team != nil && team.ParentTeam != nil && depth < len(teams); depth++ {
+		parent, ok := teamsById[*team.ParentTeam]
+		if !ok {
+			return false
+		}
+		if p, ok := teamsRepos[parent.Slug][reponame]; ok && teamRepoPermissionLevels[teamRepoRestPermission(p.Permission)] >= level {
+			return true
+		}
+		team = parent
+	}
+	return false
+}
 
-	return nil
+// rulesetEnforcementLevels orders the rulesets enforcement modes, from the weakest to the strongest
+var rulesetEnforcementLevels = map[string]int{
+	"disabled": 0,
+	"evaluate": 1,
+	"active":   2,
 }
 
 /*
-used to compare org rulesets but also repo rulesets
+guardRulesetEnforcement prevents a ruleset's enforcement to be weakened
+(active -> evaluate -> disabled) when the rulesets enforcement guard is on,
+unless destructive_operations.rulesets_enforcement is set.
+The attempted weakening is reported, and the remote enforcement is kept.
+It returns true if there is nothing else to update on the ruleset.
 */
-func compareRulesets(rulesetname string, lrs *GithubRuleSet, rrs *GithubRuleSet) bool {
-	if lrs.Enforcement != rrs.Enforcement {
+func (r *GoliacReconciliatorImpl) guardRulesetEnforcement(ctx context.Context, dryrun bool, lRuleset *GithubRuleSet, rRuleset *GithubRuleSet) bool {
+	if !r.repoconfig.RulesetsEnforcementGuard || r.repoconfig.DestructiveOperations.AllowDestructiveRulesetsEnforcement {
 		return false
 	}
-	if len(lrs.BypassApps) != len(rrs.BypassApps) {
+	if rulesetEnforcementLevels[lRuleset.Enforcement] >= rulesetEnforcementLevels[rRuleset.Enforcement] {
 		return false
 	}
-	for k, v := range lrs.BypassApps {
-		if rrs.BypassApps[k] != v {
-			return false
+
+	r.logSkippedCommand(ctx, dryrun, "update_ruleset", "ruleset: %s, not weakening enforcement from %s to %s (destructive_operations.rulesets_enforcement is not set)", lRuleset.Name, rRuleset.Enforcement, lRuleset.Enforcement)
+	lRuleset.Enforcement = rRuleset.Enforcement
+
+	return compareRulesets(lRuleset.Name, lRuleset, rRuleset)
+}
+
+/*
+guardLastWriterTeam prevents a (non archived) repository to be left without
+any team with write (or maintain, admin) access: the removal (or downgrade)
+of its last writer teams is reported, and the remote access is kept.
+It returns the teams access to update and to remove.
+*/
+func (r *GoliacReconciliatorImpl) guardLastWriterTeam(ctx context.Context, dryrun bool, reponame string, rRepo *GithubRepoComparable, toAdd map[string]string, toUpdate map[string]string, toRemove []string) (map[string]string, []string) {
+	isWriter := func(permission string) bool {
+		return teamRepoPermissionLevels[permission] >= teamRepoPermissionLevels["push"]
+	}
+
+	rPermissions := rRepo.TeamsPermissions()
+	hadWriter := false
+	for _, permission := range rPermissions {
+		hadWriter = hadWriter || isWriter(permission)
+	}
+	if !hadWriter {
+		return toUpdate, toRemove
+	}
+
+	// the access inherited from a parent team is kept
+	for _, permission := range rRepo.InheritedTeams {
+		if isWriter(permission) {
+			return toUpdate, toRemove
 		}
 	}
-	if res, _, _ := entity.StringArrayEquivalent(lrs.OnInclude, rrs.OnInclude); !res {
-		return false
+	for _, permission := range toAdd {
+		if isWriter(permission) {
+			return toUpdate, toRemove
+		}
 	}
-	if res, _, _ := entity.StringArrayEquivalent(lrs.OnExclude, rrs.OnExclude); !res {
-		return false
+	removed := make(map[string]bool)
+	for _, teamSlug := range toRemove {
+		removed[teamSlug] = true
 	}
-	if len(lrs.Rules) != len(rrs.Rules) {
-		return false
+	for teamSlug, permission := range rPermissions {
+		if removed[teamSlug] {
+			continue
+		}
+		if p, ok := toUpdate[teamSlug]; ok {
+			permission = p
+		}
+		if isWriter(permission) {
+			return toUpdate, toRemove
+		}
 	}
-	for k, v := range lrs.Rules {
-		if !entity.CompareRulesetParameters(k, v, rrs.Rules[k]) {
-			return false
+
+	// the last writer teams are kept
+	keptUpdate := make(map[string]string)
+	for teamSlug, permission := range toUpdate {
+		if isWriter(rPermissions[teamSlug]) {
+			r.logSkippedCommand(ctx, dryrun, "update_repository_update_team", "repositoryname: %s, teamslug: %s, permission: %s, not removing the last team with write access", reponame, teamSlug, permission)
+			continue
 		}
+		keptUpdate[teamSlug] = permission
 	}
-	if res, _, _ := entity.StringArrayEquivalent(lrs.Repositories, rrs.Repositories); !res {
-		return false
+	keptRemove := []string{}
+	for _, teamSlug := range toRemove {
+		if isWriter(rPermissions[teamSlug]) {
+			r.logSkippedCommand(ctx, dryrun, "update_repository_remove_team", "repositoryname: %s, teamslug: %s, not removing the last team with write access", reponame, teamSlug)
+			continue
+		}
+		keptRemove = append(keptRemove, teamSlug)
 	}
+	return keptUpdate, keptRemove
+}
 
-	return true
+/*
+findRemoteRepository returns the remote repository matching a local repository name.
+GitHub repository names are case insensitive, so if there is no exact match,
+we fall back to a case insensitive lookup
+*/
+func findRemoteRepository(rRepositories map[string]*GithubRepository, rRepositoriesLowerCase map[string]*GithubRepository, reponame string) *GithubRepository {
+	if rRepo, ok := rRepositories[reponame]; ok {
+		return rRepo
+	}
+	return rRepositoriesLowerCase[strings.ToLower(reponame)]
+}
+
+/*
+canonicalRepositoryName returns the name GitHub knows the repository by
+(the one resolved to a repository id when the ruleset is applied),
+or the local name if the repository doesn't exist (yet) remotely
+*/
+func canonicalRepositoryName(rRepo *GithubRepository, reponame string) string {
+	if rRepo != nil {
+		return rRepo.Name
+	}
+	return reponame
 }
 
 func (r *GoliacReconciliatorImpl) reconciliateRulesets(ctx context.Context, local GoliacLocal, remote *MutableGoliacRemoteImpl, teamsreponame string, conf *config.RepositoryConfig, dryrun bool) error {
 	repositories := local.Repositories()
+	rRepositories := remote.Repositories()
+	rRepositoriesLowerCase := make(map[string]*GithubRepository)
+	for name, rRepo := range rRepositories {
+		rRepositoriesLowerCase[strings.ToLower(name)] = rRepo
+	}
 
 	lgrs := map[string]*GithubRuleSet{}
 	// prepare local comparable
@@ -743,6 +2757,7 @@ func (r *GoliacReconciliatorImpl) reconciliateRulesets(ctx context.Context, loca
 			OnInclude:   rs.Spec.Conditions.Include,
 			OnExclude:   rs.Spec.Conditions.Exclude,
 			Rules:       map[string]entity.RuleSetParameters{},
+			Priority:    confrs.Priority,
 		}
 		for _, b := range rs.Spec.BypassApps {
 			grs.BypassApps[b.AppName] = b.Mode
@@ -750,14 +2765,26 @@ func (r *GoliacReconciliatorImpl) reconciliateRulesets(ctx context.Context, loca
 		for _, r := range rs.Spec.Rules {
 			grs.Rules[r.Ruletype] = r.Parameters
 		}
-		for reponame := range repositories {
-			if match.Match([]byte(reponame)) {
-				grs.Repositories = append(grs.Repositories, reponame)
+		for reponame, lRepo := range repositories {
+			// rulesets cannot be attached to archived repositories (nor to the immutable ones)
+			if lRepo.Archived || lRepo.Immutable {
+				continue
+			}
+			rRepo := findRemoteRepository(rRepositories, rRepositoriesLowerCase, reponame)
+			if rRepo != nil && rRepo.BoolProperties["archived"] {
+				continue
+			}
+			if match.Match([]byte(reponame)) && repositoryHasTopic(rRepo, confrs.Topic) {
+				grs.Repositories = append(grs.Repositories, canonicalRepositoryName(rRepo, reponame))
 			}
 		}
-		if match.Match([]byte(teamsreponame)) {
-			grs.Repositories = append(grs.Repositories, teamsreponame)
+		if teamsreponame != "" && match.Match([]byte(teamsreponame)) {
+			rRepo := findRemoteRepository(rRepositories, rRepositoriesLowerCase, teamsreponame)
+			if repositoryHasTopic(rRepo, confrs.Topic) {
+				grs.Repositories = append(grs.Repositories, canonicalRepositoryName(rRepo, teamsreponame))
+			}
 		}
+		r.resolveStatusChecksIntegrations(ctx, remote, grs.Rules, grs.Repositories)
 		lgrs[rs.Name] = &grs
 	}
 
@@ -765,11 +2792,13 @@ func (r *GoliacReconciliatorImpl) reconciliateRulesets(ctx context.Context, loca
 	rgrs := remote.RuleSets()
 
 	// prepare the diff computation
+	// (creations and updates are applied by priority, to get a stable apply order)
+	toAdd := []*GithubRuleSet{}
+	toUpdate := []*GithubRuleSet{}
+	toUpdateChanges := make(map[string][]string) // key is the ruleset name
 
 	onAdded := func(rulesetname string, lRuleset *GithubRuleSet, rRuleset *GithubRuleSet) {
-		// CREATE ruleset
-
-		r.AddRuleset(ctx, dryrun, lRuleset)
+		toAdd = append(toAdd, lRuleset)
 	}
 
 	onRemoved := func(rulesetname string, lRuleset *GithubRuleSet, rRuleset *GithubRuleSet) {
@@ -778,18 +2807,323 @@ func (r *GoliacReconciliatorImpl) reconciliateRulesets(ctx context.Context, loca
 	}
 
 	onChanged := func(rulesetname string, lRuleset *GithubRuleSet, rRuleset *GithubRuleSet) {
-		// UPDATE ruleset
+		if r.guardRulesetEnforcement(ctx, dryrun, lRuleset, rRuleset) {
+			return
+		}
 		lRuleset.Id = rRuleset.Id
-		r.UpdateRuleset(ctx, dryrun, lRuleset)
+		toUpdate = append(toUpdate, lRuleset)
+		toUpdateChanges[lRuleset.Name] = diffRulesets(lRuleset, rRuleset)
 	}
 
 	CompareEntities(lgrs, rgrs, compareRulesets, onAdded, onRemoved, onChanged)
 
+	sortRulesetsByPriority(toAdd)
+	sortRulesetsByPriority(toUpdate)
+
+	// CREATE ruleset
+	for _, lRuleset := range toAdd {
+		r.AddRuleset(ctx, dryrun, lRuleset)
+	}
+	// UPDATE ruleset
+	for _, lRuleset := range toUpdate {
+		r.UpdateRuleset(ctx, dryrun, lRuleset, toUpdateChanges[lRuleset.Name])
+	}
+
 	return nil
 }
 
+/*
+requiredTeamConflict is a team required (by the required_teams of goliac.yaml)
+on a repository, that the repository definition explicitly gives a weaker permission
+*/
+type requiredTeamConflict struct {
+	teamslug   string
+	condition  string // like "topic pii"
+	required   string // the permission required
+	permission string // the permission of the repository definition
+}
+
+/*
+expectedTeamsPermissions returns the teams permission (admin, maintain, push, triage, pull)
+expected on a repository, by team slug. It is shared by the reconciliation and the
+drift report:
+  - the teams of the repository definition (a team listed several times gets the strongest permission)
+  - the "<team>-goliac-owners" teams on the teams repository, and the "everyone" team
+  - the teams required (by the required_teams of goliac.yaml) on the repositories carrying
+    a Github topic or a custom property value (rRepo is the Github repository, nil if not created).
+    A team explicitly given a weaker permission in the repository definition is left
+    untouched, and returned as a conflict. The accesses of an archived repository are
+    not reconciled, so no team is required on it
+*/
+func expectedTeamsPermissions(repoconfig *config.RepositoryConfig, slugs *slugCache, localTeams map[string]*entity.Team, teamsreponame string, reponame string, lRepo *entity.Repository, rRepo *GithubRepository) (map[string]string, []requiredTeamConflict) {
+	permissions := make(map[string]string)
+	setPermission := func(teamslug string, permission string) {
+		if p, ok := permissions[teamslug]; !ok || teamRepoPermissionLevels[permission] > teamRepoPermissionLevels[p] {
+			permissions[teamslug] = permission
+		}
+	}
+	for _, a := range lRepo.Spec.Admins {
+		setPermission(slugs.Team(a), "admin")
+	}
+	for _, m := range lRepo.Spec.Maintainers {
+		setPermission(slugs.Team(m), "maintain")
+	}
+	for _, w := range lRepo.Spec.Writers {
+		setPermission(slugs.Team(w), "push")
+	}
+	// add the team owner's name ;-)
+	if lRepo.Owner != nil {
+		setPermission(slugs.Team(*lRepo.Owner), "push")
+	}
+	for _, t := range lRepo.Spec.Triagers {
+		setPermission(slugs.Team(t), "triage")
+	}
+	for _, reader := range lRepo.Spec.Readers {
+		setPermission(slugs.Team(reader), "pull")
+	}
+
+	// special case for the Goliac "teams" repo
+	if reponame == teamsreponame {
+		for teamname := range localTeams {
+			setPermission(slugs.Team(teamname)+config.Config.GoliacTeamOwnerSuffix, teamsRepositoryOwnersPermission(repoconfig))
+		}
+	}
+
+	// adding the "everyone" team to each repository
+	if repoconfig.EveryoneTeamEnabled {
+		setPermission("everyone", "pull")
+	}
+
+	conflicts := []requiredTeamConflict{}
+	if len(repoconfig.RequiredTeams) == 0 || reponame == teamsreponame || lRepo.Archived {
+		return permissions, conflicts
+	}
+
+	explicit := make(map[string]bool)
+	for teamslug := range permissions {
+		explicit[teamslug] = true
+	}
+	for _, required := range repoconfig.RequiredTeams {
+		if required.Topic == "" && required.CustomProperty.Name == "" {
+			continue
+		}
+		if !repositoryHasTopic(rRepo, required.Topic) {
+			continue
+		}
+		if required.CustomProperty.Name != "" && lRepo.Spec.CustomProperties[required.CustomProperty.Name] != required.CustomProperty.Value {
+			continue
+		}
+		for _, r := range []struct {
+			permission string
+			teamnames  []string
+		}{{"pull", required.Readers}, {"push", required.Writers}} {
+			for _, teamname := range r.teamnames {
+				// (reported by the validation)
+				if _, ok := localTeams[teamname]; !ok {
+					continue
+				}
+				teamslug := slugs.Team(teamname)
+				current, ok := permissions[teamslug]
+				switch {
+				case ok && teamRepoPermissionLevels[current] >= teamRepoPermissionLevels[r.permission]:
+					continue
+				case ok && explicit[teamslug]:
+					conflicts = append(conflicts, requiredTeamConflict{
+						teamslug:   teamslug,
+						condition:  required.Condition(),
+						required:   r.permission,
+						permission: current,
+					})
+				default:
+					permissions[teamslug] = r.permission
+				}
+			}
+		}
+	}
+	return permissions, conflicts
+}
+
+/*
+repositoryHasTopic returns true if no topic is required, or if the (Github)
+repository carries it. A repository not yet created doesn't have any topic
+*/
+func repositoryHasTopic(rRepo *GithubRepository, topic string) bool {
+	if topic == "" {
+		return true
+	}
+	if rRepo == nil {
+		return false
+	}
+	for _, t := range rRepo.Topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+resolveStatusChecksIntegrations binds the required status checks of a ruleset
+(asking for it) to the integration reporting them on the default branch of the
+matched repositories. A check is only bound if all the repositories agree on
+its integration, else it can still be reported by any integration
+*/
+func (r *GoliacReconciliatorImpl) resolveStatusChecksIntegrations(ctx context.Context, remote *MutableGoliacRemoteImpl, rules map[string]entity.RuleSetParameters, reponames []string) {
+	parameters, ok := rules["required_status_checks"]
+	if !ok || !parameters.ResolveStatusChecksIntegrations || len(parameters.RequiredStatusChecks) == 0 || len(reponames) == 0 {
+		return
+	}
+
+	checks, err := remote.RepositoriesChecksIntegrations(ctx, reponames)
+	if err != nil {
+		logrus.Warnf("not able to fetch the repositories checks (the required status checks are not bound to an integration): %v", err)
+		return
+	}
+
+	integrations := make(map[string]int)
+	for _, context := range parameters.RequiredStatusChecks {
+		integration := 0
+		for i, reponame := range reponames {
+			id := checks[reponame][context]
+			if id == 0 || (i > 0 && id != integration) {
+				integration = 0
+				break
+			}
+			integration = id
+		}
+		if integration != 0 {
+			integrations[context] = integration
+		}
+	}
+	parameters.RequiredStatusChecksIntegrations = integrations
+	rules["required_status_checks"] = parameters
+}
+
+/*
+sortRulesetsByPriority sorts the rulesets by ascending priority, then by name
+*/
+func sortRulesetsByPriority(rulesets []*GithubRuleSet) {
+	sort.SliceStable(rulesets, func(i, j int) bool {
+		if rulesets[i].Priority != rulesets[j].Priority {
+			return rulesets[i].Priority < rulesets[j].Priority
+		}
+		return rulesets[i].Name < rulesets[j].Name
+	})
+}
+
+/*
+logCommand logs a reconciliation operation, and records it if
+a changes collector is attached to the context
+*/
+func (r *GoliacReconciliatorImpl) logCommand(ctx context.Context, dryrun bool, command string, format string, args ...interface{}) {
+	r.logCommandWithChanges(ctx, dryrun, command, nil, format, args...)
+}
+
+/*
+logCommandWithChanges is the same as logCommand, with the field-level
+changes of the operation (like the rules of a ruleset that differ)
+*/
+func (r *GoliacReconciliatorImpl) logCommandWithChanges(ctx context.Context, dryrun bool, command string, fieldChanges []string, format string, args ...interface{}) {
+	fields := map[string]interface{}{"dryrun": dryrun, "command": command, "author": config.GetAuthor(ctx)}
+	if len(fieldChanges) > 0 {
+		fields["changes"] = fieldChanges
+	}
+	if r.simulated {
+		fields["simulated"] = true
+	}
+	logrus.WithFields(fields).Infof(format, args...)
+
+	if changes, ok := ctx.Value(config.ContextKeyChanges).(*config.GoliacChanges); ok {
+		changes.Author = config.GetAuthor(ctx)
+		changes.Operations = append(changes.Operations, config.GoliacOperation{
+			Command:      command,
+			Detail:       fmt.Sprintf(format, args...),
+			Changes:      fieldChanges,
+			Organization: config.GetOrganization(ctx),
+			Simulated:    r.simulated,
+			Timestamp:    time.Now(),
+		})
+	}
+}
+
+/*
+logWarning reports a warning raised by the reconciliation (like a visibility
+flip-flop), notified by the server
+*/
+func (r *GoliacReconciliatorImpl) logWarning(ctx context.Context, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logrus.WithFields(map[string]interface{}{"author": config.GetAuthor(ctx)}).Warn(message)
+
+	if changes, ok := ctx.Value(config.ContextKeyChanges).(*config.GoliacChanges); ok {
+		changes.Warnings = append(changes.Warnings, message)
+	}
+}
+
+/*
+logDestructiveCommand reports a reconciliation operation deleting
+a team or a repository, with the reason given for it (if any)
+*/
+func (r *GoliacReconciliatorImpl) logDestructiveCommand(ctx context.Context, dryrun bool, command string, reason string, format string, args ...interface{}) {
+	fields := map[string]interface{}{"dryrun": dryrun, "command": command, "author": config.GetAuthor(ctx), "reason": reason}
+	if r.simulated {
+		fields["simulated"] = true
+	}
+	logrus.WithFields(fields).Infof(format, args...)
+
+	if changes, ok := ctx.Value(config.ContextKeyChanges).(*config.GoliacChanges); ok {
+		changes.Author = config.GetAuthor(ctx)
+		changes.Operations = append(changes.Operations, config.GoliacOperation{
+			Command:      command,
+			Detail:       fmt.Sprintf(format, args...),
+			Destructive:  true,
+			Reason:       reason,
+			Organization: config.GetOrganization(ctx),
+			Simulated:    r.simulated,
+			Timestamp:    time.Now(),
+		})
+	}
+}
+
+/*
+deletionReason returns the reason given for a deletion: the one set on
+the entity (if any), else the one given in the commit message
+*/
+func deletionReason(ctx context.Context, reason string) string {
+	if reason != "" {
+		return reason
+	}
+	return config.GetReason(ctx)
+}
+
+/*
+logSkippedCommand reports a reconciliation operation that was
+not applied on purpose (a guard prevented it)
+*/
+func (r *GoliacReconciliatorImpl) logSkippedCommand(ctx context.Context, dryrun bool, command string, format string, args ...interface{}) {
+	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": command, "author": config.GetAuthor(ctx), "skipped": true}).Warnf(format, args...)
+
+	// reported in a plan, so the author understands why it is not applied
+	if changes, ok := ctx.Value(config.ContextKeyChanges).(*config.GoliacChanges); ok && changes.ReportSkipped {
+		changes.Operations = append(changes.Operations, config.GoliacOperation{
+			Command:      command,
+			Detail:       fmt.Sprintf(format, args...),
+			Organization: config.GetOrganization(ctx),
+			Skipped:      true,
+			Timestamp:    time.Now(),
+		})
+	}
+}
+
+func (r *GoliacReconciliatorImpl) CancelOrgInvitation(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, invitation *GithubOrgInvitation) {
+	r.logCommand(ctx, dryrun, "cancel_org_invitation", "ghuserid: %s, invited on %s", invitation.Login, invitation.CreatedAt.Format("2006-01-02"))
+	remote.CancelOrgInvitation(invitation.Login)
+	if r.executor != nil {
+		r.executor.CancelOrgInvitation(ctx, dryrun, invitation)
+	}
+}
+
 func (r *GoliacReconciliatorImpl) AddUserToOrg(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, ghuserid string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "add_user_to_org"}).Infof("ghuserid: %s", ghuserid)
+	r.logCommand(ctx, dryrun, "add_user_to_org", "ghuserid: %s", ghuserid)
 	remote.AddUserToOrg(ghuserid)
 	if r.executor != nil {
 		r.executor.AddUserToOrg(ctx, dryrun, ghuserid)
@@ -798,7 +3132,7 @@ func (r *GoliacReconciliatorImpl) AddUserToOrg(ctx context.Context, dryrun bool,
 
 func (r *GoliacReconciliatorImpl) RemoveUserFromOrg(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, ghuserid string) {
 	if r.repoconfig.DestructiveOperations.AllowDestructiveUsers {
-		logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "remove_user_from_org"}).Infof("ghuserid: %s", ghuserid)
+		r.logCommand(ctx, dryrun, "remove_user_from_org", "ghuserid: %s", ghuserid)
 		remote.RemoveUserFromOrg(ghuserid)
 		if r.executor != nil {
 			r.executor.RemoveUserFromOrg(ctx, dryrun, ghuserid)
@@ -808,34 +3142,66 @@ func (r *GoliacReconciliatorImpl) RemoveUserFromOrg(ctx context.Context, dryrun
 	}
 }
 
+func (r *GoliacReconciliatorImpl) RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, ghuserid string) {
+	if r.repoconfig.DestructiveOperations.AllowDestructiveOutsideCollaborators {
+		r.logCommand(ctx, dryrun, "remove_outside_collaborator_from_org", "ghuserid: %s", ghuserid)
+		remote.RemoveOutsideCollaboratorFromOrg(ghuserid)
+		if r.executor != nil {
+			r.executor.RemoveOutsideCollaboratorFromOrg(ctx, dryrun, ghuserid)
+		}
+	}
+}
+
+func (r *GoliacReconciliatorImpl) UpdateUserOrgRole(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, ghuserid string, role string) {
+	r.logCommand(ctx, dryrun, "update_user_org_role", "ghuserid: %s, role: %s", ghuserid, role)
+	remote.UpdateUserOrgRole(ghuserid, role)
+	if r.executor != nil {
+		r.executor.UpdateUserOrgRole(ctx, dryrun, ghuserid, role)
+	}
+}
+
 func (r *GoliacReconciliatorImpl) CreateTeam(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamname string, description string, parentTeam *int, members []string) {
 	parenTeamId := "nil"
 	if parentTeam != nil {
 		parenTeamId = fmt.Sprintf("%d", *parentTeam)
 	}
 
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "create_team"}).Infof("teamname: %s, parentTeam: %s, members: %s", teamname, parenTeamId, strings.Join(members, ","))
+	r.logCommand(ctx, dryrun, "create_team", "teamname: %s, parentTeam: %s, members: %s", teamname, parenTeamId, strings.Join(members, ","))
 	remote.CreateTeam(teamname, description, members)
 	if r.executor != nil {
 		r.executor.CreateTeam(ctx, dryrun, teamname, description, parentTeam, members)
 	}
 }
 func (r *GoliacReconciliatorImpl) UpdateTeamAddMember(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, ghuserid string, role string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_team_add_member"}).Infof("teamslug: %s, ghuserid: %s, role: %s", teamslug, ghuserid, role)
+	r.logCommand(ctx, dryrun, "update_team_add_member", "teamslug: %s, ghuserid: %s, role: %s", teamslug, ghuserid, role)
 	remote.UpdateTeamAddMember(teamslug, ghuserid, "member")
 	if r.executor != nil {
 		r.executor.UpdateTeamAddMember(ctx, dryrun, teamslug, ghuserid, "member")
 	}
 }
 func (r *GoliacReconciliatorImpl) UpdateTeamRemoveMember(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, ghuserid string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_team_remove_member"}).Infof("teamslug: %s, ghuserid: %s", teamslug, ghuserid)
+	r.logCommand(ctx, dryrun, "update_team_remove_member", "teamslug: %s, ghuserid: %s", teamslug, ghuserid)
 	remote.UpdateTeamRemoveMember(teamslug, ghuserid)
 	if r.executor != nil {
 		r.executor.UpdateTeamRemoveMember(ctx, dryrun, teamslug, ghuserid)
 	}
 }
+func (r *GoliacReconciliatorImpl) UpdateTeamAddMembers(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, ghuserids []string, role string) {
+	r.logCommand(ctx, dryrun, "update_team_add_members", "teamslug: %s, ghuserids: %s, role: %s", teamslug, strings.Join(ghuserids, ","), role)
+	remote.UpdateTeamAddMembers(teamslug, ghuserids, role)
+	if r.executor != nil {
+		r.executor.UpdateTeamAddMembers(ctx, dryrun, teamslug, ghuserids, role)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, ghuserids []string) {
+	r.logCommand(ctx, dryrun, "update_team_remove_members", "teamslug: %s, ghuserids: %s", teamslug, strings.Join(ghuserids, ","))
+	remote.UpdateTeamRemoveMembers(teamslug, ghuserids)
+	if r.executor != nil {
+		r.executor.UpdateTeamRemoveMembers(ctx, dryrun, teamslug, ghuserids)
+	}
+}
 func (r *GoliacReconciliatorImpl) UpdateTeamChangeMaintainerToMember(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, ghuserid string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_team_change_maintainer_to_member"}).Infof("teamslug: %s, ghuserid: %s", teamslug, ghuserid)
+	r.logCommand(ctx, dryrun, "update_team_change_maintainer_to_member", "teamslug: %s, ghuserid: %s", teamslug, ghuserid)
 	remote.UpdateTeamUpdateMember(teamslug, ghuserid, "member")
 	if r.executor != nil {
 		r.executor.UpdateTeamUpdateMember(ctx, dryrun, teamslug, ghuserid, "member")
@@ -847,32 +3213,58 @@ func (r *GoliacReconciliatorImpl) UpdateTeamSetParent(ctx context.Context, dryru
 		parenTeamId = fmt.Sprintf("%d", *parentTeam)
 	}
 
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_team_parentteam"}).Infof("teamslug: %s, parentteam: %s (%s)", teamslug, parenTeamId, parentTeamName)
+	r.logCommand(ctx, dryrun, "update_team_parentteam", "teamslug: %s, parentteam: %s (%s)", teamslug, parenTeamId, parentTeamName)
 	remote.UpdateTeamSetParent(ctx, dryrun, teamslug, parentTeam)
 	if r.executor != nil {
 		r.executor.UpdateTeamSetParent(ctx, dryrun, teamslug, parentTeam)
 	}
 }
-func (r *GoliacReconciliatorImpl) DeleteTeam(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string) {
+func (r *GoliacReconciliatorImpl) UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, reviewAssignment *GithubTeamReviewAssignment) {
+	if reviewAssignment == nil {
+		r.logCommand(ctx, dryrun, "update_team_review_assignment", "teamslug: %s, disabled", teamslug)
+	} else {
+		r.logCommand(ctx, dryrun, "update_team_review_assignment", "teamslug: %s, algorithm: %s, team members count: %d, notify team: %v", teamslug, reviewAssignment.Algorithm, reviewAssignment.TeamMembersCount, reviewAssignment.NotifyTeam)
+	}
+	remote.UpdateTeamReviewAssignment(teamslug, reviewAssignment)
+	if r.executor != nil {
+		r.executor.UpdateTeamReviewAssignment(ctx, dryrun, teamslug, reviewAssignment)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, notificationSetting string) {
+	r.logCommand(ctx, dryrun, "update_team_notification_setting", "teamslug: %s, notification setting: %s", teamslug, notificationSetting)
+	remote.UpdateTeamNotificationSetting(teamslug, notificationSetting)
+	if r.executor != nil {
+		r.executor.UpdateTeamNotificationSetting(ctx, dryrun, teamslug, notificationSetting)
+	}
+}
+func (r *GoliacReconciliatorImpl) DeleteTeam(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, teamslug string, reason string) {
 	if r.repoconfig.DestructiveOperations.AllowDestructiveTeams {
-		logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "delete_team"}).Infof("teamslug: %s", teamslug)
+		reason = deletionReason(ctx, reason)
+		r.logDestructiveCommand(ctx, dryrun, "delete_team", reason, "teamslug: %s", teamslug)
 		remote.DeleteTeam(teamslug)
 		if r.executor != nil {
-			r.executor.DeleteTeam(ctx, dryrun, teamslug)
+			r.executor.DeleteTeam(ctx, dryrun, teamslug, reason)
 		}
 	} else {
 		r.unmanaged.Teams[teamslug] = true
 	}
 }
 func (r *GoliacReconciliatorImpl) CreateRepository(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, descrition string, writers []string, readers []string, boolProperties map[string]bool) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "create_repository"}).Infof("repositoryname: %s, readers: %s, writers: %s, boolProperties: %v", reponame, strings.Join(readers, ","), strings.Join(writers, ","), boolProperties)
+	r.logCommand(ctx, dryrun, "create_repository", "repositoryname: %s, readers: %s, writers: %s, boolProperties: %v", reponame, strings.Join(readers, ","), strings.Join(writers, ","), boolProperties)
 	remote.CreateRepository(reponame, reponame, writers, readers, boolProperties)
 	if r.executor != nil {
 		r.executor.CreateRepository(ctx, dryrun, reponame, reponame, writers, readers, boolProperties)
 	}
 }
+func (r *GoliacReconciliatorImpl) CreateRepositoryFork(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) {
+	r.logCommand(ctx, dryrun, "create_repository_fork", "repositoryname: %s, upstream: %s, readers: %s, writers: %s, boolProperties: %v", reponame, upstream, strings.Join(readers, ","), strings.Join(writers, ","), boolProperties)
+	remote.CreateRepository(reponame, reponame, writers, readers, boolProperties)
+	if r.executor != nil {
+		r.executor.CreateRepositoryFork(ctx, dryrun, reponame, upstream, writers, readers, boolProperties)
+	}
+}
 func (r *GoliacReconciliatorImpl) UpdateRepositoryAddTeamAccess(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, teamslug string, permission string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_add_team"}).Infof("repositoryname: %s, teamslug: %s, permission: %s", reponame, teamslug, permission)
+	r.logCommand(ctx, dryrun, "update_repository_add_team", "repositoryname: %s, teamslug: %s, permission: %s", reponame, teamslug, permission)
 	remote.UpdateRepositoryAddTeamAccess(reponame, teamslug, permission)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryAddTeamAccess(ctx, dryrun, reponame, teamslug, permission)
@@ -880,26 +3272,27 @@ func (r *GoliacReconciliatorImpl) UpdateRepositoryAddTeamAccess(ctx context.Cont
 }
 
 func (r *GoliacReconciliatorImpl) UpdateRepositoryUpdateTeamAccess(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, teamslug string, permission string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_update_team"}).Infof("repositoryname: %s, teamslug:%s, permission: %s", reponame, teamslug, permission)
+	r.logCommand(ctx, dryrun, "update_repository_update_team", "repositoryname: %s, teamslug:%s, permission: %s", reponame, teamslug, permission)
 	remote.UpdateRepositoryUpdateTeamAccess(reponame, teamslug, permission)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryUpdateTeamAccess(ctx, dryrun, reponame, teamslug, permission)
 	}
 }
 func (r *GoliacReconciliatorImpl) UpdateRepositoryRemoveTeamAccess(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, teamslug string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_remove_team"}).Infof("repositoryname: %s, teamslug:%s", reponame, teamslug)
+	r.logCommand(ctx, dryrun, "update_repository_remove_team", "repositoryname: %s, teamslug:%s", reponame, teamslug)
 	remote.UpdateRepositoryRemoveTeamAccess(reponame, teamslug)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryRemoveTeamAccess(ctx, dryrun, reponame, teamslug)
 	}
 }
 
-func (r *GoliacReconciliatorImpl) DeleteRepository(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string) {
+func (r *GoliacReconciliatorImpl) DeleteRepository(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, reason string) {
 	if r.repoconfig.DestructiveOperations.AllowDestructiveRepositories {
-		logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "delete_repository"}).Infof("repositoryname: %s", reponame)
+		reason = deletionReason(ctx, reason)
+		r.logDestructiveCommand(ctx, dryrun, "delete_repository", reason, "repositoryname: %s", reponame)
 		remote.DeleteRepository(reponame)
 		if r.executor != nil {
-			r.executor.DeleteRepository(ctx, dryrun, reponame)
+			r.executor.DeleteRepository(ctx, dryrun, reponame, reason)
 		}
 	} else {
 		r.unmanaged.Repositories[reponame] = true
@@ -907,35 +3300,164 @@ func (r *GoliacReconciliatorImpl) DeleteRepository(ctx context.Context, dryrun b
 }
 
 func (r *GoliacReconciliatorImpl) RenameRepository(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, newname string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "rename_repository"}).Infof("repositoryname: %s newname: %s", reponame, newname)
+	r.logCommand(ctx, dryrun, "rename_repository", "repositoryname: %s newname: %s", reponame, newname)
 	remote.RenameRepository(reponame, newname)
 	if r.executor != nil {
 		r.executor.RenameRepository(ctx, dryrun, reponame, newname)
 	}
 }
 
+func (r *GoliacReconciliatorImpl) UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) {
+	r.logCommand(ctx, dryrun, "update_repository_file", "repositoryname: %s file: %s", reponame, filename)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryFile(ctx, dryrun, reponame, filename, content, sha)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, currentValue bool, enabled bool) {
+	r.logCommand(ctx, dryrun, "update_repository_dependabot_alerts", "repositoryname: %s dependabot_alerts: %v -> %v", reponame, currentValue, enabled)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryDependabotAlerts(ctx, dryrun, reponame, enabled)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, currentValue bool, enabled bool) {
+	r.logCommand(ctx, dryrun, "update_repository_dependabot_security_updates", "repositoryname: %s dependabot_security_updates: %v -> %v", reponame, currentValue, enabled)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryDependabotSecurityUpdates(ctx, dryrun, reponame, enabled)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *GithubLabel) {
+	r.logCommand(ctx, dryrun, "add_repository_label", "repositoryname: %s label: %s color: %s", reponame, label.Name, label.Color)
+	if r.executor != nil {
+		r.executor.AddRepositoryLabel(ctx, dryrun, reponame, label)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	r.logCommand(ctx, dryrun, "link_repository_project", "repositoryname: %s project: %s", reponame, project.Title)
+	if r.executor != nil {
+		r.executor.LinkRepositoryProject(ctx, dryrun, reponame, project)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	r.logCommand(ctx, dryrun, "unlink_repository_project", "repositoryname: %s project: %s", reponame, project.Title)
+	if r.executor != nil {
+		r.executor.UnlinkRepositoryProject(ctx, dryrun, reponame, project)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *GithubLabel) {
+	r.logCommand(ctx, dryrun, "update_repository_label", "repositoryname: %s label: %s -> %s color: %s", reponame, labelname, label.Name, label.Color)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryLabel(ctx, dryrun, reponame, labelname, label)
+	}
+}
+
+func (r *GoliacReconciliatorImpl) DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string) {
+	if !r.repoconfig.DestructiveOperations.AllowDestructiveLabels {
+		r.logSkippedCommand(ctx, dryrun, "delete_repository_label", "repositoryname: %s label: %s, destructive_operations.labels is not set", reponame, labelname)
+		return
+	}
+	r.logCommand(ctx, dryrun, "delete_repository_label", "repositoryname: %s label: %s", reponame, labelname)
+	if r.executor != nil {
+		r.executor.DeleteRepositoryLabel(ctx, dryrun, reponame, labelname)
+	}
+}
+
 func (r *GoliacReconciliatorImpl) UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, propertyName string, propertyValue bool) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_update_bool_property"}).Infof("repositoryname: %s %s:%v", reponame, propertyName, propertyValue)
+	r.logCommand(ctx, dryrun, "update_repository_update_bool_property", "repositoryname: %s %s:%v", reponame, propertyName, propertyValue)
 	remote.UpdateRepositoryUpdateBoolProperty(reponame, propertyName, propertyValue)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryUpdateBoolProperty(ctx, dryrun, reponame, propertyName, propertyValue)
 	}
 }
+func (r *GoliacReconciliatorImpl) UpdateOrgSetting(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, settingName string, currentValue bool, settingValue bool) {
+	r.logCommand(ctx, dryrun, "update_org_setting", "setting: %s %v -> %v", settingName, currentValue, settingValue)
+	remote.UpdateOrgSetting(settingName, settingValue)
+	if r.executor != nil {
+		r.executor.UpdateOrgSetting(ctx, dryrun, settingName, settingValue)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, currentBranchName string, branchName string) {
+	r.logCommand(ctx, dryrun, "update_org_default_branch_name", "default branch name: %s -> %s", currentBranchName, branchName)
+	if r.executor != nil {
+		r.executor.UpdateOrgDefaultBranchName(ctx, dryrun, branchName)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, currentValue string, fieldValue string) {
+	r.logCommand(ctx, dryrun, "update_org_profile", "setting: %s %q -> %q", fieldName, currentValue, fieldValue)
+	if r.executor != nil {
+		r.executor.UpdateOrgProfileField(ctx, dryrun, fieldName, fieldValue)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, currentValue string, settingValue string) {
+	r.logCommand(ctx, dryrun, "update_org_actions_setting", "setting: %s %s -> %s", settingName, currentValue, settingValue)
+	if r.executor != nil {
+		r.executor.UpdateOrgActionsSetting(ctx, dryrun, settingName, settingValue)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *GithubOrgAllowedActions, changes []string) {
+	r.logCommandWithChanges(ctx, dryrun, "update_org_allowed_actions", changes, "changes: %s", strings.Join(changes, "; "))
+	if r.executor != nil {
+		r.executor.UpdateOrgAllowedActions(ctx, dryrun, allowedActions)
+	}
+}
+func (r *GoliacReconciliatorImpl) AddOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	r.logCommand(ctx, dryrun, "add_org_webhook", "url: %s events: %v active: %v", webhook.Url, webhook.Events, webhook.Active)
+	if r.executor != nil {
+		r.executor.AddOrgWebhook(ctx, dryrun, webhook)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook, changes []string) {
+	r.logCommandWithChanges(ctx, dryrun, "update_org_webhook", changes, "url: %s (id: %d)", webhook.Url, webhook.Id)
+	if r.executor != nil {
+		r.executor.UpdateOrgWebhook(ctx, dryrun, webhook)
+	}
+}
+func (r *GoliacReconciliatorImpl) DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	if !r.repoconfig.DestructiveOperations.AllowDestructiveOrgWebhooks {
+		r.logSkippedCommand(ctx, dryrun, "delete_org_webhook", "url: %s (id: %d), destructive_operations.org_webhooks is not set", webhook.Url, webhook.Id)
+		return
+	}
+	r.logCommand(ctx, dryrun, "delete_org_webhook", "url: %s (id: %d)", webhook.Url, webhook.Id)
+	if r.executor != nil {
+		r.executor.DeleteOrgWebhook(ctx, dryrun, webhook)
+	}
+}
+func (r *GoliacReconciliatorImpl) AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	r.logCommand(ctx, dryrun, "add_security_manager_team", "teamslug: %s", teamslug)
+	if r.executor != nil {
+		r.executor.AddOrgSecurityManagerTeam(ctx, dryrun, teamslug)
+	}
+}
+func (r *GoliacReconciliatorImpl) RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	if !r.repoconfig.DestructiveOperations.AllowDestructiveSecurityManagers {
+		r.logSkippedCommand(ctx, dryrun, "remove_security_manager_team", "teamslug: %s, destructive_operations.security_manager_teams is not set", teamslug)
+		return
+	}
+	r.logCommand(ctx, dryrun, "remove_security_manager_team", "teamslug: %s", teamslug)
+	if r.executor != nil {
+		r.executor.RemoveOrgSecurityManagerTeam(ctx, dryrun, teamslug)
+	}
+}
 func (r *GoliacReconciliatorImpl) AddRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "add_ruleset"}).Infof("ruleset: %s (id: %d) enforcement: %s", ruleset.Name, ruleset.Id, ruleset.Enforcement)
+	r.logCommand(ctx, dryrun, "add_ruleset", "ruleset: %s (id: %d) enforcement: %s", ruleset.Name, ruleset.Id, ruleset.Enforcement)
 	if r.executor != nil {
 		r.executor.AddRuleset(ctx, dryrun, ruleset)
 	}
 }
-func (r *GoliacReconciliatorImpl) UpdateRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_ruleset"}).Infof("ruleset: %s (id: %d) enforcement: %s", ruleset.Name, ruleset.Id, ruleset.Enforcement)
+func (r *GoliacReconciliatorImpl) UpdateRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet, changes []string) {
+	r.logCommandWithChanges(ctx, dryrun, "update_ruleset", changes, "ruleset: %s (id: %d) enforcement: %s changes: %s", ruleset.Name, ruleset.Id, ruleset.Enforcement, strings.Join(changes, "; "))
 	if r.executor != nil {
 		r.executor.UpdateRuleset(ctx, dryrun, ruleset)
 	}
 }
 func (r *GoliacReconciliatorImpl) DeleteRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet) {
 	if r.repoconfig.DestructiveOperations.AllowDestructiveRulesets {
-		logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "delete_ruleset"}).Infof("ruleset id:%d", ruleset.Id)
+		r.logCommand(ctx, dryrun, "delete_ruleset", "ruleset id:%d", ruleset.Id)
 		if r.executor != nil {
 			r.executor.DeleteRuleset(ctx, dryrun, ruleset.Id)
 		}
@@ -944,39 +3466,60 @@ func (r *GoliacReconciliatorImpl) DeleteRuleset(ctx context.Context, dryrun bool
 	}
 }
 func (r *GoliacReconciliatorImpl) AddRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "add_repository_ruleset"}).Infof("repository: %s, ruleset: %s (id: %d) enforcement: %s", reponame, ruleset.Name, ruleset.Id, ruleset.Enforcement)
+	r.logCommand(ctx, dryrun, "add_repository_ruleset", "repository: %s, ruleset: %s (id: %d) enforcement: %s", reponame, ruleset.Name, ruleset.Id, ruleset.Enforcement)
 	if r.executor != nil {
 		r.executor.AddRepositoryRuleset(ctx, dryrun, reponame, ruleset)
 	}
 }
-func (r *GoliacReconciliatorImpl) UpdateRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_ruleset"}).Infof("repository: %s, ruleset: %s (id: %d) enforcement: %s", reponame, ruleset.Name, ruleset.Id, ruleset.Enforcement)
+func (r *GoliacReconciliatorImpl) UpdateRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet, changes []string) {
+	r.logCommandWithChanges(ctx, dryrun, "update_repository_ruleset", changes, "repository: %s, ruleset: %s (id: %d) enforcement: %s changes: %s", reponame, ruleset.Name, ruleset.Id, ruleset.Enforcement, strings.Join(changes, "; "))
 	if r.executor != nil {
 		r.executor.UpdateRepositoryRuleset(ctx, dryrun, reponame, ruleset)
 	}
 }
 func (r *GoliacReconciliatorImpl) DeleteRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "delete_repository_ruleset"}).Infof("repository: %s, ruleset id:%d", reponame, ruleset.Id)
+	r.logCommand(ctx, dryrun, "delete_repository_ruleset", "repository: %s, ruleset id:%d", reponame, ruleset.Id)
 	if r.executor != nil {
 		r.executor.DeleteRepositoryRuleset(ctx, dryrun, reponame, ruleset.Id)
 	}
 }
+func (r *GoliacReconciliatorImpl) UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, properties map[string]string) {
+	r.logCommand(ctx, dryrun, "update_repository_update_string_properties", "repositoryname: %s %v", reponame, properties)
+	remote.UpdateRepositoryUpdateStringProperties(reponame, properties)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryUpdateStringProperties(ctx, dryrun, reponame, properties)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, propertyName string, propertyValue string) {
+	r.logCommand(ctx, dryrun, "update_repository_set_custom_property", "repositoryname: %s %s:%s", reponame, propertyName, propertyValue)
+	remote.UpdateRepositorySetCustomProperty(reponame, propertyName, propertyValue)
+	if r.executor != nil {
+		r.executor.UpdateRepositorySetCustomProperty(ctx, dryrun, reponame, propertyName, propertyValue)
+	}
+}
+func (r *GoliacReconciliatorImpl) UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, propertyName string) {
+	r.logCommand(ctx, dryrun, "update_repository_remove_custom_property", "repositoryname: %s %s", reponame, propertyName)
+	remote.UpdateRepositoryRemoveCustomProperty(reponame, propertyName)
+	if r.executor != nil {
+		r.executor.UpdateRepositoryRemoveCustomProperty(ctx, dryrun, reponame, propertyName)
+	}
+}
 func (r *GoliacReconciliatorImpl) UpdateRepositorySetExternalUser(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, collaboatorGithubId string, permission string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_set_external_user"}).Infof("repositoryname: %s collaborator:%s permission:%s", reponame, collaboatorGithubId, permission)
+	r.logCommand(ctx, dryrun, "update_repository_set_external_user", "repositoryname: %s collaborator:%s permission:%s", reponame, collaboatorGithubId, permission)
 	remote.UpdateRepositorySetExternalUser(reponame, collaboatorGithubId, permission)
 	if r.executor != nil {
 		r.executor.UpdateRepositorySetExternalUser(ctx, dryrun, reponame, collaboatorGithubId, permission)
 	}
 }
 func (r *GoliacReconciliatorImpl) UpdateRepositoryRemoveInternalUser(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, collaboatorGithubId string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_remove_internal_user"}).Infof("repositoryname: %s collaborator:%s", reponame, collaboatorGithubId)
+	r.logCommand(ctx, dryrun, "update_repository_remove_internal_user", "repositoryname: %s collaborator:%s", reponame, collaboatorGithubId)
 	remote.UpdateRepositoryRemoveInternalUser(reponame, collaboatorGithubId)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryRemoveInternalUser(ctx, dryrun, reponame, collaboatorGithubId)
 	}
 }
 func (r *GoliacReconciliatorImpl) UpdateRepositoryRemoveExternalUser(ctx context.Context, dryrun bool, remote *MutableGoliacRemoteImpl, reponame string, collaboatorGithubId string) {
-	logrus.WithFields(map[string]interface{}{"dryrun": dryrun, "command": "update_repository_remove_external_user"}).Infof("repositoryname: %s collaborator:%s", reponame, collaboatorGithubId)
+	r.logCommand(ctx, dryrun, "update_repository_remove_external_user", "repositoryname: %s collaborator:%s", reponame, collaboatorGithubId)
 	remote.UpdateRepositoryRemoveExternalUser(reponame, collaboatorGithubId)
 	if r.executor != nil {
 		r.executor.UpdateRepositoryRemoveExternalUser(ctx, dryrun, reponame, collaboatorGithubId)
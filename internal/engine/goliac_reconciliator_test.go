@@ -1,9 +1,15 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/entity"
@@ -12,6 +18,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/gosimple/slug"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,6 +33,12 @@ type GoliacLocalMock struct {
 func (m *GoliacLocalMock) Clone(fs billy.Filesystem, accesstoken, repositoryUrl, branch string) error {
 	return nil
 }
+func (m *GoliacLocalMock) CloneRef(fs billy.Filesystem, accesstoken, repositoryUrl, ref string) error {
+	return nil
+}
+func (m *GoliacLocalMock) ChangedFilesSince(ref string) ([]string, error) {
+	return nil, nil
+}
 func (m *GoliacLocalMock) ListCommitsFromTag(tagname string) ([]*object.Commit, error) {
 	return nil, fmt.Errorf("not tag %s found", tagname)
 }
@@ -41,10 +54,10 @@ func (m *GoliacLocalMock) PushTag(tagname string, hash plumbing.Hash, accesstoke
 func (m *GoliacLocalMock) LoadRepoConfig() (*config.RepositoryConfig, error) {
 	return &config.RepositoryConfig{}, nil
 }
-func (m *GoliacLocalMock) LoadAndValidate() ([]error, []entity.Warning) {
+func (m *GoliacLocalMock) LoadAndValidate(repoconfig *config.RepositoryConfig) ([]error, []entity.Warning) {
 	return nil, nil
 }
-func (m *GoliacLocalMock) LoadAndValidateLocal(fs billy.Filesystem) ([]error, []entity.Warning) {
+func (m *GoliacLocalMock) LoadAndValidateLocal(fs billy.Filesystem, repoconfig *config.RepositoryConfig) ([]error, []entity.Warning) {
 	return nil, nil
 }
 func (m *GoliacLocalMock) Teams() map[string]*entity.Team {
@@ -65,7 +78,7 @@ func (m *GoliacLocalMock) RuleSets() map[string]*entity.RuleSet {
 func (m *GoliacLocalMock) UpdateAndCommitCodeOwners(repoconfig *config.RepositoryConfig, dryrun bool, accesstoken string, branch string, tagname string, githubOrganization string) error {
 	return nil
 }
-func (m *GoliacLocalMock) UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, accesstoken string, branch string, tagname string) error {
+func (m *GoliacLocalMock) UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, reposToDeleteList []string, accesstoken string, branch string, tagname string) error {
 	return nil
 }
 func (m *GoliacLocalMock) SyncUsersAndTeams(repoconfig *config.RepositoryConfig, plugin UserSyncPlugin, accesstoken string, dryrun bool, force bool, feedback observability.RemoteObservability) (bool, error) {
@@ -76,12 +89,28 @@ func (m *GoliacLocalMock) Close(fs billy.Filesystem) {
 }
 
 type GoliacRemoteMock struct {
-	users      map[string]string
-	teams      map[string]*GithubTeam // key is the slug team
-	repos      map[string]*GithubRepository
-	teamsrepos map[string]map[string]*GithubTeamRepo // key is the slug team
-	rulesets   map[string]*GithubRuleSet
-	appids     map[string]int
+	users       map[string]string
+	teams       map[string]*GithubTeam // key is the slug team
+	repos       map[string]*GithubRepository
+	teamsrepos  map[string]map[string]*GithubTeamRepo // key is the slug team
+	rulesets    map[string]*GithubRuleSet
+	appids      map[string]int
+	orgsettings map[string]bool
+	orgactions  map[string]string
+	orgallowed  *GithubOrgAllowedActions
+	orgprofile  map[string]string
+	orgbranch   string
+	outsidecoll map[string]bool
+	webhooks    map[string]*GithubOrgWebhook
+	secmanagers map[string]bool
+	invitations map[string]*GithubOrgInvitation
+	files       map[string]*GithubFile // key is "reponame:filename"
+	labels      map[string]map[string]*GithubLabel
+	dependabot  map[string]*GithubRepositoryDependabot
+	projects    map[string]*GithubProject            // key is the project title
+	repoproj    map[string]map[string]*GithubProject // key is the reponame, then the project title
+	projectsErr error                                // error returned by OrgProjects
+	checks      map[string]map[string]int            // key is the reponame, then the check
 }
 
 func (m *GoliacRemoteMock) Load(ctx context.Context, continueOnError bool) error {
@@ -123,6 +152,90 @@ func (m *GoliacRemoteMock) TeamRepositories(ctx context.Context) map[string]map[
 func (m *GoliacRemoteMock) AppIds(ctx context.Context) map[string]int {
 	return m.appids
 }
+func (m *GoliacRemoteMock) OrgSettings(ctx context.Context) map[string]bool {
+	return m.orgsettings
+}
+func (m *GoliacRemoteMock) OrgActionsSettings(ctx context.Context) map[string]string {
+	return m.orgactions
+}
+func (m *GoliacRemoteMock) OrgAllowedActions(ctx context.Context) *GithubOrgAllowedActions {
+	return m.orgallowed
+}
+func (m *GoliacRemoteMock) OrgProfile(ctx context.Context) map[string]string {
+	return m.orgprofile
+}
+func (m *GoliacRemoteMock) OrgDefaultBranchName(ctx context.Context) string {
+	return m.orgbranch
+}
+func (m *GoliacRemoteMock) OutsideCollaborators(ctx context.Context) map[string]bool {
+	return m.outsidecoll
+}
+func (m *GoliacRemoteMock) OrgWebhooks(ctx context.Context) map[string]*GithubOrgWebhook {
+	return m.webhooks
+}
+func (m *GoliacRemoteMock) OrgInvitations(ctx context.Context) map[string]*GithubOrgInvitation {
+	return m.invitations
+}
+func (m *GoliacRemoteMock) OrgSecurityManagerTeams(ctx context.Context) map[string]bool {
+	return m.secmanagers
+}
+func (m *GoliacRemoteMock) RepositoriesFile(ctx context.Context, reponames []string, filename string) (map[string]*GithubFile, error) {
+	files := make(map[string]*GithubFile)
+	for _, reponame := range reponames {
+		if f, ok := m.files[reponame+":"+filename]; ok {
+			files[reponame] = f
+		}
+	}
+	return files, nil
+}
+func (m *GoliacRemoteMock) RepositoriesLabels(ctx context.Context, reponames []string) (map[string]map[string]*GithubLabel, error) {
+	labels := make(map[string]map[string]*GithubLabel)
+	for _, reponame := range reponames {
+		if l, ok := m.labels[reponame]; ok {
+			labels[reponame] = l
+		}
+	}
+	return labels, nil
+}
+func (m *GoliacRemoteMock) RepositoriesDependabot(ctx context.Context, reponames []string) (map[string]*GithubRepositoryDependabot, error) {
+	dependabot := make(map[string]*GithubRepositoryDependabot)
+	for _, reponame := range reponames {
+		if d, ok := m.dependabot[reponame]; ok {
+			dependabot[reponame] = d
+		}
+	}
+	return dependabot, nil
+}
+func (m *GoliacRemoteMock) OrgProjects(ctx context.Context) (map[string]*GithubProject, error) {
+	if m.projectsErr != nil {
+		return nil, m.projectsErr
+	}
+	return m.projects, nil
+}
+func (m *GoliacRemoteMock) RepositoriesProjects(ctx context.Context, reponames []string) (map[string]map[string]*GithubProject, error) {
+	projects := make(map[string]map[string]*GithubProject)
+	for _, reponame := range reponames {
+		if p, ok := m.repoproj[reponame]; ok {
+			projects[reponame] = p
+		}
+	}
+	return projects, nil
+}
+func (m *GoliacRemoteMock) RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error) {
+	checks := make(map[string]map[string]int)
+	for _, reponame := range reponames {
+		if c, ok := m.checks[reponame]; ok {
+			checks[reponame] = c
+		}
+	}
+	return checks, nil
+}
+func (m *GoliacRemoteMock) RefreshTeam(ctx context.Context, teamslug string) error {
+	if _, ok := m.teams[teamslug]; !ok {
+		return fmt.Errorf("team %s not found", teamslug)
+	}
+	return nil
+}
 func (m *GoliacRemoteMock) CountAssets(ctx context.Context) (int, error) {
 	return 3, nil
 }
@@ -132,61 +245,138 @@ func (g *GoliacRemoteMock) SetRemoteObservability(feedback observability.RemoteO
 type ReconciliatorListenerRecorder struct {
 	UsersCreated map[string]string
 	UsersRemoved map[string]string
+	// organization role (member or admin) per githubid
+	UsersOrgRoleUpdated map[string]string
+	// outside collaborators removed from the organization
+	OutsideCollaboratorsRemoved map[string]bool
+	// logins of the org invitations cancelled
+	InvitationsCancelled []string
 
 	TeamsCreated      map[string][]string
 	TeamMemberAdded   map[string][]string
 	TeamMemberRemoved map[string][]string
 	TeamMemberUpdated map[string][]string
-	TeamParentUpdated map[string]*int
-	TeamDeleted       map[string]bool
-
-	RepositoryCreated              map[string]bool
-	RepositoryTeamAdded            map[string][]string
-	RepositoryTeamUpdated          map[string][]string
-	RepositoryTeamRemoved          map[string][]string
-	RepositoriesDeleted            map[string]bool
-	RepositoriesRenamed            map[string]bool
-	RepositoriesUpdatePrivate      map[string]bool
-	RepositoriesUpdateArchived     map[string]bool
-	RepositoriesSetExternalUser    map[string]string
-	RepositoriesRemoveExternalUser map[string]bool
-	RepositoriesRemoveInternalUser map[string]bool
-	RepositoryRuleSetCreated       map[string]map[string]*GithubRuleSet
-	RepositoryRuleSetUpdated       map[string]map[string]*GithubRuleSet
-	RepositoryRuleSetDeleted       map[string][]int
-
-	RuleSetCreated map[string]*GithubRuleSet
-	RuleSetUpdated map[string]*GithubRuleSet
-	RuleSetDeleted []int
+	// number of batched membership calls per team
+	TeamMembersBatches map[string]int
+	TeamParentUpdated  map[string]*int
+	TeamDeleted        map[string]bool
+	// code review assignment per team (nil when disabled)
+	TeamReviewAssignmentUpdated    map[string]*GithubTeamReviewAssignment
+	TeamNotificationSettingUpdated map[string]string
+
+	// dryrun of the created teams and repositories
+	Dryruns map[string]bool
+
+	RepositoryCreated                  map[string]bool
+	RepositoryForked                   map[string]string
+	RepositoryTeamAdded                map[string][]string
+	RepositoryTeamUpdated              map[string][]string
+	RepositoryTeamRemoved              map[string][]string
+	RepositoryTeamPermission           map[string]map[string]string // reponame, teamslug, permission
+	RepositoriesDeleted                map[string]bool
+	RepositoriesRenamed                map[string]bool
+	RepositoryFilesUpdated             map[string]string // key is "reponame:filename", value is the content
+	RepositoriesUpdatePrivate          map[string]bool
+	RepositoriesUpdateArchived         map[string]bool
+	RepositoriesUpdateBoolProperty     map[string]map[string]bool
+	RepositoriesUpdateStringProperties map[string]map[string]string
+	RepositoriesSetExternalUser        map[string]string
+	RepositoriesRemoveExternalUser     map[string]bool
+	RepositoriesRemoveInternalUser     map[string]bool
+	RepositoriesSetCustomProperty      map[string]map[string]string
+	RepositoriesRemoveCustomProperty   map[string][]string
+	RepositoryRuleSetCreated           map[string]map[string]*GithubRuleSet
+	RepositoryRuleSetUpdated           map[string]map[string]*GithubRuleSet
+	RepositoryRuleSetDeleted           map[string][]int
+
+	RuleSetCreated      map[string]*GithubRuleSet
+	RuleSetCreatedOrder []string
+	RuleSetUpdated      map[string]*GithubRuleSet
+	RuleSetDeleted      []int
+
+	OrgSettingsUpdated map[string]bool
+	OrgActionsUpdated  map[string]string
+	OrgAllowedActions  *GithubOrgAllowedActions
+	OrgDefaultBranch   string
+	OrgProfileUpdated  map[string]string
+
+	OrgWebhooksAdded   map[string]*GithubOrgWebhook // key is the url
+	OrgWebhooksUpdated map[string]*GithubOrgWebhook
+	OrgWebhooksDeleted []int
+
+	SecurityManagerTeamsAdded   []string
+	SecurityManagerTeamsRemoved []string
+
+	RepositoryLabelsAdded   map[string]map[string]*GithubLabel // key is the reponame, then the label name
+	RepositoryLabelsUpdated map[string]map[string]*GithubLabel // key is the reponame, then the previous label name
+	RepositoryLabelsDeleted map[string][]string
+
+	RepositoriesDependabotAlerts          map[string]bool
+	RepositoriesDependabotSecurityUpdates map[string]bool
+	RepositoriesProjectsLinked            map[string][]string
+	RepositoriesProjectsUnlinked          map[string][]string
+
+	// reason given when deleting a team or a repository
+	DeletionReasons map[string]string
 }
 
 func NewReconciliatorListenerRecorder() *ReconciliatorListenerRecorder {
 	r := ReconciliatorListenerRecorder{
-		UsersCreated:                   make(map[string]string),
-		UsersRemoved:                   make(map[string]string),
-		TeamsCreated:                   make(map[string][]string),
-		TeamMemberAdded:                make(map[string][]string),
-		TeamMemberRemoved:              make(map[string][]string),
-		TeamMemberUpdated:              make(map[string][]string),
-		TeamParentUpdated:              make(map[string]*int),
-		TeamDeleted:                    make(map[string]bool),
-		RepositoryCreated:              make(map[string]bool),
-		RepositoryTeamAdded:            make(map[string][]string),
-		RepositoryTeamUpdated:          make(map[string][]string),
-		RepositoryTeamRemoved:          make(map[string][]string),
-		RepositoriesDeleted:            make(map[string]bool),
-		RepositoriesRenamed:            make(map[string]bool),
-		RepositoriesUpdatePrivate:      make(map[string]bool),
-		RepositoriesUpdateArchived:     make(map[string]bool),
-		RepositoriesSetExternalUser:    make(map[string]string),
-		RepositoriesRemoveExternalUser: make(map[string]bool),
-		RepositoriesRemoveInternalUser: make(map[string]bool),
-		RepositoryRuleSetCreated:       make(map[string]map[string]*GithubRuleSet),
-		RepositoryRuleSetUpdated:       make(map[string]map[string]*GithubRuleSet),
-		RepositoryRuleSetDeleted:       make(map[string][]int, 0),
-		RuleSetCreated:                 make(map[string]*GithubRuleSet),
-		RuleSetUpdated:                 make(map[string]*GithubRuleSet),
-		RuleSetDeleted:                 make([]int, 0),
+		UsersCreated:                          make(map[string]string),
+		UsersRemoved:                          make(map[string]string),
+		UsersOrgRoleUpdated:                   make(map[string]string),
+		InvitationsCancelled:                  make([]string, 0),
+		OutsideCollaboratorsRemoved:           make(map[string]bool),
+		TeamsCreated:                          make(map[string][]string),
+		TeamMemberAdded:                       make(map[string][]string),
+		TeamMemberRemoved:                     make(map[string][]string),
+		TeamMemberUpdated:                     make(map[string][]string),
+		TeamMembersBatches:                    make(map[string]int),
+		TeamParentUpdated:                     make(map[string]*int),
+		TeamDeleted:                           make(map[string]bool),
+		TeamReviewAssignmentUpdated:           make(map[string]*GithubTeamReviewAssignment),
+		TeamNotificationSettingUpdated:        make(map[string]string),
+		Dryruns:                               make(map[string]bool),
+		DeletionReasons:                       make(map[string]string),
+		RepositoryCreated:                     make(map[string]bool),
+		RepositoryForked:                      make(map[string]string),
+		RepositoryTeamAdded:                   make(map[string][]string),
+		RepositoryTeamUpdated:                 make(map[string][]string),
+		RepositoryTeamRemoved:                 make(map[string][]string),
+		RepositoryTeamPermission:              make(map[string]map[string]string),
+		RepositoriesDeleted:                   make(map[string]bool),
+		RepositoriesRenamed:                   make(map[string]bool),
+		RepositoryFilesUpdated:                make(map[string]string),
+		RepositoriesUpdatePrivate:             make(map[string]bool),
+		RepositoriesUpdateArchived:            make(map[string]bool),
+		RepositoriesUpdateBoolProperty:        make(map[string]map[string]bool),
+		RepositoriesUpdateStringProperties:    make(map[string]map[string]string),
+		RepositoriesSetExternalUser:           make(map[string]string),
+		RepositoriesRemoveExternalUser:        make(map[string]bool),
+		RepositoriesRemoveInternalUser:        make(map[string]bool),
+		RepositoriesSetCustomProperty:         make(map[string]map[string]string),
+		RepositoriesRemoveCustomProperty:      make(map[string][]string),
+		RepositoryRuleSetCreated:              make(map[string]map[string]*GithubRuleSet),
+		RepositoryRuleSetUpdated:              make(map[string]map[string]*GithubRuleSet),
+		RepositoryRuleSetDeleted:              make(map[string][]int, 0),
+		RuleSetCreated:                        make(map[string]*GithubRuleSet),
+		RuleSetUpdated:                        make(map[string]*GithubRuleSet),
+		RuleSetDeleted:                        make([]int, 0),
+		OrgSettingsUpdated:                    make(map[string]bool),
+		OrgActionsUpdated:                     make(map[string]string),
+		OrgProfileUpdated:                     make(map[string]string),
+		OrgWebhooksAdded:                      make(map[string]*GithubOrgWebhook),
+		OrgWebhooksUpdated:                    make(map[string]*GithubOrgWebhook),
+		OrgWebhooksDeleted:                    make([]int, 0),
+		SecurityManagerTeamsAdded:             make([]string, 0),
+		SecurityManagerTeamsRemoved:           make([]string, 0),
+		RepositoryLabelsAdded:                 make(map[string]map[string]*GithubLabel),
+		RepositoryLabelsUpdated:               make(map[string]map[string]*GithubLabel),
+		RepositoryLabelsDeleted:               make(map[string][]string),
+		RepositoriesDependabotAlerts:          make(map[string]bool),
+		RepositoriesDependabotSecurityUpdates: make(map[string]bool),
+		RepositoriesProjectsLinked:            make(map[string][]string),
+		RepositoriesProjectsUnlinked:          make(map[string][]string),
 	}
 	return &r
 }
@@ -196,8 +386,18 @@ func (r *ReconciliatorListenerRecorder) AddUserToOrg(ctx context.Context, dryrun
 func (r *ReconciliatorListenerRecorder) RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
 	r.UsersRemoved[ghuserid] = ghuserid
 }
+func (r *ReconciliatorListenerRecorder) CancelOrgInvitation(ctx context.Context, dryrun bool, invitation *GithubOrgInvitation) {
+	r.InvitationsCancelled = append(r.InvitationsCancelled, invitation.Login)
+}
+func (r *ReconciliatorListenerRecorder) RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	r.OutsideCollaboratorsRemoved[ghuserid] = true
+}
+func (r *ReconciliatorListenerRecorder) UpdateUserOrgRole(ctx context.Context, dryrun bool, ghuserid string, role string) {
+	r.UsersOrgRoleUpdated[ghuserid] = role
+}
 func (r *ReconciliatorListenerRecorder) CreateTeam(ctx context.Context, dryrun bool, teamname string, description string, parentTeam *int, members []string) {
 	r.TeamsCreated[teamname] = append(r.TeamsCreated[teamname], members...)
+	r.Dryruns[teamname] = dryrun
 }
 func (r *ReconciliatorListenerRecorder) UpdateTeamAddMember(ctx context.Context, dryrun bool, teamslug string, username string, role string) {
 	r.TeamMemberAdded[teamslug] = append(r.TeamMemberAdded[teamslug], username)
@@ -205,35 +405,140 @@ func (r *ReconciliatorListenerRecorder) UpdateTeamAddMember(ctx context.Context,
 func (r *ReconciliatorListenerRecorder) UpdateTeamRemoveMember(ctx context.Context, dryrun bool, teamslug string, username string) {
 	r.TeamMemberRemoved[teamslug] = append(r.TeamMemberRemoved[teamslug], username)
 }
+func (r *ReconciliatorListenerRecorder) UpdateTeamAddMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string, role string) {
+	r.TeamMemberAdded[teamslug] = append(r.TeamMemberAdded[teamslug], usernames...)
+	r.TeamMembersBatches[teamslug]++
+}
+func (r *ReconciliatorListenerRecorder) UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string) {
+	r.TeamMemberRemoved[teamslug] = append(r.TeamMemberRemoved[teamslug], usernames...)
+	r.TeamMembersBatches[teamslug]++
+}
 func (r *ReconciliatorListenerRecorder) UpdateTeamUpdateMember(ctx context.Context, dryrun bool, teamslug string, username string, role string) {
 	r.TeamMemberUpdated[teamslug] = append(r.TeamMemberUpdated[teamslug], username)
 }
 func (r *ReconciliatorListenerRecorder) UpdateTeamSetParent(ctx context.Context, dryrun bool, teamslug string, parentTeam *int) {
 	r.TeamParentUpdated[teamslug] = parentTeam
 }
-func (r *ReconciliatorListenerRecorder) DeleteTeam(ctx context.Context, dryrun bool, teamslug string) {
+func (r *ReconciliatorListenerRecorder) UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, teamslug string, reviewAssignment *GithubTeamReviewAssignment) {
+	r.TeamReviewAssignmentUpdated[teamslug] = reviewAssignment
+}
+func (r *ReconciliatorListenerRecorder) UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, teamslug string, notificationSetting string) {
+	r.TeamNotificationSettingUpdated[teamslug] = notificationSetting
+}
+func (r *ReconciliatorListenerRecorder) DeleteTeam(ctx context.Context, dryrun bool, teamslug string, reason string) {
 	r.TeamDeleted[teamslug] = true
+	r.DeletionReasons[teamslug] = reason
 }
 func (r *ReconciliatorListenerRecorder) CreateRepository(ctx context.Context, dryrun bool, reponame string, descrition string, writers []string, readers []string, boolProperties map[string]bool) {
 	r.RepositoryCreated[reponame] = true
+	r.Dryruns[reponame] = dryrun
+}
+func (r *ReconciliatorListenerRecorder) CreateRepositoryFork(ctx context.Context, dryrun bool, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) {
+	r.RepositoryForked[reponame] = upstream
+	r.Dryruns[reponame] = dryrun
 }
 func (r *ReconciliatorListenerRecorder) UpdateRepositoryAddTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string) {
 	r.RepositoryTeamAdded[reponame] = append(r.RepositoryTeamAdded[reponame], teamslug)
+	r.setRepositoryTeamPermission(reponame, teamslug, permission)
 }
 func (r *ReconciliatorListenerRecorder) UpdateRepositoryUpdateTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string) {
 	r.RepositoryTeamUpdated[reponame] = append(r.RepositoryTeamUpdated[reponame], teamslug)
+	r.setRepositoryTeamPermission(reponame, teamslug, permission)
+}
+func (r *ReconciliatorListenerRecorder) setRepositoryTeamPermission(reponame string, teamslug string, permission string) {
+	if r.RepositoryTeamPermission[reponame] == nil {
+		r.RepositoryTeamPermission[reponame] = make(map[string]string)
+	}
+	r.RepositoryTeamPermission[reponame][teamslug] = permission
 }
 func (r *ReconciliatorListenerRecorder) UpdateRepositoryRemoveTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string) {
 	r.RepositoryTeamRemoved[reponame] = append(r.RepositoryTeamRemoved[reponame], teamslug)
 }
-func (r *ReconciliatorListenerRecorder) DeleteRepository(ctx context.Context, dryrun bool, reponame string) {
+func (r *ReconciliatorListenerRecorder) DeleteRepository(ctx context.Context, dryrun bool, reponame string, reason string) {
 	r.RepositoriesDeleted[reponame] = true
+	r.DeletionReasons[reponame] = reason
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgSetting(ctx context.Context, dryrun bool, settingName string, settingValue bool) {
+	r.OrgSettingsUpdated[settingName] = settingValue
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, settingValue string) {
+	r.OrgActionsUpdated[settingName] = settingValue
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *GithubOrgAllowedActions) {
+	r.OrgAllowedActions = allowedActions
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, fieldValue string) {
+	r.OrgProfileUpdated[fieldName] = fieldValue
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, branchName string) {
+	r.OrgDefaultBranch = branchName
+}
+func (r *ReconciliatorListenerRecorder) AddOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	r.OrgWebhooksAdded[webhook.Url] = webhook
+}
+func (r *ReconciliatorListenerRecorder) UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	r.OrgWebhooksUpdated[webhook.Url] = webhook
+}
+func (r *ReconciliatorListenerRecorder) DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	r.OrgWebhooksDeleted = append(r.OrgWebhooksDeleted, webhook.Id)
+}
+func (r *ReconciliatorListenerRecorder) AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	r.SecurityManagerTeamsAdded = append(r.SecurityManagerTeamsAdded, teamslug)
+}
+func (r *ReconciliatorListenerRecorder) RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	r.SecurityManagerTeamsRemoved = append(r.SecurityManagerTeamsRemoved, teamslug)
+}
+func (r *ReconciliatorListenerRecorder) AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *GithubLabel) {
+	if _, ok := r.RepositoryLabelsAdded[reponame]; !ok {
+		r.RepositoryLabelsAdded[reponame] = make(map[string]*GithubLabel)
+	}
+	r.RepositoryLabelsAdded[reponame][label.Name] = label
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *GithubLabel) {
+	if _, ok := r.RepositoryLabelsUpdated[reponame]; !ok {
+		r.RepositoryLabelsUpdated[reponame] = make(map[string]*GithubLabel)
+	}
+	r.RepositoryLabelsUpdated[reponame][labelname] = label
+}
+func (r *ReconciliatorListenerRecorder) DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string) {
+	r.RepositoryLabelsDeleted[reponame] = append(r.RepositoryLabelsDeleted[reponame], labelname)
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	r.RepositoriesDependabotAlerts[reponame] = enabled
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	r.RepositoriesDependabotSecurityUpdates[reponame] = enabled
+}
+func (r *ReconciliatorListenerRecorder) LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	r.RepositoriesProjectsLinked[reponame] = append(r.RepositoriesProjectsLinked[reponame], project.Title)
+}
+func (r *ReconciliatorListenerRecorder) UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	r.RepositoriesProjectsUnlinked[reponame] = append(r.RepositoriesProjectsUnlinked[reponame], project.Title)
 }
 func (r *ReconciliatorListenerRecorder) RenameRepository(ctx context.Context, dryrun bool, reponame string, newname string) {
 	r.RepositoriesRenamed[reponame] = true
 }
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) {
+	r.RepositoryFilesUpdated[reponame+":"+filename] = content
+}
 func (r *ReconciliatorListenerRecorder) UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue bool) {
 	r.RepositoriesUpdatePrivate[reponame] = true
+	if r.RepositoriesUpdateBoolProperty[reponame] == nil {
+		r.RepositoriesUpdateBoolProperty[reponame] = make(map[string]bool)
+	}
+	r.RepositoriesUpdateBoolProperty[reponame][propertyName] = propertyValue
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, reponame string, properties map[string]string) {
+	r.RepositoriesUpdateStringProperties[reponame] = properties
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue string) {
+	if r.RepositoriesSetCustomProperty[reponame] == nil {
+		r.RepositoriesSetCustomProperty[reponame] = make(map[string]string)
+	}
+	r.RepositoriesSetCustomProperty[reponame][propertyName] = propertyValue
+}
+func (r *ReconciliatorListenerRecorder) UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string) {
+	r.RepositoriesRemoveCustomProperty[reponame] = append(r.RepositoriesRemoveCustomProperty[reponame], propertyName)
 }
 func (r *ReconciliatorListenerRecorder) UpdateRepositorySetExternalUser(ctx context.Context, dryrun bool, reponame string, githubid string, permission string) {
 	r.RepositoriesSetExternalUser[githubid] = permission
@@ -270,6 +575,7 @@ func (r *ReconciliatorListenerRecorder) DeleteRepositoryRuleset(ctx context.Cont
 }
 func (r *ReconciliatorListenerRecorder) AddRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet) {
 	r.RuleSetCreated[ruleset.Name] = ruleset
+	r.RuleSetCreatedOrder = append(r.RuleSetCreatedOrder, ruleset.Name)
 }
 func (r *ReconciliatorListenerRecorder) UpdateRuleset(ctx context.Context, dryrun bool, ruleset *GithubRuleSet) {
 	r.RuleSetUpdated[ruleset.Name] = ruleset
@@ -314,7 +620,7 @@ func TestReconciliation(t *testing.T) {
 		local.users["new.member"] = &newMember
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"new_member": "MEMBER", "new_owner": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -323,7 +629,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 2 members created
 		assert.Equal(t, 2, len(recorder.TeamsCreated["new"]))
@@ -358,7 +664,7 @@ func TestReconciliation(t *testing.T) {
 		local.users["new.member"] = &newMember
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"new_member": "MEMBER", "new_owner": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -367,7 +673,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 2 members created
 		assert.Equal(t, 2, len(recorder.TeamsCreated["nouveauté"]))
@@ -408,7 +714,7 @@ func TestReconciliation(t *testing.T) {
 		local.users["existing.member"] = &existing_member
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"existing_member": "MEMBER", "existing_owner": "MEMBER", "existing_owner2": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -429,13 +735,68 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["existing"+config.Config.GoliacTeamOwnerSuffix] = existingowners
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 members added
 		assert.Equal(t, 0, len(recorder.TeamsCreated))
 		assert.Equal(t, 1, len(recorder.TeamMemberAdded["existing"]))
 	})
 
+	t.Run("happy path: existing team with many membership changes batched", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"owner"}
+		local.teams["existing"] = existingTeam
+
+		for _, username := range []string{"owner", "new1", "new2", "new3"} {
+			user := entity.User{}
+			user.Name = username
+			user.Spec.GithubID = username + "_githubid"
+			local.users[username] = &user
+			if username != "owner" {
+				existingTeam.Spec.Members = append(existingTeam.Spec.Members, username)
+			}
+		}
+
+		remote := GoliacRemoteMock{
+			users:      map[string]string{"owner_githubid": "MEMBER", "new1_githubid": "MEMBER", "new2_githubid": "MEMBER", "new3_githubid": "MEMBER"},
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.teams["existing"] = &GithubTeam{
+			Name:    "existing",
+			Slug:    "existing",
+			Members: []string{"owner_githubid", "old1_githubid", "old2_githubid"},
+		}
+		remote.teams["existing"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "existing" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "existing" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"owner_githubid"},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// 3 members added and 2 removed, in 2 calls
+		assert.Equal(t, []string{"new1_githubid", "new2_githubid", "new3_githubid"}, recorder.TeamMemberAdded["existing"])
+		assert.Equal(t, []string{"old1_githubid", "old2_githubid"}, recorder.TeamMemberRemoved["existing"])
+		assert.Equal(t, 2, recorder.TeamMembersBatches["existing"])
+	})
+
 	t.Run("happy path: existing team with non english slug with new members", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
@@ -470,7 +831,7 @@ func TestReconciliation(t *testing.T) {
 		local.users["existing.member"] = &existing_member
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"existing_member": "MEMBER", "existing_owner": "MEMBER", "existing_owner2": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -492,7 +853,7 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["exist-ing"+config.Config.GoliacTeamOwnerSuffix] = existingowners
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 members added
 		ctx := context.TODO()
@@ -531,7 +892,7 @@ func TestReconciliation(t *testing.T) {
 		local.users["new.member"] = &newMember
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"new_member": "MEMBER", "new_owner": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -540,7 +901,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 2 members created
 		assert.Equal(t, 2, len(recorder.TeamsCreated["new"]))
@@ -549,6 +910,58 @@ func TestReconciliation(t *testing.T) {
 		assert.Equal(t, 2, len(recorder.TeamsCreated["everyone"]))
 	})
 
+	t.Run("happy path: the everyone team cannot be mass-mentioned", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{
+			EveryoneTeamEnabled:             true,
+			EveryoneTeamNotificationSetting: "notifications_disabled",
+		}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		member := entity.User{}
+		member.Name = "member"
+		member.Spec.GithubID = "member_githubid"
+		local.users["member"] = &member
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["member_githubid"] = "MEMBER"
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the everyone team is created with its notifications disabled
+		assert.Equal(t, 1, len(recorder.TeamsCreated["everyone"]))
+		assert.Equal(t, map[string]string{"everyone": "notifications_disabled"}, recorder.TeamNotificationSettingUpdated)
+
+		// and an existing everyone team is updated
+		recorder = NewReconciliatorListenerRecorder()
+		r = NewGoliacReconciliatorImpl(recorder, &repoconf)
+		remote.teams["everyone"] = &GithubTeam{
+			Name:                "everyone",
+			Slug:                "everyone",
+			Members:             []string{"member_githubid"},
+			NotificationSetting: "notifications_enabled",
+		}
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.TeamsCreated))
+		assert.Equal(t, map[string]string{"everyone": "notifications_disabled"}, recorder.TeamNotificationSettingUpdated)
+	})
+
 	t.Run("happy path: removed team without destructive operation", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
@@ -578,7 +991,7 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["removing"] = removing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team deleted
 		assert.Equal(t, 0, len(recorder.TeamDeleted))
@@ -652,7 +1065,7 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["childteam"+config.Config.GoliacTeamOwnerSuffix] = childTeamOwners
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 0 parent updated
 		assert.Equal(t, 0, len(recorder.TeamParentUpdated))
@@ -730,7 +1143,7 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["childteam"+config.Config.GoliacTeamOwnerSuffix] = childTeamOwners
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team parent updated
 		assert.Equal(t, 1, len(recorder.TeamParentUpdated))
@@ -763,12 +1176,107 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["removing"] = removing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team deleted
 		assert.Equal(t, 1, len(recorder.TeamDeleted))
 	})
 
+	t.Run("happy path: teams without the team name prefix are not managed", func(t *testing.T) {
+		config.Config.GoliacTeamNamePrefix = "t-"
+		defer func() {
+			config.Config.GoliacTeamNamePrefix = ""
+		}()
+
+		recorder := NewReconciliatorListenerRecorder()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.DestructiveOperations.AllowDestructiveTeams = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, teamname := range []string{"existing", "new"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			local.teams[teamname] = team
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		for _, teamslug := range []string{"t-existing", "t-existing" + config.Config.GoliacTeamOwnerSuffix, "t-removing", "idp-synced"} {
+			remote.teams[teamslug] = &GithubTeam{
+				Name:    teamslug,
+				Slug:    teamslug,
+				Members: []string{},
+			}
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the new team (and its owners team) is created with the prefix
+		assert.Equal(t, 2, len(recorder.TeamsCreated))
+		_, ok := recorder.TeamsCreated["t-new"]
+		assert.True(t, ok)
+		_, ok = recorder.TeamsCreated["t-new"+config.Config.GoliacTeamOwnerSuffix]
+		assert.True(t, ok)
+		// the team without the prefix is left untouched
+		assert.Equal(t, map[string]bool{"t-removing": true}, recorder.TeamDeleted)
+	})
+
+	t.Run("happy path: removed team with a reason", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.DestructiveOperations.AllowDestructiveTeams = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.teams["removing"] = &GithubTeam{
+			Name:    "removing",
+			Slug:    "removing",
+			Members: []string{"existing_owner"},
+		}
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.KeyReason, "team merged into platform")
+		ctx = context.WithValue(ctx, config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.True(t, recorder.TeamDeleted["removing"])
+		assert.Equal(t, "team merged into platform", recorder.DeletionReasons["removing"])
+		destructive := []config.GoliacOperation{}
+		for _, o := range changes.Operations {
+			if o.Destructive {
+				destructive = append(destructive, o)
+			}
+		}
+		assert.Equal(t, 1, len(destructive))
+		assert.Equal(t, "delete_team", destructive[0].Command)
+		assert.Equal(t, "team merged into platform", destructive[0].Reason)
+		assert.False(t, destructive[0].Timestamp.IsZero())
+	})
+
 	t.Run("happy path: new repo without owner", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 		repoconf := config.RepositoryConfig{}
@@ -801,12 +1309,76 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo created
 		assert.Equal(t, 1, len(recorder.RepositoryCreated))
 	})
 
+	t.Run("happy path: new repo declared as a fork", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		newRepo := &entity.Repository{}
+		newRepo.Name = "new"
+		newRepo.Spec.ForkOf = "upstream-org/upstream-repo"
+		local.repos["new"] = newRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the repo is forked, not created
+		assert.Equal(t, map[string]string{"new": "upstream-org/upstream-repo"}, recorder.RepositoryForked)
+		assert.Equal(t, 0, len(recorder.RepositoryCreated))
+	})
+
+	t.Run("happy path: the teams repo doesn't live in the organization", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		newRepo := &entity.Repository{}
+		newRepo.Name = "new"
+		local.repos["new"] = newRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// only the new repo is created (not the teams repo)
+		assert.Equal(t, map[string]bool{"new": true}, recorder.RepositoryCreated)
+	})
+
 	t.Run("happy path: new repo with owner", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
@@ -854,7 +1426,7 @@ func TestReconciliation(t *testing.T) {
 		remote.teams["existing"] = existing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo created
 		assert.Equal(t, 1, len(recorder.RepositoryCreated))
@@ -919,14 +1491,14 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
 		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
-		assert.Equal(t, 1, len(recorder.RepositoryTeamRemoved))
-		assert.Equal(t, 2, len(recorder.RepositoryTeamAdded))
-		assert.Equal(t, 0, len(recorder.RepositoryTeamUpdated))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved))
+		assert.Equal(t, 1, len(recorder.RepositoryTeamAdded))
+		assert.Equal(t, 1, len(recorder.RepositoryTeamUpdated))
 	})
 
 	t.Run("happy path: existing repo without new owner but with everyone team", func(t *testing.T) {
@@ -990,7 +1562,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1017,6 +1589,8 @@ func TestReconciliation(t *testing.T) {
 		lRepo.Name = "myrepo"
 		lRepo.Spec.Readers = []string{"reader"}
 		lRepo.Spec.Writers = []string{}
+		// the owner team is admin of its repository
+		lRepo.Spec.Admins = []string{"existing"}
 		lowner := "existing"
 		lRepo.Owner = &lowner
 		local.repos["myrepo"] = lRepo
@@ -1072,7 +1646,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team added
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1082,7 +1656,7 @@ func TestReconciliation(t *testing.T) {
 		assert.Equal(t, 0, len(recorder.RepositoryTeamUpdated))
 	})
 
-	t.Run("happy path: remove a team from an existing repo", func(t *testing.T) {
+	t.Run("happy path: explicit team permission levels", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
 		repoconf := config.RepositoryConfig{}
@@ -1096,14 +1670,348 @@ func TestReconciliation(t *testing.T) {
 		}
 		lRepo := &entity.Repository{}
 		lRepo.Name = "myrepo"
-		lRepo.Spec.Readers = []string{}
-		lRepo.Spec.Writers = []string{}
-		lowner := "existing"
-		lRepo.Owner = &lowner
+		lRepo.Spec.Admins = []string{"admin"}
+		lRepo.Spec.Maintainers = []string{"maintainer"}
+		lRepo.Spec.Triagers = []string{"triager"}
+		lRepo.Spec.Writers = []string{"writer"}
+		lRepo.Spec.Readers = []string{"reader"}
 		local.repos["myrepo"] = lRepo
 
-		existingTeam := &entity.Team{}
-		existingTeam.Name = "existing"
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		// admin, maintainer and reader are already set correctly,
+		// triager and writer are (wrongly) admin
+		for teamslug, permission := range map[string]string{
+			"admin":      "ADMIN",
+			"maintainer": "MAINTAIN",
+			"triager":    "ADMIN",
+			"writer":     "ADMIN",
+			"reader":     "READ",
+		} {
+			local.teams[teamslug] = &entity.Team{}
+			local.teams[teamslug].Name = teamslug
+			remote.teams[teamslug] = &GithubTeam{
+				Name: teamslug,
+				Slug: teamslug,
+			}
+			remote.teamsrepos[teamslug] = map[string]*GithubTeamRepo{
+				"myrepo": {
+					Name:       "myrepo",
+					Permission: permission,
+				},
+			}
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryCreated))
+		assert.Equal(t, map[string]string{
+			"triager": "triage",
+			"writer":  "push",
+		}, recorder.RepositoryTeamPermission["myrepo"])
+		// permission levels are updated in place
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved["myrepo"]))
+		assert.Equal(t, 2, len(recorder.RepositoryTeamUpdated["myrepo"]))
+	})
+
+	t.Run("happy path: team access inherited from a parent team is not removed", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		parentName := "parent"
+		local.teams["parent"] = &entity.Team{}
+		local.teams["parent"].Name = "parent"
+		local.teams["child"] = &entity.Team{}
+		local.teams["child"].Name = "child"
+		local.teams["child"].ParentTeam = &parentName
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Writers = []string{"parent"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		parentId := 1
+		remote.teams["parent"] = &GithubTeam{
+			Name: "parent",
+			Id:   parentId,
+			Slug: "parent",
+		}
+		remote.teams["child"] = &GithubTeam{
+			Name:       "child",
+			Id:         2,
+			Slug:       "child",
+			ParentTeam: &parentId,
+		}
+		// the child team inherits the write access of its parent team
+		remote.teamsrepos["parent"] = map[string]*GithubTeamRepo{
+			"myrepo": {
+				Name:       "myrepo",
+				Permission: "WRITE",
+			},
+		}
+		remote.teamsrepos["child"] = map[string]*GithubTeamRepo{
+			"myrepo": {
+				Name:       "myrepo",
+				Permission: "WRITE",
+			},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryTeamAdded["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamUpdated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved["myrepo"]))
+	})
+
+	t.Run("happy path: a direct grant equal to the parent team permission is not inherited", func(t *testing.T) {
+		parentId := 1
+		teams := map[string]*GithubTeam{
+			"parent": {Name: "parent", Id: parentId, Slug: "parent"},
+			"child":  {Name: "child", Id: 2, Slug: "child", ParentTeam: &parentId},
+		}
+		teamsRepos := map[string]map[string]*GithubTeamRepo{
+			"parent": {"myrepo": {Name: "myrepo", Permission: "WRITE"}},
+			"child":  {"myrepo": {Name: "myrepo", Permission: "WRITE"}},
+		}
+		teamsById := teamsByIdIndex(teams)
+
+		// not declared for the child team: inherited
+		assert.True(t, isTeamRepoAccessInherited(teams, teamsById, teamsRepos, "child", "myrepo", "WRITE", false))
+		// declared for the child team: a direct grant of its own
+		assert.False(t, isTeamRepoAccessInherited(teams, teamsById, teamsRepos, "child", "myrepo", "WRITE", true))
+		// stronger than the parent team permission: a direct grant
+		assert.False(t, isTeamRepoAccessInherited(teams, teamsById, teamsRepos, "child", "myrepo", "ADMIN", false))
+	})
+
+	t.Run("not happy path: unwanted team access inherited from a parent team is reported", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		parentName := "parent"
+		local.teams["parent"] = &entity.Team{}
+		local.teams["parent"].Name = "parent"
+		local.teams["child"] = &entity.Team{}
+		local.teams["child"].Name = "child"
+		local.teams["child"].ParentTeam = &parentName
+
+		noInheritance := false
+		// the child team is not expected to access myrepo1
+		lRepo1 := &entity.Repository{}
+		lRepo1.Name = "myrepo1"
+		lRepo1.Spec.Writers = []string{"parent"}
+		lRepo1.Spec.AllowInheritedAccess = &noInheritance
+		local.repos["myrepo1"] = lRepo1
+		// the child team is expected to write in myrepo2 (as inherited)
+		lRepo2 := &entity.Repository{}
+		lRepo2.Name = "myrepo2"
+		lRepo2.Spec.Writers = []string{"parent", "child"}
+		lRepo2.Spec.AllowInheritedAccess = &noInheritance
+		local.repos["myrepo2"] = lRepo2
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		parentId := 1
+		remote.teams["parent"] = &GithubTeam{
+			Name: "parent",
+			Id:   parentId,
+			Slug: "parent",
+		}
+		remote.teams["child"] = &GithubTeam{
+			Name:       "child",
+			Id:         2,
+			Slug:       "child",
+			ParentTeam: &parentId,
+		}
+		remote.teamsrepos["parent"] = map[string]*GithubTeamRepo{}
+		remote.teamsrepos["child"] = map[string]*GithubTeamRepo{}
+		for _, reponame := range []string{"myrepo1", "myrepo2"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+			// the child team inherits the write access of its parent team
+			remote.teamsrepos["parent"][reponame] = &GithubTeamRepo{Name: reponame, Permission: "WRITE"}
+			remote.teamsrepos["child"][reponame] = &GithubTeamRepo{Name: reponame, Permission: "WRITE"}
+		}
+
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved["myrepo1"]))
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{
+			"repositoryname: myrepo1, teamslug: child, the push access inherited from a parent team can't be removed (allowInheritedAccess is false, but Github doesn't allow to prevent the inheritance)",
+		}, skipped)
+	})
+
+	t.Run("happy path: teams repository owners permission", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{
+			AdminTeam:                       "admin",
+			TeamsRepositoryOwnersPermission: "maintain",
+		}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, teamname := range []string{"admin", "team1", "team2"} {
+			local.teams[teamname] = &entity.Team{}
+			local.teams[teamname].Name = teamname
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		for teamname, permission := range map[string]string{
+			"admin": "WRITE",
+			"team1": "WRITE",
+			"team2": "MAINTAIN",
+		} {
+			teamslug := teamname + config.Config.GoliacTeamOwnerSuffix
+			remote.teams[teamname] = &GithubTeam{
+				Name: teamname,
+				Slug: teamname,
+			}
+			remote.teams[teamslug] = &GithubTeam{
+				Name: teamslug,
+				Slug: teamslug,
+			}
+			remote.teamsrepos[teamslug] = map[string]*GithubTeamRepo{
+				"teams": {
+					Name:       "teams",
+					Permission: permission,
+				},
+			}
+		}
+		remote.teamsrepos["admin"] = map[string]*GithubTeamRepo{
+			"teams": {
+				Name:       "teams",
+				Permission: "WRITE",
+			},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the teams owners get maintain (in place), the admin team stays a writer
+		assert.Equal(t, map[string]string{
+			"admin" + config.Config.GoliacTeamOwnerSuffix: "maintain",
+			"team1" + config.Config.GoliacTeamOwnerSuffix: "maintain",
+		}, recorder.RepositoryTeamPermission["teams"])
+		assert.Equal(t, 2, len(recorder.RepositoryTeamUpdated["teams"]))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamAdded["teams"]))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved["teams"]))
+	})
+
+	t.Run("happy path: remove a team from an existing repo", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{}
+		lRepo.Spec.Writers = []string{}
+		lowner := "existing"
+		lRepo.Owner = &lowner
+		local.repos["myrepo"] = lRepo
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
 		existingTeam.Spec.Owners = []string{"existing_owner"}
 		existingTeam.Spec.Members = []string{"existing_member"}
 		local.teams["existing"] = existingTeam
@@ -1158,7 +2066,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team removed
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1233,7 +2141,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 member removed
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1244,6 +2152,64 @@ func TestReconciliation(t *testing.T) {
 		assert.Equal(t, 1, len(recorder.TeamMemberRemoved))
 	})
 
+	t.Run("happy path: remove a user from the org and from a team in the same run", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveUsers = true
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		existingOwner := entity.User{}
+		existingOwner.Spec.GithubID = "existing_owner"
+		local.users["existing_owner"] = &existingOwner
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"existing_owner"}
+		existingTeam.Spec.Members = []string{}
+		local.teams["existing"] = existingTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["existing_owner"] = "existing_owner"
+		remote.users["leaving_member"] = "leaving_member"
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		existing := &GithubTeam{
+			Name:        "existing",
+			Slug:        "existing",
+			Members:     []string{"existing_owner"},
+			Maintainers: []string{"leaving_member"},
+		}
+		remote.teams["existing"] = existing
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the user is removed from the org (and so from its teams by Github)
+		assert.Equal(t, 1, len(recorder.UsersRemoved))
+		assert.Equal(t, "leaving_member", recorder.UsersRemoved["leaving_member"])
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved))
+		assert.Equal(t, 0, len(recorder.TeamMemberUpdated))
+		// the remote cache is not modified by the reconciliation
+		assert.Equal(t, []string{"leaving_member"}, remote.teams["existing"].Maintainers)
+	})
+
 	t.Run("happy path: update a team member from maintainer to member", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
@@ -1277,7 +2243,7 @@ func TestReconciliation(t *testing.T) {
 		local.teams["existing"] = existingTeam
 
 		remote := GoliacRemoteMock{
-			users:      make(map[string]string),
+			users:      map[string]string{"existing_member": "MEMBER", "existing_owner": "MEMBER"},
 			teams:      make(map[string]*GithubTeam),
 			repos:      make(map[string]*GithubRepository),
 			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
@@ -1310,7 +2276,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 member removed
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1387,7 +2353,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1460,10 +2426,10 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo updated
-		assert.Equal(t, 1, len(recorder.TeamsCreated)) // the newerTeam-goliac-owners team
+		assert.Equal(t, 2, len(recorder.TeamsCreated)) // the newerTeam (without members) and newerTeam-goliac-owners teams
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
 		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
 		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved))
@@ -1536,7 +2502,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1611,7 +2577,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1692,7 +2658,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 team updated
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1705,6 +2671,82 @@ func TestReconciliation(t *testing.T) {
 		assert.Equal(t, 0, len(recorder.RepositoriesRemoveExternalUser))
 	})
 
+	t.Run("happy path: existing repo with escalated external collaborator (from read or write to admin)", func(t *testing.T) {
+		for rPermission, expected := range map[string]int{"READ": 1, "WRITE": 1, "ADMIN": 0} {
+			recorder := NewReconciliatorListenerRecorder()
+
+			repoconf := config.RepositoryConfig{}
+
+			r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+			local := GoliacLocalMock{
+				users:     make(map[string]*entity.User),
+				externals: make(map[string]*entity.User),
+				teams:     make(map[string]*entity.Team),
+				repos:     make(map[string]*entity.Repository),
+			}
+
+			outside1 := entity.User{}
+			outside1.Name = "outside1"
+			outside1.Spec.GithubID = "outside1-githubid"
+			local.externals["outside1"] = &outside1
+
+			lRepo := &entity.Repository{}
+			lRepo.Name = "myrepo"
+			lRepo.Spec.Readers = []string{}
+			lRepo.Spec.Writers = []string{}
+			lRepo.Spec.ExternalUserAdmins = []string{"outside1"}
+			lowner := "existing"
+			lRepo.Owner = &lowner
+			local.repos["myrepo"] = lRepo
+
+			existingTeam := &entity.Team{}
+			existingTeam.Name = "existing"
+			existingTeam.Spec.Owners = []string{"existing_owner"}
+			existingTeam.Spec.Members = []string{}
+			local.teams["existing"] = existingTeam
+
+			remote := GoliacRemoteMock{
+				users:      make(map[string]string),
+				teams:      make(map[string]*GithubTeam),
+				repos:      make(map[string]*GithubRepository),
+				teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+				rulesets:   make(map[string]*GithubRuleSet),
+				appids:     make(map[string]int),
+			}
+			remote.teams["existing"] = &GithubTeam{
+				Name:    "existing",
+				Slug:    "existing",
+				Members: []string{"existing_owner"},
+			}
+			remote.repos["teams"] = &GithubRepository{
+				Name:           "teams",
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+			remote.repos["myrepo"] = &GithubRepository{
+				Name:           "myrepo",
+				ExternalUsers:  map[string]string{"outside1-githubid": rPermission},
+				BoolProperties: make(map[string]bool),
+			}
+
+			remote.teamsrepos["existing"] = make(map[string]*GithubTeamRepo)
+			remote.teamsrepos["existing"]["myrepo"] = &GithubTeamRepo{
+				Name:       "myrepo",
+				Permission: "WRITE",
+			}
+
+			toArchive := make(map[string]*GithubRepoComparable)
+			r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+			assert.Equal(t, expected, len(recorder.RepositoriesSetExternalUser), rPermission)
+			if expected > 0 {
+				assert.Equal(t, "admin", recorder.RepositoriesSetExternalUser["outside1-githubid"], rPermission)
+			}
+			assert.Equal(t, 0, len(recorder.RepositoriesRemoveExternalUser), rPermission)
+		}
+	})
+
 	t.Run("happy path: removed repo without destructive operation", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
@@ -1732,7 +2774,7 @@ func TestReconciliation(t *testing.T) {
 		remote.repos["removing"] = removing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo deleted
 		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
@@ -1768,17 +2810,18 @@ func TestReconciliation(t *testing.T) {
 		remote.repos["removing"] = removing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo deleted
 		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
 		assert.Equal(t, 1, len(toArchive))
 	})
 
-	t.Run("happy path: removed repo withou archive_on_delete", func(t *testing.T) {
+	t.Run("happy path: archived repo deleted after the grace period", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 		repoconfig := &config.RepositoryConfig{
-			ArchiveOnDelete: false,
+			ArchiveOnDelete:               true,
+			RepositoryDeletionGracePeriod: 30,
 		}
 		repoconfig.DestructiveOperations.AllowDestructiveRepositories = true
 		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
@@ -1797,56 +2840,68 @@ func TestReconciliation(t *testing.T) {
 			rulesets:   make(map[string]*GithubRuleSet),
 			appids:     make(map[string]int),
 		}
-		removing := &GithubRepository{
-			Name:           "removing",
-			ExternalUsers:  map[string]string{},
-			BoolProperties: map[string]bool{},
+
+		// 'expired' was archived 31 days ago, 'recent' 10 days ago
+		for reponame, days := range map[string]int{"expired": 31, "recent": 10} {
+			archivedAt := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			lRepo.Archived = true
+			lRepo.ArchivedAt = &archivedAt
+			lRepo.DeletionReason = "project sunset"
+			local.repos[reponame] = lRepo
+
+			remote.repos[reponame] = &GithubRepository{
+				Name:          reponame,
+				ExternalUsers: map[string]string{},
+				BoolProperties: map[string]bool{
+					"archived": true,
+					"private":  true,
+				},
+			}
 		}
-		remote.repos["removing"] = removing
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		toDelete := make(map[string]bool)
+		// the reason set on the repository takes precedence over the commit one
+		ctx := context.WithValue(context.TODO(), config.KeyReason, "cleanup")
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, toDelete)
 
-		// 1 repo deleted
 		assert.Equal(t, 1, len(recorder.RepositoriesDeleted))
+		assert.True(t, recorder.RepositoriesDeleted["expired"])
+		assert.Equal(t, "project sunset", recorder.DeletionReasons["expired"])
+		// the archived definition is only removed once the repository is gone
+		assert.Equal(t, 0, len(toDelete))
 		assert.Equal(t, 0, len(toArchive))
+
+		// next run: the repository is gone
+		delete(remote.repos, "expired")
+		recorder = NewReconciliatorListenerRecorder()
+		r = NewGoliacReconciliatorImpl(recorder, repoconfig)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, toDelete)
+
+		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
+		assert.Equal(t, map[string]bool{"expired": true}, toDelete)
 	})
 
-	t.Run("happy path: rename repo", func(t *testing.T) {
-		recorder := NewReconciliatorListenerRecorder()
+	t.Run("not happy path: the archived repo deletion failed", func(t *testing.T) {
 		repoconfig := &config.RepositoryConfig{
-			AdminTeam:       "admin-team",
-			ArchiveOnDelete: false,
+			ArchiveOnDelete:               true,
+			RepositoryDeletionGracePeriod: 30,
 		}
 		repoconfig.DestructiveOperations.AllowDestructiveRepositories = true
-		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
 
 		local := GoliacLocalMock{
 			users: make(map[string]*entity.User),
 			teams: make(map[string]*entity.Team),
 			repos: make(map[string]*entity.Repository),
 		}
-
+		archivedAt := time.Now().Add(-31 * 24 * time.Hour)
 		lRepo := &entity.Repository{}
-		lRepo.Name = "myrepo"
-		lRepo.Spec.Readers = []string{}
-		lRepo.Spec.Writers = []string{}
-		lRepo.Spec.ExternalUserWriters = []string{}
-		lowner := "existing"
-		lRepo.Owner = &lowner
-		lRepo.RenameTo = "myrepo2" // HERE we rename the repo
-		local.repos["myrepo"] = lRepo
-
-		adminTeam := &entity.Team{}
-		adminTeam.Name = "admin-team"
-		adminTeam.Spec.Owners = []string{"existing_owner"}
-		local.teams["admin-team"] = adminTeam
-
-		existingTeam := &entity.Team{}
-		existingTeam.Name = "existing"
-		existingTeam.Spec.Owners = []string{"existing_owner"}
-		existingTeam.Spec.Members = []string{}
-		local.teams["existing"] = existingTeam
+		lRepo.Name = "expired"
+		lRepo.Archived = true
+		lRepo.ArchivedAt = &archivedAt
+		local.repos["expired"] = lRepo
 
 		remote := GoliacRemoteMock{
 			users:      make(map[string]string),
@@ -1856,19 +2911,123 @@ func TestReconciliation(t *testing.T) {
 			rulesets:   make(map[string]*GithubRuleSet),
 			appids:     make(map[string]int),
 		}
-		rAdminTeam := &GithubTeam{
-			Name:    "admin-team",
-			Slug:    "admin-team",
-			Members: []string{"admin-team"},
+		remote.repos["expired"] = &GithubRepository{
+			Name:          "expired",
+			ExternalUsers: map[string]string{},
+			BoolProperties: map[string]bool{
+				"archived": true,
+				"private":  true,
+			},
 		}
-		remote.teams["admin-team"] = rAdminTeam
 
-		rAdminTeamOwners := &GithubTeam{
-			Name:    "admin-team-goliac-owners",
-			Slug:    "admin-team-goliac-owners",
-			Members: []string{"admin-team"},
+		// the Github deletion fails: the repository is still there on the next runs
+		for run := 0; run < 2; run++ {
+			recorder := NewReconciliatorListenerRecorder()
+			r := NewGoliacReconciliatorImpl(recorder, repoconfig)
+			toArchive := make(map[string]*GithubRepoComparable)
+			toDelete := make(map[string]bool)
+			r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, toDelete)
+
+			// the deletion is tried again, and the archived definition is kept
+			assert.True(t, recorder.RepositoriesDeleted["expired"])
+			assert.Equal(t, 0, len(toDelete))
 		}
-		remote.teams["admin-team-goliac-owners"] = rAdminTeamOwners
+	})
+
+	t.Run("happy path: removed repo withou archive_on_delete", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconfig := &config.RepositoryConfig{
+			ArchiveOnDelete: false,
+		}
+		repoconfig.DestructiveOperations.AllowDestructiveRepositories = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		removing := &GithubRepository{
+			Name:           "removing",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["removing"] = removing
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// 1 repo deleted
+		assert.Equal(t, 1, len(recorder.RepositoriesDeleted))
+		assert.Equal(t, 0, len(toArchive))
+	})
+
+	t.Run("happy path: rename repo", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconfig := &config.RepositoryConfig{
+			AdminTeam:       "admin-team",
+			ArchiveOnDelete: false,
+		}
+		repoconfig.DestructiveOperations.AllowDestructiveRepositories = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconfig)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{}
+		lRepo.Spec.Writers = []string{}
+		lRepo.Spec.ExternalUserWriters = []string{}
+		lowner := "existing"
+		lRepo.Owner = &lowner
+		lRepo.RenameTo = "myrepo2" // HERE we rename the repo
+		local.repos["myrepo"] = lRepo
+
+		adminTeam := &entity.Team{}
+		adminTeam.Name = "admin-team"
+		adminTeam.Spec.Owners = []string{"existing_owner"}
+		local.teams["admin-team"] = adminTeam
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"existing_owner"}
+		existingTeam.Spec.Members = []string{}
+		local.teams["existing"] = existingTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		rAdminTeam := &GithubTeam{
+			Name:    "admin-team",
+			Slug:    "admin-team",
+			Members: []string{"admin-team"},
+		}
+		remote.teams["admin-team"] = rAdminTeam
+
+		rAdminTeamOwners := &GithubTeam{
+			Name:    "admin-team-goliac-owners",
+			Slug:    "admin-team-goliac-owners",
+			Members: []string{"admin-team"},
+		}
+		remote.teams["admin-team-goliac-owners"] = rAdminTeamOwners
 
 		rExisting := &GithubTeam{
 			Name:    "existing",
@@ -1920,7 +3079,7 @@ func TestReconciliation(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "goliac-teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "goliac-teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 repo renamed
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
@@ -1934,6 +3093,99 @@ func TestReconciliation(t *testing.T) {
 	})
 }
 
+func TestReconciliationScope(t *testing.T) {
+	newLocal := func() GoliacLocalMock {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		owner := entity.User{}
+		owner.Name = "owner"
+		owner.Spec.GithubID = "owner_githubid"
+		local.users["owner"] = &owner
+		for _, teamname := range []string{"team1", "team2"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			team.Spec.Owners = []string{"owner"}
+			local.teams[teamname] = team
+		}
+		for _, reponame := range []string{"repo1", "repo2"} {
+			repo := &entity.Repository{}
+			repo.Name = reponame
+			repo.Spec.Writers = []string{"team1"}
+			local.repos[reponame] = repo
+		}
+		return local
+	}
+	newRemote := func() GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["owner_githubid"] = "MEMBER"
+		remote.teams["oldteam"] = &GithubTeam{
+			Name:    "oldteam",
+			Slug:    "oldteam",
+			Members: []string{"owner_githubid"},
+		}
+		remote.repos["oldrepo"] = &GithubRepository{
+			Name:           "oldrepo",
+			ExternalUsers:  map[string]string{},
+			InternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return remote
+	}
+	repoconf := config.RepositoryConfig{}
+	repoconf.DestructiveOperations.AllowDestructiveTeams = true
+	repoconf.DestructiveOperations.AllowDestructiveRepositories = true
+
+	t.Run("happy path: only the teams and repositories of the scope are reconciliated", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+		r.SetScope(&ReconciliationScope{
+			Teams:        map[string]bool{"team1": true},
+			Repositories: map[string]bool{"repo1": true},
+		})
+
+		local := newLocal()
+		remote := newRemote()
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 2, len(recorder.TeamsCreated))
+		assert.NotNil(t, recorder.TeamsCreated["team1"])
+		assert.NotNil(t, recorder.TeamsCreated["team1"+config.Config.GoliacTeamOwnerSuffix])
+		assert.Equal(t, map[string]bool{"repo1": true}, recorder.RepositoryCreated)
+		// the entities out of the scope are left untouched
+		assert.Equal(t, 0, len(recorder.TeamDeleted))
+		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
+	})
+
+	t.Run("happy path: a nil scope reconciliates everything", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+		r.SetScope(nil)
+
+		local := newLocal()
+		remote := newRemote()
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 4, len(recorder.TeamsCreated))
+		assert.Equal(t, map[string]bool{"repo1": true, "repo2": true}, recorder.RepositoryCreated)
+		assert.Equal(t, map[string]bool{"oldteam": true}, recorder.TeamDeleted)
+		assert.Equal(t, 1, len(recorder.RepositoriesDeleted))
+	})
+}
+
 func TestReconciliationRulesets(t *testing.T) {
 
 	t.Run("happy path: no new ruleset in goliac conf", func(t *testing.T) {
@@ -1970,7 +3222,7 @@ func TestReconciliationRulesets(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 ruleset created
 		assert.Equal(t, 0, len(recorder.RuleSetCreated))
@@ -1983,13 +3235,17 @@ func TestReconciliationRulesets(t *testing.T) {
 
 		repoconf := config.RepositoryConfig{
 			Rulesets: make([]struct {
-				Pattern string
-				Ruleset string
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
 			}, 0),
 		}
 		repoconf.Rulesets = append(repoconf.Rulesets, struct {
-			Pattern string
-			Ruleset string
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
 		}{
 			Pattern: ".*",
 			Ruleset: "new",
@@ -2025,7 +3281,7 @@ func TestReconciliationRulesets(t *testing.T) {
 		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
 		// 1 ruleset created
 		assert.Equal(t, 1, len(recorder.RuleSetCreated))
@@ -2033,22 +3289,22 @@ func TestReconciliationRulesets(t *testing.T) {
 		assert.Equal(t, 0, len(recorder.RuleSetDeleted))
 	})
 
-	t.Run("happy path: update ruleset (enforcement)", func(t *testing.T) {
+	t.Run("happy path: new rulesets are created by priority", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
-		repoconf := config.RepositoryConfig{
-			Rulesets: make([]struct {
-				Pattern string
-				Ruleset string
-			}, 0),
+		repoconf := config.RepositoryConfig{}
+		for name, priority := range map[string]int{"alpha": 3, "beta": 1, "gamma": 2, "delta": 0} {
+			repoconf.Rulesets = append(repoconf.Rulesets, struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}{
+				Pattern:  ".*",
+				Ruleset:  name,
+				Priority: priority,
+			})
 		}
-		repoconf.Rulesets = append(repoconf.Rulesets, struct {
-			Pattern string
-			Ruleset string
-		}{
-			Pattern: ".*",
-			Ruleset: "update",
-		})
 
 		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
 
@@ -2058,17 +3314,12 @@ func TestReconciliationRulesets(t *testing.T) {
 			repos:    make(map[string]*entity.Repository),
 			rulesets: make(map[string]*entity.RuleSet),
 		}
-
-		lRuleset := &entity.RuleSet{}
-		lRuleset.Name = "update"
-		lRuleset.Spec.Enforcement = "evaluate"
-		lRuleset.Spec.Rules = append(lRuleset.Spec.Rules, struct {
-			Ruletype   string
-			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
-		}{
-			"required_signatures", entity.RuleSetParameters{},
-		})
-		local.rulesets["update"] = lRuleset
+		for _, name := range []string{"alpha", "beta", "gamma", "delta"} {
+			ruleset := &entity.RuleSet{}
+			ruleset.Name = name
+			ruleset.Spec.Enforcement = "evaluate"
+			local.rulesets[name] = ruleset
+		}
 
 		remote := GoliacRemoteMock{
 			users:      make(map[string]string),
@@ -2078,35 +3329,37 @@ func TestReconciliationRulesets(t *testing.T) {
 			rulesets:   make(map[string]*GithubRuleSet),
 			appids:     make(map[string]int),
 		}
-
-		rRuleset := &GithubRuleSet{
-			Name:        "update",
-			Enforcement: "active",
-			Rules:       make(map[string]entity.RuleSetParameters),
+		// the priority is not known remotely, and must not trigger an update
+		remote.rulesets["delta"] = &GithubRuleSet{
+			Name:         "delta",
+			Id:           1,
+			Enforcement:  "evaluate",
+			BypassApps:   map[string]string{},
+			Rules:        map[string]entity.RuleSetParameters{},
+			Repositories: []string{"teams"},
 		}
-		rRuleset.Rules["required_signatures"] = entity.RuleSetParameters{}
-		remote.rulesets["update"] = rRuleset
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
-		// 1 ruleset created
-		assert.Equal(t, 0, len(recorder.RuleSetCreated))
-		assert.Equal(t, 1, len(recorder.RuleSetUpdated))
+		assert.Equal(t, []string{"beta", "gamma", "alpha"}, recorder.RuleSetCreatedOrder)
+		assert.Equal(t, 0, len(recorder.RuleSetUpdated))
 		assert.Equal(t, 0, len(recorder.RuleSetDeleted))
 	})
 
-	t.Run("happy path: delete ruleset", func(t *testing.T) {
+	t.Run("happy path: repository ruleset with the name of an organization ruleset", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
-		repoconf := config.RepositoryConfig{
-			Rulesets: make([]struct {
-				Pattern string
-				Ruleset string
-			}, 0),
-		}
-		repoconf.DestructiveOperations.AllowDestructiveRulesets = true
-
+		repoconf := config.RepositoryConfig{}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: ".*",
+			Ruleset: "shared",
+		})
 		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
 
 		local := GoliacLocalMock{
@@ -2115,6 +3368,16 @@ func TestReconciliationRulesets(t *testing.T) {
 			repos:    make(map[string]*entity.Repository),
 			rulesets: make(map[string]*entity.RuleSet),
 		}
+		orgRuleset := &entity.RuleSet{}
+		orgRuleset.Name = "shared"
+		orgRuleset.Spec.Enforcement = "evaluate"
+		local.rulesets["shared"] = orgRuleset
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Rulesets = []entity.RepositoryRuleSet{{Name: "shared"}}
+		lRepo.Spec.Rulesets[0].Enforcement = "active"
+		local.repos["myrepo"] = lRepo
 
 		remote := GoliacRemoteMock{
 			users:      make(map[string]string),
@@ -2124,62 +3387,70 @@ func TestReconciliationRulesets(t *testing.T) {
 			rulesets:   make(map[string]*GithubRuleSet),
 			appids:     make(map[string]int),
 		}
-
-		rRuleset := &GithubRuleSet{
-			Name:        "delete",
-			Enforcement: "active",
-			Rules:       make(map[string]entity.RuleSetParameters),
+		remote.rulesets["shared"] = &GithubRuleSet{
+			Name:         "shared",
+			Id:           1,
+			Enforcement:  "evaluate",
+			BypassApps:   map[string]string{},
+			Rules:        map[string]entity.RuleSetParameters{},
+			Repositories: []string{"myrepo"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			BoolProperties: map[string]bool{"private": true},
+			RuleSets:       map[string]*GithubRuleSet{},
 		}
-		rRuleset.Rules["required_signatures"] = entity.RuleSetParameters{}
-		remote.rulesets["delete"] = rRuleset
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
-		// 1 ruleset created
+		// the repository ruleset is created on the repository itself, next to the organization one
+		assert.NotNil(t, recorder.RepositoryRuleSetCreated["myrepo"]["shared"])
+		assert.Equal(t, "active", recorder.RepositoryRuleSetCreated["myrepo"]["shared"].Enforcement)
 		assert.Equal(t, 0, len(recorder.RuleSetCreated))
-		assert.Equal(t, 0, len(recorder.RuleSetUpdated))
-		assert.Equal(t, 1, len(recorder.RuleSetDeleted))
+		assert.Equal(t, 0, len(recorder.RuleSetDeleted))
 	})
 
-	t.Run("happy path: repo with ruleset", func(t *testing.T) {
+	t.Run("happy path: new ruleset skips archived repositories", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
 
-		repoconf := config.RepositoryConfig{}
+		repoconf := config.RepositoryConfig{
+			Rulesets: make([]struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}, 0),
+		}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: "^repo.*",
+			Ruleset: "new",
+		})
 
 		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
 
 		local := GoliacLocalMock{
-			users: make(map[string]*entity.User),
-			teams: make(map[string]*entity.Team),
-			repos: make(map[string]*entity.Repository),
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
 		}
-		newRepo := &entity.Repository{}
-		newRepo.Name = "myrepo"
-		newRepo.Spec.Readers = []string{}
-		newRepo.Spec.Writers = []string{}
-		owner := "existing"
-		newRepo.Owner = &owner
 
-		lruleset := entity.RepositoryRuleSet{
-			Name: "myruleset",
-		}
-		lruleset.Enforcement = "active"
-		lruleset.Conditions.Include = []string{"~DEFAULT_BRANCH"}
-		lruleset.Rules = append(lruleset.Rules, struct {
+		newRuleset := &entity.RuleSet{}
+		newRuleset.Name = "new"
+		newRuleset.Spec.Enforcement = "evaluate"
+		newRuleset.Spec.Rules = append(newRuleset.Spec.Rules, struct {
 			Ruletype   string
 			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
 		}{
 			"required_signatures", entity.RuleSetParameters{},
 		})
-		newRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
-		local.repos["myrepo"] = newRepo
-
-		existingTeam := &entity.Team{}
-		existingTeam.Name = "existing"
-		existingTeam.Spec.Owners = []string{"existing_owner"}
-		existingTeam.Spec.Members = []string{"existing_member"}
-		local.teams["existing"] = existingTeam
+		local.rulesets["new"] = newRuleset
 
 		remote := GoliacRemoteMock{
 			users:      make(map[string]string),
@@ -2189,22 +3460,1710 @@ func TestReconciliationRulesets(t *testing.T) {
 			rulesets:   make(map[string]*GithubRuleSet),
 			appids:     make(map[string]int),
 		}
-		remote.repos["teams"] = &GithubRepository{
-			Name:           "teams",
-			ExternalUsers:  map[string]string{},
-			BoolProperties: map[string]bool{},
-		}
-		existing := &GithubTeam{
-			Name:    "existing",
-			Slug:    "existing",
-			Members: []string{"existing_owner", "existing_member"},
-		}
-		remote.teams["existing"] = existing
 
-		myrepo := &GithubRepository{
-			Name:  "myrepo",
-			Id:    1234,
-			RefId: "sdfsf",
+		// repo_active: active locally and remotely
+		// repo_archived: archived locally and remotely
+		// repo_remote_archived: archived remotely only (to be unarchived)
+		for _, reponame := range []string{"repo_active", "repo_archived", "repo_remote_archived"} {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			lRepo.Spec.Readers = []string{}
+			lRepo.Spec.Writers = []string{}
+			lRepo.Archived = reponame == "repo_archived"
+			local.repos[reponame] = lRepo
+
+			remote.repos[reponame] = &GithubRepository{
+				Name: reponame,
+				BoolProperties: map[string]bool{
+					"private":  false,
+					"archived": reponame != "repo_active",
+				},
+				ExternalUsers: map[string]string{},
+				InternalUsers: map[string]string{},
+			}
+		}
+		remote.repos["archived_unmatched"] = &GithubRepository{
+			Name: "archived_unmatched",
+			BoolProperties: map[string]bool{
+				"archived": true,
+			},
+			ExternalUsers: map[string]string{},
+			InternalUsers: map[string]string{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// 1 ruleset created, only on the active repositories
+		assert.Equal(t, 1, len(recorder.RuleSetCreated))
+		rs := recorder.RuleSetCreated["new"]
+		assert.NotNil(t, rs)
+		sort.Strings(rs.Repositories)
+		assert.Equal(t, []string{"repo_active", "repo_remote_archived"}, rs.Repositories)
+	})
+
+	t.Run("happy path: ruleset repositories are resolved to their remote name", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{
+			Rulesets: make([]struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}, 0),
+		}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: "^myrepo.*",
+			Ruleset: "new",
+		})
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+
+		newRuleset := &entity.RuleSet{}
+		newRuleset.Name = "new"
+		newRuleset.Spec.Enforcement = "evaluate"
+		newRuleset.Spec.Rules = append(newRuleset.Spec.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_signatures", entity.RuleSetParameters{},
+		})
+		local.rulesets["new"] = newRuleset
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo.dotted"
+		local.repos["myrepo.dotted"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		// GitHub knows the repository with a different case
+		remote.repos["MyRepo.Dotted"] = &GithubRepository{
+			Name:           "MyRepo.Dotted",
+			Id:             1234,
+			BoolProperties: map[string]bool{},
+			ExternalUsers:  map[string]string{},
+			InternalUsers:  map[string]string{},
+		}
+		remote.rulesets["new"] = &GithubRuleSet{
+			Name:        "new",
+			Id:          1,
+			Enforcement: "evaluate",
+			BypassApps:  map[string]string{},
+			Rules: map[string]entity.RuleSetParameters{
+				"required_signatures": {},
+			},
+			Repositories: []string{"MyRepo.Dotted"},
+		}
+
+		ctx := context.TODO()
+		err := r.(*GoliacReconciliatorImpl).reconciliateRulesets(ctx, &local, NewMutableGoliacRemoteImpl(ctx, &remote), "teams", &repoconf, false)
+
+		assert.Nil(t, err)
+		// the ruleset already targets the repository
+		assert.Equal(t, 0, len(recorder.RuleSetCreated))
+		assert.Equal(t, 0, len(recorder.RuleSetUpdated))
+		assert.Equal(t, 0, len(recorder.RuleSetDeleted))
+	})
+
+	t.Run("happy path: ruleset repositories are selected by pattern and topic", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: "^repo",
+			Ruleset: "new",
+			Topic:   "production",
+		})
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+
+		newRuleset := &entity.RuleSet{}
+		newRuleset.Name = "new"
+		newRuleset.Spec.Enforcement = "evaluate"
+		newRuleset.Spec.Rules = append(newRuleset.Spec.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_signatures", entity.RuleSetParameters{},
+		})
+		local.rulesets["new"] = newRuleset
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		repoTopics := map[string][]string{
+			"repo_prod":     {"golang", "production"},
+			"repo_staging":  {"staging"},
+			"other_prod":    {"production"},
+			"repo_notfound": nil,
+		}
+		for reponame, topics := range repoTopics {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			local.repos[reponame] = lRepo
+			if reponame == "repo_notfound" {
+				// not yet created
+				continue
+			}
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				BoolProperties: map[string]bool{},
+				ExternalUsers:  map[string]string{},
+				InternalUsers:  map[string]string{},
+				Topics:         topics,
+			}
+		}
+
+		ctx := context.TODO()
+		err := r.(*GoliacReconciliatorImpl).reconciliateRulesets(ctx, &local, NewMutableGoliacRemoteImpl(ctx, &remote), "teams", &repoconf, false)
+
+		assert.Nil(t, err)
+		rs := recorder.RuleSetCreated["new"]
+		assert.NotNil(t, rs)
+		assert.Equal(t, []string{"repo_prod"}, rs.Repositories)
+	})
+
+	t.Run("happy path: update ruleset (only the strict status checks policy)", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: ".*",
+			Ruleset: "update",
+		})
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+
+		lRuleset := &entity.RuleSet{}
+		lRuleset.Name = "update"
+		lRuleset.Spec.Enforcement = "active"
+		lRuleset.Spec.Rules = append(lRuleset.Spec.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_status_checks", entity.RuleSetParameters{
+				RequiredStatusChecks:             []string{"build"},
+				StrictRequiredStatusChecksPolicy: true,
+			},
+		})
+		local.rulesets["update"] = lRuleset
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.rulesets["update"] = &GithubRuleSet{
+			Name:        "update",
+			Enforcement: "active",
+			BypassApps:  map[string]string{},
+			Rules: map[string]entity.RuleSetParameters{
+				"required_status_checks": {
+					RequiredStatusChecks: []string{"build"},
+				},
+			},
+			Repositories: []string{"teams"},
+		}
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		err := r.(*GoliacReconciliatorImpl).reconciliateRulesets(ctx, &local, NewMutableGoliacRemoteImpl(ctx, &remote), "teams", &repoconf, false)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(recorder.RuleSetUpdated))
+		assert.True(t, recorder.RuleSetUpdated["update"].Rules["required_status_checks"].StrictRequiredStatusChecksPolicy)
+		found := false
+		for _, o := range changes.Operations {
+			if o.Command == "update_ruleset" {
+				found = true
+				assert.Equal(t, []string{"rule required_status_checks: strictRequiredStatusChecksPolicy: false -> true"}, o.Changes)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("happy path: update ruleset (enforcement)", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{
+			Rulesets: make([]struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}, 0),
+		}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: ".*",
+			Ruleset: "update",
+		})
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+
+		lRuleset := &entity.RuleSet{}
+		lRuleset.Name = "update"
+		lRuleset.Spec.Enforcement = "evaluate"
+		lRuleset.Spec.Rules = append(lRuleset.Spec.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_signatures", entity.RuleSetParameters{},
+		})
+		local.rulesets["update"] = lRuleset
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+
+		rRuleset := &GithubRuleSet{
+			Name:        "update",
+			Enforcement: "active",
+			Rules:       make(map[string]entity.RuleSetParameters),
+		}
+		rRuleset.Rules["required_signatures"] = entity.RuleSetParameters{}
+		remote.rulesets["update"] = rRuleset
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// 1 ruleset created
+		assert.Equal(t, 0, len(recorder.RuleSetCreated))
+		assert.Equal(t, 1, len(recorder.RuleSetUpdated))
+		assert.Equal(t, 0, len(recorder.RuleSetDeleted))
+
+		// the plan tells what changed
+		found := false
+		for _, o := range changes.Operations {
+			if o.Command == "update_ruleset" {
+				found = true
+				assert.Equal(t, []string{"enforcement: active -> evaluate", "repositories added: teams"}, o.Changes)
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("happy path: rulesets enforcement guard", func(t *testing.T) {
+		tests := []struct {
+			name                string
+			allowWeaken         bool
+			localEnforcement    string
+			remoteEnforcement   string
+			expectedUpdated     int
+			expectedEnforcement string
+		}{
+			{"weakening is blocked", false, "evaluate", "active", 0, ""},
+			{"weakening to disabled is blocked", false, "disabled", "evaluate", 0, ""},
+			{"weakening is explicitly allowed", true, "disabled", "active", 1, "disabled"},
+			{"strengthening is allowed", false, "active", "evaluate", 1, "active"},
+		}
+
+		for _, tt := range tests {
+			recorder := NewReconciliatorListenerRecorder()
+
+			repoconf := config.RepositoryConfig{
+				Rulesets: make([]struct {
+					Pattern  string
+					Ruleset  string
+					Priority int
+					Topic    string
+				}, 0),
+				RulesetsEnforcementGuard: true,
+			}
+			repoconf.DestructiveOperations.AllowDestructiveRulesetsEnforcement = tt.allowWeaken
+			repoconf.Rulesets = append(repoconf.Rulesets, struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}{
+				Pattern: "^nomatch$",
+				Ruleset: "update",
+			})
+
+			r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+			local := GoliacLocalMock{
+				users:    make(map[string]*entity.User),
+				teams:    make(map[string]*entity.Team),
+				repos:    make(map[string]*entity.Repository),
+				rulesets: make(map[string]*entity.RuleSet),
+			}
+
+			lRuleset := &entity.RuleSet{}
+			lRuleset.Name = "update"
+			lRuleset.Spec.Enforcement = tt.localEnforcement
+			lRuleset.Spec.Rules = append(lRuleset.Spec.Rules, struct {
+				Ruletype   string
+				Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+			}{
+				"required_signatures", entity.RuleSetParameters{},
+			})
+			local.rulesets["update"] = lRuleset
+
+			remote := GoliacRemoteMock{
+				users:      make(map[string]string),
+				teams:      make(map[string]*GithubTeam),
+				repos:      make(map[string]*GithubRepository),
+				teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+				rulesets:   make(map[string]*GithubRuleSet),
+				appids:     make(map[string]int),
+			}
+
+			rRuleset := &GithubRuleSet{
+				Name:        "update",
+				Enforcement: tt.remoteEnforcement,
+				Rules:       make(map[string]entity.RuleSetParameters),
+			}
+			rRuleset.Rules["required_signatures"] = entity.RuleSetParameters{}
+			remote.rulesets["update"] = rRuleset
+
+			changes := config.GoliacChanges{ReportSkipped: true}
+			ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+			toArchive := make(map[string]*GithubRepoComparable)
+			r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+			assert.Equal(t, tt.expectedUpdated, len(recorder.RuleSetUpdated), tt.name)
+			if tt.expectedUpdated > 0 {
+				assert.Equal(t, tt.expectedEnforcement, recorder.RuleSetUpdated["update"].Enforcement, tt.name)
+			}
+
+			// a refused weakening is reported in the plan, as skipped
+			skipped := 0
+			for _, o := range changes.Operations {
+				if o.Skipped && o.Command == "update_ruleset" {
+					skipped++
+				}
+			}
+			assert.Equal(t, 1-tt.expectedUpdated, skipped, tt.name)
+		}
+	})
+
+	t.Run("happy path: delete ruleset", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{
+			Rulesets: make([]struct {
+				Pattern  string
+				Ruleset  string
+				Priority int
+				Topic    string
+			}, 0),
+		}
+		repoconf.DestructiveOperations.AllowDestructiveRulesets = true
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+
+		rRuleset := &GithubRuleSet{
+			Name:        "delete",
+			Enforcement: "active",
+			Rules:       make(map[string]entity.RuleSetParameters),
+		}
+		rRuleset.Rules["required_signatures"] = entity.RuleSetParameters{}
+		remote.rulesets["delete"] = rRuleset
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// 1 ruleset created
+		assert.Equal(t, 0, len(recorder.RuleSetCreated))
+		assert.Equal(t, 0, len(recorder.RuleSetUpdated))
+		assert.Equal(t, 1, len(recorder.RuleSetDeleted))
+	})
+
+	t.Run("happy path: repo with ruleset", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		newRepo := &entity.Repository{}
+		newRepo.Name = "myrepo"
+		newRepo.Spec.Readers = []string{}
+		newRepo.Spec.Writers = []string{}
+		owner := "existing"
+		newRepo.Owner = &owner
+
+		lruleset := entity.RepositoryRuleSet{
+			Name: "myruleset",
+		}
+		lruleset.Enforcement = "active"
+		lruleset.Conditions.Include = []string{"~DEFAULT_BRANCH"}
+		lruleset.Rules = append(lruleset.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_signatures", entity.RuleSetParameters{},
+		})
+		newRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
+		local.repos["myrepo"] = newRepo
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"existing_owner"}
+		existingTeam.Spec.Members = []string{"existing_member"}
+		local.teams["existing"] = existingTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		existing := &GithubTeam{
+			Name:    "existing",
+			Slug:    "existing",
+			Members: []string{"existing_owner", "existing_member"},
+		}
+		remote.teams["existing"] = existing
+
+		myrepo := &GithubRepository{
+			Name:  "myrepo",
+			Id:    1234,
+			RefId: "sdfsf",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+		rruleset := GithubRuleSet{
+			Name:        "myruleset",
+			Enforcement: "active",
+			// the owner team bypass is added by Goliac
+			BypassTeams: map[string]string{"existing": "always"},
+			OnInclude:   []string{"~DEFAULT_BRANCH"},
+			Rules: map[string]entity.RuleSetParameters{
+				"required_signatures": {},
+			},
+		}
+		myrepo.RuleSets["myruleset"] = &rruleset
+
+		remote.repos["myrepo"] = myrepo
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryCreated))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetDeleted["myrepo"]))
+	})
+
+	t.Run("happy path: repo with new ruleset", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		newRepo := &entity.Repository{}
+		newRepo.Name = "myrepo"
+		newRepo.Spec.Readers = []string{}
+		newRepo.Spec.Writers = []string{}
+		owner := "existing"
+		newRepo.Owner = &owner
+
+		lruleset := entity.RepositoryRuleSet{
+			Name: "myruleset",
+		}
+		lruleset.Enforcement = "active"
+		lruleset.Conditions.Include = []string{"~DEFAULT_BRANCH"}
+		lruleset.Rules = append(lruleset.Rules, struct {
+			Ruletype   string
+			Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+		}{
+			"required_signatures", entity.RuleSetParameters{},
+		})
+		newRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
+		local.repos["myrepo"] = newRepo
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"existing_owner"}
+		existingTeam.Spec.Members = []string{"existing_member"}
+		local.teams["existing"] = existingTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		existing := &GithubTeam{
+			Name:    "existing",
+			Slug:    "existing",
+			Members: []string{"existing_owner", "existing_member"},
+		}
+		remote.teams["existing"] = existing
+
+		myrepo := &GithubRepository{
+			Name:  "myrepo",
+			Id:    1234,
+			RefId: "sdfsf",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+
+		remote.repos["myrepo"] = myrepo
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryCreated))
+		assert.Equal(t, 1, len(recorder.RepositoryRuleSetCreated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetDeleted["myrepo"]))
+	})
+}
+
+func TestReconciliationRulesetsStatusChecksIntegrations(t *testing.T) {
+
+	requiredStatusChecks := struct {
+		Ruletype   string
+		Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+	}{
+		"required_status_checks", entity.RuleSetParameters{
+			RequiredStatusChecks:            []string{"build", "lint"},
+			ResolveStatusChecksIntegrations: true,
+		},
+	}
+
+	t.Run("happy path: the status checks are bound when all the repositories agree", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: "^repo",
+			Ruleset: "checks",
+		})
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+		for _, reponame := range []string{"repo1", "repo2"} {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			local.repos[reponame] = lRepo
+		}
+
+		checksRuleset := &entity.RuleSet{}
+		checksRuleset.Name = "checks"
+		checksRuleset.Spec.Enforcement = "active"
+		checksRuleset.Spec.Rules = append(checksRuleset.Spec.Rules, requiredStatusChecks)
+		local.rulesets["checks"] = checksRuleset
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			checks: map[string]map[string]int{
+				"repo1": {"build": 15368, "lint": 15368},
+				"repo2": {"build": 15368, "lint": 42},
+			},
+		}
+
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", map[string]*GithubRepoComparable{}, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 1, len(recorder.RuleSetCreated))
+		rules := recorder.RuleSetCreated["checks"].Rules["required_status_checks"]
+		assert.Equal(t, map[string]int{"build": 15368}, rules.RequiredStatusChecksIntegrations)
+		// the local definition is left untouched
+		assert.Nil(t, checksRuleset.Spec.Rules[0].Parameters.RequiredStatusChecksIntegrations)
+	})
+
+	t.Run("happy path: a ruleset already bound is not updated", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lruleset := entity.RepositoryRuleSet{
+			Name: "myruleset",
+		}
+		lruleset.Enforcement = "active"
+		lruleset.Conditions.Include = []string{"~DEFAULT_BRANCH"}
+		lruleset.Rules = append(lruleset.Rules, requiredStatusChecks)
+		lRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			checks: map[string]map[string]int{
+				"myrepo": {"build": 15368},
+			},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private": true,
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets: map[string]*GithubRuleSet{
+				"myruleset": {
+					Name:        "myruleset",
+					Enforcement: "active",
+					BypassApps:  map[string]string{},
+					OnInclude:   []string{"~DEFAULT_BRANCH"},
+					Rules: map[string]entity.RuleSetParameters{
+						"required_status_checks": {
+							RequiredStatusChecks:             []string{"lint", "build"},
+							RequiredStatusChecksIntegrations: map[string]int{"build": 15368},
+						},
+					},
+				},
+			},
+		}
+
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", map[string]*GithubRepoComparable{}, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
+	})
+}
+
+func TestReconciliationCustomProperties(t *testing.T) {
+
+	t.Run("happy path: repo with custom properties", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{}
+		lRepo.Spec.Writers = []string{}
+		lRepo.Spec.CustomProperties = map[string]string{
+			"data-classification": "confidential", // changed
+			"cost-center":         "1234",         // added
+			"legacy":              "",             // removed
+		}
+		owner := "existing"
+		lRepo.Owner = &owner
+		local.repos["myrepo"] = lRepo
+
+		existingTeam := &entity.Team{}
+		existingTeam.Name = "existing"
+		existingTeam.Spec.Owners = []string{"existing_owner"}
+		existingTeam.Spec.Members = []string{}
+		local.teams["existing"] = existingTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.teams["existing"] = &GithubTeam{
+			Name:    "existing",
+			Slug:    "existing",
+			Members: []string{"existing_owner"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+			CustomProperties: map[string]string{
+				"data-classification": "public",
+				"legacy":              "true",
+				"managed-elsewhere":   "foobar",
+			},
+		}
+		remote.teamsrepos["existing"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "WRITE"},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 2, len(recorder.RepositoriesSetCustomProperty["myrepo"]))
+		assert.Equal(t, "confidential", recorder.RepositoriesSetCustomProperty["myrepo"]["data-classification"])
+		assert.Equal(t, "1234", recorder.RepositoriesSetCustomProperty["myrepo"]["cost-center"])
+		// properties not declared locally are not touched
+		assert.Equal(t, []string{"legacy"}, recorder.RepositoriesRemoveCustomProperty["myrepo"])
+	})
+}
+
+func TestReconciliationVisibility(t *testing.T) {
+	tests := []struct {
+		name           string
+		localPublic    bool
+		remotePrivate  bool
+		repoAllowed    bool
+		globalAllowed  bool
+		expectedUpdate bool
+	}{
+		{"public to private is refused", false, false, false, false, false},
+		{"public to private allowed on the repository", false, false, true, false, true},
+		{"public to private allowed globally", false, false, false, true, true},
+		{"private to public is not affected", true, true, false, false, true},
+		{"public stays public", true, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run("happy path: "+tt.name, func(t *testing.T) {
+			recorder := NewReconciliatorListenerRecorder()
+
+			repoconf := config.RepositoryConfig{
+				AllowVisibilityReduction: tt.globalAllowed,
+			}
+
+			r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+			local := GoliacLocalMock{
+				users: make(map[string]*entity.User),
+				teams: make(map[string]*entity.Team),
+				repos: make(map[string]*entity.Repository),
+			}
+			lRepo := &entity.Repository{}
+			lRepo.Name = "myrepo"
+			lRepo.Spec.IsPublic = tt.localPublic
+			lRepo.Spec.AllowVisibilityReduction = tt.repoAllowed
+			local.repos["myrepo"] = lRepo
+
+			remote := GoliacRemoteMock{
+				users:      make(map[string]string),
+				teams:      make(map[string]*GithubTeam),
+				repos:      make(map[string]*GithubRepository),
+				teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+				rulesets:   make(map[string]*GithubRuleSet),
+				appids:     make(map[string]int),
+			}
+			remote.repos["teams"] = &GithubRepository{
+				Name:           "teams",
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+			remote.repos["myrepo"] = &GithubRepository{
+				Name: "myrepo",
+				BoolProperties: map[string]bool{
+					"private":                tt.remotePrivate,
+					"allow_update_branch":    false,
+					"archived":               false,
+					"allow_auto_merge":       false,
+					"delete_branch_on_merge": false,
+				},
+				ExternalUsers: make(map[string]string),
+				InternalUsers: make(map[string]string),
+				RuleSets:      map[string]*GithubRuleSet{},
+			}
+
+			changes := config.GoliacChanges{ReportSkipped: true}
+			ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+			toArchive := make(map[string]*GithubRepoComparable)
+			r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+			assert.Equal(t, tt.expectedUpdate, recorder.RepositoriesUpdatePrivate["myrepo"])
+
+			// a refused visibility reduction is reported in the plan, as skipped
+			refused := !tt.localPublic && !tt.remotePrivate && !tt.expectedUpdate
+			skipped := false
+			for _, o := range changes.Operations {
+				if o.Skipped && o.Command == "update_repository_update_bool_property" {
+					skipped = true
+				}
+			}
+			assert.Equal(t, refused, skipped)
+		})
+	}
+}
+
+func TestReconciliationVisibilityFlipFlop(t *testing.T) {
+	t.Run("happy path: a visibility changed back is held", func(t *testing.T) {
+		guard := NewVisibilityGuard()
+		repoconf := config.RepositoryConfig{
+			AllowVisibilityReduction: true,
+		}
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		local.repos["myrepo"] = lRepo
+
+		remotePrivate := true
+		// reconciliate runs a reconciliation wanting the repository public (or private),
+		// and returns if the visibility was updated, the skipped operations and the warnings
+		reconciliate := func(public bool) (bool, []string, []string) {
+			lRepo.Spec.IsPublic = public
+			remote := GoliacRemoteMock{
+				users:      make(map[string]string),
+				teams:      make(map[string]*GithubTeam),
+				repos:      make(map[string]*GithubRepository),
+				teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+				rulesets:   make(map[string]*GithubRuleSet),
+				appids:     make(map[string]int),
+			}
+			remote.repos["teams"] = &GithubRepository{
+				Name:           "teams",
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+			remote.repos["myrepo"] = &GithubRepository{
+				Name: "myrepo",
+				BoolProperties: map[string]bool{
+					"private":                remotePrivate,
+					"allow_update_branch":    false,
+					"archived":               false,
+					"allow_auto_merge":       false,
+					"delete_branch_on_merge": false,
+				},
+				ExternalUsers: make(map[string]string),
+				InternalUsers: make(map[string]string),
+				RuleSets:      map[string]*GithubRuleSet{},
+			}
+
+			recorder := NewReconciliatorListenerRecorder()
+			r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+			r.SetVisibilityGuard(guard)
+
+			changes := config.GoliacChanges{ReportSkipped: true}
+			ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+			toArchive := make(map[string]*GithubRepoComparable)
+			r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+			updated := recorder.RepositoriesUpdatePrivate["myrepo"]
+			if updated {
+				remotePrivate = !public
+			}
+			skipped := []string{}
+			for _, o := range changes.Operations {
+				if o.Skipped {
+					skipped = append(skipped, o.Detail)
+				}
+			}
+			return updated, skipped, changes.Warnings
+		}
+
+		// the repository is made public
+		updated, skipped, warnings := reconciliate(true)
+		assert.True(t, updated)
+		assert.Equal(t, 0, len(skipped))
+		assert.Equal(t, 0, len(warnings))
+
+		// the next reconciliation wants it private again: the flip-flop is detected
+		updated, skipped, warnings = reconciliate(false)
+		assert.False(t, updated)
+		assert.Equal(t, []string{"repositoryname: myrepo private:true, holding the visibility private:false (visibility flip-flop)"}, skipped)
+		assert.Equal(t, []string{"visibility flip-flop on the repository myrepo: the last reconciliation set private:false, and it is changed back to private:true. Holding private:false, check the definitions of the repository"}, warnings)
+
+		// the visibility stays held (and the warning is not raised again)
+		updated, skipped, warnings = reconciliate(false)
+		assert.False(t, updated)
+		assert.Equal(t, 1, len(skipped))
+		assert.Equal(t, 0, len(warnings))
+
+		// the conflict is solved: the hold is released
+		updated, _, _ = reconciliate(true)
+		assert.False(t, updated)
+
+		// and the visibility can be changed again
+		updated, skipped, warnings = reconciliate(false)
+		assert.True(t, updated)
+		assert.Equal(t, 0, len(skipped))
+		assert.Equal(t, 0, len(warnings))
+	})
+
+	t.Run("happy path: a dryrun reconciliation doesn't remember the visibility changes", func(t *testing.T) {
+		guard := NewVisibilityGuard()
+
+		guard.begin()
+		allowed, flipflop, _ := guard.check("myrepo", false)
+		assert.True(t, allowed)
+		assert.False(t, flipflop)
+		guard.record("myrepo", false)
+		guard.end(true, true)
+
+		guard.begin()
+		allowed, flipflop, _ = guard.check("myrepo", true)
+		assert.True(t, allowed)
+		assert.False(t, flipflop)
+	})
+}
+
+func TestReconciliationRepositoryFeatures(t *testing.T) {
+	t.Run("happy path: repository features are only reconciled when set", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		hasIssues := true
+		hasWiki := false
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.HasIssues = &hasIssues
+		lRepo.Spec.HasWiki = &hasWiki
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+				"has_issues":             true,
+				"has_wiki":               true,
+				"has_projects":           true,
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// has_issues already matches, and has_projects is not set locally
+		assert.Equal(t, map[string]bool{"has_wiki": false}, recorder.RepositoriesUpdateBoolProperty["myrepo"])
+	})
+
+	t.Run("happy path: fork policy only reconciled on private repositories", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		allowForking := false
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		for _, reponame := range []string{"private-repo", "public-repo"} {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			lRepo.Spec.IsPublic = reponame == "public-repo"
+			lRepo.Spec.AllowForking = &allowForking
+			local.repos[reponame] = lRepo
+
+			remote.repos[reponame] = &GithubRepository{
+				Name: reponame,
+				BoolProperties: map[string]bool{
+					"private":                reponame == "private-repo",
+					"allow_update_branch":    false,
+					"archived":               false,
+					"allow_auto_merge":       false,
+					"delete_branch_on_merge": false,
+					"allow_forking":          true,
+				},
+				ExternalUsers: make(map[string]string),
+				InternalUsers: make(map[string]string),
+				RuleSets:      map[string]*GithubRuleSet{},
+			}
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the public repository fork policy is forced by Github
+		assert.Equal(t, map[string]bool{"allow_forking": false}, recorder.RepositoriesUpdateBoolProperty["private-repo"])
+		assert.Equal(t, 0, len(recorder.RepositoriesUpdateBoolProperty["public-repo"]))
+	})
+
+	t.Run("happy path: merge commit messages only reconciled when the merge strategy is enabled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.SquashMergeCommitTitle = "PR_TITLE"
+		lRepo.Spec.MergeCommitTitle = "PR_TITLE"
+		lRepo.Spec.MergeCommitMessage = "PR_BODY"
+		local.repos["myrepo"] = lRepo
+
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+				"allow_squash_merge":     true,
+				"allow_merge_commit":     false,
+			},
+			StringProperties: map[string]string{
+				"squash_merge_commit_title":   "COMMIT_OR_PR_TITLE",
+				"squash_merge_commit_message": "COMMIT_MESSAGES",
+				"merge_commit_title":          "MERGE_MESSAGE",
+				"merge_commit_message":        "PR_TITLE",
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the squash title is updated with its (current) message, the merge commit is disabled
+		assert.Equal(t, map[string]string{
+			"squash_merge_commit_title":   "PR_TITLE",
+			"squash_merge_commit_message": "COMMIT_MESSAGES",
+		}, recorder.RepositoriesUpdateStringProperties["myrepo"])
+	})
+
+	t.Run("happy path: merge strategies enabled along with their messages", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		// as seeded by the default_merge_strategy of goliac.yaml
+		allowed := true
+		notAllowed := false
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.AllowSquashMerge = &notAllowed
+		lRepo.Spec.AllowMergeCommit = &allowed
+		lRepo.Spec.MergeCommitTitle = "PR_TITLE"
+		local.repos["myrepo"] = lRepo
+
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"allow_update_branch":    false,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+				"allow_squash_merge":     true,
+				"allow_merge_commit":     false,
+				"allow_rebase_merge":     true,
+			},
+			StringProperties: map[string]string{
+				"merge_commit_title":   "MERGE_MESSAGE",
+				"merge_commit_message": "PR_TITLE",
+			},
+			ExternalUsers: make(map[string]string),
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the rebase merge (not set) is left untouched
+		assert.Equal(t, map[string]bool{
+			"allow_squash_merge": false,
+			"allow_merge_commit": true,
+		}, recorder.RepositoriesUpdateBoolProperty["myrepo"])
+		// the merge commit title is updated as the strategy is being enabled
+		assert.Equal(t, map[string]string{
+			"merge_commit_title":   "PR_TITLE",
+			"merge_commit_message": "PR_TITLE",
+		}, recorder.RepositoriesUpdateStringProperties["myrepo"])
+	})
+}
+
+func TestReconciliationLastWriterTeam(t *testing.T) {
+	newMocks := func(lRepo *entity.Repository, rTeamsAccess map[string]string) (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, teamname := range []string{"team1", "team2"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			local.teams[teamname] = team
+		}
+		local.repos[lRepo.Name] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		for _, teamslug := range []string{"team1", "team2"} {
+			remote.teams[teamslug] = &GithubTeam{
+				Name: teamslug,
+				Slug: teamslug,
+			}
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos[lRepo.Name] = &GithubRepository{
+			Name: lRepo.Name,
+			BoolProperties: map[string]bool{
+				"private":                true,
+				"archived":               false,
+				"allow_auto_merge":       false,
+				"delete_branch_on_merge": false,
+				"allow_update_branch":    false,
+			},
+			ExternalUsers: map[string]string{},
+			InternalUsers: map[string]string{},
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+		for teamslug, permission := range rTeamsAccess {
+			remote.teamsrepos[teamslug] = map[string]*GithubTeamRepo{
+				lRepo.Name: {Name: lRepo.Name, Permission: permission},
+			}
+		}
+		return &local, &remote
+	}
+
+	t.Run("not happy path: the last writer team is kept", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{"team1"}
+		local, remote := newMocks(lRepo, map[string]string{"team1": "WRITE", "team2": "ADMIN"})
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryTeamUpdated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved["myrepo"]))
+	})
+
+	t.Run("happy path: the writer team is replaced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Writers = []string{"team2"}
+		local, remote := newMocks(lRepo, map[string]string{"team1": "WRITE"})
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, []string{"team2"}, recorder.RepositoryTeamAdded["myrepo"])
+		assert.Equal(t, []string{"team1"}, recorder.RepositoryTeamRemoved["myrepo"])
+	})
+}
+
+func TestReconciliationExternalUsersDefaultAccess(t *testing.T) {
+	t.Run("happy path: external users default access on matching repositories", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:     make(map[string]*entity.User),
+			externals: make(map[string]*entity.User),
+			teams:     make(map[string]*entity.Team),
+			repos:     make(map[string]*entity.Repository),
+		}
+		partner1 := &entity.User{}
+		partner1.Name = "partner1"
+		partner1.Spec.GithubID = "partner1_githubid"
+		partner1.Spec.DefaultAccess = "read"
+		partner1.Spec.RepoPattern = "^partner-"
+		local.externals["partner1"] = partner1
+
+		partner2 := &entity.User{}
+		partner2.Name = "partner2"
+		partner2.Spec.GithubID = "partner2_githubid"
+		partner2.Spec.DefaultAccess = "write"
+		partner2.Spec.RepoPattern = "^partner-"
+		local.externals["partner2"] = partner2
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "partner-repo"
+		// explicit entries override the default access
+		lRepo.Spec.ExternalUserReaders = []string{"partner2"}
+		local.repos["partner-repo"] = lRepo
+
+		otherRepo := &entity.Repository{}
+		otherRepo.Name = "other-repo"
+		local.repos["other-repo"] = otherRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		for _, reponame := range []string{"partner-repo", "other-repo"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name: reponame,
+				BoolProperties: map[string]bool{
+					"private":                true,
+					"allow_update_branch":    false,
+					"archived":               false,
+					"allow_auto_merge":       false,
+					"delete_branch_on_merge": false,
+				},
+				ExternalUsers: make(map[string]string),
+				InternalUsers: make(map[string]string),
+				RuleSets:      map[string]*GithubRuleSet{},
+			}
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{
+			"partner1_githubid": "pull",
+			"partner2_githubid": "pull",
+		}, recorder.RepositoriesSetExternalUser)
+	})
+
+	t.Run("not happy path: an invalid repoPattern is skipped", func(t *testing.T) {
+		externals := make(map[string]*entity.User)
+		partner1 := &entity.User{}
+		partner1.Name = "partner1"
+		partner1.Spec.GithubID = "partner1_githubid"
+		partner1.Spec.DefaultAccess = "read"
+		partner1.Spec.RepoPattern = "^partner-("
+		externals["partner1"] = partner1
+
+		partner2 := &entity.User{}
+		partner2.Name = "partner2"
+		partner2.Spec.GithubID = "partner2_githubid"
+		partner2.Spec.DefaultAccess = "write"
+		partner2.Spec.RepoPattern = "^partner-"
+		externals["partner2"] = partner2
+
+		repoPatterns := externalUsersRepoPatterns(externals)
+		assert.Equal(t, 1, len(repoPatterns))
+		assert.NotNil(t, repoPatterns["partner2"])
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "partner-repo"
+		eReaders, eWriters, eAdmins := repositoryExternalUsers(externals, repoPatterns, "partner-repo", lRepo)
+		assert.Equal(t, []string{}, eReaders)
+		assert.Equal(t, []string{"partner2_githubid"}, eWriters)
+		assert.Equal(t, []string{}, eAdmins)
+	})
+}
+
+func TestReconciliationRequiredTeams(t *testing.T) {
+	newMocks := func() (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, teamname := range []string{"owner", "security"} {
+			local.teams[teamname] = &entity.Team{}
+			local.teams[teamname].Name = teamname
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &local, &remote
+	}
+	addRepo := func(local *GoliacLocalMock, remote *GoliacRemoteMock, reponame string, topics []string) *entity.Repository {
+		owner := "owner"
+		lRepo := &entity.Repository{}
+		lRepo.Name = reponame
+		lRepo.Owner = &owner
+		local.repos[reponame] = lRepo
+		remote.repos[reponame] = &GithubRepository{
+			Name:           reponame,
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+			Topics:         topics,
+		}
+		remote.teamsrepos["owner"] = map[string]*GithubTeamRepo{}
+		return lRepo
+	}
+	newRepoconf := func() *config.RepositoryConfig {
+		repoconf := &config.RepositoryConfig{}
+		required := config.RequiredTeams{Topic: "pii", Readers: []string{"security"}}
+		repoconf.RequiredTeams = []config.RequiredTeams{required}
+		required = config.RequiredTeams{Writers: []string{"security"}}
+		required.CustomProperty.Name = "classification"
+		required.CustomProperty.Value = "confidential"
+		repoconf.RequiredTeams = append(repoconf.RequiredTeams, required)
+		return repoconf
+	}
+
+	t.Run("happy path: the required teams are added", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, newRepoconf())
+
+		local, remote := newMocks()
+		addRepo(local, remote, "pii-repo", []string{"pii"})
+		confidential := addRepo(local, remote, "confidential-repo", []string{})
+		confidential.Spec.CustomProperties = map[string]string{"classification": "confidential"}
+		addRepo(local, remote, "other-repo", []string{"production"})
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"owner": "push", "security": "pull"}, recorder.RepositoryTeamPermission["pii-repo"])
+		assert.Equal(t, map[string]string{"owner": "push", "security": "push"}, recorder.RepositoryTeamPermission["confidential-repo"])
+		assert.Equal(t, map[string]string{"owner": "push"}, recorder.RepositoryTeamPermission["other-repo"])
+	})
+
+	t.Run("happy path: a stronger explicit permission is kept", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, newRepoconf())
+
+		local, remote := newMocks()
+		lRepo := addRepo(local, remote, "pii-repo", []string{"pii"})
+		lRepo.Spec.Maintainers = []string{"security"}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"owner": "push", "security": "maintain"}, recorder.RepositoryTeamPermission["pii-repo"])
+	})
+
+	t.Run("not happy path: a weaker explicit permission is reported", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, newRepoconf())
+
+		local, remote := newMocks()
+		lRepo := addRepo(local, remote, "confidential-repo", []string{})
+		lRepo.Spec.CustomProperties = map[string]string{"classification": "confidential"}
+		lRepo.Spec.Readers = []string{"security"}
+
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, local, remote, "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"owner": "push", "security": "pull"}, recorder.RepositoryTeamPermission["confidential-repo"])
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{
+			"repositoryname: confidential-repo, teamslug: security, the custom property classification=confidential requires the push permission, but the repository definition gives pull",
+		}, skipped)
+	})
+}
+
+func TestReconciliationExternalUsersExpiration(t *testing.T) {
+	t.Run("happy path: expired external users accesses are removed, and never added", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users:     make(map[string]*entity.User),
+			externals: make(map[string]*entity.User),
+			teams:     make(map[string]*entity.Team),
+			repos:     make(map[string]*entity.Repository),
+		}
+		for _, name := range []string{"partner1", "partner2", "partner3"} {
+			partner := &entity.User{}
+			partner.Name = name
+			partner.Spec.GithubID = name + "_githubid"
+			local.externals[name] = partner
+		}
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.ExternalUserReaders = []string{"partner1", "partner2"}
+		lRepo.Spec.ExternalUserWriters = []string{"partner3"}
+		lRepo.Spec.ExternalUsersExpiresAt = map[string]string{
+			"partner1": "2020-01-01", // expired, and still on Github
+			"partner2": "2020-01-01", // expired, not on Github
+			"partner3": time.Now().Add(48 * time.Hour).Format(entity.ExpiresAtLayout),
+		}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
 			BoolProperties: map[string]bool{
 				"private":                true,
 				"allow_update_branch":    false,
@@ -2212,52 +5171,2472 @@ func TestReconciliationRulesets(t *testing.T) {
 				"allow_auto_merge":       false,
 				"delete_branch_on_merge": false,
 			},
-			ExternalUsers: make(map[string]string),
-			InternalUsers: make(map[string]string),
-			RuleSets:      map[string]*GithubRuleSet{},
-		}
-		rruleset := GithubRuleSet{
-			Name:        "myruleset",
+			ExternalUsers: map[string]string{"partner1_githubid": "READ"},
+			InternalUsers: make(map[string]string),
+			RuleSets:      map[string]*GithubRuleSet{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"partner3_githubid": "push"}, recorder.RepositoriesSetExternalUser)
+		assert.Equal(t, map[string]bool{"partner1_githubid": true}, recorder.RepositoriesRemoveExternalUser)
+	})
+}
+
+func TestReconciliationOrgRoles(t *testing.T) {
+	newMocks := func(roles map[string]string, remoteRoles map[string]string) (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for githubid, role := range roles {
+			user := entity.User{}
+			user.Name = githubid
+			user.Spec.GithubID = githubid
+			user.Spec.OrgRole = role
+			local.users[githubid] = &user
+		}
+
+		remote := GoliacRemoteMock{
+			users:      remoteRoles,
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &local, &remote
+	}
+
+	t.Run("happy path: org roles enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks(
+			map[string]string{"alice": "admin", "bob": "", "carol": "admin", "dave": "admin"},
+			map[string]string{"alice": "ADMIN", "bob": "ADMIN", "carol": "MEMBER"},
+		)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "dave", recorder.UsersCreated["dave"])
+		assert.Equal(t, map[string]string{"bob": "member", "carol": "admin", "dave": "admin"}, recorder.UsersOrgRoleUpdated)
+	})
+
+	t.Run("not happy path: the last owner is not demoted", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks(
+			map[string]string{"alice": "member", "bob": "member"},
+			map[string]string{"alice": "ADMIN", "bob": "MEMBER"},
+		)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.UsersOrgRoleUpdated))
+	})
+}
+
+func TestReconciliationOrgInvitations(t *testing.T) {
+	newMocks := func() (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, githubid := range []string{"alice", "Bob", "carol", "dave"} {
+			user := entity.User{}
+			user.Name = githubid
+			user.Spec.GithubID = githubid
+			local.users[githubid] = &user
+		}
+
+		remote := GoliacRemoteMock{
+			users:      map[string]string{"alice": "ADMIN"},
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			invitations: map[string]*GithubOrgInvitation{
+				"bob":   {Id: 1, Login: "bob", Role: "direct_member", CreatedAt: time.Now().Add(-48 * time.Hour)},
+				"carol": {Id: 2, Login: "carol", Role: "direct_member", CreatedAt: time.Now().Add(-30 * 24 * time.Hour)},
+				"eve":   {Id: 3, Login: "eve", Role: "direct_member", CreatedAt: time.Now().Add(-30 * 24 * time.Hour)},
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &local, &remote
+	}
+
+	t.Run("happy path: a pending invitation satisfies the membership", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks()
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"dave": "dave"}, recorder.UsersCreated)
+		assert.Equal(t, 0, len(recorder.InvitationsCancelled))
+	})
+
+	t.Run("happy path: the old pending invitations are cancelled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := config.RepositoryConfig{}
+		repoconf.PendingInvitationsMaxAge = 7
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local, remote := newMocks()
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, []string{"carol", "eve"}, recorder.InvitationsCancelled)
+		// carol is invited again
+		assert.Equal(t, map[string]string{"carol": "carol", "dave": "dave"}, recorder.UsersCreated)
+	})
+
+	t.Run("happy path: the team additions of a pending user are deferred", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks()
+		ateam := &entity.Team{}
+		ateam.Name = "ateam"
+		ateam.Spec.Owners = []string{"alice"}
+		ateam.Spec.Members = []string{"Bob", "dave"}
+		local.teams["ateam"] = ateam
+		remote.teams["ateam"] = &GithubTeam{
+			Name:    "ateam",
+			Slug:    "ateam",
+			Members: []string{"alice"},
+		}
+		remote.teams["ateam"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "ateam" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "ateam" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"alice"},
+		}
+
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, local, remote, "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// bob (already pending) and dave (invited now) are not added to the team until they accept the invitation
+		assert.Equal(t, map[string]string{"dave": "dave"}, recorder.UsersCreated)
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded))
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved))
+		// only the user invited by this reconciliation is reported
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{
+			"ghuserid: alice is the last owner of the organization and cannot be demoted",
+			"teamslug: ateam ghuserid: dave, the user has a pending invitation to the organization (added to the team once the invitation is accepted)",
+		}, skipped)
+	})
+
+	t.Run("happy path: a new team with a pending user converges", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks()
+		ateam := &entity.Team{}
+		ateam.Name = "ateam"
+		ateam.Spec.Owners = []string{"alice"}
+		ateam.Spec.Members = []string{"Bob"}
+		local.teams["ateam"] = ateam
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the team is created without the pending user
+		assert.Equal(t, []string{"alice"}, recorder.TeamsCreated["ateam"])
+
+		// once created, the team is in sync (while bob's invitation is pending)
+		recorder = NewReconciliatorListenerRecorder()
+		r = NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+		remote.teams["ateam"] = &GithubTeam{
+			Name:    "ateam",
+			Slug:    "ateam",
+			Members: []string{"alice"},
+		}
+		remote.teams["ateam"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "ateam" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "ateam" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"alice"},
+		}
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		r.Reconciliate(ctx, local, remote, "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		for _, o := range changes.Operations {
+			assert.NotEqual(t, "update_team_add_member", o.Command)
+		}
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded))
+	})
+}
+
+func TestReconciliationOrgSettings(t *testing.T) {
+	t.Run("happy path: organization policies are enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		allowed := true
+		forbidden := false
+		repoconf.OrganizationPolicies.MembersCanCreatePublicRepos = &forbidden
+		repoconf.OrganizationPolicies.MembersCanCreatePrivateRepos = &allowed
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgsettings: map[string]bool{
+				"members_can_create_public_repositories":   true,
+				"members_can_create_private_repositories":  true,
+				"members_can_create_internal_repositories": true,
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// only the drifting policy is updated, unset policies are not managed
+		assert.Equal(t, map[string]bool{
+			"members_can_create_public_repositories": false,
+		}, recorder.OrgSettingsUpdated)
+	})
+
+	t.Run("not happy path: the organization settings couldn't be loaded", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		forbidden := false
+		repoconf.OrganizationPolicies.MembersCanCreatePublicRepos = &forbidden
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.OrgSettingsUpdated))
+	})
+
+	t.Run("not happy path: a setting is not returned by Github", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		allowed := true
+		forbidden := false
+		repoconf.OrganizationPolicies.MembersCanCreatePublicRepos = &forbidden
+		repoconf.OrganizationPolicies.MembersCanCreatePrivateRepos = &allowed
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgsettings: map[string]bool{
+				"members_can_create_public_repositories": true,
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the unknown setting is not updated
+		assert.Equal(t, map[string]bool{
+			"members_can_create_public_repositories": false,
+		}, recorder.OrgSettingsUpdated)
+	})
+}
+
+func TestReconciliationOrgProfile(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgprofile: map[string]string{
+				"billing_email":    "billing@example.com",
+				"company":          "Old Corp",
+				"description":      "our organization",
+				"blog":             "https://blog.example.com",
+				"twitter_username": "oldcorp",
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+
+	t.Run("happy path: only the profile fields set are managed", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		billingEmail := "billing@example.com"
+		company := "New Corp"
+		repoconf.OrgProfile.BillingEmail = &billingEmail
+		repoconf.OrgProfile.Company = &company
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"company": "New Corp"}, recorder.OrgProfileUpdated)
+	})
+
+	t.Run("happy path: an empty field is not cleared without allow_clearing", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		empty := ""
+		repoconf.OrgProfile.TwitterUsername = &empty
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, newRemote(), "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.OrgProfileUpdated))
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{
+			`setting: twitter_username "oldcorp" -> "", not clearing the organization profile field (allow_clearing is not set)`,
+		}, skipped)
+	})
+
+	t.Run("happy path: an empty field is cleared with allow_clearing", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		empty := ""
+		repoconf.OrgProfile.TwitterUsername = &empty
+		repoconf.OrgProfile.AllowClearing = true
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]string{"twitter_username": ""}, recorder.OrgProfileUpdated)
+	})
+
+	t.Run("not happy path: the profile couldn't be loaded", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		company := "New Corp"
+		repoconf.OrgProfile.Company = &company
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		remote := newRemote()
+		remote.orgprofile = nil
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.OrgProfileUpdated))
+	})
+}
+
+func TestReconciliationOrgActionsSettings(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgactions: map[string]string{
+				"default_workflow_permissions":           "write",
+				"require_approval_for_fork_pr_workflows": "true",
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+
+	t.Run("happy path: the actions permissions are enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		required := true
+		repoconf.OrganizationPolicies.ManageActionsPermissions = true
+		repoconf.OrganizationPolicies.DefaultWorkflowTokenPermissions = "read"
+		repoconf.OrganizationPolicies.RequireApprovalForForkPRs = &required
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// only the drifting setting is updated
+		assert.Equal(t, map[string]string{
+			"default_workflow_permissions": "read",
+		}, recorder.OrgActionsUpdated)
+	})
+
+	t.Run("happy path: the actions permissions are not managed without manage_actions_permissions", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.DefaultWorkflowTokenPermissions = "read"
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.OrgActionsUpdated))
+	})
+}
+
+func TestReconciliationOrgAllowedActions(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgallowed: &GithubOrgAllowedActions{
+				AllowedActions:     "selected",
+				GithubOwnedAllowed: true,
+				VerifiedAllowed:    false,
+				PatternsAllowed:    []string{"monalisa/octocat@v1"},
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+
+	t.Run("happy path: the allowlist is enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.ManageAllowedActions = true
+		repoconf.OrganizationPolicies.AllowedActions.GithubOwnedAllowed = true
+		repoconf.OrganizationPolicies.AllowedActions.PatternsAllowed = []string{"monalisa/octocat@v2", "alayacare/*"}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		changes := config.GoliacChanges{Dryrun: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, newRemote(), "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, &GithubOrgAllowedActions{
+			AllowedActions:     "selected",
+			GithubOwnedAllowed: true,
+			VerifiedAllowed:    false,
+			PatternsAllowed:    []string{"alayacare/*", "monalisa/octocat@v2"},
+		}, recorder.OrgAllowedActions)
+
+		// the plan shows the current and the desired allowlist
+		planned := []string{}
+		for _, o := range changes.Operations {
+			if o.Command == "update_org_allowed_actions" {
+				planned = append(planned, o.Changes...)
+			}
+		}
+		assert.Equal(t, []string{"patterns_allowed: [monalisa/octocat@v1] -> [alayacare/* monalisa/octocat@v2]"}, planned)
+	})
+
+	t.Run("happy path: the selected actions policy is enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.ManageAllowedActions = true
+		repoconf.OrganizationPolicies.AllowedActions.GithubOwnedAllowed = true
+		repoconf.OrganizationPolicies.AllowedActions.PatternsAllowed = []string{"monalisa/octocat@v1"}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		remote := newRemote()
+		remote.orgallowed = &GithubOrgAllowedActions{AllowedActions: "all", PatternsAllowed: []string{}}
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.NotNil(t, recorder.OrgAllowedActions)
+		assert.Equal(t, "selected", recorder.OrgAllowedActions.AllowedActions)
+	})
+
+	t.Run("happy path: no change when the allowlist is in sync", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.ManageAllowedActions = true
+		repoconf.OrganizationPolicies.AllowedActions.GithubOwnedAllowed = true
+		repoconf.OrganizationPolicies.AllowedActions.PatternsAllowed = []string{"monalisa/octocat@v1"}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Nil(t, recorder.OrgAllowedActions)
+	})
+
+	t.Run("happy path: the allowlist is not managed without manage_allowed_actions", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.AllowedActions.PatternsAllowed = []string{"monalisa/octocat@v2"}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Nil(t, recorder.OrgAllowedActions)
+	})
+}
+
+func TestReconciliationOrgDefaultBranchName(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			orgbranch:  "trunk",
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+
+	t.Run("happy path: the default branch name is enforced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.DefaultBranchName = "main"
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "main", recorder.OrgDefaultBranch)
+	})
+
+	t.Run("happy path: no drift", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.DefaultBranchName = "trunk"
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "", recorder.OrgDefaultBranch)
+	})
+
+	t.Run("not happy path: the organization settings couldn't be loaded", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrganizationPolicies.DefaultBranchName = "main"
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		remote := newRemote()
+		remote.orgbranch = ""
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "", recorder.OrgDefaultBranch)
+	})
+
+	t.Run("happy path: the default branch name is not managed if unset", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "", recorder.OrgDefaultBranch)
+	})
+}
+
+func TestReconciliationTeamsRepositoryProtection(t *testing.T) {
+	appID := config.Config.GithubAppID
+	defer func() { config.Config.GithubAppID = appID }()
+	config.Config.GithubAppID = 1234
+
+	newRemote := func(appids map[string]int) *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     appids,
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{"private": true, "delete_branch_on_merge": true},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+
+	t.Run("happy path: the teams repository is protected", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.TeamsRepositoryProtection.Enabled = true
+		repoconf.TeamsRepositoryProtection.RequiredApprovingReviewCount = 2
+		repoconf.TeamsRepositoryProtection.RequiredStatusChecks = []string{"validate"}
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(map[string]int{"goliac-app": 1234, "other-app": 42}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		ruleset := recorder.RepositoryRuleSetCreated["teams"]["goliac-teams-repository"]
+		assert.NotNil(t, ruleset)
+		assert.Equal(t, "active", ruleset.Enforcement)
+		// the Goliac app can still push its commits
+		assert.Equal(t, map[string]string{"goliac-app": "always"}, ruleset.BypassApps)
+		assert.Equal(t, 2, ruleset.Rules["pull_request"].RequiredApprovingReviewCount)
+		assert.Equal(t, []string{"validate"}, ruleset.Rules["required_status_checks"].RequiredStatusChecks)
+		_, ok := ruleset.Rules["non_fast_forward"]
+		assert.True(t, ok)
+	})
+
+	t.Run("happy path: not protected if not enabled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(map[string]int{"goliac-app": 1234}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated))
+	})
+
+	t.Run("not happy path: not protected without the Goliac app installation", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.TeamsRepositoryProtection.Enabled = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(map[string]int{"other-app": 42}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated))
+	})
+
+	t.Run("not happy path: the existing protection is kept without the Goliac app installation", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.TeamsRepositoryProtection.Enabled = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		// the app ids couldn't be loaded (or a personal access token is used)
+		remote := newRemote(map[string]int{})
+		remote.repos["teams"].RuleSets = map[string]*GithubRuleSet{
+			TEAMS_REPOSITORY_RULESET: {
+				Name:        TEAMS_REPOSITORY_RULESET,
+				Id:          12,
+				Enforcement: "active",
+				BypassApps:  map[string]string{"goliac-app": "always"},
+				OnInclude:   []string{"~DEFAULT_BRANCH"},
+				Rules: map[string]entity.RuleSetParameters{
+					"non_fast_forward": {},
+					"deletion":         {},
+				},
+			},
+		}
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetDeleted))
+	})
+}
+
+func TestReconciliationOrgWebhooks(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			webhooks: map[string]*GithubOrgWebhook{
+				"https://ci.example.com/hook": {
+					Id:          1,
+					Url:         "https://ci.example.com/hook",
+					Events:      []string{"push"},
+					Active:      true,
+					ContentType: "json",
+					HasSecret:   true,
+				},
+				"https://siem.example.com/hook": {
+					Id:          2,
+					Url:         "https://siem.example.com/hook",
+					Events:      []string{"push"},
+					Active:      true,
+					ContentType: "json",
+				},
+				"https://old.example.com/hook": {
+					Id:          3,
+					Url:         "https://old.example.com/hook",
+					Events:      []string{"push"},
+					Active:      true,
+					ContentType: "json",
+				},
+			},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+	newRepoConf := func() *config.RepositoryConfig {
+		inactive := false
+		repoconf := config.RepositoryConfig{}
+		repoconf.OrgWebhooks = []config.OrgWebhook{
+			// unchanged
+			{Url: "https://ci.example.com/hook", SecretEnv: "TEST_CI_WEBHOOK_SECRET"},
+			// drifting
+			{Url: "https://siem.example.com/hook", Events: []string{"repository", "member"}, Active: &inactive},
+			// missing
+			{Url: "https://new.example.com/hook", ContentType: "form"},
+		}
+		return &repoconf
+	}
+
+	t.Run("happy path: the org webhooks are reconciliated", func(t *testing.T) {
+		t.Setenv("TEST_CI_WEBHOOK_SECRET", "s3cr3t")
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := newRepoConf()
+		repoconf.DestructiveOperations.AllowDestructiveOrgWebhooks = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 1, len(recorder.OrgWebhooksAdded))
+		added := recorder.OrgWebhooksAdded["https://new.example.com/hook"]
+		assert.NotNil(t, added)
+		assert.Equal(t, []string{"push"}, added.Events)
+		assert.Equal(t, true, added.Active)
+		assert.Equal(t, "form", added.ContentType)
+
+		assert.Equal(t, 1, len(recorder.OrgWebhooksUpdated))
+		updated := recorder.OrgWebhooksUpdated["https://siem.example.com/hook"]
+		assert.NotNil(t, updated)
+		assert.Equal(t, 2, updated.Id)
+		assert.Equal(t, []string{"member", "repository"}, updated.Events)
+		assert.Equal(t, false, updated.Active)
+
+		assert.Equal(t, []int{3}, recorder.OrgWebhooksDeleted)
+	})
+
+	t.Run("happy path: the org webhooks are not deleted without the destructive flag", func(t *testing.T) {
+		t.Setenv("TEST_CI_WEBHOOK_SECRET", "s3cr3t")
+		recorder := NewReconciliatorListenerRecorder()
+
+		r := NewGoliacReconciliatorImpl(recorder, newRepoConf())
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 1, len(recorder.OrgWebhooksAdded))
+		assert.Equal(t, 1, len(recorder.OrgWebhooksUpdated))
+		assert.Equal(t, 0, len(recorder.OrgWebhooksDeleted))
+	})
+
+	t.Run("happy path: the org webhooks are not managed without org_webhooks", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveOrgWebhooks = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.OrgWebhooksAdded))
+		assert.Equal(t, 0, len(recorder.OrgWebhooksUpdated))
+		assert.Equal(t, 0, len(recorder.OrgWebhooksDeleted))
+	})
+
+	t.Run("not happy path: the secret environment variable is not set", func(t *testing.T) {
+		t.Setenv("TEST_CI_WEBHOOK_SECRET", "")
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := newRepoConf()
+		repoconf.DestructiveOperations.AllowDestructiveOrgWebhooks = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the webhook is neither updated nor deleted
+		_, ok := recorder.OrgWebhooksUpdated["https://ci.example.com/hook"]
+		assert.False(t, ok)
+		assert.Equal(t, []int{3}, recorder.OrgWebhooksDeleted)
+	})
+
+	t.Run("not happy path: the org webhooks couldn't be loaded", func(t *testing.T) {
+		t.Setenv("TEST_CI_WEBHOOK_SECRET", "s3cr3t")
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := newRepoConf()
+		repoconf.DestructiveOperations.AllowDestructiveOrgWebhooks = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		remote := newRemote()
+		remote.webhooks = nil
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the webhooks are not added again
+		assert.Equal(t, 0, len(recorder.OrgWebhooksAdded))
+		assert.Equal(t, 0, len(recorder.OrgWebhooksUpdated))
+		assert.Equal(t, 0, len(recorder.OrgWebhooksDeleted))
+	})
+}
+
+func TestReconciliationOrgSecurityManagerTeams(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:       make(map[string]string),
+			teams:       make(map[string]*GithubTeam),
+			repos:       make(map[string]*GithubRepository),
+			teamsrepos:  make(map[string]map[string]*GithubTeamRepo),
+			rulesets:    make(map[string]*GithubRuleSet),
+			appids:      make(map[string]int),
+			secmanagers: map[string]bool{"appsec": true, "old-team": true},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+	for _, teamname := range []string{"appsec", "Security Team"} {
+		team := &entity.Team{}
+		team.Name = teamname
+		local.teams[teamname] = team
+	}
+
+	t.Run("happy path: the security manager teams are reconciliated", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.SecurityManagerTeams = []string{"appsec", "Security Team", "unknown"}
+		repoconf.DestructiveOperations.AllowDestructiveSecurityManagers = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, []string{"security-team"}, recorder.SecurityManagerTeamsAdded)
+		assert.Equal(t, []string{"old-team"}, recorder.SecurityManagerTeamsRemoved)
+	})
+
+	t.Run("happy path: the security manager teams are not removed without the destructive flag", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.SecurityManagerTeams = []string{}
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.SecurityManagerTeamsAdded))
+		assert.Equal(t, 0, len(recorder.SecurityManagerTeamsRemoved))
+	})
+
+	t.Run("happy path: the security manager teams are not managed without security_manager_teams", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveSecurityManagers = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.SecurityManagerTeamsAdded))
+		assert.Equal(t, 0, len(recorder.SecurityManagerTeamsRemoved))
+	})
+}
+
+func TestReconciliationTeam(t *testing.T) {
+	t.Run("happy path: only the team and its repositories access are synced", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, username := range []string{"owner", "member"} {
+			user := entity.User{}
+			user.Name = username
+			user.Spec.GithubID = username + "_githubid"
+			local.users[username] = &user
+		}
+		for _, teamname := range []string{"team1", "team2"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			team.Spec.Owners = []string{"owner"}
+			team.Spec.Members = []string{"member"}
+			local.teams[teamname] = team
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{"team1", "team2"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		for _, teamslug := range []string{"team1", "team2"} {
+			// the members are missing on both teams
+			remote.teams[teamslug] = &GithubTeam{
+				Name:    teamslug,
+				Slug:    teamslug,
+				Members: []string{"owner_githubid"},
+			}
+			remote.teams[teamslug+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+				Name:    teamslug + config.Config.GoliacTeamOwnerSuffix,
+				Slug:    teamslug + config.Config.GoliacTeamOwnerSuffix,
+				Members: []string{"owner_githubid"},
+			}
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		err := r.ReconciliateTeam(context.TODO(), &local, &remote, "teams", false, "team1")
+		assert.Nil(t, err)
+
+		// team2 is left untouched
+		assert.Equal(t, map[string][]string{"team1": {"member_githubid"}}, recorder.TeamMemberAdded)
+		assert.Equal(t, []string{"team1"}, recorder.RepositoryTeamAdded["myrepo"])
+		assert.Equal(t, []string{"team1" + config.Config.GoliacTeamOwnerSuffix}, recorder.RepositoryTeamAdded["teams"])
+		assert.Equal(t, 0, len(recorder.TeamsCreated))
+		assert.Equal(t, 0, len(recorder.TeamDeleted))
+	})
+}
+
+func TestReconciliationOutsideCollaborators(t *testing.T) {
+	newLocalRemote := func() (GoliacLocalMock, GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users:     make(map[string]*entity.User),
+			externals: make(map[string]*entity.User),
+			teams:     make(map[string]*entity.Team),
+			repos:     make(map[string]*entity.Repository),
+		}
+		external := entity.User{}
+		external.Name = "external"
+		external.Spec.GithubID = "external_githubid"
+		local.externals["external"] = &external
+		// declared but not given access to any repository
+		unused := entity.User{}
+		unused.Name = "unused"
+		unused.Spec.GithubID = "unused_githubid"
+		local.externals["unused"] = &unused
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.ExternalUserReaders = []string{"external"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:       make(map[string]string),
+			teams:       make(map[string]*GithubTeam),
+			repos:       make(map[string]*GithubRepository),
+			teamsrepos:  make(map[string]map[string]*GithubTeamRepo),
+			rulesets:    make(map[string]*GithubRuleSet),
+			appids:      make(map[string]int),
+			outsidecoll: map[string]bool{"external_githubid": true, "unused_githubid": true, "rogue_githubid": true},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{"external_githubid": "READ"},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["other"] = &GithubRepository{
+			Name:           "other",
+			ExternalUsers:  map[string]string{"rogue_githubid": "WRITE", "unused_githubid": "READ"},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return local, remote
+	}
+
+	t.Run("happy path: undeclared outside collaborators are reported", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newLocalRemote()
+		toArchive := make(map[string]*GithubRepoComparable)
+		unmanaged, err := r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+		assert.Nil(t, err)
+
+		assert.Equal(t, map[string]bool{"rogue_githubid": true, "unused_githubid": true}, unmanaged.OutsideCollaborators)
+		assert.Equal(t, 0, len(recorder.OutsideCollaboratorsRemoved))
+	})
+
+	t.Run("happy path: undeclared outside collaborators are removed", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveOutsideCollaborators = true
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local, remote := newLocalRemote()
+		toArchive := make(map[string]*GithubRepoComparable)
+		unmanaged, err := r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+		assert.Nil(t, err)
+
+		assert.Equal(t, map[string]bool{"rogue_githubid": true, "unused_githubid": true}, unmanaged.OutsideCollaborators)
+		assert.Equal(t, map[string]bool{"rogue_githubid": true, "unused_githubid": true}, recorder.OutsideCollaboratorsRemoved)
+		// the remote cache is not modified
+		assert.Equal(t, 2, len(remote.repos["other"].ExternalUsers))
+	})
+}
+
+func TestReconciliationTeamReviewAssignment(t *testing.T) {
+	newLocal := func() GoliacLocalMock {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		owner := entity.User{}
+		owner.Name = "owner"
+		owner.Spec.GithubID = "owner_githubid"
+		local.users["owner"] = &owner
+
+		for _, teamname := range []string{"assigned", "unmanaged", "newteam"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			team.Spec.Owners = []string{"owner"}
+			local.teams[teamname] = team
+		}
+		local.teams["assigned"].Spec.ReviewAssignment = &entity.TeamReviewAssignment{
+			Algorithm:        "load_balance",
+			TeamMembersCount: 2,
+		}
+		local.teams["newteam"].Spec.ReviewAssignment = &entity.TeamReviewAssignment{
+			Algorithm:        "round_robin",
+			TeamMembersCount: 1,
+			NotifyTeam:       true,
+		}
+		return local
+	}
+
+	newRemote := func() GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["owner_githubid"] = "MEMBER"
+		for _, teamslug := range []string{"assigned", "unmanaged"} {
+			for _, slug := range []string{teamslug, teamslug + config.Config.GoliacTeamOwnerSuffix} {
+				remote.teams[slug] = &GithubTeam{
+					Name:    slug,
+					Slug:    slug,
+					Members: []string{"owner_githubid"},
+				}
+			}
+		}
+		// set in the Github UI
+		remote.teams["unmanaged"].ReviewAssignment = &GithubTeamReviewAssignment{
+			Algorithm:        "ROUND_ROBIN",
+			TeamMembersCount: 3,
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return remote
+	}
+
+	t.Run("happy path: the review assignment is reconciled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := newLocal()
+		remote := newRemote()
+		remote.teams["assigned"].ReviewAssignment = &GithubTeamReviewAssignment{
+			Algorithm:        "ROUND_ROBIN",
+			TeamMembersCount: 2,
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]*GithubTeamReviewAssignment{
+			"assigned": {Algorithm: "LOAD_BALANCE", TeamMembersCount: 2},
+			"newteam":  {Algorithm: "ROUND_ROBIN", TeamMembersCount: 1, NotifyTeam: true},
+		}, recorder.TeamReviewAssignmentUpdated)
+		// no membership change
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded["assigned"]))
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved["assigned"]))
+	})
+
+	t.Run("happy path: the review assignment is up to date", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := newLocal()
+		delete(local.teams, "newteam")
+		remote := newRemote()
+		remote.teams["assigned"].ReviewAssignment = &GithubTeamReviewAssignment{
+			Algorithm:        "LOAD_BALANCE",
+			TeamMembersCount: 2,
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.TeamReviewAssignmentUpdated))
+	})
+}
+
+func TestReconciliationTeamNotificationSetting(t *testing.T) {
+	newLocal := func() GoliacLocalMock {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		owner := entity.User{}
+		owner.Name = "owner"
+		owner.Spec.GithubID = "owner_githubid"
+		local.users["owner"] = &owner
+
+		for _, teamname := range []string{"quiet", "unmanaged", "newteam"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			team.Spec.Owners = []string{"owner"}
+			local.teams[teamname] = team
+		}
+		local.teams["quiet"].Spec.NotificationSetting = "notifications_disabled"
+		local.teams["newteam"].Spec.NotificationSetting = "notifications_disabled"
+		return local
+	}
+
+	newRemote := func() GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["owner_githubid"] = "MEMBER"
+		for _, teamslug := range []string{"quiet", "unmanaged"} {
+			for _, slug := range []string{teamslug, teamslug + config.Config.GoliacTeamOwnerSuffix} {
+				remote.teams[slug] = &GithubTeam{
+					Name:                slug,
+					Slug:                slug,
+					Members:             []string{"owner_githubid"},
+					NotificationSetting: "notifications_enabled",
+				}
+			}
+		}
+		// set in the Github UI
+		remote.teams["unmanaged"].NotificationSetting = "notifications_disabled"
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return remote
+	}
+
+	t.Run("happy path: the notification setting is reconciled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := newLocal()
+		remote := newRemote()
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the unmanaged team (no local notificationSetting) is left untouched
+		assert.Equal(t, map[string]string{
+			"quiet":   "notifications_disabled",
+			"newteam": "notifications_disabled",
+		}, recorder.TeamNotificationSettingUpdated)
+		// no membership change
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded["quiet"]))
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved["quiet"]))
+	})
+
+	t.Run("happy path: the notification setting is up to date", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := newLocal()
+		delete(local.teams, "newteam")
+		remote := newRemote()
+		remote.teams["quiet"].NotificationSetting = "notifications_disabled"
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.TeamNotificationSettingUpdated))
+	})
+}
+
+func TestReconciliationExternallyManagedTeam(t *testing.T) {
+	t.Run("happy path: the members of an externally managed team are not touched", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		owner := entity.User{}
+		owner.Name = "owner"
+		owner.Spec.GithubID = "owner_githubid"
+		local.users["owner"] = &owner
+
+		parentTeam := &entity.Team{}
+		parentTeam.Name = "parent"
+		parentTeam.Spec.Owners = []string{"owner"}
+		local.teams["parent"] = parentTeam
+
+		idpTeam := &entity.Team{}
+		idpTeam.Name = "idp"
+		idpTeam.Spec.ExternallyManaged = true
+		parentName := "parent"
+		idpTeam.ParentTeam = &parentName
+		local.teams["idp"] = idpTeam
+
+		newTeam := &entity.Team{}
+		newTeam.Name = "newidp"
+		newTeam.Spec.ExternallyManaged = true
+		local.teams["newidp"] = newTeam
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.Readers = []string{"idp"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["owner_githubid"] = "MEMBER"
+		remote.users["idp_githubid"] = "MEMBER"
+		for i, teamslug := range []string{"parent", "parent" + config.Config.GoliacTeamOwnerSuffix} {
+			remote.teams[teamslug] = &GithubTeam{
+				Name:    teamslug,
+				Id:      i + 1,
+				Slug:    teamslug,
+				Members: []string{"owner_githubid"},
+			}
+		}
+		// the members come from the identity provider
+		remote.teams["idp"] = &GithubTeam{
+			Name:    "idp",
+			Id:      3,
+			Slug:    "idp",
+			Members: []string{"idp_githubid"},
+		}
+		remote.teams["idp"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "idp" + config.Config.GoliacTeamOwnerSuffix,
+			Id:      4,
+			Slug:    "idp" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"idp_githubid"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		changes := config.GoliacChanges{ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the members are left untouched
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded["idp"]))
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved["idp"]))
+		assert.Equal(t, 0, len(recorder.TeamDeleted))
+		// but the team existence, parent and repositories access are managed
+		members, created := recorder.TeamsCreated["newidp"]
+		assert.True(t, created)
+		assert.Equal(t, 0, len(members))
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded["newidp"]))
+		assert.NotNil(t, recorder.TeamParentUpdated["idp"])
+		assert.Equal(t, 1, *recorder.TeamParentUpdated["idp"])
+		assert.Equal(t, []string{"idp"}, recorder.RepositoryTeamAdded["myrepo"])
+		// the changed team reports its members are not reconciled
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped && o.Command == "update_team_members" {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{"teamslug: idp, 1 members managed externally (externallyManaged)"}, skipped)
+	})
+
+	t.Run("happy path: an externally managed team in sync is not reported", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		idpTeam := &entity.Team{}
+		idpTeam.Name = "idp"
+		idpTeam.Spec.ExternallyManaged = true
+		local.teams["idp"] = idpTeam
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.users["idp_githubid"] = "MEMBER"
+		remote.teams["idp"] = &GithubTeam{
+			Name:    "idp",
+			Id:      1,
+			Slug:    "idp",
+			Members: []string{"idp_githubid"},
+		}
+		remote.teams["idp"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "idp" + config.Config.GoliacTeamOwnerSuffix,
+			Id:      2,
+			Slug:    "idp" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"idp_githubid"},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		changes := config.GoliacChanges{ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		for _, o := range changes.Operations {
+			assert.NotEqual(t, "update_team_members", o.Command)
+		}
+	})
+}
+
+func TestReconciliationChanges(t *testing.T) {
+
+	t.Run("happy path: json logs keep the command fields top-level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logrus.SetOutput(&buf)
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+		defer func() {
+			logrus.SetOutput(os.Stdout)
+			logrus.SetFormatter(&logrus.TextFormatter{})
+		}()
+
+		r := NewGoliacReconciliatorImpl(nil, &config.RepositoryConfig{}).(*GoliacReconciliatorImpl)
+		ctx := context.WithValue(context.TODO(), config.KeyAuthor, "someone@company.com")
+		r.logCommand(ctx, true, "create_team", "teamname: %s", "new")
+
+		var entry map[string]interface{}
+		err := json.Unmarshal(buf.Bytes(), &entry)
+		assert.Nil(t, err)
+		assert.Equal(t, "create_team", entry["command"])
+		assert.Equal(t, "someone@company.com", entry["author"])
+		assert.Equal(t, true, entry["dryrun"])
+		assert.Equal(t, "teamname: new", entry["msg"])
+	})
+
+	t.Run("happy path: operations are collected in the context", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		newTeam := &entity.Team{}
+		newTeam.Name = "new"
+		newTeam.Spec.Owners = []string{"new_owner"}
+		newTeam.Spec.Members = []string{"new_member"}
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: map[string]*entity.Team{"new": newTeam},
+			repos: make(map[string]*entity.Repository),
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		ctx = context.WithValue(ctx, config.KeyAuthor, "someone@company.com")
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, "someone@company.com", changes.Author)
+		found := false
+		for _, o := range changes.Operations {
+			if o.Command == "create_team" && strings.HasPrefix(o.Detail, "teamname: new,") {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestReconciliationDryrunOperations(t *testing.T) {
+	t.Run("happy path: the repositories operations are only simulated", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+
+		repoconf := config.RepositoryConfig{}
+		repoconf.DryrunOperations.DryrunRepositories = true
+
+		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+
+		owner := entity.User{}
+		owner.Name = "owner"
+		owner.Spec.GithubID = "owner_githubid"
+
+		newTeam := &entity.Team{}
+		newTeam.Name = "new"
+		newTeam.Spec.Owners = []string{"owner"}
+
+		newRepo := &entity.Repository{}
+		newRepo.Name = "newrepo"
+		lowner := "new"
+		newRepo.Owner = &lowner
+
+		local := GoliacLocalMock{
+			users: map[string]*entity.User{"owner": &owner},
+			teams: map[string]*entity.Team{"new": newTeam},
+			repos: map[string]*entity.Repository{"newrepo": newRepo},
+		}
+
+		remote := GoliacRemoteMock{
+			users:      map[string]string{"owner_githubid": "MEMBER"},
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the team is created for real, the repository only in dryrun
+		assert.Equal(t, false, recorder.Dryruns["new"])
+		assert.Equal(t, true, recorder.Dryruns["newrepo"])
+
+		simulated := make(map[string]bool)
+		for _, o := range changes.Operations {
+			simulated[o.Command] = o.Simulated
+		}
+		assert.Equal(t, false, simulated["create_team"])
+		assert.Equal(t, true, simulated["create_repository"])
+	})
+}
+
+func TestReconciliationCodeowners(t *testing.T) {
+	t.Run("happy path: CODEOWNERS files written only when they differ", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		owner := "team1"
+		for _, reponame := range []string{"missing", "uptodate", "stale", "unmanaged", "archived"} {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			lRepo.Owner = &owner
+			lRepo.Spec.ManageCodeowners = reponame != "unmanaged"
+			lRepo.Archived = reponame == "archived"
+			local.repos[reponame] = lRepo
+		}
+
+		expected := codeownersContent(config.Config.GithubAppOrganization, "team1")
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			files: map[string]*GithubFile{
+				"uptodate:.github/CODEOWNERS":  {Content: expected, Sha: "sha1"},
+				"stale:.github/CODEOWNERS":     {Content: "* @someone\n", Sha: "sha2"},
+				"unmanaged:.github/CODEOWNERS": {Content: "* @someone\n", Sha: "sha3"},
+			},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		_, err := r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+		assert.Nil(t, err)
+
+		assert.Equal(t, map[string]string{
+			"missing:.github/CODEOWNERS": expected,
+			"stale:.github/CODEOWNERS":   expected,
+		}, recorder.RepositoryFilesUpdated)
+		assert.Contains(t, expected, "/team1\n")
+	})
+}
+
+func TestReconciliationGithubLoginsCase(t *testing.T) {
+	t.Run("happy path: Github logins are compared case insensitively", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local := GoliacLocalMock{
+			users:     make(map[string]*entity.User),
+			externals: make(map[string]*entity.User),
+			teams:     make(map[string]*entity.Team),
+			repos:     make(map[string]*entity.Repository),
+		}
+		john := entity.User{}
+		john.Name = "john"
+		john.Spec.GithubID = "JohnDoe"
+		local.users["john"] = &john
+
+		partner := entity.User{}
+		partner.Name = "partner"
+		partner.Spec.GithubID = "Partner"
+		local.externals["partner"] = &partner
+
+		team1 := entity.Team{}
+		team1.Name = "team1"
+		team1.Spec.Owners = []string{"john"}
+		local.teams["team1"] = &team1
+
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Spec.ExternalUserReaders = []string{"partner"}
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:       map[string]string{"johndoe": "MEMBER"},
+			teams:       make(map[string]*GithubTeam),
+			repos:       make(map[string]*GithubRepository),
+			teamsrepos:  make(map[string]map[string]*GithubTeamRepo),
+			rulesets:    make(map[string]*GithubRuleSet),
+			appids:      make(map[string]int),
+			outsidecoll: map[string]bool{"partner": true},
+		}
+		remote.teams["team1"] = &GithubTeam{
+			Name:    "team1",
+			Slug:    "team1",
+			Id:      1,
+			Members: []string{"johndoe"},
+		}
+		remote.teams["team1"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Id:      2,
+			Members: []string{"johndoe"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:           "myrepo",
+			ExternalUsers:  map[string]string{"partner": "READ"},
+			BoolProperties: map[string]bool{},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		unmanaged, err := r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+		assert.Nil(t, err)
+
+		assert.Equal(t, 0, len(recorder.UsersCreated))
+		assert.Equal(t, 0, len(recorder.UsersRemoved))
+		assert.Equal(t, 0, len(recorder.TeamMemberAdded))
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved))
+		assert.Equal(t, 0, len(recorder.RepositoriesSetExternalUser))
+		assert.Equal(t, 0, len(recorder.RepositoriesRemoveExternalUser))
+		assert.Equal(t, 0, len(unmanaged.OutsideCollaborators))
+	})
+}
+
+func TestReconciliationArchivedRepositoryAccesses(t *testing.T) {
+	newLocalRemote := func(archivedRemote bool, remoteAccess bool) (*GoliacLocalMock, *GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		team1 := entity.Team{}
+		team1.Name = "team1"
+		team1.Spec.Owners = []string{"owner1"}
+		local.teams["team1"] = &team1
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.teams["team1"] = &GithubTeam{
+			Name:    "team1",
+			Slug:    "team1",
+			Members: []string{"owner1"},
+		}
+		remote.teams["team1"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{"owner1"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name:          "myrepo",
+			ExternalUsers: map[string]string{},
+			BoolProperties: map[string]bool{
+				"archived": archivedRemote,
+			},
+		}
+		remote.teamsrepos["team1"] = make(map[string]*GithubTeamRepo)
+		if remoteAccess {
+			remote.teamsrepos["team1"]["myrepo"] = &GithubTeamRepo{
+				Name:       "myrepo",
+				Permission: "WRITE",
+			}
+		}
+		return &local, &remote
+	}
+
+	t.Run("happy path: the accesses of an archived repository are left untouched", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newLocalRemote(true, true)
+		// the archived repository has no owner anymore
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lRepo.Archived = true
+		local.repos["myrepo"] = lRepo
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved))
+		assert.Equal(t, 0, len(recorder.RepositoryTeamAdded))
+		_, unarchived := recorder.RepositoriesUpdateBoolProperty["myrepo"]["archived"]
+		assert.False(t, unarchived)
+	})
+
+	t.Run("happy path: the accesses of an unarchived repository are reconciled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newLocalRemote(true, false)
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		lowner := "team1"
+		lRepo.Owner = &lowner
+		local.repos["myrepo"] = lRepo
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, false, recorder.RepositoriesUpdateBoolProperty["myrepo"]["archived"])
+		assert.Equal(t, []string{"team1"}, recorder.RepositoryTeamAdded["myrepo"])
+	})
+}
+
+func TestReconciliationLabels(t *testing.T) {
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			labels: map[string]map[string]*GithubLabel{
+				"service-a": {
+					"bug":     {Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+					"feature": {Name: "Feature", Color: "a2eeef", Description: "old description"},
+					"wontfix": {Name: "wontfix", Color: "ffffff", Description: ""},
+				},
+			},
+		}
+		for _, reponame := range []string{"teams", "service-a", "library"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+	serviceA := &entity.Repository{}
+	serviceA.Name = "service-a"
+	serviceA.Spec.Labels = []config.Label{
+		{Name: "feature", Color: "#A2EEEF", Description: "New feature"},
+	}
+	local.repos["service-a"] = serviceA
+	library := &entity.Repository{}
+	library.Name = "library"
+	local.repos["library"] = library
+
+	newRepoConfig := func() *config.RepositoryConfig {
+		repoconf := config.RepositoryConfig{}
+		repoconf.Labels = append(repoconf.Labels, struct {
+			Pattern string
+			Labels  []config.Label
+		}{
+			Pattern: "^service-",
+			Labels: []config.Label{
+				{Name: "bug", Color: "#D73A4A", Description: "Something isn't working"},
+				{Name: "feature", Color: "a2eeef"},
+				{Name: "security", Color: "ee0701", Description: "Security issue"},
+			},
+		})
+		return &repoconf
+	}
+
+	t.Run("happy path: the labels are reconciled, colors compared case insensitively", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, newRepoConfig())
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// bug is untouched ("#D73A4A" is "d73a4a")
+		assert.Equal(t, map[string]map[string]*GithubLabel{
+			"service-a": {
+				"security": {Name: "security", Color: "ee0701", Description: "Security issue"},
+			},
+		}, recorder.RepositoryLabelsAdded)
+		// the repository label overrides the description (and the case) of the shared one
+		assert.Equal(t, map[string]map[string]*GithubLabel{
+			"service-a": {
+				"Feature": {Name: "feature", Color: "a2eeef", Description: "New feature"},
+			},
+		}, recorder.RepositoryLabelsUpdated)
+		// extra labels are kept without destructive_operations.labels
+		assert.Equal(t, 0, len(recorder.RepositoryLabelsDeleted))
+	})
+
+	t.Run("happy path: the extra labels are deleted with destructive_operations.labels", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := newRepoConfig()
+		repoconf.DestructiveOperations.AllowDestructiveLabels = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string][]string{
+			"service-a": {"wontfix"},
+		}, recorder.RepositoryLabelsDeleted)
+	})
+}
+
+func TestDiffRulesets(t *testing.T) {
+	t.Run("happy path: same rulesets", func(t *testing.T) {
+		rs := &GithubRuleSet{
+			Name:        "rs",
 			Enforcement: "active",
+			BypassApps:  map[string]string{"app": "always"},
 			OnInclude:   []string{"~DEFAULT_BRANCH"},
+			Rules:       map[string]entity.RuleSetParameters{"creation": {}},
+		}
+		assert.Equal(t, []string{}, diffRulesets(rs, rs))
+		assert.True(t, compareRulesets("rs", rs, rs))
+	})
+
+	t.Run("happy path: field-level differences", func(t *testing.T) {
+		lrs := &GithubRuleSet{
+			Name:        "rs",
+			Enforcement: "active",
+			BypassApps:  map[string]string{"app1": "pull_request", "app3": "always"},
+			BypassTeams: map[string]string{"owner": "always"},
+			OnInclude:   []string{"~DEFAULT_BRANCH", "release"},
+			OnExclude:   []string{},
 			Rules: map[string]entity.RuleSetParameters{
+				"pull_request":        {RequiredApprovingReviewCount: 2},
 				"required_signatures": {},
 			},
 		}
-		myrepo.RuleSets["myruleset"] = &rruleset
+		rrs := &GithubRuleSet{
+			Name:        "rs",
+			Enforcement: "evaluate",
+			BypassApps:  map[string]string{"app1": "always", "app2": "always"},
+			BypassTeams: map[string]string{"former-owner": "always"},
+			OnInclude:   []string{"~DEFAULT_BRANCH"},
+			OnExclude:   []string{"legacy"},
+			Rules: map[string]entity.RuleSetParameters{
+				"pull_request": {RequiredApprovingReviewCount: 1},
+				"deletion":     {},
+			},
+		}
+		assert.Equal(t, []string{
+			"enforcement: evaluate -> active",
+			"bypass app app1: always -> pull_request",
+			"bypass app app2 removed",
+			"bypass app app3 added (always)",
+			"bypass team former-owner removed",
+			"bypass team owner added (always)",
+			"include added: release",
+			"exclude removed: legacy",
+			"rule deletion removed",
+			"rule pull_request: requiredApprovingReviewCount: 1 -> 2",
+			"rule required_signatures added",
+		}, diffRulesets(lrs, rrs))
+		assert.False(t, compareRulesets("rs", lrs, rrs))
+	})
+}
 
-		remote.repos["myrepo"] = myrepo
+func TestReconciliationExcludedTeamsAndRepositories(t *testing.T) {
+	t.Run("happy path: excluded teams and repositories are left untouched", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := &config.RepositoryConfig{
+			ExcludedTeams:        []string{"legacy-*"},
+			ExcludedRepositories: []string{"Legacy-*"},
+		}
+		repoconf.DestructiveOperations.AllowDestructiveTeams = true
+		repoconf.DestructiveOperations.AllowDestructiveRepositories = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
+		local.repos["myrepo"] = lRepo
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		for _, teamslug := range []string{"legacy-ops", "removing"} {
+			remote.teams[teamslug] = &GithubTeam{
+				Name:    teamslug,
+				Slug:    teamslug,
+				Members: []string{},
+			}
+		}
+		for _, reponame := range []string{"teams", "myrepo", "legacy-app", "removing-app"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{},
+			}
+		}
+		// the access of the excluded team is kept
+		remote.teamsrepos["legacy-ops"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "WRITE"},
+		}
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string]bool{"removing": true}, recorder.TeamDeleted)
+		assert.Equal(t, map[string]bool{"removing-app": true}, recorder.RepositoriesDeleted)
+		assert.Equal(t, 0, len(recorder.RepositoryTeamRemoved))
+	})
+}
+
+func TestReconciliationDependabot(t *testing.T) {
+	enabled := true
+	disabled := false
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			dependabot: map[string]*GithubRepositoryDependabot{
+				"enable":    {Alerts: false, SecurityUpdates: false},
+				"uptodate":  {Alerts: true, SecurityUpdates: true},
+				"disable":   {Alerts: true, SecurityUpdates: true},
+				"unmanaged": {Alerts: false, SecurityUpdates: false},
+				"archived":  {Alerts: false, SecurityUpdates: false},
+			},
+		}
+		for _, reponame := range []string{"teams", "enable", "uptodate", "disable", "unmanaged", "archived"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{"archived": reponame == "archived"},
+			}
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+	for _, reponame := range []string{"enable", "uptodate", "disable", "unmanaged", "archived"} {
+		lRepo := &entity.Repository{}
+		lRepo.Name = reponame
+		local.repos[reponame] = lRepo
+	}
+	local.repos["enable"].Spec.DependabotSecurityUpdates = &enabled
+	local.repos["uptodate"].Spec.DependabotAlerts = &enabled
+	local.repos["uptodate"].Spec.DependabotSecurityUpdates = &enabled
+	local.repos["disable"].Spec.DependabotAlerts = &disabled
+	local.repos["archived"].Archived = true
+	local.repos["archived"].Spec.DependabotAlerts = &enabled
+
+	t.Run("happy path: the dependabot settings are reconciled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// the security updates need the alerts
+		assert.Equal(t, map[string]bool{"enable": true, "disable": false}, recorder.RepositoriesDependabotAlerts)
+		assert.Equal(t, map[string]bool{"enable": true, "disable": false}, recorder.RepositoriesDependabotSecurityUpdates)
+
+		// the plan shows the current and desired values, in the order they are applied
+		details := []string{}
+		for _, o := range changes.Operations {
+			if strings.HasPrefix(o.Command, "update_repository_dependabot") {
+				details = append(details, o.Detail)
+			}
+		}
+		assert.Equal(t, []string{
+			"repositoryname: disable dependabot_security_updates: true -> false",
+			"repositoryname: disable dependabot_alerts: true -> false",
+			"repositoryname: enable dependabot_alerts: false -> true",
+			"repositoryname: enable dependabot_security_updates: false -> true",
+		}, details)
+	})
+}
+
+func TestReconciliationImmutableRepositories(t *testing.T) {
+	t.Run("happy path: immutable repositories are left untouched", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := &config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveRepositories = true
+		repoconf.Rulesets = append(repoconf.Rulesets, struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			Pattern: ".*",
+			Ruleset: "default",
+		})
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		local := GoliacLocalMock{
+			users:    make(map[string]*entity.User),
+			teams:    make(map[string]*entity.Team),
+			repos:    make(map[string]*entity.Repository),
+			rulesets: make(map[string]*entity.RuleSet),
+		}
+		team := &entity.Team{}
+		team.Name = "team1"
+		local.teams["team1"] = team
+
+		ruleset := &entity.RuleSet{}
+		ruleset.Name = "default"
+		ruleset.Spec.Enforcement = "evaluate"
+		local.rulesets["default"] = ruleset
+
+		for _, reponame := range []string{"myrepo", "Vendored", "mirror"} {
+			lRepo := &entity.Repository{}
+			lRepo.Name = reponame
+			lRepo.Spec.IsPublic = true
+			lRepo.Spec.Writers = []string{"team1"}
+			lRepo.Immutable = reponame != "myrepo"
+			local.repos[reponame] = lRepo
+		}
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		remote.teams["team1"] = &GithubTeam{
+			Name:    "team1",
+			Slug:    "team1",
+			Members: []string{},
+		}
+		remote.teams["team1"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:    "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Members: []string{},
+		}
+		for _, reponame := range []string{"teams", "myrepo", "vendored"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{"private": true},
+			}
+		}
+		// a (Goliac unknown) access on the immutable repository is kept
+		remote.teamsrepos["team1"] = map[string]*GithubTeamRepo{
+			"vendored": {Name: "vendored", Permission: "ADMIN"},
+		}
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
+		// myrepo is reconciled, not the immutable repositories
 		assert.Equal(t, 0, len(recorder.RepositoryCreated))
-		assert.Equal(t, 0, len(recorder.RepositoryRuleSetCreated["myrepo"]))
-		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
-		assert.Equal(t, 0, len(recorder.RepositoryRuleSetDeleted["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoriesDeleted))
+		assert.Equal(t, 0, len(toArchive))
+		assert.Contains(t, recorder.RepositoriesUpdatePrivate, "myrepo")
+		assert.Equal(t, []string{"team1"}, recorder.RepositoryTeamAdded["myrepo"])
+		for _, reponame := range []string{"vendored", "Vendored", "mirror"} {
+			assert.NotContains(t, recorder.RepositoriesUpdatePrivate, reponame)
+			assert.NotContains(t, recorder.RepositoryTeamAdded, reponame)
+			assert.NotContains(t, recorder.RepositoryTeamUpdated, reponame)
+			assert.NotContains(t, recorder.RepositoryTeamRemoved, reponame)
+		}
+
+		// the rulesets are not attached to the immutable repositories
+		assert.Equal(t, 1, len(recorder.RuleSetCreated))
+		assert.ElementsMatch(t, []string{"myrepo", "teams"}, recorder.RuleSetCreated["default"].Repositories)
 	})
+}
 
-	t.Run("happy path: repo with new ruleset", func(t *testing.T) {
+func TestReconciliationTeamLastOwner(t *testing.T) {
+	newMocks := func(owners []string) (GoliacLocalMock, GoliacRemoteMock) {
+		local := GoliacLocalMock{
+			users: make(map[string]*entity.User),
+			teams: make(map[string]*entity.Team),
+			repos: make(map[string]*entity.Repository),
+		}
+		for _, username := range []string{"owner1", "owner2", "member1"} {
+			user := entity.User{}
+			user.Name = username
+			user.Spec.GithubID = username + "_githubid"
+			local.users[username] = &user
+		}
+		team := &entity.Team{}
+		team.Name = "team1"
+		team.Spec.Owners = owners
+		team.Spec.Members = []string{"member1"}
+		local.teams["team1"] = team
+
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+		}
+		for _, username := range []string{"owner1", "owner2", "member1"} {
+			remote.users[username+"_githubid"] = "MEMBER"
+		}
+		remote.teams["team1"] = &GithubTeam{
+			Name:    "team1",
+			Slug:    "team1",
+			Members: []string{"owner1_githubid", "owner2_githubid", "member1_githubid"},
+		}
+		remote.teams["team1"+config.Config.GoliacTeamOwnerSuffix] = &GithubTeam{
+			Name:        "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Slug:        "team1" + config.Config.GoliacTeamOwnerSuffix,
+			Members:     []string{"owner1_githubid"},
+			Maintainers: []string{"owner2_githubid"},
+		}
+		remote.repos["teams"] = &GithubRepository{
+			Name:           "teams",
+			ExternalUsers:  map[string]string{},
+			BoolProperties: map[string]bool{},
+		}
+		return local, remote
+	}
+
+	t.Run("happy path: owners are removed while another one is kept", func(t *testing.T) {
 		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
 
-		repoconf := config.RepositoryConfig{}
+		local, remote := newMocks([]string{"owner1"})
 
-		r := NewGoliacReconciliatorImpl(recorder, &repoconf)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, []string{"owner2_githubid"}, recorder.TeamMemberRemoved["team1"+config.Config.GoliacTeamOwnerSuffix])
+		assert.Equal(t, []string{"owner2_githubid"}, recorder.TeamMemberRemoved["team1"])
+	})
+
+	t.Run("not happy path: the last owner is not removed", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		local, remote := newMocks([]string{})
+
+		changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+		ctx := context.WithValue(context.TODO(), config.ContextKeyChanges, &changes)
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(ctx, &local, &remote, "teams", true, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		// owner1 (the first one) is kept, as an owner and as a member of the team
+		assert.Equal(t, []string{"owner2_githubid"}, recorder.TeamMemberRemoved["team1"+config.Config.GoliacTeamOwnerSuffix])
+		assert.Equal(t, []string{"owner2_githubid"}, recorder.TeamMemberRemoved["team1"])
+
+		// the refused removals are reported in the plan
+		skipped := []string{}
+		for _, o := range changes.Operations {
+			if o.Skipped {
+				skipped = append(skipped, o.Detail)
+			}
+		}
+		sort.Strings(skipped)
+		assert.Equal(t, []string{
+			"teamslug: team1 ghuserid: owner1_githubid, refusing to remove the last owner of the team (a team must keep at least one owner)",
+			"teamslug: team1" + config.Config.GoliacTeamOwnerSuffix + " ghuserid: owner1_githubid, refusing to remove the last owner of the team (a team must keep at least one owner)",
+		}, skipped)
+	})
+
+	t.Run("happy path: the owners of a deleted team are not kept", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		repoconf := &config.RepositoryConfig{}
+		repoconf.DestructiveOperations.AllowDestructiveTeams = true
+		r := NewGoliacReconciliatorImpl(recorder, repoconf)
+
+		local, remote := newMocks([]string{})
+		delete(local.teams, "team1")
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.TeamMemberRemoved))
+		assert.True(t, recorder.TeamDeleted["team1"])
+		assert.True(t, recorder.TeamDeleted["team1"+config.Config.GoliacTeamOwnerSuffix])
+	})
+}
+
+func TestReconciliationProjects(t *testing.T) {
+	roadmap := &GithubProject{Id: "PVT_1", Number: 1, Title: "Roadmap"}
+	bugs := &GithubProject{Id: "PVT_2", Number: 2, Title: "Bugs"}
+	legacy := &GithubProject{Id: "PVT_3", Number: 3, Title: "Legacy"}
+
+	newRemote := func() *GoliacRemoteMock {
+		remote := GoliacRemoteMock{
+			users:      make(map[string]string),
+			teams:      make(map[string]*GithubTeam),
+			repos:      make(map[string]*GithubRepository),
+			teamsrepos: make(map[string]map[string]*GithubTeamRepo),
+			rulesets:   make(map[string]*GithubRuleSet),
+			appids:     make(map[string]int),
+			projects: map[string]*GithubProject{
+				"Roadmap": roadmap,
+				"Bugs":    bugs,
+				"Legacy":  legacy,
+			},
+			repoproj: map[string]map[string]*GithubProject{
+				"link":      {"Roadmap": roadmap},
+				"unlinkall": {"Legacy": legacy},
+				"unmanaged": {"Legacy": legacy},
+				"archived":  {},
+			},
+		}
+		for _, reponame := range []string{"teams", "link", "unlinkall", "unmanaged", "archived"} {
+			remote.repos[reponame] = &GithubRepository{
+				Name:           reponame,
+				ExternalUsers:  map[string]string{},
+				BoolProperties: map[string]bool{"archived": reponame == "archived"},
+			}
+		}
+		return &remote
+	}
+	local := GoliacLocalMock{
+		users: make(map[string]*entity.User),
+		teams: make(map[string]*entity.Team),
+		repos: make(map[string]*entity.Repository),
+	}
+	for _, reponame := range []string{"link", "unlinkall", "unmanaged", "archived"} {
+		lRepo := &entity.Repository{}
+		lRepo.Name = reponame
+		local.repos[reponame] = lRepo
+	}
+	local.repos["link"].Spec.Projects = []string{"Roadmap", "Bugs"}
+	local.repos["unlinkall"].Spec.Projects = []string{}
+	local.repos["archived"].Archived = true
+	local.repos["archived"].Spec.Projects = []string{"Roadmap"}
+
+	t.Run("happy path: the projects links are reconciled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
 
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, newRemote(), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, map[string][]string{"link": {"Bugs"}}, recorder.RepositoriesProjectsLinked)
+		assert.Equal(t, map[string][]string{"unlinkall": {"Legacy"}}, recorder.RepositoriesProjectsUnlinked)
+	})
+
+	t.Run("happy path: an unknown project is skipped", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		remote := newRemote()
+		delete(remote.projects, "Bugs")
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), &local, remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 0, len(recorder.RepositoriesProjectsLinked))
+	})
+}
+
+func TestReconciliationRulesetsOwnerBypass(t *testing.T) {
+	newLocal := func(ownerBypass *bool) *GoliacLocalMock {
 		local := GoliacLocalMock{
 			users: make(map[string]*entity.User),
 			teams: make(map[string]*entity.Team),
 			repos: make(map[string]*entity.Repository),
 		}
-		newRepo := &entity.Repository{}
-		newRepo.Name = "myrepo"
-		newRepo.Spec.Readers = []string{}
-		newRepo.Spec.Writers = []string{}
+		lRepo := &entity.Repository{}
+		lRepo.Name = "myrepo"
 		owner := "existing"
-		newRepo.Owner = &owner
+		lRepo.Owner = &owner
 
 		lruleset := entity.RepositoryRuleSet{
-			Name: "myruleset",
+			Name:        "myruleset",
+			OwnerBypass: ownerBypass,
 		}
 		lruleset.Enforcement = "active"
 		lruleset.Conditions.Include = []string{"~DEFAULT_BRANCH"}
@@ -2267,15 +7646,16 @@ func TestReconciliationRulesets(t *testing.T) {
 		}{
 			"required_signatures", entity.RuleSetParameters{},
 		})
-		newRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
-		local.repos["myrepo"] = newRepo
+		lRepo.Spec.Rulesets = []entity.RepositoryRuleSet{lruleset}
+		local.repos["myrepo"] = lRepo
 
 		existingTeam := &entity.Team{}
 		existingTeam.Name = "existing"
 		existingTeam.Spec.Owners = []string{"existing_owner"}
-		existingTeam.Spec.Members = []string{"existing_member"}
 		local.teams["existing"] = existingTeam
-
+		return &local
+	}
+	newRemote := func(bypassTeams map[string]string) *GoliacRemoteMock {
 		remote := GoliacRemoteMock{
 			users:      make(map[string]string),
 			teams:      make(map[string]*GithubTeam),
@@ -2289,37 +7669,67 @@ func TestReconciliationRulesets(t *testing.T) {
 			ExternalUsers:  map[string]string{},
 			BoolProperties: map[string]bool{},
 		}
-		existing := &GithubTeam{
+		remote.teams["existing"] = &GithubTeam{
 			Name:    "existing",
 			Slug:    "existing",
-			Members: []string{"existing_owner", "existing_member"},
+			Members: []string{"existing_owner"},
 		}
-		remote.teams["existing"] = existing
-
-		myrepo := &GithubRepository{
-			Name:  "myrepo",
-			Id:    1234,
-			RefId: "sdfsf",
+		remote.teamsrepos["existing"] = map[string]*GithubTeamRepo{
+			"myrepo": {Name: "myrepo", Permission: "WRITE"},
+		}
+		remote.repos["myrepo"] = &GithubRepository{
+			Name: "myrepo",
 			BoolProperties: map[string]bool{
-				"private":                true,
-				"allow_update_branch":    false,
-				"archived":               false,
-				"allow_auto_merge":       false,
-				"delete_branch_on_merge": false,
+				"private": true,
+			},
+			ExternalUsers: map[string]string{},
+			InternalUsers: map[string]string{},
+			RuleSets: map[string]*GithubRuleSet{
+				"myruleset": {
+					Name:        "myruleset",
+					Enforcement: "active",
+					BypassApps:  map[string]string{},
+					BypassTeams: bypassTeams,
+					OnInclude:   []string{"~DEFAULT_BRANCH"},
+					Rules: map[string]entity.RuleSetParameters{
+						"required_signatures": {},
+					},
+				},
 			},
-			ExternalUsers: make(map[string]string),
-			InternalUsers: make(map[string]string),
-			RuleSets:      map[string]*GithubRuleSet{},
 		}
+		return &remote
+	}
 
-		remote.repos["myrepo"] = myrepo
+	t.Run("happy path: the owner team bypass is added", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
 
 		toArchive := make(map[string]*GithubRepoComparable)
-		r.Reconciliate(context.TODO(), &local, &remote, "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{})
+		r.Reconciliate(context.TODO(), newLocal(nil), newRemote(map[string]string{}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 1, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
+		assert.Equal(t, map[string]string{"existing": "always"}, recorder.RepositoryRuleSetUpdated["myrepo"]["myruleset"].BypassTeams)
+	})
+
+	t.Run("happy path: the owner team bypass doesn't drift", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), newLocal(nil), newRemote(map[string]string{"existing": "always"}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
 
-		assert.Equal(t, 0, len(recorder.RepositoryCreated))
-		assert.Equal(t, 1, len(recorder.RepositoryRuleSetCreated["myrepo"]))
 		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
-		assert.Equal(t, 0, len(recorder.RepositoryRuleSetDeleted["myrepo"]))
+	})
+
+	t.Run("happy path: the owner team bypass is explicitly disabled", func(t *testing.T) {
+		recorder := NewReconciliatorListenerRecorder()
+		r := NewGoliacReconciliatorImpl(recorder, &config.RepositoryConfig{})
+
+		disabled := false
+		toArchive := make(map[string]*GithubRepoComparable)
+		r.Reconciliate(context.TODO(), newLocal(&disabled), newRemote(map[string]string{"existing": "always"}), "teams", false, "goliac-admin", toArchive, map[string]*entity.Repository{}, map[string]bool{})
+
+		assert.Equal(t, 1, len(recorder.RepositoryRuleSetUpdated["myrepo"]))
+		assert.Equal(t, 0, len(recorder.RepositoryRuleSetUpdated["myrepo"]["myruleset"].BypassTeams))
 	})
 }
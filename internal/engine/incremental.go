@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"path"
+	"strings"
+)
+
+/*
+ReconciliationScope restricts the reconciliation of the teams and of the
+repositories to the ones affected by a change of the teams repository
+(the organization-wide settings, users and rulesets are still fully reconciliated).
+A nil scope means everything is reconciliated
+*/
+type ReconciliationScope struct {
+	Teams        map[string]bool // team names
+	Repositories map[string]bool // repository names
+}
+
+/*
+NewReconciliationScope maps the files changed in the teams repository to the
+teams and repositories they define:
+  - teams/<...>/<team>/team.yaml defines the team <team>
+  - teams/<...>/<repo>.yaml and archived/<repo>.yaml define the repository <repo>
+
+Any other change (users, rulesets, goliac.yaml, ...) can affect any team or
+repository: it returns nil, i.e. a full reconciliation. A team moved to another
+parent team also returns nil (the accesses inherited from the parent teams change).
+So the scope can be larger than needed, but never smaller.
+*/
+func NewReconciliationScope(changedFiles []string) *ReconciliationScope {
+	scope := &ReconciliationScope{
+		Teams:        make(map[string]bool),
+		Repositories: make(map[string]bool),
+	}
+	// team name -> directory of its team.yaml
+	teamsDirectories := make(map[string]string)
+
+	for _, file := range changedFiles {
+		file = path.Clean(strings.TrimPrefix(file, "/"))
+		dir, filename := path.Split(file)
+		dir = strings.TrimSuffix(dir, "/")
+
+		switch {
+		// the .github directory (CODEOWNERS, workflows) is not read by Goliac
+		case strings.HasPrefix(file, ".github/"):
+			continue
+		case path.Ext(filename) != ".yaml":
+			return nil
+		case dir == "archived":
+			scope.Repositories[strings.TrimSuffix(filename, ".yaml")] = true
+		case strings.HasPrefix(dir, "teams/") && filename == "team.yaml":
+			teamname := path.Base(dir)
+			if previous, ok := teamsDirectories[teamname]; ok && previous != dir {
+				return nil
+			}
+			teamsDirectories[teamname] = dir
+			scope.Teams[teamname] = true
+		case strings.HasPrefix(dir, "teams/"):
+			scope.Repositories[strings.TrimSuffix(filename, ".yaml")] = true
+		default:
+			return nil
+		}
+	}
+	return scope
+}
+
+// HasTeam returns true if the team (name) is part of the scope (always true for a nil scope)
+func (s *ReconciliationScope) HasTeam(teamname string) bool {
+	return s == nil || s.Teams[teamname]
+}
+
+// HasRepository returns true if the repository is part of the scope (always true for a nil scope)
+func (s *ReconciliationScope) HasRepository(reponame string) bool {
+	return s == nil || s.Repositories[reponame]
+}
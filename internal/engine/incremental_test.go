@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReconciliationScope(t *testing.T) {
+	t.Run("happy path: teams and repositories changes", func(t *testing.T) {
+		scope := NewReconciliationScope([]string{
+			"teams/parent/team.yaml",
+			"teams/parent/child/team.yaml",
+			"teams/parent/child/repo1.yaml",
+			"archived/repo2.yaml",
+			".github/CODEOWNERS",
+		})
+		assert.NotNil(t, scope)
+		assert.Equal(t, map[string]bool{"parent": true, "child": true}, scope.Teams)
+		assert.Equal(t, map[string]bool{"repo1": true, "repo2": true}, scope.Repositories)
+		assert.True(t, scope.HasTeam("child"))
+		assert.False(t, scope.HasTeam("other"))
+		assert.True(t, scope.HasRepository("repo2"))
+		assert.False(t, scope.HasRepository("other"))
+	})
+
+	t.Run("happy path: a repository moved to another team", func(t *testing.T) {
+		scope := NewReconciliationScope([]string{
+			"teams/team1/repo1.yaml",
+			"teams/team2/repo1.yaml",
+		})
+		assert.NotNil(t, scope)
+		assert.Equal(t, 0, len(scope.Teams))
+		assert.Equal(t, map[string]bool{"repo1": true}, scope.Repositories)
+	})
+
+	t.Run("happy path: a nil scope contains everything", func(t *testing.T) {
+		var scope *ReconciliationScope
+		assert.True(t, scope.HasTeam("team1"))
+		assert.True(t, scope.HasRepository("repo1"))
+	})
+
+	t.Run("not happy path: changes not limited to teams and repositories", func(t *testing.T) {
+		for _, file := range []string{
+			"goliac.yaml",
+			"users/org/user1.yaml",
+			"users/external/user1.yaml",
+			"rulesets/default.yaml",
+			"teams/repo1.yaml",
+			"teams/team1/README.md",
+		} {
+			assert.Nil(t, NewReconciliationScope([]string{"teams/team1/repo1.yaml", file}), file)
+		}
+	})
+
+	t.Run("not happy path: a team moved to another parent team", func(t *testing.T) {
+		scope := NewReconciliationScope([]string{
+			"teams/parent1/team1/team.yaml",
+			"teams/parent2/team1/team.yaml",
+		})
+		assert.Nil(t, scope)
+	})
+}
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -20,7 +21,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
-	"github.com/gosimple/slug"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
@@ -37,9 +38,13 @@ type GoliacLocal interface {
 
 type GoliacLocalGit interface {
 	Clone(fs billy.Filesystem, accesstoken, repositoryUrl, branch string) error
+	// clone and checkout an arbitrary git ref (a branch, a tag or a commit sha)
+	CloneRef(fs billy.Filesystem, accesstoken, repositoryUrl, ref string) error
 
 	// Return commits from tagname to HEAD
 	ListCommitsFromTag(tagname string) ([]*object.Commit, error)
+	// Return the files changed between a git ref (a tag or a commit sha) and HEAD
+	ChangedFilesSince(ref string) ([]string, error)
 	GetHeadCommit() (*object.Commit, error)
 	CheckoutCommit(commit *object.Commit) error
 	PushTag(tagname string, hash plumbing.Hash, accesstoken string) error
@@ -47,11 +52,12 @@ type GoliacLocalGit interface {
 	LoadRepoConfig() (*config.RepositoryConfig, error)
 
 	// Load and Validate from a github repository
-	LoadAndValidate() ([]error, []entity.Warning)
+	// (repoconfig is the goliac.yaml already loaded, nil for the default configuration)
+	LoadAndValidate(repoconfig *config.RepositoryConfig) ([]error, []entity.Warning)
 	// whenever someone create/delete a team, we must update the github CODEOWNERS
 	UpdateAndCommitCodeOwners(repoconfig *config.RepositoryConfig, dryrun bool, accesstoken string, branch string, tagname string, githubOrganization string) error
-	// whenever repos are not deleted but archived, or need to be renamed
-	UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, accesstoken string, branch string, tagname string) error
+	// whenever repos are not deleted but archived, need to be renamed, or were deleted after being archived
+	UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, reposToDeleteList []string, accesstoken string, branch string, tagname string) error
 	// whenever the users list is changing, reload users and teams, and commit them
 	// (force will bypass the max_changesets check)
 	// return true if some changes were done
@@ -59,7 +65,8 @@ type GoliacLocalGit interface {
 	Close(fs billy.Filesystem)
 
 	// Load and Validate from a local directory
-	LoadAndValidateLocal(fs billy.Filesystem) ([]error, []entity.Warning)
+	// (repoconfig is the goliac.yaml already loaded, nil for the default configuration)
+	LoadAndValidateLocal(fs billy.Filesystem, repoconfig *config.RepositoryConfig) ([]error, []entity.Warning)
 }
 
 type GoliacLocalResources interface {
@@ -133,17 +140,9 @@ func (g *GoliacLocalImpl) Clone(fs billy.Filesystem, accesstoken, repositoryUrl,
 		return err
 	}
 
-	var auth transport.AuthMethod
-	if strings.HasPrefix(repositoryUrl, "https://") {
-		auth = &http.BasicAuth{
-			Username: "x-access-token", // This can be anything except an empty string
-			Password: accesstoken,
-		}
-	} else if strings.HasPrefix(repositoryUrl, "inmemory:///") {
-		auth = nil
-	} else {
-		// ssh clone not supported yet
-		return fmt.Errorf("not supported")
+	auth, err := cloneAuth(accesstoken, repositoryUrl)
+	if err != nil {
+		return err
 	}
 	repo, err := git.PlainClone(tmpDir, false, &git.CloneOptions{
 		URL:           repositoryUrl,
@@ -158,6 +157,82 @@ func (g *GoliacLocalImpl) Clone(fs billy.Filesystem, accesstoken, repositoryUrl,
 	return err
 }
 
+func (g *GoliacLocalImpl) CloneRef(fs billy.Filesystem, accesstoken, repositoryUrl, ref string) error {
+	if g.repo != nil {
+		g.Close(fs)
+	}
+
+	// create a temp directory
+	tmpDir, err := utils.MkdirTemp(fs, "", "goliac")
+	if err != nil {
+		return err
+	}
+
+	auth, err := cloneAuth(accesstoken, repositoryUrl)
+	if err != nil {
+		return err
+	}
+	// all the branches are fetched (as origin/<branch>)
+	repo, err := git.PlainClone(tmpDir, false, &git.CloneOptions{
+		URL:  repositoryUrl,
+		Auth: auth,
+	})
+	if err != nil {
+		return err
+	}
+	g.repo = repo
+
+	var hash *plumbing.Hash
+	for _, revision := range []string{"origin/" + ref, ref} {
+		hash, err = repo.ResolveRevision(plumbing.Revision(revision))
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("not able to find the git ref %s: %v", ref, err)
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return w.Checkout(&git.CheckoutOptions{
+		Hash:  *hash,
+		Force: true,
+	})
+}
+
+func cloneAuth(accesstoken, repositoryUrl string) (transport.AuthMethod, error) {
+	if strings.HasPrefix(repositoryUrl, "https://") {
+		return &http.BasicAuth{
+			Username: "x-access-token", // This can be anything except an empty string
+			Password: accesstoken,
+		}, nil
+	} else if strings.HasPrefix(repositoryUrl, "inmemory:///") {
+		return nil, nil
+	}
+	// ssh clone not supported yet
+	return nil, fmt.Errorf("not supported")
+}
+
+/*
+CheckRemoteRepository checks (like a git ls-remote, without cloning it)
+that a git repository is reachable with the given access token
+*/
+func CheckRemoteRepository(ctx context.Context, accesstoken, repositoryUrl string) error {
+	auth, err := cloneAuth(accesstoken, repositoryUrl)
+	if err != nil {
+		return err
+	}
+	remote := git.NewRemote(memory.NewStorage(), &goconfig.RemoteConfig{
+		Name: "origin",
+		URLs: []string{repositoryUrl},
+	})
+	_, err = remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	return err
+}
+
 func (g *GoliacLocalImpl) PushTag(tagname string, hash plumbing.Hash, accesstoken string) error {
 	// Create or move the tag to the commit
 	tagRefName := plumbing.ReferenceName("refs/tags/" + tagname)
@@ -215,6 +290,67 @@ func (g *GoliacLocalImpl) GetHeadCommit() (*object.Commit, error) {
 	return headCommit, nil
 }
 
+/*
+ChangedFilesSince returns the (sorted) files added, modified, deleted or
+renamed (both names) between the git ref and HEAD. The ref must be an
+ancestor of HEAD (like the tag of the last applied commit)
+*/
+func (g *GoliacLocalImpl) ChangedFilesSince(ref string) ([]string, error) {
+	if g.repo == nil {
+		return nil, fmt.Errorf("git repository not cloned")
+	}
+
+	hash, err := g.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("not able to resolve %s: %v", ref, err)
+	}
+	fromCommit, err := g.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	headCommit, err := g.GetHeadCommit()
+	if err != nil {
+		return nil, err
+	}
+
+	isAncestor, err := fromCommit.IsAncestor(headCommit)
+	if err != nil {
+		return nil, err
+	}
+	if !isAncestor {
+		return nil, fmt.Errorf("%s is not an ancestor of HEAD", ref)
+	}
+
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(fromTree, headTree)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool)
+	for _, change := range changes {
+		if change.From.Name != "" {
+			files[change.From.Name] = true
+		}
+		if change.To.Name != "" {
+			files[change.To.Name] = true
+		}
+	}
+	changedFiles := make([]string, 0, len(files))
+	for file := range files {
+		changedFiles = append(changedFiles, file)
+	}
+	sort.Strings(changedFiles)
+	return changedFiles, nil
+}
+
 func (g *GoliacLocalImpl) ListCommitsFromTag(tagname string) ([]*object.Commit, error) {
 	if g.repo == nil {
 		return nil, fmt.Errorf("git repository not cloned")
@@ -288,10 +424,17 @@ func (g *GoliacLocalImpl) LoadRepoConfig() (*config.RepositoryConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	return LoadRepoConfigFromFs(w.Filesystem)
+}
 
+/*
+LoadRepoConfigFromFs reads the goliac.yaml configuration file of a teams
+repository directory
+*/
+func LoadRepoConfigFromFs(fs billy.Filesystem) (*config.RepositoryConfig, error) {
 	var repoconfig config.RepositoryConfig
 
-	content, err := utils.ReadFile(w.Filesystem, "goliac.yaml")
+	content, err := utils.ReadFile(fs, "goliac.yaml")
 	if err != nil {
 		return nil, fmt.Errorf("not able to find the /goliac.yaml configuration file: %v", err)
 	}
@@ -303,8 +446,18 @@ func (g *GoliacLocalImpl) LoadRepoConfig() (*config.RepositoryConfig, error) {
 	return &repoconfig, nil
 }
 
+/*
+defaultRepoConfig returns the configuration used without goliac.yaml
+(with the default values)
+*/
+func defaultRepoConfig() *config.RepositoryConfig {
+	repoconfig := config.RepositoryConfig{}
+	yaml.Unmarshal([]byte("{}"), &repoconfig)
+	return &repoconfig
+}
+
 func (g *GoliacLocalImpl) codeowners_regenerate(adminteam string, githubOrganization string) string {
-	adminteamname := fmt.Sprintf("@%s/%s", githubOrganization, slug.Make(adminteam))
+	adminteamname := fmt.Sprintf("@%s/%s", githubOrganization, TeamSlug(adminteam))
 
 	codeowners := "# DO NOT MODIFY THIS FILE MANUALLY\n"
 
@@ -319,7 +472,7 @@ func (g *GoliacLocalImpl) codeowners_regenerate(adminteam string, githubOrganiza
 		if strings.Contains(teampath, " ") {
 			teampath = strings.ReplaceAll(teampath, " ", "\\ ")
 		}
-		codeownersrules = append(codeownersrules, fmt.Sprintf("%s @%s/%s%s %s\n", teampath, githubOrganization, slug.Make(t), config.Config.GoliacTeamOwnerSuffix, adminteamname))
+		codeownersrules = append(codeownersrules, fmt.Sprintf("%s @%s/%s%s %s\n", teampath, githubOrganization, TeamSlug(t), config.Config.GoliacTeamOwnerSuffix, adminteamname))
 	}
 
 	// sort by path length
@@ -349,7 +502,7 @@ func (g *GoliacLocalImpl) buildTeamPath(teamname string) string {
 	return g.buildTeamPath(*team.ParentTeam) + "/" + teamname
 }
 
-func (g *GoliacLocalImpl) UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, accesstoken string, branch string, tagname string) error {
+func (g *GoliacLocalImpl) UpdateRepos(reposToArchiveList []string, reposToRename map[string]*entity.Repository, reposToDeleteList []string, accesstoken string, branch string, tagname string) error {
 	if g.repo == nil {
 		return fmt.Errorf("git repository not cloned")
 	}
@@ -393,6 +546,9 @@ func (g *GoliacLocalImpl) UpdateRepos(reposToArchiveList []string, reposToRename
 			repo.ApiVersion = "v1"
 			repo.Kind = "Repository"
 			repo.Name = reponame
+			// used to delete the repository once the deletion grace period elapsed
+			now := time.Now().UTC().Truncate(time.Second)
+			repo.ArchivedAt = &now
 
 			filename := filepath.Join("archived", reponame+".yaml")
 			file, err := w.Filesystem.Create(filename)
@@ -438,6 +594,11 @@ func (g *GoliacLocalImpl) UpdateRepos(reposToArchiveList []string, reposToRename
 
 		for directoryPath, repository := range reposToRename {
 			newRepository := *repository
+			// keep the definition as written (without the teams/_defaults.yaml specs)
+			if written, err := entity.NewRepository(w.Filesystem, filepath.Join(directoryPath, repository.Name+".yaml")); err == nil {
+				newRepository = *written
+				newRepository.DirectoryPath = repository.DirectoryPath
+			}
 			newRepository.Name = repository.RenameTo
 			newRepository.RenameTo = ""
 
@@ -479,6 +640,28 @@ func (g *GoliacLocalImpl) UpdateRepos(reposToArchiveList []string, reposToRename
 		}
 	}
 
+	if len(reposToDeleteList) != 0 {
+		for _, reponame := range reposToDeleteList {
+			_, err = w.Remove(filepath.Join("archived", reponame+".yaml"))
+			if err != nil {
+				return err
+			}
+			delete(g.repositories, reponame)
+		}
+
+		_, err = w.Commit("removing deleted archived repositories", &git.CommitOptions{
+			Author: &object.Signature{
+				Name:  "Goliac",
+				Email: config.Config.GoliacEmail,
+				When:  time.Now(),
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
 	err = g.repo.Push(&git.PushOptions{
 		RemoteName: "origin",
 		Auth: &http.BasicAuth{
@@ -641,6 +824,9 @@ func syncUsersViaUserPlugin(repoconfig *config.RepositoryConfig, fs billy.Filesy
 			deletedusers = append(deletedusers, filepath.Join(usersOrgPath, fmt.Sprintf("%s.yaml", username)))
 			fs.Remove(filepath.Join(usersOrgPath, fmt.Sprintf("%s.yaml", username)))
 		} else {
+			// the organization role is not managed by the user sync plugins
+			newuser.Spec.OrgRole = user.Spec.OrgRole
+
 			// check if user changed
 			if !newuser.Equals(user) {
 				// changed user
@@ -787,7 +973,7 @@ func (g *GoliacLocalImpl) SyncUsersAndTeams(repoconfig *config.RepositoryConfig,
  * - read the organization files
  * - validate the organization
  */
-func (g *GoliacLocalImpl) LoadAndValidate() ([]error, []entity.Warning) {
+func (g *GoliacLocalImpl) LoadAndValidate(repoconfig *config.RepositoryConfig) ([]error, []entity.Warning) {
 	if g.repo == nil {
 		return []error{fmt.Errorf("the repository has not been cloned. Did you called .Clone()?")}, []entity.Warning{}
 	}
@@ -798,7 +984,7 @@ func (g *GoliacLocalImpl) LoadAndValidate() ([]error, []entity.Warning) {
 	if err != nil {
 		return []error{err}, []entity.Warning{}
 	}
-	errs, warns := g.LoadAndValidateLocal(w.Filesystem)
+	errs, warns := g.LoadAndValidateLocal(w.Filesystem, repoconfig)
 
 	return errs, warns
 }
@@ -846,7 +1032,12 @@ func (g *GoliacLocalImpl) loadUsers(fs billy.Filesystem) ([]error, []entity.Warn
  * - a slice of errors that must stop the vlidation process
  * - a slice of warning that must not stop the validation process
  */
-func (g *GoliacLocalImpl) LoadAndValidateLocal(fs billy.Filesystem) ([]error, []entity.Warning) {
+func (g *GoliacLocalImpl) LoadAndValidateLocal(fs billy.Filesystem, repoconfig *config.RepositoryConfig) ([]error, []entity.Warning) {
+	// check the files against their schema first (unknown fields, wrong types)
+	if errors := ValidateSchema(fs); len(errors) > 0 {
+		return errors, []entity.Warning{}
+	}
+
 	errors, warnings := g.loadUsers(fs)
 
 	if len(errors) > 0 {
@@ -859,12 +1050,32 @@ func (g *GoliacLocalImpl) LoadAndValidateLocal(fs billy.Filesystem) ([]error, []
 	warnings = append(warnings, warns...)
 	g.teams = teams
 
+	// check the teams minimum owners (as defined in goliac.yaml, if any)
+	if repoconfig == nil {
+		repoconfig = defaultRepoConfig()
+	}
+	errs, warns = ValidateTeamsOwners(g.teams, repoconfig)
+	errors = append(errors, errs...)
+	warnings = append(warnings, warns...)
+
 	// Parse all repositories in the <orgDirectory>/teams/<teamname> directories
-	repos, errs, warns := entity.ReadRepositories(fs, "archived", "teams", g.teams, g.externalUsers)
+	repos, errs, warns := entity.ReadRepositoriesWithDefaults(fs, "archived", "teams", g.teams, g.externalUsers, repoconfig.RepositorySpecDefaults())
 	errors = append(errors, errs...)
 	warnings = append(warnings, warns...)
 	g.repositories = repos
 
+	// check the naming conventions (as defined in goliac.yaml, if any)
+	errors = append(errors, ValidateNames(g.teams, g.repositories, repoconfig)...)
+
+	// check the repositories merge strategies (against the default_merge_strategy of goliac.yaml)
+	errors = append(errors, ValidateMergeStrategies(g.repositories, repoconfig)...)
+	errors = append(errors, ValidateRequiredTeams(g.teams, repoconfig)...)
+
+	// report the teams without any repository (they are not removed)
+	for _, teamname := range UnusedTeams(g.teams, g.repositories, repoconfig) {
+		warnings = append(warnings, fmt.Errorf("team %s (%s) is not used: it has no repository access, nor subteam", teamname, teamFilename(g.teams, teamname)))
+	}
+
 	rulesets, errs, warns := entity.ReadRuleSetDirectory(fs, "rulesets")
 	errors = append(errors, errs...)
 	warnings = append(warnings, warns...)
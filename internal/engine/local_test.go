@@ -2,6 +2,7 @@ package engine
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -81,6 +82,14 @@ spec:
 apiVersion: v1
 kind: Repository
 name: repo1
+`), 0644)
+	if err != nil {
+		return err
+	}
+	err = utils.WriteFile(fs, "teams/team1/repo2.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo2
 `), 0644)
 	if err != nil {
 		return err
@@ -95,12 +104,50 @@ func TestRepository(t *testing.T) {
 		fs := memfs.New()
 		createBasicStructure(fs)
 		g := NewGoliacLocalImpl()
-		errs, warns := g.LoadAndValidateLocal(fs)
+		errs, warns := g.LoadAndValidateLocal(fs, nil)
 
 		assert.Equal(t, 0, len(errs))
 		assert.Equal(t, 0, len(warns))
 	})
 
+	t.Run("happy path: the goliac.yaml configuration given is used", func(t *testing.T) {
+		fs := memfs.New()
+		createBasicStructure(fs)
+
+		// team1 has 2 owners
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamMinimumOwners.Count = 3
+		repoconfig.TeamMinimumOwners.Enforcement = "fail"
+
+		g := NewGoliacLocalImpl()
+		errs, _ := g.LoadAndValidateLocal(fs, repoconfig)
+
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/team1/team.yaml: team team1 has 2 owner(s), at least 3 required", errs[0].Error())
+	})
+
+	t.Run("happy path: an unused team is reported", func(t *testing.T) {
+		fs := memfs.New()
+		createBasicStructure(fs)
+		err := utils.WriteFile(fs, "teams/team2/team.yaml", []byte(`
+apiVersion: v1
+kind: Team
+name: team2
+spec:
+  owners:
+  - user1
+  - user2
+`), 0644)
+		assert.Nil(t, err)
+
+		g := NewGoliacLocalImpl()
+		errs, warns := g.LoadAndValidateLocal(fs, nil)
+
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 1, len(warns))
+		assert.Equal(t, "team team2 (teams/team2/team.yaml) is not used: it has no repository access, nor subteam", warns[0].Error())
+	})
+
 	t.Run("happy path: local repository", func(t *testing.T) {
 		fs := memfs.New()
 		storer := memory.NewStorage()
@@ -136,7 +183,7 @@ func TestRepository(t *testing.T) {
 			repo:          r,
 		}
 
-		errs, warns := g.LoadAndValidate()
+		errs, warns := g.LoadAndValidate(nil)
 
 		assert.Equal(t, 0, len(errs))
 		assert.Equal(t, 0, len(warns))
@@ -460,6 +507,35 @@ func TestBasicGitops(t *testing.T) {
 
 	})
 
+	t.Run("ChangedFilesSince", func(t *testing.T) {
+		rootfs := memfs.New()
+		src, _ := rootfs.Chroot("/src")
+		target, _ := src.Chroot("/target")
+
+		_, clonedRepo, err := helperCreateAndClone(rootfs, src, target)
+		assert.Nil(t, err)
+
+		g := GoliacLocalImpl{
+			repo: clonedRepo,
+		}
+
+		files, err := g.ChangedFilesSince("v0.1.0")
+		assert.Nil(t, err)
+		// (the fixture repository also commits its own git storage)
+		assert.Contains(t, files, "teams/github-admins/repo2.yaml")
+		assert.NotContains(t, files, "teams/github-admins/repo1.yaml")
+		assert.NotContains(t, files, "teams/github-admins/team.yaml")
+
+		head, err := g.GetHeadCommit()
+		assert.Nil(t, err)
+		files, err = g.ChangedFilesSince(head.Hash.String())
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(files))
+
+		_, err = g.ChangedFilesSince("unknown")
+		assert.NotNil(t, err)
+	})
+
 	t.Run("CheckoutCommit", func(t *testing.T) {
 		rootfs := memfs.New()
 		src, _ := rootfs.Chroot("/src")
@@ -729,13 +805,25 @@ func TestGoliacLocalImpl(t *testing.T) {
 		}
 
 		// archive the repository 'repo1'
-		err = g.UpdateRepos([]string{"repo1"}, map[string]*entity.Repository{}, "none", "master", "foobar")
+		err = g.UpdateRepos([]string{"repo1"}, map[string]*entity.Repository{}, []string{}, "none", "master", "foobar")
 		assert.Nil(t, err)
 
 		// check the content of the 'archived/repo1.yaml' file
 		content, err := utils.ReadFile(target, "archived/repo1.yaml")
 		assert.Nil(t, err)
-		assert.Equal(t, "apiVersion: v1\nkind: Repository\nname: repo1\n", string(content))
+		assert.True(t, strings.HasPrefix(string(content), "apiVersion: v1\nkind: Repository\nname: repo1\narchivedAt: "))
+
+		archived, err := entity.NewRepository(target, "archived/repo1.yaml")
+		assert.Nil(t, err)
+		assert.NotNil(t, archived.ArchivedAt)
+
+		// the grace period elapsed: remove the archived repository
+		err = g.UpdateRepos([]string{}, map[string]*entity.Repository{}, []string{"repo1"}, "none", "master", "foobar")
+		assert.Nil(t, err)
+
+		exist, err := utils.Exists(target, "archived/repo1.yaml")
+		assert.Nil(t, err)
+		assert.False(t, exist)
 	})
 
 	t.Run("UpdateAndCommitCodeOwners", func(t *testing.T) {
@@ -2,6 +2,8 @@ package engine
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/gosimple/slug"
 )
@@ -19,6 +21,12 @@ type MutableGoliacRemoteImpl struct {
 	teamSlugByName map[string]string
 	rulesets       map[string]*GithubRuleSet
 	appIds         map[string]int
+	orgSettings    map[string]bool
+	outsideColl    map[string]bool
+	invitations    map[string]*GithubOrgInvitation
+	invited        map[string]bool // the users invited during this reconciliation
+	isEnterprise   bool
+	remote         GoliacRemote
 }
 
 func NewMutableGoliacRemoteImpl(ctx context.Context, remote GoliacRemote) *MutableGoliacRemoteImpl {
@@ -62,6 +70,25 @@ func NewMutableGoliacRemoteImpl(ctx context.Context, remote GoliacRemote) *Mutab
 		appids[k] = v
 	}
 
+	// nil if the organization settings couldn't be loaded
+	var orgSettings map[string]bool
+	if rOrgSettings := remote.OrgSettings(ctx); rOrgSettings != nil {
+		orgSettings = make(map[string]bool)
+		for k, v := range rOrgSettings {
+			orgSettings[k] = v
+		}
+	}
+
+	outsideCollaborators := make(map[string]bool)
+	for k, v := range remote.OutsideCollaborators(ctx) {
+		outsideCollaborators[k] = v
+	}
+
+	invitations := make(map[string]*GithubOrgInvitation)
+	for k, v := range remote.OrgInvitations(ctx) {
+		invitations[k] = v
+	}
+
 	return &MutableGoliacRemoteImpl{
 		users:          rUsers,
 		repositories:   rRepositories,
@@ -70,9 +97,19 @@ func NewMutableGoliacRemoteImpl(ctx context.Context, remote GoliacRemote) *Mutab
 		teamSlugByName: rTeamSlugByName,
 		rulesets:       rulesets,
 		appIds:         appids,
+		orgSettings:    orgSettings,
+		outsideColl:    outsideCollaborators,
+		invitations:    invitations,
+		invited:        make(map[string]bool),
+		isEnterprise:   remote.IsEnterprise(),
+		remote:         remote,
 	}
 }
 
+func (m *MutableGoliacRemoteImpl) IsEnterprise() bool {
+	return m.isEnterprise
+}
+
 func (m *MutableGoliacRemoteImpl) Users() map[string]string {
 	return m.users
 }
@@ -97,14 +134,112 @@ func (g *MutableGoliacRemoteImpl) AppIds() map[string]int {
 	return g.appIds
 }
 
+func (m *MutableGoliacRemoteImpl) OrgSettings() map[string]bool {
+	return m.orgSettings
+}
+
+func (m *MutableGoliacRemoteImpl) OutsideCollaborators() map[string]bool {
+	return m.outsideColl
+}
+
+func (m *MutableGoliacRemoteImpl) OrgInvitations() map[string]*GithubOrgInvitation {
+	return m.invitations
+}
+
+// Invited returns true if the user was invited to the organization during this reconciliation
+func (m *MutableGoliacRemoteImpl) Invited(login string) bool {
+	return m.invited[strings.ToLower(login)]
+}
+
+// RepositoriesChecksIntegrations is loaded on demand from the (not mutable) remote
+func (m *MutableGoliacRemoteImpl) RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error) {
+	return m.remote.RepositoriesChecksIntegrations(ctx, reponames)
+}
+
 // LISTENER
 
+func (m *MutableGoliacRemoteImpl) UpdateOrgSetting(settingName string, settingValue bool) {
+	if m.orgSettings == nil {
+		m.orgSettings = make(map[string]bool)
+	}
+	m.orgSettings[settingName] = settingValue
+}
+
 func (m *MutableGoliacRemoteImpl) AddUserToOrg(ghuserid string) {
-	m.users[ghuserid] = ghuserid
+	m.users[ghuserid] = "MEMBER"
+	// Github sends an invitation: the user is pending until it is accepted
+	m.invitations[ghuserid] = &GithubOrgInvitation{
+		Login:     ghuserid,
+		Role:      "direct_member",
+		CreatedAt: time.Now(),
+	}
+	m.invited[strings.ToLower(ghuserid)] = true
+}
+
+func (m *MutableGoliacRemoteImpl) CancelOrgInvitation(login string) {
+	delete(m.invitations, login)
+}
+
+func (m *MutableGoliacRemoteImpl) UpdateUserOrgRole(ghuserid string, role string) {
+	m.users[ghuserid] = strings.ToUpper(role)
 }
 
 func (m *MutableGoliacRemoteImpl) RemoveUserFromOrg(ghuserid string) {
 	delete(m.users, ghuserid)
+	// Github also removes the user from all the teams of the organization
+	for _, t := range m.teams {
+		t.Members = removeGithubId(t.Members, ghuserid)
+		t.Maintainers = removeGithubId(t.Maintainers, ghuserid)
+	}
+}
+
+func (m *MutableGoliacRemoteImpl) RemoveOutsideCollaboratorFromOrg(ghuserid string) {
+	delete(m.outsideColl, ghuserid)
+	// Github also removes the outside collaborator from all the repositories
+	for _, r := range m.repositories {
+		if _, ok := r.ExternalUsers[ghuserid]; ok {
+			// copy on write (the remote cache shares the same map)
+			externalUsers := make(map[string]string)
+			for k, v := range r.ExternalUsers {
+				if k != ghuserid {
+					externalUsers[k] = v
+				}
+			}
+			r.ExternalUsers = externalUsers
+		}
+	}
+}
+
+/*
+removeGithubId returns a copy of the githubids list without githubid
+(the copy ensures the remote cache sharing the same list is not modified)
+*/
+func removeGithubId(githubids []string, githubid string) []string {
+	result := make([]string, 0, len(githubids))
+	for _, g := range githubids {
+		if g != githubid {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+/*
+addGithubId returns a copy of the githubids list with githubid (if not already present)
+*/
+func addGithubId(githubids []string, githubid string) []string {
+	result := make([]string, 0, len(githubids)+1)
+	found := false
+	for _, g := range githubids {
+		if g == githubid {
+			found = true
+		}
+		result = append(result, g)
+	}
+	if !found {
+		result = append(result, githubid)
+	}
+	return result
 }
 
 func (m *MutableGoliacRemoteImpl) CreateTeam(teamname string, description string, members []string) {
@@ -133,6 +268,20 @@ func (m *MutableGoliacRemoteImpl) UpdateTeamRemoveMember(teamslug string, userna
 		}
 	}
 }
+func (m *MutableGoliacRemoteImpl) UpdateTeamAddMembers(teamslug string, usernames []string, role string) {
+	if t, ok := m.teams[teamslug]; ok {
+		for _, username := range usernames {
+			t.Members = addGithubId(t.Members, username)
+		}
+	}
+}
+func (m *MutableGoliacRemoteImpl) UpdateTeamRemoveMembers(teamslug string, usernames []string) {
+	if t, ok := m.teams[teamslug]; ok {
+		for _, username := range usernames {
+			t.Members = removeGithubId(t.Members, username)
+		}
+	}
+}
 func (m *MutableGoliacRemoteImpl) UpdateTeamUpdateMember(teamslug string, username string, role string) {
 	if role == "maintainer" {
 		if t, ok := m.teams[teamslug]; ok {
@@ -161,6 +310,16 @@ func (m *MutableGoliacRemoteImpl) UpdateTeamSetParent(ctx context.Context, dryru
 		t.ParentTeam = parentTeam
 	}
 }
+func (m *MutableGoliacRemoteImpl) UpdateTeamReviewAssignment(teamslug string, reviewAssignment *GithubTeamReviewAssignment) {
+	if t, ok := m.teams[teamslug]; ok {
+		t.ReviewAssignment = reviewAssignment
+	}
+}
+func (m *MutableGoliacRemoteImpl) UpdateTeamNotificationSetting(teamslug string, notificationSetting string) {
+	if t, ok := m.teams[teamslug]; ok {
+		t.NotificationSetting = notificationSetting
+	}
+}
 func (m *MutableGoliacRemoteImpl) DeleteTeam(teamslug string) {
 	if t, ok := m.teams[teamslug]; ok {
 		teamname := t.Name
@@ -171,9 +330,10 @@ func (m *MutableGoliacRemoteImpl) DeleteTeam(teamslug string) {
 }
 func (m *MutableGoliacRemoteImpl) CreateRepository(reponame string, descrition string, writers []string, readers []string, boolProperties map[string]bool) {
 	r := GithubRepository{
-		Name:           reponame,
-		BoolProperties: boolProperties,
-		ExternalUsers:  map[string]string{},
+		Name:             reponame,
+		BoolProperties:   boolProperties,
+		ExternalUsers:    map[string]string{},
+		CustomProperties: map[string]string{},
 	}
 	m.repositories[reponame] = &r
 }
@@ -181,7 +341,7 @@ func (m *MutableGoliacRemoteImpl) UpdateRepositoryAddTeamAccess(reponame string,
 	if tr, ok := m.teamRepos[teamslug]; ok {
 		tr[reponame] = &GithubTeamRepo{
 			Name:       reponame,
-			Permission: permission,
+			Permission: teamRepoPermission(permission),
 		}
 	}
 }
@@ -189,7 +349,7 @@ func (m *MutableGoliacRemoteImpl) UpdateRepositoryAddTeamAccess(reponame string,
 func (m *MutableGoliacRemoteImpl) UpdateRepositoryUpdateTeamAccess(reponame string, teamslug string, permission string) {
 	if tr, ok := m.teamRepos[teamslug]; ok {
 		if r, ok := tr[reponame]; ok {
-			r.Permission = permission
+			r.Permission = teamRepoPermission(permission)
 		}
 	}
 }
@@ -231,12 +391,50 @@ UpdateRepositoryUpdateBoolProperty is used for
 - allow_auto_merge
 - delete_branch_on_merge
 - allow_update_branch
+- has_issues
+- has_wiki
+- has_projects
+- allow_forking
 */
 func (m *MutableGoliacRemoteImpl) UpdateRepositoryUpdateBoolProperty(reponame string, propertyName string, propertyValue bool) {
 	if r, ok := m.repositories[reponame]; ok {
 		r.BoolProperties[propertyName] = propertyValue
 	}
 }
+
+/*
+UpdateRepositoryUpdateStringProperties is used for
+- squash_merge_commit_title
+- squash_merge_commit_message
+- merge_commit_title
+- merge_commit_message
+*/
+func (m *MutableGoliacRemoteImpl) UpdateRepositoryUpdateStringProperties(reponame string, properties map[string]string) {
+	if r, ok := m.repositories[reponame]; ok {
+		// copy to not modify the remote cache
+		stringProperties := make(map[string]string)
+		for k, v := range r.StringProperties {
+			stringProperties[k] = v
+		}
+		for k, v := range properties {
+			stringProperties[k] = v
+		}
+		r.StringProperties = stringProperties
+	}
+}
+func (m *MutableGoliacRemoteImpl) UpdateRepositorySetCustomProperty(reponame string, propertyName string, propertyValue string) {
+	if r, ok := m.repositories[reponame]; ok {
+		if r.CustomProperties == nil {
+			r.CustomProperties = map[string]string{}
+		}
+		r.CustomProperties[propertyName] = propertyValue
+	}
+}
+func (m *MutableGoliacRemoteImpl) UpdateRepositoryRemoveCustomProperty(reponame string, propertyName string) {
+	if r, ok := m.repositories[reponame]; ok {
+		delete(r.CustomProperties, propertyName)
+	}
+}
 func (m *MutableGoliacRemoteImpl) UpdateRepositorySetExternalUser(reponame string, collaboatorGithubId string, permission string) {
 	if r, ok := m.repositories[reponame]; ok {
 		r.ExternalUsers[collaboatorGithubId] = permission
@@ -5,16 +5,25 @@ import "context"
 type ReconciliatorExecutor interface {
 	AddUserToOrg(ctx context.Context, dryrun bool, ghuserid string)
 	RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string)
+	CancelOrgInvitation(ctx context.Context, dryrun bool, invitation *GithubOrgInvitation)
+	UpdateUserOrgRole(ctx context.Context, dryrun bool, ghuserid string, role string) // role can be 'member' or 'admin'
+	RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, ghuserid string)
 
 	CreateTeam(ctx context.Context, dryrun bool, teamname string, description string, parentTeam *int, members []string)
 	UpdateTeamAddMember(ctx context.Context, dryrun bool, teamslug string, username string, role string)    // role can be 'member' or 'maintainer'
 	UpdateTeamUpdateMember(ctx context.Context, dryrun bool, teamslug string, username string, role string) // role can be 'member' or 'maintainer'
 	UpdateTeamRemoveMember(ctx context.Context, dryrun bool, teamslug string, username string)
+	UpdateTeamAddMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string, role string) // role can be 'member' or 'maintainer'
+	UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string)
 	UpdateTeamSetParent(ctx context.Context, dryrun bool, teamslug string, parentTeam *int)
-	DeleteTeam(ctx context.Context, dryrun bool, teamslug string)
+	UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, teamslug string, reviewAssignment *GithubTeamReviewAssignment) // nil to disable it
+	UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, teamslug string, notificationSetting string)                // notifications_enabled or notifications_disabled
+	DeleteTeam(ctx context.Context, dryrun bool, teamslug string, reason string)
 
 	CreateRepository(ctx context.Context, dryrun bool, reponame string, descrition string, writers []string, readers []string, boolProperties map[string]bool)
+	CreateRepositoryFork(ctx context.Context, dryrun bool, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) // upstream is owner/repo
 	UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue bool)
+	UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, reponame string, properties map[string]string) // properties updated in a single call
 	UpdateRepositoryAddTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string)    // permission can be "pull", "push", or "admin" which correspond to read, write, and admin access.
 	UpdateRepositoryUpdateTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string) // permission can be "pull", "push", or "admin" which correspond to read, write, and admin access.
 	UpdateRepositoryRemoveTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string)
@@ -24,11 +33,31 @@ type ReconciliatorExecutor interface {
 	AddRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet)
 	UpdateRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, ruleset *GithubRuleSet)
 	DeleteRepositoryRuleset(ctx context.Context, dryrun bool, reponame string, rulesetid int)
+	UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue string)
+	UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string)
 	UpdateRepositorySetExternalUser(ctx context.Context, dryrun bool, reponame string, githubid string, permission string) // permission can be "pull" or "push"
 	UpdateRepositoryRemoveExternalUser(ctx context.Context, dryrun bool, reponame string, githubid string)
 	UpdateRepositoryRemoveInternalUser(ctx context.Context, dryrun bool, reponame string, githubid string)
-	DeleteRepository(ctx context.Context, dryrun bool, reponame string)
+	DeleteRepository(ctx context.Context, dryrun bool, reponame string, reason string)
 	RenameRepository(ctx context.Context, dryrun bool, reponame string, newname string)
+	UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) // sha of the current file (empty if it doesn't exist yet)
+	AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *GithubLabel)
+	UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *GithubLabel) // labelname is the current name (the case can change)
+	DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string)
+	UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, enabled bool)
+	UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, enabled bool)
+	LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject)
+	UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject)
+	UpdateOrgSetting(ctx context.Context, dryrun bool, settingName string, settingValue bool)
+	UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, settingValue string)
+	UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *GithubOrgAllowedActions)
+	UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, branchName string)
+	UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, fieldValue string)
+	AddOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook)
+	UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) // webhook.Id is the webhook to update
+	DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook)
+	AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string)
+	RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string)
 
 	Begin(dryrun bool)
 	Rollback(dryrun bool, err error)
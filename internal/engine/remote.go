@@ -2,8 +2,12 @@ package engine
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -45,6 +49,34 @@ type GoliacRemote interface {
 	TeamRepositories(ctx context.Context) map[string]map[string]*GithubTeamRepo // key is team slug, second key is repo name
 	RuleSets(ctx context.Context) map[string]*GithubRuleSet
 	AppIds(ctx context.Context) map[string]int
+	OrgSettings(ctx context.Context) map[string]bool                    // key is the setting name (like members_can_create_public_repositories)
+	OrgActionsSettings(ctx context.Context) map[string]string           // key is the setting name (like default_workflow_permissions)
+	OrgAllowedActions(ctx context.Context) *GithubOrgAllowedActions     // nil if not available
+	OrgDefaultBranchName(ctx context.Context) string                    // default branch name of the repositories created in the organization (like main)
+	OrgProfile(ctx context.Context) map[string]string                   // key is the profile field (like billing_email)
+	OutsideCollaborators(ctx context.Context) map[string]bool           // key is the login of the outside collaborators of the organization
+	OrgInvitations(ctx context.Context) map[string]*GithubOrgInvitation // key is the login of the invited user (pending invitations only)
+	OrgWebhooks(ctx context.Context) map[string]*GithubOrgWebhook       // key is the url of the webhook
+	OrgSecurityManagerTeams(ctx context.Context) map[string]bool        // key is the slug of the security manager teams
+	OrgProjects(ctx context.Context) (map[string]*GithubProject, error) // key is the title of the (v2) projects of the organization
+
+	// content of a file on the default branch of some repositories (not cached)
+	// the key is the repository name (repositories without the file are not returned)
+	RepositoriesFile(ctx context.Context, reponames []string, filename string) (map[string]*GithubFile, error)
+	// labels of some repositories (not cached)
+	// the first key is the repository name, the second one the lowercase label name
+	RepositoriesLabels(ctx context.Context, reponames []string) (map[string]map[string]*GithubLabel, error)
+	// Dependabot settings of some repositories (loaded on demand)
+	RepositoriesDependabot(ctx context.Context, reponames []string) (map[string]*GithubRepositoryDependabot, error)
+	// (v2) projects linked to some repositories (loaded on demand)
+	// the first key is the repository name, the second one the project title
+	RepositoriesProjects(ctx context.Context, reponames []string) (map[string]map[string]*GithubProject, error)
+	// integration id (the Github App) reporting each check of the default branch of some repositories (loaded on demand)
+	// the first key is the repository name, the second one the check name
+	RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error)
+
+	// reload (from Github) the members and the repositories access of a team (and of its owners team)
+	RefreshTeam(ctx context.Context, teamslug string) error
 
 	IsEnterprise() bool // check if we are on an Enterprise version, or if we are on GHES 3.11+
 
@@ -58,22 +90,34 @@ type GoliacRemoteExecutor interface {
 }
 
 type GithubRepository struct {
-	Name           string
-	Id             int
-	RefId          string
-	BoolProperties map[string]bool           // archived, private, allow_auto_merge, delete_branch_on_merge, allow_update_branch
-	ExternalUsers  map[string]string         // [githubid]permission
-	InternalUsers  map[string]string         // [githubid]permission
-	RuleSets       map[string]*GithubRuleSet // [name]ruleset
+	Name             string
+	Id               int
+	RefId            string
+	BoolProperties   map[string]bool           // archived, private, allow_auto_merge, delete_branch_on_merge, allow_update_branch, has_issues, has_wiki, has_projects, allow_forking, allow_merge_commit, allow_squash_merge, allow_rebase_merge
+	StringProperties map[string]string         // squash_merge_commit_title, squash_merge_commit_message, merge_commit_title, merge_commit_message
+	ExternalUsers    map[string]string         // [githubid]permission
+	InternalUsers    map[string]string         // [githubid]permission
+	RuleSets         map[string]*GithubRuleSet // [name]ruleset
+	CustomProperties map[string]string         // [propertyName]value (only loaded for Enterprise)
+	Topics           []string                  // (not managed) used to assign the organization rulesets
 }
 
 type GithubTeam struct {
-	Name        string
-	Id          int
-	Slug        string
-	Members     []string // user login, aka githubid
-	Maintainers []string // user login (that are not in the Members array)
-	ParentTeam  *int
+	Name                string
+	Id                  int
+	NodeId              string // graphql id
+	Slug                string
+	Members             []string // user login, aka githubid
+	Maintainers         []string // user login (that are not in the Members array)
+	ParentTeam          *int
+	ReviewAssignment    *GithubTeamReviewAssignment // nil if the code review assignment is disabled
+	NotificationSetting string                      // notifications_enabled, notifications_disabled
+}
+
+type GithubTeamReviewAssignment struct {
+	Algorithm        string // ROUND_ROBIN, LOAD_BALANCE
+	TeamMembersCount int
+	NotifyTeam       bool
 }
 
 type GithubTeamRepo struct {
@@ -83,6 +127,7 @@ type GithubTeamRepo struct {
 
 type GoliacRemoteImpl struct {
 	client                github.GitHubClient
+	organization          string // the Github organization managed by this remote
 	users                 map[string]string
 	repositories          map[string]*GithubRepository
 	repositoriesByRefId   map[string]*GithubRepository
@@ -91,15 +136,43 @@ type GoliacRemoteImpl struct {
 	teamSlugByName        map[string]string
 	rulesets              map[string]*GithubRuleSet
 	appIds                map[string]int
+	orgSettings           map[string]bool
+	orgDefaultBranchName  string
+	orgProfile            map[string]string
+	orgActionsSettings    map[string]string
+	orgAllowedActions     *GithubOrgAllowedActions
+	outsideCollaborators  map[string]bool
+	orgInvitations        map[string]*GithubOrgInvitation
+	orgWebhooks           map[string]*GithubOrgWebhook
+	securityManagerTeams  map[string]bool
+	dependabot            map[string]*GithubRepositoryDependabot
+	orgProjects           map[string]*GithubProject
+	repositoriesProjects  map[string]map[string]*GithubProject
+	checksIntegrations    map[string]map[string]int
 	ttlExpireUsers        time.Time
 	ttlExpireRepositories time.Time
 	ttlExpireTeams        time.Time
 	ttlExpireTeamsRepos   time.Time
 	ttlExpireRulesets     time.Time
 	ttlExpireAppIds       time.Time
+	ttlExpireOrgSettings  time.Time
+	orgSettingsLoaded     bool // if the last load of the organization settings succeeded
+	ttlExpireOrgActions   time.Time
+	ttlExpireAllowedActs  time.Time
+	ttlExpireOutsideColl  time.Time
+	ttlExpireInvitations  time.Time
+	ttlExpireOrgWebhooks  time.Time
+	ttlExpireSecManagers  time.Time
+	ttlExpireDependabot   time.Time
+	ttlExpireOrgProjects  time.Time
+	ttlExpireRepoProjects time.Time
+	ttlExpireChecks       time.Time
 	isEnterprise          bool
 	feedback              observability.RemoteObservability
 	loadTeamsMutex        sync.Mutex
+	dependabotMutex       sync.Mutex
+	projectsMutex         sync.Mutex
+	checksMutex           sync.Mutex
 }
 
 type GHESInfo struct {
@@ -173,7 +246,7 @@ type GraplQLAssets struct {
 
 func (g *GoliacRemoteImpl) CountAssets(ctx context.Context) (int, error) {
 	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
+	variables["orgLogin"] = g.organization
 
 	data, err := g.client.QueryGraphQLAPI(ctx, getAssets, variables)
 	if err != nil {
@@ -189,8 +262,8 @@ func (g *GoliacRemoteImpl) CountAssets(ctx context.Context) (int, error) {
 	if len(gResult.Errors) > 0 {
 		return 0, fmt.Errorf("graphql error on CountAssets: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
 	}
-	return 2*gResult.Data.Organization.Repositories.TotalCount + // we multiply by 2 because we have the repositories, and the teams per repostiory to fetch
-		2*gResult.Data.Organization.Teams.TotalCount + // we multiply by 2 because we have the teams and the members per team to fetch
+	return gResult.Data.Organization.Repositories.TotalCount +
+		3*gResult.Data.Organization.Teams.TotalCount + // we multiply by 3 because we have the teams, the members per team and the repositories per team to fetch
 		gResult.Data.Organization.MembersWithRole.TotalCount +
 		gResult.Data.Organization.SamlIdentityProvider.ExternalIdentities.TotalCount, nil
 }
@@ -245,7 +318,7 @@ func isEnterprise(ctx context.Context, orgname string, client github.GitHubClien
 	return false
 }
 
-func NewGoliacRemoteImpl(client github.GitHubClient) *GoliacRemoteImpl {
+func NewGoliacRemoteImpl(client github.GitHubClient, organization string) *GoliacRemoteImpl {
 	ctx := context.Background()
 	return &GoliacRemoteImpl{
 		client:                client,
@@ -257,13 +330,36 @@ func NewGoliacRemoteImpl(client github.GitHubClient) *GoliacRemoteImpl {
 		teamSlugByName:        make(map[string]string),
 		rulesets:              make(map[string]*GithubRuleSet),
 		appIds:                make(map[string]int),
+		orgSettings:           make(map[string]bool),
+		orgProfile:            make(map[string]string),
+		orgActionsSettings:    make(map[string]string),
+		outsideCollaborators:  make(map[string]bool),
+		orgInvitations:        make(map[string]*GithubOrgInvitation),
+		orgWebhooks:           make(map[string]*GithubOrgWebhook),
+		securityManagerTeams:  make(map[string]bool),
+		dependabot:            make(map[string]*GithubRepositoryDependabot),
+		orgProjects:           make(map[string]*GithubProject),
+		repositoriesProjects:  make(map[string]map[string]*GithubProject),
+		checksIntegrations:    make(map[string]map[string]int),
 		ttlExpireUsers:        time.Now(),
 		ttlExpireRepositories: time.Now(),
 		ttlExpireTeams:        time.Now(),
 		ttlExpireTeamsRepos:   time.Now(),
 		ttlExpireRulesets:     time.Now(),
 		ttlExpireAppIds:       time.Now(),
-		isEnterprise:          isEnterprise(ctx, config.Config.GithubAppOrganization, client),
+		ttlExpireOrgSettings:  time.Now(),
+		ttlExpireOrgActions:   time.Now(),
+		ttlExpireAllowedActs:  time.Now(),
+		ttlExpireOutsideColl:  time.Now(),
+		ttlExpireInvitations:  time.Now(),
+		ttlExpireOrgWebhooks:  time.Now(),
+		ttlExpireSecManagers:  time.Now(),
+		ttlExpireDependabot:   time.Now(),
+		ttlExpireOrgProjects:  time.Now(),
+		ttlExpireRepoProjects: time.Now(),
+		ttlExpireChecks:       time.Now(),
+		organization:          organization,
+		isEnterprise:          isEnterprise(ctx, organization, client),
 		feedback:              nil,
 	}
 }
@@ -275,6 +371,8 @@ func (g *GoliacRemoteImpl) IsEnterprise() bool {
 func (g *GoliacRemoteImpl) FlushCacheUsersTeamsOnly() {
 	g.ttlExpireUsers = time.Now()
 	g.ttlExpireTeams = time.Now()
+	g.ttlExpireOutsideColl = time.Now()
+	g.ttlExpireInvitations = time.Now()
 }
 
 func (g *GoliacRemoteImpl) FlushCache() {
@@ -284,6 +382,17 @@ func (g *GoliacRemoteImpl) FlushCache() {
 	g.ttlExpireTeamsRepos = time.Now()
 	g.ttlExpireRulesets = time.Now()
 	g.ttlExpireAppIds = time.Now()
+	g.ttlExpireOrgSettings = time.Now()
+	g.ttlExpireOrgActions = time.Now()
+	g.ttlExpireAllowedActs = time.Now()
+	g.ttlExpireOutsideColl = time.Now()
+	g.ttlExpireInvitations = time.Now()
+	g.ttlExpireOrgWebhooks = time.Now()
+	g.ttlExpireSecManagers = time.Now()
+	g.ttlExpireDependabot = time.Now()
+	g.ttlExpireOrgProjects = time.Now()
+	g.ttlExpireRepoProjects = time.Now()
+	g.ttlExpireChecks = time.Now()
 }
 
 func (g *GoliacRemoteImpl) RuleSets(ctx context.Context) map[string]*GithubRuleSet {
@@ -308,121 +417,341 @@ func (g *GoliacRemoteImpl) AppIds(ctx context.Context) map[string]int {
 	return g.appIds
 }
 
-func (g *GoliacRemoteImpl) Users(ctx context.Context) map[string]string {
-	if time.Now().After(g.ttlExpireUsers) {
-		users, err := g.loadOrgUsers(ctx)
+// OrgSettings returns nil if the organization settings couldn't be loaded
+func (g *GoliacRemoteImpl) OrgSettings(ctx context.Context) map[string]bool {
+	if time.Now().After(g.ttlExpireOrgSettings) {
+		orgSettings, defaultBranchName, orgProfile, err := g.loadOrgSettings(ctx)
 		if err == nil {
-			g.users = users
-			g.ttlExpireUsers = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+			g.orgSettings = orgSettings
+			g.orgDefaultBranchName = defaultBranchName
+			g.orgProfile = orgProfile
+			g.orgSettingsLoaded = true
+			g.ttlExpireOrgSettings = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			g.orgSettingsLoaded = false
+			logrus.Debugf("Error loading org settings: %v", err)
 		}
 	}
-	return g.users
+	if !g.orgSettingsLoaded {
+		return nil
+	}
+	return g.orgSettings
 }
 
-func (g *GoliacRemoteImpl) TeamSlugByName(ctx context.Context) map[string]string {
-	if time.Now().After(g.ttlExpireTeams) {
-		teams, teamSlugByName, err := g.loadTeams(ctx)
+// OrgProfile is loaded (and cached) with the organization settings
+// It returns nil if the organization profile couldn't be loaded
+func (g *GoliacRemoteImpl) OrgProfile(ctx context.Context) map[string]string {
+	g.OrgSettings(ctx)
+	if !g.orgSettingsLoaded {
+		return nil
+	}
+	return g.orgProfile
+}
+
+// OrgDefaultBranchName is loaded (and cached) with the organization settings
+// It returns "" if the organization settings couldn't be loaded
+func (g *GoliacRemoteImpl) OrgDefaultBranchName(ctx context.Context) string {
+	if g.OrgSettings(ctx) == nil {
+		return ""
+	}
+	return g.orgDefaultBranchName
+}
+
+func (g *GoliacRemoteImpl) OrgActionsSettings(ctx context.Context) map[string]string {
+	if time.Now().After(g.ttlExpireOrgActions) {
+		orgActionsSettings, err := g.loadOrgActionsSettings(ctx)
 		if err == nil {
-			g.teams = teams
-			g.teamSlugByName = teamSlugByName
-			g.ttlExpireTeams = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+			g.orgActionsSettings = orgActionsSettings
+			g.ttlExpireOrgActions = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			logrus.Debugf("Error loading org actions settings: %v", err)
 		}
 	}
-	return g.teamSlugByName
+	return g.orgActionsSettings
 }
 
-/*
-Used to get the teams (and load it if needed)
-if current is true, it will return the current in memory teams without checking the TTL (useful for the UI)
-*/
-func (g *GoliacRemoteImpl) Teams(ctx context.Context, current bool) map[string]*GithubTeam {
-	if current && len(g.teams) > 0 {
-		return g.teams
+func (g *GoliacRemoteImpl) OrgAllowedActions(ctx context.Context) *GithubOrgAllowedActions {
+	if time.Now().After(g.ttlExpireAllowedActs) {
+		orgAllowedActions, err := g.loadOrgAllowedActions(ctx)
+		if err == nil {
+			g.orgAllowedActions = orgAllowedActions
+			g.ttlExpireAllowedActs = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			logrus.Debugf("Error loading org allowed actions: %v", err)
+		}
 	}
-	g.loadTeamsMutex.Lock()
-	defer g.loadTeamsMutex.Unlock()
-	if time.Now().After(g.ttlExpireTeams) {
-		teams, teamSlugByName, err := g.loadTeams(ctx)
+	return g.orgAllowedActions
+}
+
+func (g *GoliacRemoteImpl) OutsideCollaborators(ctx context.Context) map[string]bool {
+	if time.Now().After(g.ttlExpireOutsideColl) {
+		outsideCollaborators, err := g.loadOutsideCollaborators(ctx)
 		if err == nil {
-			g.teams = teams
-			g.teamSlugByName = teamSlugByName
-			g.ttlExpireTeams = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+			g.outsideCollaborators = outsideCollaborators
+			g.ttlExpireOutsideColl = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			logrus.Debugf("Error loading outside collaborators: %v", err)
 		}
 	}
-	return g.teams
+	return g.outsideCollaborators
 }
 
-func (g *GoliacRemoteImpl) Repositories(ctx context.Context) map[string]*GithubRepository {
-	if time.Now().After(g.ttlExpireRepositories) {
-		repositories, repositoriesByRefIds, err := g.loadRepositories(ctx)
+// OrgWebhooks returns nil if the organization webhooks couldn't be loaded
+func (g *GoliacRemoteImpl) OrgWebhooks(ctx context.Context) map[string]*GithubOrgWebhook {
+	if time.Now().After(g.ttlExpireOrgWebhooks) {
+		orgWebhooks, err := g.loadOrgWebhooks(ctx)
 		if err == nil {
-			g.repositories = repositories
-			g.repositoriesByRefId = repositoriesByRefIds
-			g.ttlExpireRepositories = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+			g.orgWebhooks = orgWebhooks
+			g.ttlExpireOrgWebhooks = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			logrus.Warnf("Error loading org webhooks: %v", err)
+			return nil
 		}
 	}
-	return g.repositories
+	return g.orgWebhooks
 }
 
-func (g *GoliacRemoteImpl) TeamRepositories(ctx context.Context) map[string]map[string]*GithubTeamRepo {
-	if time.Now().After(g.ttlExpireTeamsRepos) {
-		if config.Config.GithubConcurrentThreads <= 1 {
-			teamsrepos, err := g.loadTeamReposNonConcurrently(ctx)
-			if err == nil {
-				g.teamRepos = teamsrepos
-				g.ttlExpireTeamsRepos = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
-			}
+func (g *GoliacRemoteImpl) OrgSecurityManagerTeams(ctx context.Context) map[string]bool {
+	if time.Now().After(g.ttlExpireSecManagers) {
+		securityManagerTeams, err := g.loadOrgSecurityManagerTeams(ctx)
+		if err == nil {
+			g.securityManagerTeams = securityManagerTeams
+			g.ttlExpireSecManagers = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
 		} else {
-			teamsrepos, err := g.loadTeamReposConcurrently(ctx, config.Config.GithubConcurrentThreads)
-			if err == nil {
-				g.teamRepos = teamsrepos
-				g.ttlExpireTeamsRepos = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+			logrus.Debugf("Error loading org security manager teams: %v", err)
+		}
+	}
+	return g.securityManagerTeams
+}
+
+func (g *GoliacRemoteImpl) OrgProjects(ctx context.Context) (map[string]*GithubProject, error) {
+	g.projectsMutex.Lock()
+	defer g.projectsMutex.Unlock()
+	if time.Now().After(g.ttlExpireOrgProjects) {
+		orgProjects, err := g.loadOrgProjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+		g.orgProjects = orgProjects
+		g.ttlExpireOrgProjects = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+	}
+	return g.orgProjects, nil
+}
+
+/*
+loadOrgSecurityManagerTeams returns the slugs of the teams having the
+security manager role in the organization
+*/
+func (g *GoliacRemoteImpl) loadOrgSecurityManagerTeams(ctx context.Context) (map[string]bool, error) {
+	logrus.Debug("loading org security manager teams")
+	securityManagerTeams := make(map[string]bool)
+
+	// https://docs.github.com/en/rest/orgs/security-managers?apiVersion=2022-11-28#list-security-manager-teams
+	body, err := g.client.CallRestAPI(ctx,
+		fmt.Sprintf("/orgs/%s/security-managers", g.organization),
+		"",
+		"GET",
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("not able to list org security manager teams: %v. %s", err, string(body))
+	}
+
+	var teams []struct {
+		Slug string `json:"slug"`
+	}
+	err = json.Unmarshal(body, &teams)
+	if err != nil {
+		return nil, fmt.Errorf("not able to unmarshall org security manager teams: %v", err)
+	}
+
+	for _, t := range teams {
+		securityManagerTeams[t.Slug] = true
+	}
+	return securityManagerTeams, nil
+}
+
+type OrgWebhookResponse struct {
+	Id     int      `json:"id"`
+	Name   string   `json:"name"`
+	Active bool     `json:"active"`
+	Events []string `json:"events"`
+	Config struct {
+		Url         string `json:"url"`
+		ContentType string `json:"content_type"`
+		Secret      string `json:"secret"` // masked by Github (if set)
+	} `json:"config"`
+}
+
+/*
+loadOrgWebhooks returns the webhooks of the organization, by url
+(Github never returns the secrets, only if a secret is set)
+*/
+func (g *GoliacRemoteImpl) loadOrgWebhooks(ctx context.Context) (map[string]*GithubOrgWebhook, error) {
+	logrus.Debug("loading org webhooks")
+	orgWebhooks := make(map[string]*GithubOrgWebhook)
+
+	page := 1
+	for page <= FORLOOP_STOP {
+		// https://docs.github.com/en/rest/orgs/webhooks?apiVersion=2022-11-28#list-organization-webhooks
+		body, err := g.client.CallRestAPI(ctx,
+			fmt.Sprintf("/orgs/%s/hooks", g.organization),
+			fmt.Sprintf("page=%d&per_page=100", page),
+			"GET",
+			nil)
+		if err != nil {
+			return nil, fmt.Errorf("not able to list org webhooks: %v. %s", err, string(body))
+		}
+
+		var hooks []OrgWebhookResponse
+		err = json.Unmarshal(body, &hooks)
+		if err != nil {
+			return nil, fmt.Errorf("not able to unmarshall org webhooks: %v", err)
+		}
+
+		for _, h := range hooks {
+			if _, ok := orgWebhooks[h.Config.Url]; ok {
+				logrus.Warnf("several org webhooks use the url %s, only the first one is managed", h.Config.Url)
+				continue
 			}
+			events := append([]string{}, h.Events...)
+			sort.Strings(events)
+			orgWebhooks[h.Config.Url] = &GithubOrgWebhook{
+				Id:          h.Id,
+				Url:         h.Config.Url,
+				Events:      events,
+				Active:      h.Active,
+				ContentType: h.Config.ContentType,
+				HasSecret:   h.Config.Secret != "",
+			}
+		}
+		if len(hooks) < 100 {
+			break
 		}
+		page++
 	}
-	return g.teamRepos
+	return orgWebhooks, nil
 }
 
-const listAllOrgMembers = `
-query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
-    organization(login: $orgLogin) {
-		membersWithRole(first: 100, after: $endCursor) {
-		  edges {
-            node {
-              login
-            }
-            role
-          }
-          pageInfo {
-            hasNextPage
-            endCursor
-          }
-          totalCount
-        }
-    }
+/*
+loadOutsideCollaborators returns the outside collaborators of the organization
+(users with access to some repositories, without being member of the organization)
+*/
+func (g *GoliacRemoteImpl) loadOutsideCollaborators(ctx context.Context) (map[string]bool, error) {
+	logrus.Debug("loading outside collaborators")
+	outsideCollaborators := make(map[string]bool)
+
+	page := 1
+	for page <= FORLOOP_STOP {
+		// https://docs.github.com/en/rest/orgs/outside-collaborators?apiVersion=2022-11-28#list-outside-collaborators-for-an-organization
+		body, err := g.client.CallRestAPI(ctx,
+			fmt.Sprintf("/orgs/%s/outside_collaborators", g.organization),
+			fmt.Sprintf("page=%d&per_page=100", page),
+			"GET",
+			nil)
+		if err != nil {
+			return nil, fmt.Errorf("not able to list outside collaborators: %v. %s", err, string(body))
+		}
+
+		var collaborators []struct {
+			Login string `json:"login"`
+		}
+		err = json.Unmarshal(body, &collaborators)
+		if err != nil {
+			return nil, fmt.Errorf("not able to unmarshall outside collaborators: %v", err)
+		}
+
+		for _, c := range collaborators {
+			outsideCollaborators[c.Login] = true
+		}
+		if len(collaborators) < 100 {
+			break
+		}
+		page++
+	}
+	return outsideCollaborators, nil
 }
-`
 
-type GraplQLUsers struct {
-	Data struct {
-		Organization struct {
-			MembersWithRole struct {
-				Edges []struct {
-					Node struct {
-						Login string
-					} `json:"node"`
-					Role string `json:"role"`
-				} `json:"edges"`
-				PageInfo struct {
-					HasNextPage bool
-					EndCursor   string
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
-			} `json:"membersWithRole"`
+func (g *GoliacRemoteImpl) OrgInvitations(ctx context.Context) map[string]*GithubOrgInvitation {
+	if time.Now().After(g.ttlExpireInvitations) {
+		orgInvitations, err := g.loadOrgInvitations(ctx)
+		if err == nil {
+			g.orgInvitations = orgInvitations
+			g.ttlExpireInvitations = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		} else {
+			logrus.Debugf("Error loading org invitations: %v", err)
+		}
+	}
+	return g.orgInvitations
+}
+
+/*
+loadOrgInvitations returns the pending invitations of the organization, by
+login (the invitations sent to an email address are ignored)
+*/
+func (g *GoliacRemoteImpl) loadOrgInvitations(ctx context.Context) (map[string]*GithubOrgInvitation, error) {
+	logrus.Debug("loading org invitations")
+	orgInvitations := make(map[string]*GithubOrgInvitation)
+
+	page := 1
+	for page <= FORLOOP_STOP {
+		// https://docs.github.com/en/rest/orgs/members?apiVersion=2022-11-28#list-pending-organization-invitations
+		body, err := g.client.CallRestAPI(ctx,
+			fmt.Sprintf("/orgs/%s/invitations", g.organization),
+			fmt.Sprintf("page=%d&per_page=100", page),
+			"GET",
+			nil)
+		if err != nil {
+			return nil, fmt.Errorf("not able to list org invitations: %v. %s", err, string(body))
+		}
+
+		var invitations []struct {
+			Id        int       `json:"id"`
+			Login     string    `json:"login"`
+			Role      string    `json:"role"`
+			CreatedAt time.Time `json:"created_at"`
 		}
+		err = json.Unmarshal(body, &invitations)
+		if err != nil {
+			return nil, fmt.Errorf("not able to unmarshall org invitations: %v", err)
+		}
+
+		for _, i := range invitations {
+			if i.Login == "" {
+				continue
+			}
+			orgInvitations[i.Login] = &GithubOrgInvitation{
+				Id:        i.Id,
+				Login:     i.Login,
+				Role:      i.Role,
+				CreatedAt: i.CreatedAt,
+			}
+		}
+		if len(invitations) < 100 {
+			break
+		}
+		page++
 	}
+	return orgInvitations, nil
+}
+
+// number of repositories fetched per GraphQL query by RepositoriesFile
+const FILES_REPOSITORIES_PER_QUERY = 50
+
+type GithubFile struct {
+	Content string
+	Sha     string // blob sha, needed to update the file
+}
+
+type GraphQLGotRepositoriesFile struct {
+	Data map[string]*struct {
+		File *struct {
+			Text string
+			Oid  string
+		}
+	} `json:"data"`
 	Errors []struct {
 		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
 		Extensions struct {
 			Code         string
 			ErrorMessage string
@@ -432,70 +761,1303 @@ type GraplQLUsers struct {
 }
 
 /*
-loadOrgUsers returns
-map[githubid]permission (role)
-role can be 'ADMIN', 'MEMBER'
+buildRepositoriesFileQuery builds a GraphQL query fetching a file on the
+default branch of each repository, like
+
+	query getFile($orgLogin: String!, $file: String!, $r0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    file: object(expression: $file) { ... on Blob { text oid } }
+	  }
+	}
 */
-func (g *GoliacRemoteImpl) loadOrgUsers(ctx context.Context) (map[string]string, error) {
-	logrus.Debug("loading orgUsers")
-	users := make(map[string]string)
+func buildRepositoriesFileQuery(nbRepositories int) string {
+	var query strings.Builder
+	query.WriteString("query getFile($orgLogin: String!, $file: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		query.WriteString("    file: object(expression: $file) { ... on Blob { text oid } }\n")
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
+}
 
-	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
-	variables["endCursor"] = nil
+func (g *GoliacRemoteImpl) RepositoriesFile(ctx context.Context, reponames []string, filename string) (map[string]*GithubFile, error) {
+	files := make(map[string]*GithubFile)
 
-	hasNextPage := true
-	count := 0
-	for hasNextPage {
-		data, err := g.client.QueryGraphQLAPI(ctx, listAllOrgMembers, variables)
-		if err != nil {
-			return users, err
+	for start := 0; start < len(reponames); start += FILES_REPOSITORIES_PER_QUERY {
+		end := start + FILES_REPOSITORIES_PER_QUERY
+		if end > len(reponames) {
+			end = len(reponames)
 		}
-		var gResult GraplQLUsers
+		batch := reponames[start:end]
 
-		// parse first page
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = g.organization
+		variables["file"] = "HEAD:" + filename
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+
+		data, err := g.client.QueryGraphQLAPI(ctx, buildRepositoriesFileQuery(len(batch)), variables)
+		if err != nil {
+			return files, err
+		}
+		var gResult GraphQLGotRepositoriesFile
 		err = json.Unmarshal(data, &gResult)
 		if err != nil {
-			return users, err
+			return files, err
 		}
-		if len(gResult.Errors) > 0 {
-			return users, fmt.Errorf("graphql error on loadOrgUsers: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return files, fmt.Errorf("graphql error on RepositoriesFile: %v (%v)", e.Message, e.Path)
+			}
 		}
 
-		for _, c := range gResult.Data.Organization.MembersWithRole.Edges {
-			users[c.Node.Login] = c.Role
+		for i, reponame := range batch {
+			repo, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if !ok || repo == nil || repo.File == nil {
+				continue
+			}
+			files[reponame] = &GithubFile{
+				Content: repo.File.Text,
+				Sha:     repo.File.Oid,
+			}
 		}
+	}
+	return files, nil
+}
 
-		if g.feedback != nil {
-			g.feedback.LoadingAsset("users", len(gResult.Data.Organization.MembersWithRole.Edges))
-		}
+type GithubLabel struct {
+	Name        string
+	Color       string // lowercase, without the leading #
+	Description string
+}
 
-		hasNextPage = gResult.Data.Organization.MembersWithRole.PageInfo.HasNextPage
-		variables["endCursor"] = gResult.Data.Organization.MembersWithRole.PageInfo.EndCursor
+type GithubOrgInvitation struct {
+	Id        int
+	Login     string
+	Role      string // like direct_member or admin
+	CreatedAt time.Time
+}
 
-		count++
-		// sanity check to avoid loops
-		if count > FORLOOP_STOP {
-			break
+// Github Actions allowed in the organization
+type GithubOrgAllowedActions struct {
+	AllowedActions     string // all, local_only or selected (the other fields only apply to selected)
+	GithubOwnedAllowed bool
+	VerifiedAllowed    bool
+	PatternsAllowed    []string // sorted
+}
+
+type GithubOrgWebhook struct {
+	Id          int
+	Url         string
+	Events      []string // sorted
+	Active      bool
+	ContentType string // json or form
+	HasSecret   bool   // Github never returns the secret itself
+	Secret      string // only set on the webhooks to create or update (never cached)
+}
+
+type GraphQLGotRepositoriesLabels struct {
+	Data map[string]*struct {
+		Labels struct {
+			Nodes []struct {
+				Name        string
+				Color       string
+				Description string
+			}
 		}
-	}
+	} `json:"data"`
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
 
-	return users, nil
+/*
+buildRepositoriesLabelsQuery builds a GraphQL query fetching the labels
+of each repository (up to 100 labels per repository), like
+
+	query getLabels($orgLogin: String!, $r0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    labels(first: 100) { nodes { name color description } }
+	  }
+	}
+*/
+func buildRepositoriesLabelsQuery(nbRepositories int) string {
+	var query strings.Builder
+	query.WriteString("query getLabels($orgLogin: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		query.WriteString("    labels(first: 100) { nodes { name color description } }\n")
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
 }
 
-const listAllReposInOrg = `
-query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
-    organization(login: $orgLogin) {
-      repositories(first: 10, after: $endCursor) {
-        nodes {
-          name
-		  id
-		  databaseId
-          isArchived
-          isPrivate
+func (g *GoliacRemoteImpl) RepositoriesLabels(ctx context.Context, reponames []string) (map[string]map[string]*GithubLabel, error) {
+	labels := make(map[string]map[string]*GithubLabel)
+
+	for start := 0; start < len(reponames); start += FILES_REPOSITORIES_PER_QUERY {
+		end := start + FILES_REPOSITORIES_PER_QUERY
+		if end > len(reponames) {
+			end = len(reponames)
+		}
+		batch := reponames[start:end]
+
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = g.organization
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+
+		data, err := g.client.QueryGraphQLAPI(ctx, buildRepositoriesLabelsQuery(len(batch)), variables)
+		if err != nil {
+			return labels, err
+		}
+		var gResult GraphQLGotRepositoriesLabels
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return labels, err
+		}
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return labels, fmt.Errorf("graphql error on RepositoriesLabels: %v (%v)", e.Message, e.Path)
+			}
+		}
+
+		for i, reponame := range batch {
+			repo, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if !ok || repo == nil {
+				continue
+			}
+			repoLabels := make(map[string]*GithubLabel)
+			for _, l := range repo.Labels.Nodes {
+				repoLabels[strings.ToLower(l.Name)] = &GithubLabel{
+					Name:        l.Name,
+					Color:       strings.ToLower(l.Color),
+					Description: l.Description,
+				}
+			}
+			labels[reponame] = repoLabels
+		}
+	}
+	return labels, nil
+}
+
+type GithubRepositoryDependabot struct {
+	Alerts          bool // vulnerability alerts
+	SecurityUpdates bool // automated security fixes
+}
+
+type GraphQLGotRepositoriesVulnerabilityAlerts struct {
+	Data map[string]*struct {
+		HasVulnerabilityAlertsEnabled bool
+	} `json:"data"`
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+type AutomatedSecurityFixes struct {
+	Enabled bool `json:"enabled"`
+	Paused  bool `json:"paused"`
+}
+
+/*
+buildRepositoriesVulnerabilityAlertsQuery builds a GraphQL query fetching
+if the vulnerability alerts are enabled on each repository, like
+
+	query getVulnerabilityAlerts($orgLogin: String!, $r0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    hasVulnerabilityAlertsEnabled
+	  }
+	}
+*/
+func buildRepositoriesVulnerabilityAlertsQuery(nbRepositories int) string {
+	var query strings.Builder
+	query.WriteString("query getVulnerabilityAlerts($orgLogin: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		query.WriteString("    hasVulnerabilityAlertsEnabled\n")
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
+}
+
+/*
+RepositoriesDependabot returns the Dependabot settings of the repositories.
+They are only loaded for the repositories asked (a REST call is needed per
+repository for the security updates), and kept in cache
+*/
+func (g *GoliacRemoteImpl) RepositoriesDependabot(ctx context.Context, reponames []string) (map[string]*GithubRepositoryDependabot, error) {
+	g.dependabotMutex.Lock()
+	defer g.dependabotMutex.Unlock()
+
+	if time.Now().After(g.ttlExpireDependabot) {
+		g.dependabot = make(map[string]*GithubRepositoryDependabot)
+		g.ttlExpireDependabot = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+	}
+
+	toLoad := []string{}
+	for _, reponame := range reponames {
+		if _, ok := g.dependabot[reponame]; !ok {
+			toLoad = append(toLoad, reponame)
+		}
+	}
+	if err := g.loadRepositoriesDependabot(ctx, toLoad); err != nil {
+		return nil, err
+	}
+
+	dependabot := make(map[string]*GithubRepositoryDependabot)
+	for _, reponame := range reponames {
+		if d, ok := g.dependabot[reponame]; ok {
+			dependabot[reponame] = &GithubRepositoryDependabot{
+				Alerts:          d.Alerts,
+				SecurityUpdates: d.SecurityUpdates,
+			}
+		}
+	}
+	return dependabot, nil
+}
+
+func (g *GoliacRemoteImpl) loadRepositoriesDependabot(ctx context.Context, reponames []string) error {
+	for start := 0; start < len(reponames); start += FILES_REPOSITORIES_PER_QUERY {
+		end := start + FILES_REPOSITORIES_PER_QUERY
+		if end > len(reponames) {
+			end = len(reponames)
+		}
+		batch := reponames[start:end]
+
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = g.organization
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+
+		data, err := g.client.QueryGraphQLAPI(ctx, buildRepositoriesVulnerabilityAlertsQuery(len(batch)), variables)
+		if err != nil {
+			return err
+		}
+		var gResult GraphQLGotRepositoriesVulnerabilityAlerts
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return err
+		}
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return fmt.Errorf("graphql error on RepositoriesDependabot: %v (%v)", e.Message, e.Path)
+			}
+		}
+
+		dependabots := make([]*GithubRepositoryDependabot, len(batch))
+		for i := range batch {
+			repo, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if !ok || repo == nil {
+				continue
+			}
+			dependabots[i] = &GithubRepositoryDependabot{
+				Alerts: repo.HasVulnerabilityAlertsEnabled,
+			}
+		}
+
+		// the security updates are only available with the REST API (one call per repository)
+		err = loadConcurrently(len(batch), func(i int) error {
+			dependabot := dependabots[i]
+			// the security updates need the vulnerability alerts
+			if dependabot == nil || !dependabot.Alerts {
+				return nil
+			}
+			reponame := batch[i]
+			// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#check-if-dependabot-security-updates-are-enabled-for-a-repository
+			body, err := g.client.CallRestAPI(ctx, fmt.Sprintf("/repos/%s/%s/automated-security-fixes", g.organization, reponame), "", "GET", nil)
+			if err != nil {
+				return fmt.Errorf("not able to get the dependabot security updates of the repository %s: %v", reponame, err)
+			}
+			var fixes AutomatedSecurityFixes
+			if err := json.Unmarshal(body, &fixes); err != nil {
+				return fmt.Errorf("not able to get the dependabot security updates of the repository %s: %v", reponame, err)
+			}
+			dependabot.SecurityUpdates = fixes.Enabled
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, reponame := range batch {
+			if dependabots[i] != nil {
+				g.dependabot[reponame] = dependabots[i]
+			}
+		}
+	}
+	return nil
+}
+
+type GithubProject struct {
+	Id     string // graphql id
+	Number int
+	Title  string
+}
+
+type GraphQLProjectNode struct {
+	Id     string
+	Number int
+	Title  string
+}
+
+const listAllProjectsInOrg = `
+query listAllProjectsInOrg($orgLogin: String!, $endCursor: String) {
+  organization(login: $orgLogin) {
+    projectsV2(first: 100, after: $endCursor) {
+      nodes {
+        id
+        number
+        title
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}
+`
+
+type GraphQLGotOrgProjects struct {
+	Data struct {
+		Organization struct {
+			ProjectsV2 struct {
+				Nodes    []GraphQLProjectNode
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				} `json:"pageInfo"`
+			} `json:"projectsV2"`
+		}
+	}
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+loadOrgProjects returns the (v2) projects of the organization, by title
+(the classic projects are not supported). If several projects have the same
+title, only the first one (the lowest number) is returned
+*/
+func (g *GoliacRemoteImpl) loadOrgProjects(ctx context.Context) (map[string]*GithubProject, error) {
+	logrus.Debug("loading org projects")
+	projects := make(map[string]*GithubProject)
+
+	variables := make(map[string]interface{})
+	variables["orgLogin"] = g.organization
+	variables["endCursor"] = nil
+
+	hasNextPage := true
+	count := 0
+	for hasNextPage {
+		data, err := g.client.QueryGraphQLAPI(ctx, listAllProjectsInOrg, variables)
+		if err != nil {
+			return nil, err
+		}
+		var gResult GraphQLGotOrgProjects
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return nil, err
+		}
+		if len(gResult.Errors) > 0 {
+			return nil, fmt.Errorf("graphql error on loadOrgProjects: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
+		}
+
+		for _, p := range gResult.Data.Organization.ProjectsV2.Nodes {
+			if other, ok := projects[p.Title]; ok && other.Number < p.Number {
+				continue
+			}
+			projects[p.Title] = &GithubProject{
+				Id:     p.Id,
+				Number: p.Number,
+				Title:  p.Title,
+			}
+		}
+
+		hasNextPage = gResult.Data.Organization.ProjectsV2.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.ProjectsV2.PageInfo.EndCursor
+
+		count++
+		// sanity check to avoid loops
+		if count > FORLOOP_STOP {
+			break
+		}
+	}
+	return projects, nil
+}
+
+type GraphQLGotRepositoriesProjects struct {
+	Data map[string]*struct {
+		ProjectsV2 struct {
+			Nodes []GraphQLProjectNode
+		} `json:"projectsV2"`
+	} `json:"data"`
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+buildRepositoriesProjectsQuery builds a GraphQL query fetching the (v2)
+projects linked to each repository (at most 100 per repository), like
+
+	query getRepositoriesProjects($orgLogin: String!, $r0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    projectsV2(first: 100) {
+	      nodes {
+	        id
+	        number
+	        title
+	      }
+	    }
+	  }
+	}
+*/
+func buildRepositoriesProjectsQuery(nbRepositories int) string {
+	var query strings.Builder
+	query.WriteString("query getRepositoriesProjects($orgLogin: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		query.WriteString("    projectsV2(first: 100) {\n")
+		query.WriteString("      nodes {\n")
+		query.WriteString("        id\n")
+		query.WriteString("        number\n")
+		query.WriteString("        title\n")
+		query.WriteString("      }\n")
+		query.WriteString("    }\n")
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
+}
+
+/*
+RepositoriesProjects returns the (v2) projects linked to the repositories.
+They are only loaded for the repositories asked, and kept in cache
+*/
+func (g *GoliacRemoteImpl) RepositoriesProjects(ctx context.Context, reponames []string) (map[string]map[string]*GithubProject, error) {
+	g.projectsMutex.Lock()
+	defer g.projectsMutex.Unlock()
+
+	if time.Now().After(g.ttlExpireRepoProjects) {
+		g.repositoriesProjects = make(map[string]map[string]*GithubProject)
+		g.ttlExpireRepoProjects = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+	}
+
+	toLoad := []string{}
+	for _, reponame := range reponames {
+		if _, ok := g.repositoriesProjects[reponame]; !ok {
+			toLoad = append(toLoad, reponame)
+		}
+	}
+	if err := g.loadRepositoriesProjects(ctx, toLoad); err != nil {
+		return nil, err
+	}
+
+	projects := make(map[string]map[string]*GithubProject)
+	for _, reponame := range reponames {
+		if repoProjects, ok := g.repositoriesProjects[reponame]; ok {
+			projects[reponame] = make(map[string]*GithubProject)
+			for title, p := range repoProjects {
+				projects[reponame][title] = p
+			}
+		}
+	}
+	return projects, nil
+}
+
+func (g *GoliacRemoteImpl) loadRepositoriesProjects(ctx context.Context, reponames []string) error {
+	for start := 0; start < len(reponames); start += FILES_REPOSITORIES_PER_QUERY {
+		end := start + FILES_REPOSITORIES_PER_QUERY
+		if end > len(reponames) {
+			end = len(reponames)
+		}
+		batch := reponames[start:end]
+
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = g.organization
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+
+		data, err := g.client.QueryGraphQLAPI(ctx, buildRepositoriesProjectsQuery(len(batch)), variables)
+		if err != nil {
+			return err
+		}
+		var gResult GraphQLGotRepositoriesProjects
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return err
+		}
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return fmt.Errorf("graphql error on RepositoriesProjects: %v (%v)", e.Message, e.Path)
+			}
+		}
+
+		for i, reponame := range batch {
+			repo, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if !ok || repo == nil {
+				continue
+			}
+			projects := make(map[string]*GithubProject)
+			for _, p := range repo.ProjectsV2.Nodes {
+				projects[p.Title] = &GithubProject{
+					Id:     p.Id,
+					Number: p.Number,
+					Title:  p.Title,
+				}
+			}
+			g.repositoriesProjects[reponame] = projects
+		}
+	}
+	return nil
+}
+
+// number of repositories fetched per GraphQL query by RepositoriesChecksIntegrations
+const CHECKS_REPOSITORIES_PER_QUERY = 10
+
+type GraphQLGotRepositoriesChecks struct {
+	Data map[string]*struct {
+		DefaultBranchRef *struct {
+			Target struct {
+				CheckSuites struct {
+					Nodes []struct {
+						App *struct {
+							DatabaseId int
+						}
+						CheckRuns struct {
+							Nodes []struct {
+								Name string
+							}
+						}
+					}
+				}
+			}
+		}
+	} `json:"data"`
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Type       string        `json:"type"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+buildRepositoriesChecksQuery builds a GraphQL query fetching the checks (and
+the Github App reporting them) of the last commit of the default branch of
+each repository, like
+
+	query getChecks($orgLogin: String!, $r0: String!) {
+	  r0: repository(owner: $orgLogin, name: $r0) {
+	    defaultBranchRef { target { ... on Commit { checkSuites(first: 20) { nodes {
+	      app { databaseId }
+	      checkRuns(first: 100) { nodes { name } }
+	    } } } } }
+	  }
+	}
+*/
+func buildRepositoriesChecksQuery(nbRepositories int) string {
+	var query strings.Builder
+	query.WriteString("query getChecks($orgLogin: String!")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, ", $r%d: String!", i)
+	}
+	query.WriteString(") {\n")
+	for i := 0; i < nbRepositories; i++ {
+		fmt.Fprintf(&query, "  r%d: repository(owner: $orgLogin, name: $r%d) {\n", i, i)
+		query.WriteString("    defaultBranchRef { target { ... on Commit { checkSuites(first: 20) { nodes {\n")
+		query.WriteString("      app { databaseId }\n")
+		query.WriteString("      checkRuns(first: 100) { nodes { name } }\n")
+		query.WriteString("    } } } } }\n")
+		query.WriteString("  }\n")
+	}
+	query.WriteString("}\n")
+	return query.String()
+}
+
+/*
+RepositoriesChecksIntegrations returns the integration id (the Github App)
+reporting each check of the last commit of the default branch of the
+repositories. A check reported by several integrations is not returned.
+They are only loaded for the repositories asked, and kept in cache
+*/
+func (g *GoliacRemoteImpl) RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error) {
+	g.checksMutex.Lock()
+	defer g.checksMutex.Unlock()
+
+	if time.Now().After(g.ttlExpireChecks) {
+		g.checksIntegrations = make(map[string]map[string]int)
+		g.ttlExpireChecks = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+	}
+
+	toLoad := []string{}
+	for _, reponame := range reponames {
+		if _, ok := g.checksIntegrations[reponame]; !ok {
+			toLoad = append(toLoad, reponame)
+		}
+	}
+	if err := g.loadRepositoriesChecksIntegrations(ctx, toLoad); err != nil {
+		return nil, err
+	}
+
+	checksIntegrations := make(map[string]map[string]int)
+	for _, reponame := range reponames {
+		if c, ok := g.checksIntegrations[reponame]; ok {
+			integrations := make(map[string]int, len(c))
+			for check, id := range c {
+				integrations[check] = id
+			}
+			checksIntegrations[reponame] = integrations
+		}
+	}
+	return checksIntegrations, nil
+}
+
+func (g *GoliacRemoteImpl) loadRepositoriesChecksIntegrations(ctx context.Context, reponames []string) error {
+	for start := 0; start < len(reponames); start += CHECKS_REPOSITORIES_PER_QUERY {
+		end := start + CHECKS_REPOSITORIES_PER_QUERY
+		if end > len(reponames) {
+			end = len(reponames)
+		}
+		batch := reponames[start:end]
+
+		variables := make(map[string]interface{})
+		variables["orgLogin"] = g.organization
+		for i, reponame := range batch {
+			variables[fmt.Sprintf("r%d", i)] = reponame
+		}
+
+		data, err := g.client.QueryGraphQLAPI(ctx, buildRepositoriesChecksQuery(len(batch)), variables)
+		if err != nil {
+			return err
+		}
+		var gResult GraphQLGotRepositoriesChecks
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return err
+		}
+		for _, e := range gResult.Errors {
+			// repositories not (yet) created are ignored
+			if e.Type != "NOT_FOUND" {
+				return fmt.Errorf("graphql error on RepositoriesChecksIntegrations: %v (%v)", e.Message, e.Path)
+			}
+		}
+
+		for i, reponame := range batch {
+			integrations := make(map[string]int)
+			repo, ok := gResult.Data[fmt.Sprintf("r%d", i)]
+			if ok && repo != nil && repo.DefaultBranchRef != nil {
+				ambiguous := make(map[string]bool)
+				for _, suite := range repo.DefaultBranchRef.Target.CheckSuites.Nodes {
+					if suite.App == nil {
+						continue
+					}
+					for _, run := range suite.CheckRuns.Nodes {
+						if id, ok := integrations[run.Name]; ok && id != suite.App.DatabaseId {
+							ambiguous[run.Name] = true
+						}
+						integrations[run.Name] = suite.App.DatabaseId
+					}
+				}
+				for check := range ambiguous {
+					delete(integrations, check)
+				}
+			}
+			g.checksIntegrations[reponame] = integrations
+		}
+	}
+	return nil
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#enable-vulnerability-alerts
+	method := "PUT"
+	if !enabled {
+		method = "DELETE"
+	}
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/vulnerability-alerts", g.organization, reponame),
+			"",
+			method,
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the dependabot alerts of the repository %s: %v. %s", reponame, err, string(body))
+			return
+		}
+	}
+
+	g.dependabotMutex.Lock()
+	defer g.dependabotMutex.Unlock()
+	if d, ok := g.dependabot[reponame]; ok {
+		d.Alerts = enabled
+		if !enabled {
+			d.SecurityUpdates = false
+		}
+	}
+}
+
+const linkProjectV2ToRepository = `
+mutation linkProjectV2ToRepository($projectId: ID!, $repositoryId: ID!) {
+  linkProjectV2ToRepository(input: {projectId: $projectId, repositoryId: $repositoryId}) {
+    repository {
+      id
+    }
+  }
+}
+`
+
+const unlinkProjectV2FromRepository = `
+mutation unlinkProjectV2FromRepository($projectId: ID!, $repositoryId: ID!) {
+  unlinkProjectV2FromRepository(input: {projectId: $projectId, repositoryId: $repositoryId}) {
+    repository {
+      id
+    }
+  }
+}
+`
+
+func (g *GoliacRemoteImpl) LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	if !g.updateRepositoryProjectLink(ctx, dryrun, reponame, project, linkProjectV2ToRepository) {
+		return
+	}
+
+	g.projectsMutex.Lock()
+	defer g.projectsMutex.Unlock()
+	if projects, ok := g.repositoriesProjects[reponame]; ok {
+		projects[project.Title] = project
+	}
+}
+
+func (g *GoliacRemoteImpl) UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *GithubProject) {
+	if !g.updateRepositoryProjectLink(ctx, dryrun, reponame, project, unlinkProjectV2FromRepository) {
+		return
+	}
+
+	g.projectsMutex.Lock()
+	defer g.projectsMutex.Unlock()
+	if projects, ok := g.repositoriesProjects[reponame]; ok {
+		delete(projects, project.Title)
+	}
+}
+
+/*
+updateRepositoryProjectLink runs the link (or unlink) mutation, and returns
+true if it succeeded (there is no REST api for the v2 projects)
+*/
+func (g *GoliacRemoteImpl) updateRepositoryProjectLink(ctx context.Context, dryrun bool, reponame string, project *GithubProject, mutation string) bool {
+	if dryrun {
+		return true
+	}
+	repo, ok := g.repositories[reponame]
+	if !ok {
+		logrus.Errorf("failed to update the link of the repository %s to the project %s: repository not found", reponame, project.Title)
+		return false
+	}
+
+	// https://docs.github.com/en/graphql/reference/mutations#linkprojectv2torepository
+	body, err := g.client.QueryGraphQLAPI(ctx, mutation, map[string]interface{}{
+		"projectId":    project.Id,
+		"repositoryId": repo.RefId,
+	})
+	if err != nil {
+		logrus.Errorf("failed to update the link of the repository %s to the project %s: %v. %s", reponame, project.Title, err, string(body))
+		return false
+	}
+	var res struct {
+		Errors []struct {
+			Message string
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &res); err == nil && len(res.Errors) > 0 {
+		logrus.Errorf("failed to update the link of the repository %s to the project %s: %s", reponame, project.Title, res.Errors[0].Message)
+		return false
+	}
+	return true
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#enable-dependabot-security-updates
+	method := "PUT"
+	if !enabled {
+		method = "DELETE"
+	}
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/automated-security-fixes", g.organization, reponame),
+			"",
+			method,
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the dependabot security updates of the repository %s: %v. %s", reponame, err, string(body))
+			return
+		}
+	}
+
+	g.dependabotMutex.Lock()
+	defer g.dependabotMutex.Unlock()
+	if d, ok := g.dependabot[reponame]; ok {
+		d.SecurityUpdates = enabled
+	}
+}
+
+type OrgSettings struct {
+	MembersCanCreatePublicRepositories   *bool  `json:"members_can_create_public_repositories"`
+	MembersCanCreatePrivateRepositories  *bool  `json:"members_can_create_private_repositories"`
+	MembersCanCreateInternalRepositories *bool  `json:"members_can_create_internal_repositories"`
+	DefaultRepositoryBranch              string `json:"default_repository_branch"`
+	BillingEmail                         string `json:"billing_email"`
+	Company                              string `json:"company"`
+	Description                          string `json:"description"`
+	Blog                                 string `json:"blog"`
+	TwitterUsername                      string `json:"twitter_username"`
+}
+
+/*
+loadOrgSettings returns the organization settings managed by Goliac
+map[setting name]value
+(settings not returned by Github, like members_can_create_internal_repositories
+outside of Github Enterprise, are not in the map), and the default branch name
+of the new repositories
+*/
+func (g *GoliacRemoteImpl) loadOrgSettings(ctx context.Context) (map[string]bool, string, map[string]string, error) {
+	// https://docs.github.com/en/rest/orgs/orgs?apiVersion=2022-11-28#get-an-organization
+	body, err := g.client.CallRestAPI(ctx, "/orgs/"+g.organization, "", "GET", nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var settings OrgSettings
+	err = json.Unmarshal(body, &settings)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("not able to get github org settings: %v", err)
+	}
+
+	orgSettings := make(map[string]bool)
+	if settings.MembersCanCreatePublicRepositories != nil {
+		orgSettings["members_can_create_public_repositories"] = *settings.MembersCanCreatePublicRepositories
+	}
+	if settings.MembersCanCreatePrivateRepositories != nil {
+		orgSettings["members_can_create_private_repositories"] = *settings.MembersCanCreatePrivateRepositories
+	}
+	if settings.MembersCanCreateInternalRepositories != nil {
+		orgSettings["members_can_create_internal_repositories"] = *settings.MembersCanCreateInternalRepositories
+	}
+	orgProfile := map[string]string{
+		"billing_email":    settings.BillingEmail,
+		"company":          settings.Company,
+		"description":      settings.Description,
+		"blog":             settings.Blog,
+		"twitter_username": settings.TwitterUsername,
+	}
+	return orgSettings, settings.DefaultRepositoryBranch, orgProfile, nil
+}
+
+type OrgWorkflowPermissions struct {
+	DefaultWorkflowPermissions string `json:"default_workflow_permissions"`
+}
+
+type OrgForkPRWorkflowsPrivateRepos struct {
+	RunWorkflowsFromForkPullRequests  bool `json:"run_workflows_from_fork_pull_requests"`
+	SendWriteTokensToWorkflows        bool `json:"send_write_tokens_to_workflows"`
+	SendSecretsAndVariables           bool `json:"send_secrets_and_variables"`
+	RequireApprovalForForkPRWorkflows bool `json:"require_approval_for_fork_pr_workflows"`
+}
+
+type OrgActionsPermissions struct {
+	EnabledRepositories string `json:"enabled_repositories"`
+	AllowedActions      string `json:"allowed_actions"`
+}
+
+type OrgSelectedActions struct {
+	GithubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+	PatternsAllowed    []string `json:"patterns_allowed"`
+}
+
+/*
+loadOrgAllowedActions returns the Github Actions allowed in the organization
+(the allowlist is only loaded if the organization allows the selected actions)
+*/
+func (g *GoliacRemoteImpl) loadOrgAllowedActions(ctx context.Context) (*GithubOrgAllowedActions, error) {
+	// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#get-github-actions-permissions-for-an-organization
+	body, err := g.client.CallRestAPI(ctx, "/orgs/"+g.organization+"/actions/permissions", "", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	var permissions OrgActionsPermissions
+	if err := json.Unmarshal(body, &permissions); err != nil {
+		return nil, fmt.Errorf("not able to get github org actions permissions: %v", err)
+	}
+	allowedActions := &GithubOrgAllowedActions{
+		AllowedActions:  permissions.AllowedActions,
+		PatternsAllowed: []string{},
+	}
+	if permissions.AllowedActions != "selected" {
+		return allowedActions, nil
+	}
+
+	// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#get-allowed-actions-and-reusable-workflows-for-an-organization
+	body, err = g.client.CallRestAPI(ctx, "/orgs/"+g.organization+"/actions/permissions/selected-actions", "", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	var selected OrgSelectedActions
+	if err := json.Unmarshal(body, &selected); err != nil {
+		return nil, fmt.Errorf("not able to get github org selected actions: %v", err)
+	}
+	allowedActions.GithubOwnedAllowed = selected.GithubOwnedAllowed
+	allowedActions.VerifiedAllowed = selected.VerifiedAllowed
+	if selected.PatternsAllowed != nil {
+		allowedActions.PatternsAllowed = selected.PatternsAllowed
+	}
+	sort.Strings(allowedActions.PatternsAllowed)
+
+	return allowedActions, nil
+}
+
+/*
+loadOrgActionsSettings returns the Github Actions settings of the organization managed by Goliac
+map[setting name]value
+- default_workflow_permissions: read or write
+- require_approval_for_fork_pr_workflows: true or false (for the private repositories)
+(settings not returned by Github are not in the map)
+*/
+func (g *GoliacRemoteImpl) loadOrgActionsSettings(ctx context.Context) (map[string]string, error) {
+	orgActionsSettings := make(map[string]string)
+
+	// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#get-default-workflow-permissions-for-an-organization
+	body, err := g.client.CallRestAPI(ctx, "/orgs/"+g.organization+"/actions/permissions/workflow", "", "GET", nil)
+	if err != nil {
+		return nil, err
+	}
+	var workflow OrgWorkflowPermissions
+	if err := json.Unmarshal(body, &workflow); err != nil {
+		return nil, fmt.Errorf("not able to get github org workflow permissions: %v", err)
+	}
+	if workflow.DefaultWorkflowPermissions != "" {
+		orgActionsSettings["default_workflow_permissions"] = workflow.DefaultWorkflowPermissions
+	}
+
+	// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#get-private-repo-fork-pr-workflow-settings-for-an-organization
+	body, err = g.client.CallRestAPI(ctx, "/orgs/"+g.organization+"/actions/permissions/fork-pr-workflows-private-repos", "", "GET", nil)
+	if err != nil {
+		// not available on all the plans
+		logrus.Debugf("not able to get github org fork PR workflows settings: %v", err)
+		return orgActionsSettings, nil
+	}
+	var forkPR OrgForkPRWorkflowsPrivateRepos
+	if err := json.Unmarshal(body, &forkPR); err != nil {
+		return nil, fmt.Errorf("not able to get github org fork PR workflows settings: %v", err)
+	}
+	orgActionsSettings["require_approval_for_fork_pr_workflows"] = strconv.FormatBool(forkPR.RequireApprovalForForkPRWorkflows)
+
+	return orgActionsSettings, nil
+}
+
+func (g *GoliacRemoteImpl) Users(ctx context.Context) map[string]string {
+	if time.Now().After(g.ttlExpireUsers) {
+		users, err := g.loadOrgUsers(ctx)
+		if err == nil {
+			g.users = users
+			g.ttlExpireUsers = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		}
+	}
+	return g.users
+}
+
+func (g *GoliacRemoteImpl) TeamSlugByName(ctx context.Context) map[string]string {
+	if time.Now().After(g.ttlExpireTeams) {
+		teams, teamSlugByName, err := g.loadTeams(ctx)
+		if err == nil {
+			g.teams = teams
+			g.teamSlugByName = teamSlugByName
+			g.ttlExpireTeams = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		}
+	}
+	return g.teamSlugByName
+}
+
+/*
+Used to get the teams (and load it if needed)
+if current is true, it will return the current in memory teams without checking the TTL (useful for the UI)
+*/
+func (g *GoliacRemoteImpl) Teams(ctx context.Context, current bool) map[string]*GithubTeam {
+	if current && len(g.teams) > 0 {
+		return g.teams
+	}
+	g.loadTeamsMutex.Lock()
+	defer g.loadTeamsMutex.Unlock()
+	if time.Now().After(g.ttlExpireTeams) {
+		teams, teamSlugByName, err := g.loadTeams(ctx)
+		if err == nil {
+			g.teams = teams
+			g.teamSlugByName = teamSlugByName
+			g.ttlExpireTeams = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		}
+	}
+	return g.teams
+}
+
+func (g *GoliacRemoteImpl) Repositories(ctx context.Context) map[string]*GithubRepository {
+	if time.Now().After(g.ttlExpireRepositories) {
+		repositories, repositoriesByRefIds, err := g.loadRepositories(ctx)
+		if err == nil {
+			g.repositories = repositories
+			g.repositoriesByRefId = repositoriesByRefIds
+			g.ttlExpireRepositories = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		}
+	}
+	return g.repositories
+}
+
+func (g *GoliacRemoteImpl) TeamRepositories(ctx context.Context) map[string]map[string]*GithubTeamRepo {
+	if time.Now().After(g.ttlExpireTeamsRepos) {
+		teamsrepos, err := g.loadTeamsRepositories(ctx)
+		if err == nil {
+			g.teamRepos = teamsrepos
+			g.ttlExpireTeamsRepos = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
+		}
+	}
+	return g.teamRepos
+}
+
+/*
+RefreshTeam reloads the members and the repositories access of a team
+(and of its "<team>-goliac-owners" team) without reloading the whole cache
+*/
+func (g *GoliacRemoteImpl) RefreshTeam(ctx context.Context, teamslug string) error {
+	teams := g.Teams(ctx, true)
+	if _, ok := teams[teamslug]; !ok {
+		return fmt.Errorf("team %s not found", teamslug)
+	}
+	// ensure the teams repositories are loaded before refreshing one team
+	g.TeamRepositories(ctx)
+
+	for _, slug := range []string{teamslug, teamslug + config.Config.GoliacTeamOwnerSuffix} {
+		team, ok := teams[slug]
+		if !ok {
+			continue
+		}
+		refreshed := &GithubTeam{
+			Name:        team.Name,
+			Id:          team.Id,
+			Slug:        team.Slug,
+			ParentTeam:  team.ParentTeam,
+			Members:     []string{},
+			Maintainers: []string{},
+		}
+		if err := g.loadTeamsMembers(ctx, refreshed); err != nil {
+			return err
+		}
+		repos := make(map[string]*GithubTeamRepo)
+		if err := g.loadTeamRepositories(ctx, slug, "", repos); err != nil {
+			return err
+		}
+
+		g.loadTeamsMutex.Lock()
+		g.teams[slug] = refreshed
+		if g.teamRepos == nil {
+			g.teamRepos = make(map[string]map[string]*GithubTeamRepo)
+		}
+		g.teamRepos[slug] = repos
+		g.loadTeamsMutex.Unlock()
+	}
+	return nil
+}
+
+const listAllOrgMembers = `
+query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
+    organization(login: $orgLogin) {
+		membersWithRole(first: 100, after: $endCursor) {
+		  edges {
+            node {
+              login
+            }
+            role
+          }
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+          totalCount
+        }
+    }
+}
+`
+
+type GraplQLUsers struct {
+	Data struct {
+		Organization struct {
+			MembersWithRole struct {
+				Edges []struct {
+					Node struct {
+						Login string
+					} `json:"node"`
+					Role string `json:"role"`
+				} `json:"edges"`
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			} `json:"membersWithRole"`
+		}
+	}
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+loadOrgUsers returns
+map[githubid]permission (role)
+role can be 'ADMIN', 'MEMBER'
+*/
+func (g *GoliacRemoteImpl) loadOrgUsers(ctx context.Context) (map[string]string, error) {
+	logrus.Debug("loading orgUsers")
+	users := make(map[string]string)
+
+	variables := make(map[string]interface{})
+	variables["orgLogin"] = g.organization
+	variables["endCursor"] = nil
+
+	hasNextPage := true
+	count := 0
+	for hasNextPage {
+		data, err := g.client.QueryGraphQLAPI(ctx, listAllOrgMembers, variables)
+		if err != nil {
+			return users, err
+		}
+		var gResult GraplQLUsers
+
+		// parse first page
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return users, err
+		}
+		if len(gResult.Errors) > 0 {
+			return users, fmt.Errorf("graphql error on loadOrgUsers: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
+		}
+
+		for _, c := range gResult.Data.Organization.MembersWithRole.Edges {
+			users[c.Node.Login] = c.Role
+		}
+
+		if g.feedback != nil {
+			g.feedback.LoadingAsset("users", len(gResult.Data.Organization.MembersWithRole.Edges))
+		}
+
+		hasNextPage = gResult.Data.Organization.MembersWithRole.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.MembersWithRole.PageInfo.EndCursor
+
+		count++
+		// sanity check to avoid loops
+		if count > FORLOOP_STOP {
+			break
+		}
+	}
+
+	return users, nil
+}
+
+const listAllReposInOrg = `
+query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
+    organization(login: $orgLogin) {
+      repositories(first: 10, after: $endCursor) {
+        nodes {
+          name
+		  id
+		  databaseId
+          isArchived
+          isPrivate
 		  autoMergeAllowed
           deleteBranchOnMerge
           allowUpdateBranch
+          hasIssuesEnabled
+          hasWikiEnabled
+          hasProjectsEnabled
+          forkingAllowed
+          mergeCommitAllowed
+          squashMergeAllowed
+          rebaseMergeAllowed
+          squashMergeCommitTitle
+          squashMergeCommitMessage
+          mergeCommitTitle
+          mergeCommitMessage
+          repositoryTopics(first: 20) {
+            nodes {
+              topic {
+                name
+              }
+            }
+          }
           directCollaborators: collaborators(affiliation: DIRECT, first: 100) {
             edges {
               node {
@@ -503,6 +2065,10 @@ query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
               }
               permission
             }
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
           }
           outsideCollaborators: collaborators(affiliation: OUTSIDE, first: 100) {
             edges {
@@ -511,6 +2077,10 @@ query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
               }
               permission
             }
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
           }
           rulesets(first: 20) {
             nodes {
@@ -523,6 +2093,26 @@ query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
               name
               target
               enforcement
+              bypassActors(first:100) {
+                app:nodes {
+                  actor {
+                    ... on App {
+                      databaseId
+                      name
+                    }
+                  }
+                  bypassMode
+                }
+                team:nodes {
+                  actor {
+                    ... on Team {
+                      databaseId
+                      slug
+                    }
+                  }
+                  bypassMode
+                }
+              }
               conditions {
                 refName {
                   include
@@ -539,6 +2129,13 @@ query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
                       requiredReviewThreadResolution
                       requireLastPushApproval
                     }
+                    ... on RequiredStatusChecksParameters {
+                      requiredStatusChecks {
+                        context
+                        integrationId
+                      }
+                      strictRequiredStatusChecksPolicy
+                    }
                   }
                   type
                 }
@@ -546,55 +2143,257 @@ query listAllReposInOrg($orgLogin: String!, $endCursor: String) {
             }
           }
         }
-        pageInfo {
-          hasNextPage
-          endCursor
-        }
-        totalCount
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        totalCount
+      }
+    }
+  }
+`
+
+type GraphQLRepositoryCollaborators struct {
+	Edges []struct {
+		Node struct {
+			Login string
+		}
+		Permission string
+	}
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   string
+	} `json:"pageInfo"`
+}
+
+type GraplQLRepositories struct {
+	Data struct {
+		Organization struct {
+			Repositories struct {
+				Nodes []struct {
+					Name                     string
+					Id                       string
+					DatabaseId               int
+					IsArchived               bool
+					IsPrivate                bool
+					AutoMergeAllowed         bool
+					DeleteBranchOnMerge      bool
+					AllowUpdateBranch        bool
+					HasIssuesEnabled         bool
+					HasWikiEnabled           bool
+					HasProjectsEnabled       bool
+					ForkingAllowed           bool
+					MergeCommitAllowed       bool
+					SquashMergeAllowed       bool
+					RebaseMergeAllowed       bool
+					SquashMergeCommitTitle   string
+					SquashMergeCommitMessage string
+					MergeCommitTitle         string
+					MergeCommitMessage       string
+					RepositoryTopics         struct {
+						Nodes []struct {
+							Topic struct {
+								Name string
+							}
+						}
+					}
+					DirectCollaborators  GraphQLRepositoryCollaborators
+					OutsideCollaborators GraphQLRepositoryCollaborators
+					Rulesets             struct {
+						Nodes []GraphQLGithubRuleSet
+					}
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			} `json:"repositories"`
+		}
+	}
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+func (g *GoliacRemoteImpl) loadRepositories(ctx context.Context) (map[string]*GithubRepository, map[string]*GithubRepository, error) {
+	logrus.Debug("loading repositories")
+	repositories := make(map[string]*GithubRepository)
+	repositoriesByRefId := make(map[string]*GithubRepository)
+
+	variables := make(map[string]interface{})
+	variables["orgLogin"] = g.organization
+	variables["endCursor"] = nil
+
+	// repositories with more than one page of (direct or outside) collaborators
+	remainingCollaborators := []remainingRepositoryCollaborators{}
+
+	progress := newLoadingProgress("repositories")
+
+	var retErr error
+	hasNextPage := true
+	count := 0
+	for hasNextPage {
+		data, err := g.client.QueryGraphQLAPI(ctx, listAllReposInOrg, variables)
+		if err != nil {
+			return repositories, repositoriesByRefId, err
+		}
+		var gResult GraplQLRepositories
+
+		// parse first page
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return repositories, repositoriesByRefId, err
+		}
+		if len(gResult.Errors) > 0 {
+			retErr = fmt.Errorf("graphql error on loadRepositories: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
+		}
+
+		for _, c := range gResult.Data.Organization.Repositories.Nodes {
+			repo := &GithubRepository{
+				Name:  c.Name,
+				Id:    c.DatabaseId,
+				RefId: c.Id,
+				BoolProperties: map[string]bool{
+					"archived":               c.IsArchived,
+					"private":                c.IsPrivate,
+					"allow_auto_merge":       c.AutoMergeAllowed,
+					"delete_branch_on_merge": c.DeleteBranchOnMerge,
+					"allow_update_branch":    c.AllowUpdateBranch,
+					"has_issues":             c.HasIssuesEnabled,
+					"has_wiki":               c.HasWikiEnabled,
+					"has_projects":           c.HasProjectsEnabled,
+					"allow_forking":          c.ForkingAllowed,
+					"allow_merge_commit":     c.MergeCommitAllowed,
+					"allow_squash_merge":     c.SquashMergeAllowed,
+					"allow_rebase_merge":     c.RebaseMergeAllowed,
+				},
+				StringProperties: map[string]string{
+					"squash_merge_commit_title":   c.SquashMergeCommitTitle,
+					"squash_merge_commit_message": c.SquashMergeCommitMessage,
+					"merge_commit_title":          c.MergeCommitTitle,
+					"merge_commit_message":        c.MergeCommitMessage,
+				},
+				ExternalUsers:    make(map[string]string),
+				InternalUsers:    make(map[string]string),
+				RuleSets:         make(map[string]*GithubRuleSet),
+				CustomProperties: make(map[string]string),
+			}
+			for _, topic := range c.RepositoryTopics.Nodes {
+				repo.Topics = append(repo.Topics, topic.Topic.Name)
+			}
+			addRepositoryCollaborators(repo.ExternalUsers, &c.OutsideCollaborators)
+			addRepositoryCollaborators(repo.InternalUsers, &c.DirectCollaborators)
+			if c.OutsideCollaborators.PageInfo.HasNextPage {
+				remainingCollaborators = append(remainingCollaborators, remainingRepositoryCollaborators{
+					repository:  c.Name,
+					affiliation: "OUTSIDE",
+					endCursor:   c.OutsideCollaborators.PageInfo.EndCursor,
+					users:       repo.ExternalUsers,
+				})
+			}
+			if c.DirectCollaborators.PageInfo.HasNextPage {
+				remainingCollaborators = append(remainingCollaborators, remainingRepositoryCollaborators{
+					repository:  c.Name,
+					affiliation: "DIRECT",
+					endCursor:   c.DirectCollaborators.PageInfo.EndCursor,
+					users:       repo.InternalUsers,
+				})
+			}
+			for _, ruleset := range c.Rulesets.Nodes {
+				// if the source is the repository itself, it is not a organization ruleset
+				// we add the ruleset
+				if ruleset.Source.Name == c.Name {
+					repo.RuleSets[ruleset.Name] = g.fromGraphQLToGithubRuleset(&ruleset)
+				}
+			}
+			repositories[c.Name] = repo
+			repositoriesByRefId[c.Id] = repo
+		}
+
+		if g.feedback != nil {
+			g.feedback.LoadingAsset("repositories", len(gResult.Data.Organization.Repositories.Nodes))
+		}
+		progress.setTotal(gResult.Data.Organization.Repositories.TotalCount)
+		progress.add(len(gResult.Data.Organization.Repositories.Nodes))
+
+		hasNextPage = gResult.Data.Organization.Repositories.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.Repositories.PageInfo.EndCursor
+
+		count++
+		// sanity check to avoid loops
+		if count > FORLOOP_STOP {
+			break
+		}
+	}
+
+	progress.done()
+
+	// each remaining entry fills its own collaborators map, so they can be fetched concurrently
+	err := loadConcurrently(len(remainingCollaborators), func(i int) error {
+		return g.loadRepositoryCollaborators(ctx, &remainingCollaborators[i])
+	})
+	if err != nil {
+		return repositories, repositoriesByRefId, err
+	}
+
+	if g.isEnterprise {
+		err := g.loadRepositoriesCustomProperties(ctx, repositories)
+		if err != nil {
+			return repositories, repositoriesByRefId, err
+		}
+	}
+
+	return repositories, repositoriesByRefId, retErr
+}
+
+// addRepositoryCollaborators fills the users map (map[githubid]permission) with a page of collaborators
+func addRepositoryCollaborators(users map[string]string, page *GraphQLRepositoryCollaborators) {
+	for _, e := range page.Edges {
+		users[e.Node.Login] = e.Permission
+	}
+}
+
+// remainingRepositoryCollaborators are the collaborators of a repository not fetched by listAllReposInOrg
+type remainingRepositoryCollaborators struct {
+	repository  string
+	affiliation string // DIRECT or OUTSIDE
+	endCursor   string
+	users       map[string]string
+}
+
+const listRepoCollaboratorsInOrg = `
+query listRepoCollaboratorsInOrg($orgLogin: String!, $repoName: String!, $affiliation: CollaboratorAffiliation!, $endCursor: String) {
+    organization(login: $orgLogin) {
+      repository(name: $repoName) {
+        collaborators(affiliation: $affiliation, first: 100, after: $endCursor) {
+          edges {
+            node {
+              login
+            }
+            permission
+          }
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+        }
       }
     }
   }
 `
 
-type GraplQLRepositories struct {
+type GraplQLRepoCollaborators struct {
 	Data struct {
 		Organization struct {
-			Repositories struct {
-				Nodes []struct {
-					Name                string
-					Id                  string
-					DatabaseId          int
-					IsArchived          bool
-					IsPrivate           bool
-					AutoMergeAllowed    bool
-					DeleteBranchOnMerge bool
-					AllowUpdateBranch   bool
-					DirectCollaborators struct {
-						Edges []struct {
-							Node struct {
-								Login string
-							}
-							Permission string
-						}
-					}
-					OutsideCollaborators struct {
-						Edges []struct {
-							Node struct {
-								Login string
-							}
-							Permission string
-						}
-					}
-					Rulesets struct {
-						Nodes []GraphQLGithubRuleSet
-					}
-				} `json:"nodes"`
-				PageInfo struct {
-					HasNextPage bool
-					EndCursor   string
-				} `json:"pageInfo"`
-				TotalCount int `json:"totalCount"`
-			} `json:"repositories"`
+			Repository struct {
+				Collaborators GraphQLRepositoryCollaborators
+			}
 		}
 	}
 	Errors []struct {
@@ -607,73 +2406,38 @@ type GraplQLRepositories struct {
 	} `json:"errors"`
 }
 
-func (g *GoliacRemoteImpl) loadRepositories(ctx context.Context) (map[string]*GithubRepository, map[string]*GithubRepository, error) {
-	logrus.Debug("loading repositories")
-	repositories := make(map[string]*GithubRepository)
-	repositoriesByRefId := make(map[string]*GithubRepository)
-
+/*
+loadRepositoryCollaborators fetches the (direct or outside) collaborators of a
+specific repository, starting after the endCursor
+*/
+func (g *GoliacRemoteImpl) loadRepositoryCollaborators(ctx context.Context, remaining *remainingRepositoryCollaborators) error {
 	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
-	variables["endCursor"] = nil
+	variables["orgLogin"] = g.organization
+	variables["repoName"] = remaining.repository
+	variables["affiliation"] = remaining.affiliation
+	variables["endCursor"] = remaining.endCursor
 
-	var retErr error
 	hasNextPage := true
 	count := 0
 	for hasNextPage {
-		data, err := g.client.QueryGraphQLAPI(ctx, listAllReposInOrg, variables)
+		data, err := g.client.QueryGraphQLAPI(ctx, listRepoCollaboratorsInOrg, variables)
 		if err != nil {
-			return repositories, repositoriesByRefId, err
+			return err
 		}
-		var gResult GraplQLRepositories
+		var gResult GraplQLRepoCollaborators
 
-		// parse first page
 		err = json.Unmarshal(data, &gResult)
 		if err != nil {
-			return repositories, repositoriesByRefId, err
+			return err
 		}
 		if len(gResult.Errors) > 0 {
-			retErr = fmt.Errorf("graphql error on loadRepositories: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
-		}
-
-		for _, c := range gResult.Data.Organization.Repositories.Nodes {
-			repo := &GithubRepository{
-				Name:  c.Name,
-				Id:    c.DatabaseId,
-				RefId: c.Id,
-				BoolProperties: map[string]bool{
-					"archived":               c.IsArchived,
-					"private":                c.IsPrivate,
-					"allow_auto_merge":       c.AutoMergeAllowed,
-					"delete_branch_on_merge": c.DeleteBranchOnMerge,
-					"allow_update_branch":    c.AllowUpdateBranch,
-				},
-				ExternalUsers: make(map[string]string),
-				InternalUsers: make(map[string]string),
-				RuleSets:      make(map[string]*GithubRuleSet),
-			}
-			for _, outsideCollaborator := range c.OutsideCollaborators.Edges {
-				repo.ExternalUsers[outsideCollaborator.Node.Login] = outsideCollaborator.Permission
-			}
-			for _, internalCollaborator := range c.DirectCollaborators.Edges {
-				repo.InternalUsers[internalCollaborator.Node.Login] = internalCollaborator.Permission
-			}
-			for _, ruleset := range c.Rulesets.Nodes {
-				// if the source is the repository itself, it is not a organization ruleset
-				// we add the ruleset
-				if ruleset.Source.Name == c.Name {
-					repo.RuleSets[ruleset.Name] = g.fromGraphQLToGithubRuleset(&ruleset)
-				}
-			}
-			repositories[c.Name] = repo
-			repositoriesByRefId[c.Id] = repo
+			return fmt.Errorf("graphql error on loadRepositoryCollaborators: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
 		}
 
-		if g.feedback != nil {
-			g.feedback.LoadingAsset("repositories", len(gResult.Data.Organization.Repositories.Nodes))
-		}
+		addRepositoryCollaborators(remaining.users, &gResult.Data.Organization.Repository.Collaborators)
 
-		hasNextPage = gResult.Data.Organization.Repositories.PageInfo.HasNextPage
-		variables["endCursor"] = gResult.Data.Organization.Repositories.PageInfo.EndCursor
+		hasNextPage = gResult.Data.Organization.Repository.Collaborators.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.Repository.Collaborators.PageInfo.EndCursor
 
 		count++
 		// sanity check to avoid loops
@@ -681,8 +2445,68 @@ func (g *GoliacRemoteImpl) loadRepositories(ctx context.Context) (map[string]*Gi
 			break
 		}
 	}
+	return nil
+}
 
-	return repositories, repositoriesByRefId, retErr
+type RepositoryCustomPropertiesResponse struct {
+	RepositoryName string `json:"repository_name"`
+	Properties     []struct {
+		PropertyName string      `json:"property_name"`
+		Value        interface{} `json:"value"`
+	} `json:"properties"`
+}
+
+/*
+loadRepositoriesCustomProperties fetches the (Enterprise) custom properties
+values of all repositories of the organization
+*/
+func (g *GoliacRemoteImpl) loadRepositoriesCustomProperties(ctx context.Context, repositories map[string]*GithubRepository) error {
+	logrus.Debug("loading repositories custom properties")
+
+	page := 1
+	for page <= FORLOOP_STOP {
+		// https://docs.github.com/en/enterprise-cloud@latest/rest/orgs/custom-properties?apiVersion=2022-11-28#list-custom-property-values-for-organization-repositories
+		body, err := g.client.CallRestAPI(ctx,
+			fmt.Sprintf("/orgs/%s/properties/values", g.organization),
+			fmt.Sprintf("page=%d&per_page=100", page),
+			"GET",
+			nil)
+		if err != nil {
+			return fmt.Errorf("not able to list repositories custom properties: %v. %s", err, string(body))
+		}
+
+		var values []RepositoryCustomPropertiesResponse
+		err = json.Unmarshal(body, &values)
+		if err != nil {
+			return fmt.Errorf("not able to unmarshall repositories custom properties: %v", err)
+		}
+
+		for _, v := range values {
+			repo, ok := repositories[v.RepositoryName]
+			if !ok {
+				continue
+			}
+			for _, p := range v.Properties {
+				switch value := p.Value.(type) {
+				case string:
+					repo.CustomProperties[p.PropertyName] = value
+				case []interface{}:
+					// multi select values
+					multi := make([]string, 0, len(value))
+					for _, m := range value {
+						multi = append(multi, fmt.Sprintf("%v", m))
+					}
+					repo.CustomProperties[p.PropertyName] = strings.Join(multi, ",")
+				}
+			}
+		}
+
+		if len(values) < 100 {
+			break
+		}
+		page++
+	}
+	return nil
 }
 
 const listAllTeamsInOrg = `
@@ -690,12 +2514,18 @@ query listAllTeamsInOrg($orgLogin: String!, $endCursor: String) {
     organization(login: $orgLogin) {
       teams(first: 100, after: $endCursor) {
         nodes {
+          id
           name
 		  databaseId
           slug
 		  parentTeam {
 		    databaseId
 		  }
+          reviewRequestDelegationEnabled
+          reviewRequestDelegationAlgorithm
+          reviewRequestDelegationMemberCount
+          reviewRequestDelegationNotifyTeam
+          notificationSetting
         }
         pageInfo {
           hasNextPage
@@ -712,12 +2542,18 @@ type GraplQLTeams struct {
 		Organization struct {
 			Teams struct {
 				Nodes []struct {
+					Id         string
 					Name       string
 					DatabaseId int `json:"databaseId"`
 					Slug       string
 					ParentTeam struct {
 						DatabaseId int `json:"databaseId"`
 					} `json:"parentTeam"`
+					ReviewRequestDelegationEnabled     bool   `json:"reviewRequestDelegationEnabled"`
+					ReviewRequestDelegationAlgorithm   string `json:"reviewRequestDelegationAlgorithm"`
+					ReviewRequestDelegationMemberCount int    `json:"reviewRequestDelegationMemberCount"`
+					ReviewRequestDelegationNotifyTeam  bool   `json:"reviewRequestDelegationNotifyTeam"`
+					NotificationSetting                string `json:"notificationSetting"`
 				} `json:"nodes"`
 				PageInfo struct {
 					HasNextPage bool
@@ -752,7 +2588,7 @@ func (g *GoliacRemoteImpl) loadAppIds(ctx context.Context) (map[string]int, erro
 
 	// https://docs.github.com/en/enterprise-cloud@latest/rest/orgs/orgs?apiVersion=2022-11-28#list-app-installations-for-an-organization
 	body, err := g.client.CallRestAPI(ctx,
-		fmt.Sprintf("/orgs/%s/installations", config.Config.GithubAppOrganization),
+		fmt.Sprintf("/orgs/%s/installations", g.organization),
 		"page=1&per_page=30",
 		"GET",
 		nil)
@@ -775,7 +2611,7 @@ func (g *GoliacRemoteImpl) loadAppIds(ctx context.Context) (map[string]int, erro
 		// we need to paginate
 		for i := 2; i <= (installations.TotalCount/30)+1; i++ {
 			body, err := g.client.CallRestAPI(ctx,
-				fmt.Sprintf("/orgs/%s/installations", config.Config.GithubAppOrganization),
+				fmt.Sprintf("/orgs/%s/installations", g.organization),
 				fmt.Sprintf("page=%d&per_page=30", i),
 				"GET",
 				nil)
@@ -875,27 +2711,15 @@ func (g *GoliacRemoteImpl) Load(ctx context.Context, continueOnError bool) error
 	}
 
 	if time.Now().After(g.ttlExpireTeamsRepos) {
-		if config.Config.GithubConcurrentThreads <= 1 {
-			teamsrepos, err := g.loadTeamReposNonConcurrently(ctx)
-			if err != nil {
-				if !continueOnError {
-					return err
-				}
-				logrus.Debugf("Error loading teams-repos: %v", err)
-				retErr = fmt.Errorf("error loading teams-repos: %v", err)
-			}
-			g.teamRepos = teamsrepos
-		} else {
-			teamsrepos, err := g.loadTeamReposConcurrently(ctx, config.Config.GithubConcurrentThreads)
-			if err != nil {
-				if !continueOnError {
-					return err
-				}
-				logrus.Debugf("Error loading teams-repos: %v", err)
-				retErr = fmt.Errorf("error loading teams-repos: %v", err)
+		teamsrepos, err := g.loadTeamsRepositories(ctx)
+		if err != nil {
+			if !continueOnError {
+				return err
 			}
-			g.teamRepos = teamsrepos
+			logrus.Debugf("Error loading teams-repos: %v", err)
+			retErr = fmt.Errorf("error loading teams-repos: %v", err)
 		}
+		g.teamRepos = teamsrepos
 		g.ttlExpireTeamsRepos = time.Now().Add(time.Duration(config.Config.GithubCacheTTL) * time.Second)
 	}
 
@@ -906,61 +2730,222 @@ func (g *GoliacRemoteImpl) Load(ctx context.Context, continueOnError bool) error
 	return retErr
 }
 
-func (g *GoliacRemoteImpl) loadTeamReposNonConcurrently(ctx context.Context) (map[string]map[string]*GithubTeamRepo, error) {
-	logrus.Debug("loading teamReposNonConcurrently")
-	teamRepos := make(map[string]map[string]*GithubTeamRepo)
+const listAllTeamsReposInOrg = `
+query listAllTeamsReposInOrg($orgLogin: String!, $endCursor: String) {
+    organization(login: $orgLogin) {
+      teams(first: 20, after: $endCursor) {
+        nodes {
+          slug
+          repositories(first: 100) {
+            edges {
+              permission
+              node {
+                name
+              }
+            }
+            pageInfo {
+              hasNextPage
+              endCursor
+            }
+          }
+        }
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        totalCount
+      }
+    }
+  }
+`
 
-	teamsPerRepo := make(map[string]map[string]*GithubTeamRepo)
-	for repository := range g.repositories {
-		repos, err := g.loadTeamRepos(ctx, repository)
-		if err != nil {
-			return teamRepos, err
+const listAllReposOfTeamInOrg = `
+query listAllReposOfTeamInOrg($orgLogin: String!, $teamSlug: String!, $endCursor: String) {
+    organization(login: $orgLogin) {
+      team(slug: $teamSlug) {
+        repositories(first: 100, after: $endCursor) {
+          edges {
+            permission
+            node {
+              name
+            }
+          }
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+        }
+      }
+    }
+  }
+`
+
+type GraphQLTeamRepositories struct {
+	Edges []struct {
+		Permission string `json:"permission"`
+		Node       struct {
+			Name string `json:"name"`
+		} `json:"node"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   string
+	} `json:"pageInfo"`
+}
+
+type GraplQLTeamsRepos struct {
+	Data struct {
+		Organization struct {
+			Teams struct {
+				Nodes []struct {
+					Slug         string
+					Repositories GraphQLTeamRepositories `json:"repositories"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				} `json:"pageInfo"`
+				TotalCount int `json:"totalCount"`
+			} `json:"teams"`
 		}
-		if g.feedback != nil {
-			g.feedback.LoadingAsset("teams_repos", 1)
+	}
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+type GraplQLTeamRepos struct {
+	Data struct {
+		Organization struct {
+			Team struct {
+				Repositories GraphQLTeamRepositories `json:"repositories"`
+			} `json:"team"`
 		}
-		teamsPerRepo[repository] = repos
 	}
+	Errors []struct {
+		Path       []interface{} `json:"path"`
+		Extensions struct {
+			Code         string
+			ErrorMessage string
+		} `json:"extensions"`
+		Message string
+	} `json:"errors"`
+}
+
+/*
+teamRepoPermission converts a team repository permission as used by the REST api
+(admin, maintain, push, triage, pull) into the GraphQL one (ADMIN, MAINTAIN, WRITE, TRIAGE, READ)
+*/
+func teamRepoPermission(permission string) string {
+	switch permission {
+	case "admin":
+		return "ADMIN"
+	case "maintain":
+		return "MAINTAIN"
+	case "push":
+		return "WRITE"
+	case "triage":
+		return "TRIAGE"
+	default:
+		return "READ"
+	}
+}
 
-	// we have all the teams per repo, now we need to invert the map
-	for repository, repos := range teamsPerRepo {
-		for team, repo := range repos {
-			if _, ok := teamRepos[team]; ok {
-				teamRepos[team][repository] = repo
-			} else {
-				teamRepos[team] = map[string]*GithubTeamRepo{repository: repo}
-			}
+// addTeamRepositories fills the (team's) repos map with a page of repositories
+func addTeamRepositories(repos map[string]*GithubTeamRepo, page *GraphQLTeamRepositories) {
+	for _, e := range page.Edges {
+		permission := ""
+		switch e.Permission {
+		case "ADMIN", "MAINTAIN", "WRITE", "TRIAGE", "READ":
+			permission = e.Permission
+		}
+		repos[e.Node.Name] = &GithubTeamRepo{
+			Name:       e.Node.Name,
+			Permission: permission,
 		}
 	}
+}
 
-	return teamRepos, nil
+/*
+loadingProgress periodically logs (every LOADING_PROGRESS_INTERVAL at most) the
+progress of the fetch of an asset from Github, like "fetched 1200/5000 repositories".
+It is safe to use it from concurrent workers
+*/
+type loadingProgress struct {
+	asset   string
+	total   int
+	fetched int
+	last    time.Time
+	mutex   sync.Mutex
 }
 
-func (g *GoliacRemoteImpl) loadTeamReposConcurrently(ctx context.Context, maxGoroutines int64) (map[string]map[string]*GithubTeamRepo, error) {
-	logrus.Debug("loading teamReposConcurrently")
-	teamRepos := make(map[string]map[string]*GithubTeamRepo)
+// minimum delay between 2 progress lines of the same asset
+const LOADING_PROGRESS_INTERVAL = 10 * time.Second
+
+func newLoadingProgress(asset string) *loadingProgress {
+	return &loadingProgress{
+		asset: asset,
+		last:  time.Now(),
+	}
+}
+
+// setTotal updates the number of assets to fetch (usually known after the first page)
+func (p *loadingProgress) setTotal(total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.total = total
+}
+
+// add reports nb newly fetched assets
+func (p *loadingProgress) add(nb int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.fetched += nb
+	if time.Since(p.last) >= LOADING_PROGRESS_INTERVAL {
+		p.last = time.Now()
+		logrus.Infof("fetched %d/%d %s", p.fetched, p.total, p.asset)
+	}
+}
+
+// done logs the final progress line
+func (p *loadingProgress) done() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	logrus.Debugf("fetched %d/%d %s", p.fetched, p.total, p.asset)
+}
 
-	teamsPerRepo := make(map[string]map[string]*GithubTeamRepo)
+/*
+loadConcurrently calls load(i) for i in [0, nb), spreading the calls over a pool
+of workers (bounded by GithubConcurrentThreads). If GithubConcurrentThreads is 1
+(or less) the calls are done serially.
+It returns the first error encountered
+*/
+func loadConcurrently(nb int, load func(i int) error) error {
+	if config.Config.GithubConcurrentThreads <= 1 {
+		for i := 0; i < nb; i++ {
+			if err := load(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
 	var wg sync.WaitGroup
-	var wg2 sync.WaitGroup
 
-	// Create buffered channels
-	reposChan := make(chan string, len(g.repositories))
+	indexChan := make(chan int, nb)
 	errChan := make(chan error, 1) // will hold the first error
-	teamReposChan := make(chan struct {
-		repoName string
-		repos    map[string]*GithubTeamRepo
-	}, len(g.repositories))
 
-	// Create worker goroutines
-	for i := int64(0); i < maxGoroutines; i++ {
+	for w := int64(0); w < config.Config.GithubConcurrentThreads; w++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for repoName := range reposChan {
-				repos, err := g.loadTeamRepos(ctx, repoName)
-				if err != nil {
+			for i := range indexChan {
+				if err := load(i); err != nil {
 					// Try to report the error
 					select {
 					case errChan <- err:
@@ -968,105 +2953,153 @@ func (g *GoliacRemoteImpl) loadTeamReposConcurrently(ctx context.Context, maxGor
 					}
 					return
 				}
-				teamReposChan <- struct {
-					repoName string
-					repos    map[string]*GithubTeamRepo
-				}{repoName, repos}
 			}
 		}()
 	}
 
-	// Send repositories to reposChan
-	for repoName := range g.repositories {
-		reposChan <- repoName
+	for i := 0; i < nb; i++ {
+		indexChan <- i
 	}
-	close(reposChan)
+	close(indexChan)
 
-	wg2.Add(1)
-	go func() {
-		defer wg2.Done()
-		for r := range teamReposChan {
-			if g.feedback != nil {
-				g.feedback.LoadingAsset("teams_repos", 1)
-			}
-			teamsPerRepo[r.repoName] = r.repos
-		}
-	}()
-
-	// Wait for all goroutines to finish
 	wg.Wait()
-	close(teamReposChan)
-	wg2.Wait()
 
-	// Check if any goroutine returned an error
 	select {
 	case err := <-errChan:
-		return teamRepos, err
+		return err
 	default:
-		//nop
+		return nil
 	}
+}
+
+/*
+loadTeamsRepositories returns
+map[teamSlug]map[repoName]repoinfo
+
+It fetches (with a single paginated GraphQL query) all the teams with their
+repositories (and permissions). Only the teams with more than 100 repositories
+need an extra (paginated) query.
+The Github GraphQL API doesn't expose the teams of a repository, so this can't
+be part of listAllReposInOrg (which already fetches the collaborators)
+*/
+func (g *GoliacRemoteImpl) loadTeamsRepositories(ctx context.Context) (map[string]map[string]*GithubTeamRepo, error) {
+	logrus.Debug("loading teamsRepositories")
+	teamRepos := make(map[string]map[string]*GithubTeamRepo)
 
-	// we have all the teams per repo, now we need to invert the map
-	for repository, repos := range teamsPerRepo {
-		for team, repo := range repos {
-			if _, ok := teamRepos[team]; ok {
-				teamRepos[team][repository] = repo
+	variables := make(map[string]interface{})
+	variables["orgLogin"] = g.organization
+	variables["endCursor"] = nil
+
+	// teams with more than one page of repositories
+	remainingTeams := make(map[string]string)
+
+	progress := newLoadingProgress("teams repositories")
+
+	hasNextPage := true
+	count := 0
+	for hasNextPage {
+		data, err := g.client.QueryGraphQLAPI(ctx, listAllTeamsReposInOrg, variables)
+		if err != nil {
+			return teamRepos, err
+		}
+		var gResult GraplQLTeamsRepos
+
+		// parse first page
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return teamRepos, err
+		}
+		if len(gResult.Errors) > 0 {
+			return teamRepos, fmt.Errorf("graphql error on loadTeamsRepositories: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
+		}
+
+		progress.setTotal(gResult.Data.Organization.Teams.TotalCount)
+		for _, t := range gResult.Data.Organization.Teams.Nodes {
+			repos := make(map[string]*GithubTeamRepo)
+			addTeamRepositories(repos, &t.Repositories)
+			teamRepos[t.Slug] = repos
+			if t.Repositories.PageInfo.HasNextPage {
+				remainingTeams[t.Slug] = t.Repositories.PageInfo.EndCursor
 			} else {
-				teamRepos[team] = map[string]*GithubTeamRepo{repository: repo}
+				progress.add(1)
 			}
 		}
+
+		if g.feedback != nil {
+			g.feedback.LoadingAsset("teams_repos", len(gResult.Data.Organization.Teams.Nodes))
+		}
+
+		hasNextPage = gResult.Data.Organization.Teams.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.Teams.PageInfo.EndCursor
+
+		count++
+		// sanity check to avoid loops
+		if count > FORLOOP_STOP {
+			break
+		}
 	}
 
-	return teamRepos, nil
-}
+	// each team fills its own repos map, so the teams can be fetched concurrently
+	remainingSlugs := make([]string, 0, len(remainingTeams))
+	for teamSlug := range remainingTeams {
+		remainingSlugs = append(remainingSlugs, teamSlug)
+	}
+	err := loadConcurrently(len(remainingSlugs), func(i int) error {
+		teamSlug := remainingSlugs[i]
+		err := g.loadTeamRepositories(ctx, teamSlug, remainingTeams[teamSlug], teamRepos[teamSlug])
+		if err != nil {
+			return err
+		}
+		progress.add(1)
+		return nil
+	})
+	progress.done()
 
-type TeamsRepoResponse struct {
-	Name       string `json:"name"`
-	Permission string `json:"permission"`
-	Slug       string `json:"slug"`
+	return teamRepos, err
 }
 
 /*
-loadTeamRepos returns
-map[teamSlug]repoinfo
+loadTeamRepositories fetches the repositories of a specific team,
+starting after the endCursor (can be empty)
 */
-func (g *GoliacRemoteImpl) loadTeamRepos(ctx context.Context, repository string) (map[string]*GithubTeamRepo, error) {
-	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#list-repository-teams
-	teamsrepo := make(map[string]*GithubTeamRepo)
-
-	data, err := g.client.CallRestAPI(
-		ctx,
-		"/repos/"+config.Config.GithubAppOrganization+"/"+repository+"/teams",
-		"",
-		"GET",
-		nil)
-	if err != nil {
-		return nil, fmt.Errorf("not able to list teams for repo %s: %v", repository, err)
+func (g *GoliacRemoteImpl) loadTeamRepositories(ctx context.Context, teamSlug string, endCursor string, repos map[string]*GithubTeamRepo) error {
+	variables := make(map[string]interface{})
+	variables["orgLogin"] = g.organization
+	variables["teamSlug"] = teamSlug
+	variables["endCursor"] = nil
+	if endCursor != "" {
+		variables["endCursor"] = endCursor
 	}
 
-	var teams []TeamsRepoResponse
-	err = json.Unmarshal(data, &teams)
-	if err != nil {
-		return nil, fmt.Errorf("not able to unmarshall teams for repo %s: %v", repository, err)
-	}
+	hasNextPage := true
+	count := 0
+	for hasNextPage {
+		data, err := g.client.QueryGraphQLAPI(ctx, listAllReposOfTeamInOrg, variables)
+		if err != nil {
+			return err
+		}
+		var gResult GraplQLTeamRepos
 
-	for _, t := range teams {
-		permission := ""
-		switch t.Permission {
-		case "admin":
-			permission = "ADMIN"
-		case "push":
-			permission = "WRITE"
-		case "pull":
-			permission = "READ"
+		err = json.Unmarshal(data, &gResult)
+		if err != nil {
+			return err
 		}
-		teamsrepo[t.Slug] = &GithubTeamRepo{
-			Name:       repository,
-			Permission: permission,
+		if len(gResult.Errors) > 0 {
+			return fmt.Errorf("graphql error on loadTeamRepositories: %v (%v)", gResult.Errors[0].Message, gResult.Errors[0].Path)
 		}
-	}
 
-	return teamsrepo, nil
+		addTeamRepositories(repos, &gResult.Data.Organization.Team.Repositories)
+
+		hasNextPage = gResult.Data.Organization.Team.Repositories.PageInfo.HasNextPage
+		variables["endCursor"] = gResult.Data.Organization.Team.Repositories.PageInfo.EndCursor
+
+		count++
+		// sanity check to avoid loops
+		if count > FORLOOP_STOP {
+			break
+		}
+	}
+	return nil
 }
 
 const listAllTeamMembersInOrg = `
@@ -1127,7 +3160,7 @@ func (g *GoliacRemoteImpl) loadTeams(ctx context.Context) (map[string]*GithubTea
 	teamSlugByName := make(map[string]string)
 
 	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
+	variables["orgLogin"] = g.organization
 	variables["endCursor"] = nil
 
 	hasNextPage := true
@@ -1150,14 +3183,24 @@ func (g *GoliacRemoteImpl) loadTeams(ctx context.Context) (map[string]*GithubTea
 
 		for _, c := range gResult.Data.Organization.Teams.Nodes {
 			team := GithubTeam{
-				Name: c.Name,
-				Id:   c.DatabaseId,
-				Slug: c.Slug,
+				Name:   c.Name,
+				Id:     c.DatabaseId,
+				NodeId: c.Id,
+				Slug:   c.Slug,
 			}
 			if c.ParentTeam.DatabaseId != 0 {
 				parentId := c.ParentTeam.DatabaseId
 				team.ParentTeam = &parentId
 			}
+			// NOTIFICATIONS_ENABLED, NOTIFICATIONS_DISABLED
+			team.NotificationSetting = strings.ToLower(c.NotificationSetting)
+			if c.ReviewRequestDelegationEnabled {
+				team.ReviewAssignment = &GithubTeamReviewAssignment{
+					Algorithm:        c.ReviewRequestDelegationAlgorithm,
+					TeamMembersCount: c.ReviewRequestDelegationMemberCount,
+					NotifyTeam:       c.ReviewRequestDelegationNotifyTeam,
+				}
+			}
 			teams[c.Slug] = &team
 			teamSlugByName[c.Name] = c.Slug
 		}
@@ -1177,61 +3220,21 @@ func (g *GoliacRemoteImpl) loadTeams(ctx context.Context) (map[string]*GithubTea
 	}
 
 	// load team's members
-	if config.Config.GithubConcurrentThreads <= 1 {
-		for _, t := range teams {
-			err := g.loadTeamsMembers(ctx, t)
-			if err != nil {
-				return teams, teamSlugByName, err
-			}
-			if g.feedback != nil {
-				g.feedback.LoadingAsset("teams_members", 1)
-			}
-		}
-	} else {
-		var wg sync.WaitGroup
-
-		// Create buffered channels
-		teamsChan := make(chan *GithubTeam, len(teams))
-		errChan := make(chan error, 1) // will hold the first error
-
-		// Create worker goroutines
-		for i := int64(0); i < config.Config.GithubConcurrentThreads; i++ {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for t := range teamsChan {
-					err := g.loadTeamsMembers(ctx, t)
-					if err != nil {
-						// Try to report the error
-						select {
-						case errChan <- err:
-						default:
-						}
-						return
-					}
-					if g.feedback != nil {
-						g.feedback.LoadingAsset("teams_members", 1)
-					}
-				}
-			}()
-		}
-
-		// Send teams to teamsChan
-		for _, t := range teams {
-			teamsChan <- t
+	teamsList := make([]*GithubTeam, 0, len(teams))
+	for _, t := range teams {
+		teamsList = append(teamsList, t)
+	}
+	err := loadConcurrently(len(teamsList), func(i int) error {
+		if err := g.loadTeamsMembers(ctx, teamsList[i]); err != nil {
+			return err
 		}
-		close(teamsChan)
-
-		// Wait for all goroutines to finish
-		wg.Wait()
-
-		// Check if any goroutine returned an error
-		select {
-		case err := <-errChan:
-			return teams, teamSlugByName, err
-		default:
-			//nop
+		if g.feedback != nil {
+			g.feedback.LoadingAsset("teams_members", 1)
 		}
+		return nil
+	})
+	if err != nil {
+		return teams, teamSlugByName, err
 	}
 
 	return teams, teamSlugByName, nil
@@ -1239,7 +3242,7 @@ func (g *GoliacRemoteImpl) loadTeams(ctx context.Context) (map[string]*GithubTea
 
 func (g *GoliacRemoteImpl) loadTeamsMembers(ctx context.Context, t *GithubTeam) error {
 	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
+	variables["orgLogin"] = g.organization
 	variables["endCursor"] = nil
 	variables["teamSlug"] = t.Slug
 
@@ -1324,6 +3327,13 @@ query listRulesets ($orgLogin: String!) {
 						requiredReviewThreadResolution
 						requireLastPushApproval
 					}
+					... on RequiredStatusChecksParameters {
+						requiredStatusChecks {
+							context
+							integrationId
+						}
+						strictRequiredStatusChecksPolicy
+					}
 				}
 				type
 			}
@@ -1347,6 +3357,14 @@ type GithubRuleSetApp struct {
 	BypassMode string // ALWAYS, PULL_REQUEST
 }
 
+type GithubRuleSetTeam struct {
+	Actor struct {
+		DatabaseId int
+		Slug       string
+	}
+	BypassMode string // ALWAYS, PULL_REQUEST
+}
+
 type GithubRuleSetRuleStatusCheck struct {
 	Context       string
 	IntegrationId int
@@ -1378,7 +3396,8 @@ type GraphQLGithubRuleSet struct {
 	Target       string // BRANCH, TAG
 	Enforcement  string // DISABLED, ACTIVE, EVALUATE
 	BypassActors struct {
-		App []GithubRuleSetApp
+		App  []GithubRuleSetApp
+		Team []GithubRuleSetTeam // only fetched for the repository rulesets
 	}
 	Conditions struct {
 		RefName struct { // target branches
@@ -1427,6 +3446,7 @@ type GithubRuleSet struct {
 	Id          int               // for tracking purpose
 	Enforcement string            // disabled, active, evaluate
 	BypassApps  map[string]string // appname, mode (always, pull_request)
+	BypassTeams map[string]string // teamslug, mode (always, pull_request), only for repository rulesets
 
 	OnInclude []string // ~DEFAULT_BRANCH, ~ALL, branch_name, ...
 	OnExclude []string //  branch_name, ...
@@ -1434,6 +3454,8 @@ type GithubRuleSet struct {
 	Rules map[string]entity.RuleSetParameters
 
 	Repositories []string // only used for organization rulesets
+
+	Priority int // local only: order of creation (not compared)
 }
 
 func (g *GoliacRemoteImpl) fromGraphQLToGithubRuleset(src *GraphQLGithubRuleSet) *GithubRuleSet {
@@ -1442,13 +3464,22 @@ func (g *GoliacRemoteImpl) fromGraphQLToGithubRuleset(src *GraphQLGithubRuleSet)
 		Id:           src.DatabaseId,
 		Enforcement:  strings.ToLower(src.Enforcement),
 		BypassApps:   map[string]string{},
+		BypassTeams:  map[string]string{},
 		OnInclude:    src.Conditions.RefName.Include,
 		OnExclude:    src.Conditions.RefName.Exclude,
 		Rules:        map[string]entity.RuleSetParameters{},
 		Repositories: []string{},
 	}
+	// each node is listed as an app and as a team: only the matching actor is set
 	for _, b := range src.BypassActors.App {
-		ruleset.BypassApps[b.Actor.Name] = strings.ToLower(b.BypassMode)
+		if b.Actor.Name != "" {
+			ruleset.BypassApps[b.Actor.Name] = strings.ToLower(b.BypassMode)
+		}
+	}
+	for _, b := range src.BypassActors.Team {
+		if b.Actor.Slug != "" {
+			ruleset.BypassTeams[b.Actor.Slug] = strings.ToLower(b.BypassMode)
+		}
 	}
 
 	for _, r := range src.Rules.Nodes {
@@ -1462,6 +3493,12 @@ func (g *GoliacRemoteImpl) fromGraphQLToGithubRuleset(src *GraphQLGithubRuleSet)
 		}
 		for _, s := range r.Parameters.RequiredStatusChecks {
 			rule.RequiredStatusChecks = append(rule.RequiredStatusChecks, s.Context)
+			if s.IntegrationId != 0 {
+				if rule.RequiredStatusChecksIntegrations == nil {
+					rule.RequiredStatusChecksIntegrations = make(map[string]int)
+				}
+				rule.RequiredStatusChecksIntegrations[s.Context] = s.IntegrationId
+			}
 		}
 		ruleset.Rules[strings.ToLower(r.Type)] = rule
 	}
@@ -1478,7 +3515,7 @@ func (g *GoliacRemoteImpl) fromGraphQLToGithubRuleset(src *GraphQLGithubRuleSet)
 func (g *GoliacRemoteImpl) loadRulesets(ctx context.Context) (map[string]*GithubRuleSet, error) {
 	logrus.Debug("loading rulesets")
 	variables := make(map[string]interface{})
-	variables["orgLogin"] = config.Config.GithubAppOrganization
+	variables["orgLogin"] = g.organization
 	variables["endCursor"] = nil
 
 	rulesets := make(map[string]*GithubRuleSet)
@@ -1532,6 +3569,15 @@ func (g *GoliacRemoteImpl) prepareRuleset(ruleset *GithubRuleSet) map[string]int
 			bypassActors = append(bypassActors, bypassActor)
 		}
 	}
+	for teamslug, mode := range ruleset.BypassTeams {
+		if team, ok := g.teams[teamslug]; ok {
+			bypassActors = append(bypassActors, map[string]interface{}{
+				"actor_id":    team.Id,
+				"actor_type":  "Team",
+				"bypass_mode": mode,
+			})
+		}
+	}
 
 	repoIds := []int{}
 	for _, r := range ruleset.Repositories {
@@ -1588,10 +3634,18 @@ func (g *GoliacRemoteImpl) prepareRuleset(ruleset *GithubRuleSet) map[string]int
 				},
 			})
 		case "required_status_checks":
+			statusChecks := make([]map[string]interface{}, 0, len(rule.RequiredStatusChecks))
+			for _, context := range rule.RequiredStatusChecks {
+				statusCheck := map[string]interface{}{"context": context}
+				if id := rule.RequiredStatusChecksIntegrations[context]; id != 0 {
+					statusCheck["integration_id"] = id
+				}
+				statusChecks = append(statusChecks, statusCheck)
+			}
 			rules = append(rules, map[string]interface{}{
 				"type": "required_status_checks",
 				"parameters": map[string]interface{}{
-					"required_status_checks":               rule.RequiredStatusChecks,
+					"required_status_checks":               statusChecks,
 					"strict_required_status_checks_policy": rule.StrictRequiredStatusChecksPolicy,
 				},
 			})
@@ -1616,7 +3670,7 @@ func (g *GoliacRemoteImpl) AddRuleset(ctx context.Context, dryrun bool, ruleset
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/rulesets", config.Config.GithubAppOrganization),
+			fmt.Sprintf("/orgs/%s/rulesets", g.organization),
 			"",
 			"POST",
 			g.prepareRuleset(ruleset),
@@ -1636,7 +3690,7 @@ func (g *GoliacRemoteImpl) UpdateRuleset(ctx context.Context, dryrun bool, rules
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/rulesets/%d", config.Config.GithubAppOrganization, ruleset.Id),
+			fmt.Sprintf("/orgs/%s/rulesets/%d", g.organization, ruleset.Id),
 			"",
 			"PUT",
 			g.prepareRuleset(ruleset),
@@ -1656,7 +3710,7 @@ func (g *GoliacRemoteImpl) DeleteRuleset(ctx context.Context, dryrun bool, rules
 	if !dryrun {
 		_, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/rulesets/%d", config.Config.GithubAppOrganization, rulesetid),
+			fmt.Sprintf("/orgs/%s/rulesets/%d", g.organization, rulesetid),
 			"",
 			"DELETE",
 			nil,
@@ -1681,7 +3735,7 @@ func (g *GoliacRemoteImpl) AddRepositoryRuleset(ctx context.Context, dryrun bool
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/%s/rulesets", config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("/orgs/%s/%s/rulesets", g.organization, reponame),
 			"",
 			"POST",
 			g.prepareRuleset(ruleset),
@@ -1703,7 +3757,7 @@ func (g *GoliacRemoteImpl) UpdateRepositoryRuleset(ctx context.Context, dryrun b
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/%s/rulesets/%d", config.Config.GithubAppOrganization, reponame, ruleset.Id),
+			fmt.Sprintf("/orgs/%s/%s/rulesets/%d", g.organization, reponame, ruleset.Id),
 			"",
 			"PUT",
 			g.prepareRuleset(ruleset),
@@ -1725,7 +3779,7 @@ func (g *GoliacRemoteImpl) DeleteRepositoryRuleset(ctx context.Context, dryrun b
 	if !dryrun {
 		_, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/%s/rulesets/%d", config.Config.GithubAppOrganization, reponame, rulesetid),
+			fmt.Sprintf("/orgs/%s/%s/rulesets/%d", g.organization, reponame, rulesetid),
 			"",
 			"DELETE",
 			nil,
@@ -1746,51 +3800,336 @@ func (g *GoliacRemoteImpl) DeleteRepositoryRuleset(ctx context.Context, dryrun b
 	}
 }
 
-func (g *GoliacRemoteImpl) AddUserToOrg(ctx context.Context, dryrun bool, ghuserid string) {
-	// add member
-	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#create-a-team
+func (g *GoliacRemoteImpl) AddUserToOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	// add member
+	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#create-a-team
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/memberships/%s", g.organization, ghuserid),
+			"",
+			"PUT",
+			map[string]interface{}{"role": "member"},
+		)
+		if err != nil {
+			logrus.Errorf("failed to add user to org: %v. %s", err, string(body))
+		}
+	}
+
+	g.users[ghuserid] = "MEMBER"
+}
+
+func (g *GoliacRemoteImpl) CancelOrgInvitation(ctx context.Context, dryrun bool, invitation *GithubOrgInvitation) {
+	// https://docs.github.com/en/rest/orgs/members?apiVersion=2022-11-28#cancel-an-organization-invitation
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/invitations/%d", g.organization, invitation.Id),
+			"",
+			"DELETE",
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to cancel the org invitation of %s: %v. %s", invitation.Login, err, string(body))
+			return
+		}
+	}
+
+	delete(g.orgInvitations, invitation.Login)
+}
+
+func (g *GoliacRemoteImpl) UpdateUserOrgRole(ctx context.Context, dryrun bool, ghuserid string, role string) {
+	// set membership role
+	// https://docs.github.com/en/rest/orgs/members?apiVersion=2022-11-28#set-organization-membership-for-a-user
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/memberships/%s", g.organization, ghuserid),
+			"",
+			"PUT",
+			map[string]interface{}{"role": role},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update user org role: %v. %s", err, string(body))
+		}
+	}
+
+	g.users[ghuserid] = strings.ToUpper(role)
+}
+
+func (g *GoliacRemoteImpl) RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	// remove member
+	// https://docs.github.com/en/rest/orgs/members?apiVersion=2022-11-28#remove-organization-membership-for-a-user
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/memberships/%s", g.organization, ghuserid),
+			"",
+			"DELETE",
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to remove user from org: %v. %s", err, string(body))
+		}
+	}
+
+	delete(g.users, ghuserid)
+	// Github also removes the user from all the teams of the organization
+	for _, t := range g.teams {
+		t.Members = removeGithubId(t.Members, ghuserid)
+		t.Maintainers = removeGithubId(t.Maintainers, ghuserid)
+	}
+}
+
+func (g *GoliacRemoteImpl) RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	// remove the outside collaborator from all the repositories of the organization
+	// https://docs.github.com/en/rest/orgs/outside-collaborators?apiVersion=2022-11-28#remove-outside-collaborator-from-an-organization
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/outside_collaborators/%s", g.organization, ghuserid),
+			"",
+			"DELETE",
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to remove outside collaborator from org: %v. %s", err, string(body))
+		}
+	}
+
+	delete(g.outsideCollaborators, ghuserid)
+	for _, r := range g.repositories {
+		delete(r.ExternalUsers, ghuserid)
+	}
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) {
+	// create or update the file on the default branch
+	// https://docs.github.com/en/rest/repos/contents?apiVersion=2022-11-28#create-or-update-file-contents
+	if !dryrun {
+		params := map[string]interface{}{
+			"message": fmt.Sprintf("update %s (managed by Goliac)", filename),
+			"content": base64.StdEncoding.EncodeToString([]byte(content)),
+			"committer": map[string]interface{}{
+				"name":  "Goliac",
+				"email": config.Config.GoliacEmail,
+			},
+		}
+		// the sha of the current file (if any)
+		if sha != "" {
+			params["sha"] = sha
+		}
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/contents/%s", g.organization, reponame, filename),
+			"",
+			"PUT",
+			params,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the file %s of the repository %s: %v. %s", filename, reponame, err, string(body))
+		}
+	}
+}
+
+func (g *GoliacRemoteImpl) AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *GithubLabel) {
+	// https://docs.github.com/en/rest/issues/labels?apiVersion=2022-11-28#create-a-label
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/labels", g.organization, reponame),
+			"",
+			"POST",
+			map[string]interface{}{
+				"name":        label.Name,
+				"color":       label.Color,
+				"description": label.Description,
+			},
+		)
+		if err != nil {
+			logrus.Errorf("failed to add the label %s to the repository %s: %v. %s", label.Name, reponame, err, string(body))
+		}
+	}
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *GithubLabel) {
+	// https://docs.github.com/en/rest/issues/labels?apiVersion=2022-11-28#update-a-label
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/labels/%s", g.organization, reponame, url.PathEscape(labelname)),
+			"",
+			"PATCH",
+			map[string]interface{}{
+				"new_name":    label.Name,
+				"color":       label.Color,
+				"description": label.Description,
+			},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the label %s of the repository %s: %v. %s", labelname, reponame, err, string(body))
+		}
+	}
+}
+
+func (g *GoliacRemoteImpl) DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string) {
+	// https://docs.github.com/en/rest/issues/labels?apiVersion=2022-11-28#delete-a-label
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/labels/%s", g.organization, reponame, url.PathEscape(labelname)),
+			"",
+			"DELETE",
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to delete the label %s of the repository %s: %v. %s", labelname, reponame, err, string(body))
+		}
+	}
+}
+
+func orgWebhookPayload(webhook *GithubOrgWebhook) map[string]interface{} {
+	hookConfig := map[string]interface{}{
+		"url":          webhook.Url,
+		"content_type": webhook.ContentType,
+		"insecure_ssl": "0",
+	}
+	if webhook.Secret != "" {
+		hookConfig["secret"] = webhook.Secret
+	}
+	return map[string]interface{}{
+		"active": webhook.Active,
+		"events": webhook.Events,
+		"config": hookConfig,
+	}
+}
+
+/*
+cachedOrgWebhook returns the webhook as Github returns it (without the secret)
+*/
+func cachedOrgWebhook(webhook *GithubOrgWebhook, id int) *GithubOrgWebhook {
+	return &GithubOrgWebhook{
+		Id:          id,
+		Url:         webhook.Url,
+		Events:      webhook.Events,
+		Active:      webhook.Active,
+		ContentType: webhook.ContentType,
+		HasSecret:   webhook.Secret != "",
+	}
+}
+
+func (g *GoliacRemoteImpl) AddOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	id := 0
+	// https://docs.github.com/en/rest/orgs/webhooks?apiVersion=2022-11-28#create-an-organization-webhook
+	if !dryrun {
+		payload := orgWebhookPayload(webhook)
+		payload["name"] = "web"
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/hooks", g.organization),
+			"",
+			"POST",
+			payload,
+		)
+		if err != nil {
+			logrus.Errorf("failed to add the org webhook %s: %v. %s", webhook.Url, err, string(body))
+			return
+		}
+		var res OrgWebhookResponse
+		if err := json.Unmarshal(body, &res); err != nil {
+			logrus.Errorf("failed to read the org webhook %s creation response: %v", webhook.Url, err)
+		}
+		id = res.Id
+	}
+
+	g.orgWebhooks[webhook.Url] = cachedOrgWebhook(webhook, id)
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	// https://docs.github.com/en/rest/orgs/webhooks?apiVersion=2022-11-28#update-an-organization-webhook
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/hooks/%d", g.organization, webhook.Id),
+			"",
+			"PATCH",
+			orgWebhookPayload(webhook),
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the org webhook %s: %v. %s", webhook.Url, err, string(body))
+			return
+		}
+	}
+
+	g.orgWebhooks[webhook.Url] = cachedOrgWebhook(webhook, webhook.Id)
+}
+
+func (g *GoliacRemoteImpl) DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *GithubOrgWebhook) {
+	// https://docs.github.com/en/rest/orgs/webhooks?apiVersion=2022-11-28#delete-an-organization-webhook
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/hooks/%d", g.organization, webhook.Id),
+			"",
+			"DELETE",
+			nil,
+		)
+		if err != nil {
+			logrus.Errorf("failed to delete the org webhook %s: %v. %s", webhook.Url, err, string(body))
+			return
+		}
+	}
+
+	delete(g.orgWebhooks, webhook.Url)
+}
+
+func (g *GoliacRemoteImpl) AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	// https://docs.github.com/en/rest/orgs/security-managers?apiVersion=2022-11-28#add-a-security-manager-team
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/memberships/%s", config.Config.GithubAppOrganization, ghuserid),
+			fmt.Sprintf("/orgs/%s/security-managers/teams/%s", g.organization, teamslug),
 			"",
 			"PUT",
-			map[string]interface{}{"role": "member"},
+			nil,
 		)
 		if err != nil {
-			logrus.Errorf("failed to add user to org: %v. %s", err, string(body))
+			logrus.Errorf("failed to add the security manager team %s: %v. %s", teamslug, err, string(body))
+			return
 		}
 	}
 
-	g.users[ghuserid] = ghuserid
+	g.securityManagerTeams[teamslug] = true
 }
 
-func (g *GoliacRemoteImpl) RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
-	// remove member
-	// https://docs.github.com/en/rest/orgs/members?apiVersion=2022-11-28#remove-organization-membership-for-a-user
+func (g *GoliacRemoteImpl) RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	// https://docs.github.com/en/rest/orgs/security-managers?apiVersion=2022-11-28#remove-a-security-manager-team
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/memberships/%s", config.Config.GithubAppOrganization, ghuserid),
+			fmt.Sprintf("/orgs/%s/security-managers/teams/%s", g.organization, teamslug),
 			"",
 			"DELETE",
 			nil,
 		)
 		if err != nil {
-			logrus.Errorf("failed to remove user from org: %v. %s", err, string(body))
+			logrus.Errorf("failed to remove the security manager team %s: %v. %s", teamslug, err, string(body))
+			return
 		}
 	}
 
-	delete(g.users, ghuserid)
+	delete(g.securityManagerTeams, teamslug)
 }
 
 type CreateTeamResponse struct {
-	Name string
-	Slug string
+	Name   string
+	Slug   string
+	NodeId string `json:"node_id"`
 }
 
 func (g *GoliacRemoteImpl) CreateTeam(ctx context.Context, dryrun bool, teamname string, description string, parentTeam *int, members []string) {
 	slugname := slug.Make(teamname)
+	nodeId := ""
 	// create team
 	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#create-a-team
 	if !dryrun {
@@ -1804,7 +4143,7 @@ func (g *GoliacRemoteImpl) CreateTeam(ctx context.Context, dryrun bool, teamname
 		}
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams", config.Config.GithubAppOrganization),
+			fmt.Sprintf("/orgs/%s/teams", g.organization),
 			"",
 			"POST",
 			params,
@@ -1821,25 +4160,14 @@ func (g *GoliacRemoteImpl) CreateTeam(ctx context.Context, dryrun bool, teamname
 		}
 
 		// add members
-		for _, member := range members {
-			// https://docs.github.com/en/rest/teams/members?apiVersion=2022-11-28#add-or-update-team-membership-for-a-user
-			body, err := g.client.CallRestAPI(
-				ctx,
-				fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", config.Config.GithubAppOrganization, res.Slug, member),
-				"",
-				"PUT",
-				map[string]interface{}{"role": "member"},
-			)
-			if err != nil {
-				logrus.Errorf("failed to create team: %v. %s", err, string(body))
-				return
-			}
-		}
+		g.updateTeamMembershipsConcurrently(ctx, res.Slug, members, "PUT", "member")
 		slugname = res.Slug
+		nodeId = res.NodeId
 	}
 
 	g.teams[slugname] = &GithubTeam{
 		Name:        teamname,
+		NodeId:      nodeId,
 		Slug:        slugname,
 		Members:     members,
 		Maintainers: []string{},
@@ -1847,13 +4175,43 @@ func (g *GoliacRemoteImpl) CreateTeam(ctx context.Context, dryrun bool, teamname
 	g.teamSlugByName[teamname] = slugname
 }
 
+/*
+ * updateTeamMembershipsConcurrently adds (PUT) or removes (DELETE) team members.
+ * Github doesn't provide a bulk membership API, so instead of doing one
+ * call after the other (which is painful for large teams like the
+ * 'everyone' team), we spread the calls over a pool of workers
+ * (bounded by GithubConcurrentThreads)
+ */
+func (g *GoliacRemoteImpl) updateTeamMembershipsConcurrently(ctx context.Context, teamslug string, members []string, method string, role string) {
+	var params map[string]interface{}
+	if method == "PUT" {
+		params = map[string]interface{}{"role": role}
+	}
+
+	// a failing member is logged, and doesn't stop the other updates
+	loadConcurrently(len(members), func(i int) error {
+		// https://docs.github.com/en/rest/teams/members?apiVersion=2022-11-28#add-or-update-team-membership-for-a-user
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", g.organization, teamslug, members[i]),
+			"",
+			method,
+			params,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update (%s) member %s of team %s: %v. %s", method, members[i], teamslug, err, string(body))
+		}
+		return nil
+	})
+}
+
 // role = member or maintainer (usually we use member)
 func (g *GoliacRemoteImpl) UpdateTeamAddMember(ctx context.Context, dryrun bool, teamslug string, username string, role string) {
 	// https://docs.github.com/en/rest/teams/members?apiVersion=2022-11-28#add-or-update-team-membership-for-a-user
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", config.Config.GithubAppOrganization, teamslug, username),
+			fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", g.organization, teamslug, username),
 			"",
 			"PUT",
 			map[string]interface{}{"role": role},
@@ -1900,7 +4258,7 @@ func (g *GoliacRemoteImpl) UpdateTeamUpdateMember(ctx context.Context, dryrun bo
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", config.Config.GithubAppOrganization, teamslug, username),
+			fmt.Sprintf("/orgs/%s/teams/%s/memberships/%s", g.organization, teamslug, username),
 			"",
 			"PUT",
 			map[string]interface{}{"role": role},
@@ -1960,7 +4318,7 @@ func (g *GoliacRemoteImpl) UpdateTeamRemoveMember(ctx context.Context, dryrun bo
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", config.Config.GithubAppOrganization, teamslug, username),
+			fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", g.organization, teamslug, username),
 			"",
 			"DELETE",
 			nil,
@@ -1985,13 +4343,42 @@ func (g *GoliacRemoteImpl) UpdateTeamRemoveMember(ctx context.Context, dryrun bo
 	}
 }
 
+// role = member or maintainer (usually we use member)
+func (g *GoliacRemoteImpl) UpdateTeamAddMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string, role string) {
+	if !dryrun {
+		g.updateTeamMembershipsConcurrently(ctx, teamslug, usernames, "PUT", role)
+	}
+
+	if team, ok := g.teams[teamslug]; ok {
+		for _, username := range usernames {
+			if role == "maintainer" {
+				team.Maintainers = addGithubId(team.Maintainers, username)
+			} else {
+				team.Members = addGithubId(team.Members, username)
+			}
+		}
+	}
+}
+
+func (g *GoliacRemoteImpl) UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string) {
+	if !dryrun {
+		g.updateTeamMembershipsConcurrently(ctx, teamslug, usernames, "DELETE", "")
+	}
+
+	if team, ok := g.teams[teamslug]; ok {
+		for _, username := range usernames {
+			team.Members = removeGithubId(team.Members, username)
+		}
+	}
+}
+
 func (g *GoliacRemoteImpl) UpdateTeamSetParent(ctx context.Context, dryrun bool, teamslug string, parentTeam *int) {
 	// set parent's team
 	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#update-a-team
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s", config.Config.GithubAppOrganization, teamslug),
+			fmt.Sprintf("/orgs/%s/teams/%s", g.organization, teamslug),
 			"",
 			"PATCH",
 			map[string]interface{}{"parent_team_id": parentTeam},
@@ -2002,13 +4389,83 @@ func (g *GoliacRemoteImpl) UpdateTeamSetParent(ctx context.Context, dryrun bool,
 	}
 }
 
-func (g *GoliacRemoteImpl) DeleteTeam(ctx context.Context, dryrun bool, teamslug string) {
+const updateTeamReviewAssignment = `
+mutation updateTeamReviewAssignment($teamId: ID!, $enabled: Boolean!, $algorithm: TeamReviewAssignmentAlgorithm, $teamMemberCount: Int, $notifyTeam: Boolean) {
+  updateTeamReviewAssignment(input: {id: $teamId, enabled: $enabled, algorithm: $algorithm, teamMemberCount: $teamMemberCount, notifyTeam: $notifyTeam}) {
+    team {
+      id
+    }
+  }
+}
+`
+
+func (g *GoliacRemoteImpl) UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, teamslug string, reviewAssignment *GithubTeamReviewAssignment) {
+	team, ok := g.teams[teamslug]
+	if !ok {
+		logrus.Errorf("failed to update the review assignment of team %s: team not found", teamslug)
+		return
+	}
+
+	// there is no REST api for the code review assignment
+	// https://docs.github.com/en/graphql/reference/mutations#updateteamreviewassignment
+	if !dryrun {
+		variables := map[string]interface{}{
+			"teamId":  team.NodeId,
+			"enabled": reviewAssignment != nil,
+		}
+		if reviewAssignment != nil {
+			variables["algorithm"] = reviewAssignment.Algorithm
+			variables["teamMemberCount"] = reviewAssignment.TeamMembersCount
+			variables["notifyTeam"] = reviewAssignment.NotifyTeam
+		}
+		body, err := g.client.QueryGraphQLAPI(ctx, updateTeamReviewAssignment, variables)
+		if err != nil {
+			logrus.Errorf("failed to update the review assignment of team %s: %v. %s", teamslug, err, string(body))
+			return
+		}
+		var res struct {
+			Errors []struct {
+				Message string
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal(body, &res); err == nil && len(res.Errors) > 0 {
+			logrus.Errorf("failed to update the review assignment of team %s: %s", teamslug, res.Errors[0].Message)
+			return
+		}
+	}
+
+	team.ReviewAssignment = reviewAssignment
+}
+
+func (g *GoliacRemoteImpl) UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, teamslug string, notificationSetting string) {
+	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#update-a-team
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/orgs/%s/teams/%s", g.organization, teamslug),
+			"",
+			"PATCH",
+			map[string]interface{}{"notification_setting": notificationSetting},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the notification setting of team %s: %v. %s", teamslug, err, string(body))
+			return
+		}
+	}
+
+	if team, ok := g.teams[teamslug]; ok {
+		team.NotificationSetting = notificationSetting
+	}
+}
+
+func (g *GoliacRemoteImpl) DeleteTeam(ctx context.Context, dryrun bool, teamslug string, reason string) {
 	// delete team
 	// https://docs.github.com/en/rest/teams/teams?apiVersion=2022-11-28#delete-a-team
 	if !dryrun {
+		logrus.WithFields(map[string]interface{}{"teamslug": teamslug, "reason": reason}).Info("deleting team")
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s", config.Config.GithubAppOrganization, teamslug),
+			fmt.Sprintf("/orgs/%s/teams/%s", g.organization, teamslug),
 			"",
 			"DELETE",
 			nil,
@@ -2056,7 +4513,7 @@ func (g *GoliacRemoteImpl) CreateRepository(ctx context.Context, dryrun bool, re
 
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/repos", config.Config.GithubAppOrganization),
+			fmt.Sprintf("/orgs/%s/repos", g.organization),
 			"",
 			"POST",
 			props,
@@ -2077,12 +4534,82 @@ func (g *GoliacRemoteImpl) CreateRepository(ctx context.Context, dryrun bool, re
 		repoRefId = resp.NodeId
 	}
 
+	g.addCreatedRepository(ctx, dryrun, reponame, repoId, repoRefId, writers, readers, boolProperties)
+}
+
+/*
+CreateRepositoryFork creates the repository as a fork of the upstream (owner/repo)
+repository, instead of an empty one. The fork keeps the upstream settings, so we
+apply the boolProperties afterward (except the visibility, forced by Github for a fork)
+*/
+func (g *GoliacRemoteImpl) CreateRepositoryFork(ctx context.Context, dryrun bool, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) {
+	repoId := 0
+	repoRefId := reponame
+	// https://docs.github.com/en/rest/repos/forks?apiVersion=2022-11-28#create-a-fork
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/forks", upstream),
+			"",
+			"POST",
+			map[string]interface{}{
+				"organization":        g.organization,
+				"name":                reponame,
+				"default_branch_only": false,
+			},
+		)
+		if err != nil {
+			logrus.Errorf("failed to fork repository %s into %s: %v. %s", upstream, reponame, err, string(body))
+			return
+		}
+
+		// get the repo id
+		var resp CreateRepositoryResponse
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			logrus.Errorf("failed to read the fork repository action response: %v", err)
+			return
+		}
+		repoId = resp.Id
+		repoRefId = resp.NodeId
+
+		props := make(map[string]interface{})
+		for k, v := range boolProperties {
+			if k != "private" {
+				props[k] = v
+			}
+		}
+		// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#update-a-repository
+		body, err = g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s", g.organization, reponame),
+			"",
+			"PATCH",
+			props,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update the forked repository %s: %v. %s", reponame, err, string(body))
+		}
+	}
+
+	g.addCreatedRepository(ctx, dryrun, reponame, repoId, repoRefId, writers, readers, boolProperties)
+}
+
+/*
+addCreatedRepository adds a newly created repository to the repositories list,
+and gives it the readers and writers teams access
+*/
+func (g *GoliacRemoteImpl) addCreatedRepository(ctx context.Context, dryrun bool, reponame string, repoId int, repoRefId string, writers []string, readers []string, boolProperties map[string]bool) {
 	// update the repositories list
 	newRepo := &GithubRepository{
-		Name:           reponame,
-		Id:             repoId,
-		RefId:          repoRefId,
-		BoolProperties: boolProperties,
+		Name:             reponame,
+		Id:               repoId,
+		RefId:            repoRefId,
+		BoolProperties:   boolProperties,
+		ExternalUsers:    make(map[string]string),
+		InternalUsers:    make(map[string]string),
+		RuleSets:         make(map[string]*GithubRuleSet),
+		CustomProperties: make(map[string]string),
 	}
 	g.repositories[reponame] = newRepo
 	g.repositoriesByRefId[repoRefId] = newRepo
@@ -2093,7 +4620,7 @@ func (g *GoliacRemoteImpl) CreateRepository(ctx context.Context, dryrun bool, re
 		if !dryrun {
 			body, err := g.client.CallRestAPI(
 				ctx,
-				fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", config.Config.GithubAppOrganization, reader, config.Config.GithubAppOrganization, reponame),
+				fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", g.organization, reader, g.organization, reponame),
 				"",
 				"PUT",
 				map[string]interface{}{"permission": "pull"},
@@ -2119,7 +4646,7 @@ func (g *GoliacRemoteImpl) CreateRepository(ctx context.Context, dryrun bool, re
 		if !dryrun {
 			body, err := g.client.CallRestAPI(
 				ctx,
-				fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", config.Config.GithubAppOrganization, writer, config.Config.GithubAppOrganization, reponame),
+				fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", g.organization, writer, g.organization, reponame),
 				"",
 				"PUT",
 				map[string]interface{}{"permission": "push"},
@@ -2147,7 +4674,7 @@ func (g *GoliacRemoteImpl) UpdateRepositoryAddTeamAccess(ctx context.Context, dr
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s/repos/%s/%s", config.Config.GithubAppOrganization, teamslug, config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("/orgs/%s/teams/%s/repos/%s/%s", g.organization, teamslug, g.organization, reponame),
 			"",
 			"PUT",
 			map[string]interface{}{"permission": permission},
@@ -2161,13 +4688,9 @@ func (g *GoliacRemoteImpl) UpdateRepositoryAddTeamAccess(ctx context.Context, dr
 	if teamsRepos == nil {
 		teamsRepos = make(map[string]*GithubTeamRepo)
 	}
-	rPermission := "READ"
-	if permission == "push" {
-		rPermission = "WRITE"
-	}
 	teamsRepos[reponame] = &GithubTeamRepo{
 		Name:       reponame,
-		Permission: rPermission,
+		Permission: teamRepoPermission(permission),
 	}
 	g.teamRepos[teamslug] = teamsRepos
 }
@@ -2178,7 +4701,7 @@ func (g *GoliacRemoteImpl) UpdateRepositoryUpdateTeamAccess(ctx context.Context,
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/orgs/%s/teams/%s/repos/%s/%s", config.Config.GithubAppOrganization, teamslug, config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("/orgs/%s/teams/%s/repos/%s/%s", g.organization, teamslug, g.organization, reponame),
 			"",
 			"PUT",
 			map[string]interface{}{"permission": permission},
@@ -2192,13 +4715,9 @@ func (g *GoliacRemoteImpl) UpdateRepositoryUpdateTeamAccess(ctx context.Context,
 	if teamsRepos == nil {
 		teamsRepos = make(map[string]*GithubTeamRepo)
 	}
-	rPermission := "READ"
-	if permission == "push" {
-		rPermission = "WRITE"
-	}
 	teamsRepos[reponame] = &GithubTeamRepo{
 		Name:       reponame,
-		Permission: rPermission,
+		Permission: teamRepoPermission(permission),
 	}
 	g.teamRepos[teamslug] = teamsRepos
 }
@@ -2209,7 +4728,7 @@ func (g *GoliacRemoteImpl) UpdateRepositoryRemoveTeamAccess(ctx context.Context,
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", config.Config.GithubAppOrganization, teamslug, config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("orgs/%s/teams/%s/repos/%s/%s", g.organization, teamslug, g.organization, reponame),
 			"",
 			"DELETE",
 			nil,
@@ -2232,13 +4751,17 @@ Used for
 - delete_branch_on_merge
 - allow_update_branch
 - archived
+- has_issues
+- has_wiki
+- has_projects
+- allow_forking
 */
 func (g *GoliacRemoteImpl) UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue bool) {
 	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#update-a-repository
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("repos/%s/%s", config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("repos/%s/%s", g.organization, reponame),
 			"",
 			"PATCH",
 			map[string]interface{}{propertyName: propertyValue},
@@ -2253,12 +4776,225 @@ func (g *GoliacRemoteImpl) UpdateRepositoryUpdateBoolProperty(ctx context.Contex
 	}
 }
 
+/*
+Used for
+- squash_merge_commit_title
+- squash_merge_commit_message
+- merge_commit_title
+- merge_commit_message
+(a merge commit title and its message are updated together)
+*/
+func (g *GoliacRemoteImpl) UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, reponame string, properties map[string]string) {
+	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#update-a-repository
+	if !dryrun {
+		payload := make(map[string]interface{})
+		for k, v := range properties {
+			payload[k] = v
+		}
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("repos/%s/%s", g.organization, reponame),
+			"",
+			"PATCH",
+			payload,
+		)
+		if err != nil {
+			logrus.Errorf("failed to update repository %s settings: %v. %s", reponame, err, string(body))
+		}
+	}
+
+	if repo, ok := g.repositories[reponame]; ok {
+		if repo.StringProperties == nil {
+			repo.StringProperties = make(map[string]string)
+		}
+		for k, v := range properties {
+			repo.StringProperties[k] = v
+		}
+	}
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgSetting(ctx context.Context, dryrun bool, settingName string, settingValue bool) {
+	// https://docs.github.com/en/rest/orgs/orgs?apiVersion=2022-11-28#update-an-organization
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			"/orgs/"+g.organization,
+			"",
+			"PATCH",
+			map[string]interface{}{settingName: settingValue},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update organization setting %s: %v. %s", settingName, err, string(body))
+		}
+	}
+
+	g.orgSettings[settingName] = settingValue
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, fieldValue string) {
+	// https://docs.github.com/en/rest/orgs/orgs?apiVersion=2022-11-28#update-an-organization
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			"/orgs/"+g.organization,
+			"",
+			"PATCH",
+			map[string]interface{}{fieldName: fieldValue},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update organization profile field %s: %v. %s", fieldName, err, string(body))
+		}
+	}
+
+	g.orgProfile[fieldName] = fieldValue
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, branchName string) {
+	// https://docs.github.com/en/rest/orgs/orgs?apiVersion=2022-11-28#update-an-organization
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			"/orgs/"+g.organization,
+			"",
+			"PATCH",
+			map[string]interface{}{"default_repository_branch": branchName},
+		)
+		if err != nil {
+			logrus.Errorf("failed to update organization default branch name: %v. %s", err, string(body))
+		}
+	}
+
+	g.orgDefaultBranchName = branchName
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, settingValue string) {
+	if !dryrun {
+		var err error
+		var body []byte
+		switch settingName {
+		case "default_workflow_permissions":
+			// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#set-default-workflow-permissions-for-an-organization
+			body, err = g.client.CallRestAPI(
+				ctx,
+				"/orgs/"+g.organization+"/actions/permissions/workflow",
+				"",
+				"PUT",
+				map[string]interface{}{"default_workflow_permissions": settingValue},
+			)
+		case "require_approval_for_fork_pr_workflows":
+			// the other fork PR workflows settings must be sent as is
+			// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#set-private-repo-fork-pr-workflow-settings-for-an-organization
+			endpoint := "/orgs/" + g.organization + "/actions/permissions/fork-pr-workflows-private-repos"
+			body, err = g.client.CallRestAPI(ctx, endpoint, "", "GET", nil)
+			if err == nil {
+				var forkPR OrgForkPRWorkflowsPrivateRepos
+				err = json.Unmarshal(body, &forkPR)
+				if err == nil {
+					body, err = g.client.CallRestAPI(ctx, endpoint, "", "PUT", map[string]interface{}{
+						"run_workflows_from_fork_pull_requests":  forkPR.RunWorkflowsFromForkPullRequests,
+						"send_write_tokens_to_workflows":         forkPR.SendWriteTokensToWorkflows,
+						"send_secrets_and_variables":             forkPR.SendSecretsAndVariables,
+						"require_approval_for_fork_pr_workflows": settingValue == "true",
+					})
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown setting")
+		}
+		if err != nil {
+			logrus.Errorf("failed to update organization actions setting %s: %v. %s", settingName, err, string(body))
+		}
+	}
+
+	g.orgActionsSettings[settingName] = settingValue
+}
+
+func (g *GoliacRemoteImpl) UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *GithubOrgAllowedActions) {
+	if !dryrun {
+		// the enabled repositories must be sent as is
+		// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#set-github-actions-permissions-for-an-organization
+		endpoint := "/orgs/" + g.organization + "/actions/permissions"
+		body, err := g.client.CallRestAPI(ctx, endpoint, "", "GET", nil)
+		if err == nil {
+			var permissions OrgActionsPermissions
+			err = json.Unmarshal(body, &permissions)
+			if err == nil && permissions.AllowedActions != allowedActions.AllowedActions {
+				body, err = g.client.CallRestAPI(ctx, endpoint, "", "PUT", map[string]interface{}{
+					"enabled_repositories": permissions.EnabledRepositories,
+					"allowed_actions":      allowedActions.AllowedActions,
+				})
+			}
+		}
+		if err == nil && allowedActions.AllowedActions == "selected" {
+			// https://docs.github.com/en/rest/actions/permissions?apiVersion=2022-11-28#set-allowed-actions-and-reusable-workflows-for-an-organization
+			body, err = g.client.CallRestAPI(ctx, endpoint+"/selected-actions", "", "PUT", map[string]interface{}{
+				"github_owned_allowed": allowedActions.GithubOwnedAllowed,
+				"verified_allowed":     allowedActions.VerifiedAllowed,
+				"patterns_allowed":     allowedActions.PatternsAllowed,
+			})
+		}
+		if err != nil {
+			logrus.Errorf("failed to update organization allowed actions: %v. %s", err, string(body))
+		}
+	}
+
+	g.orgAllowedActions = allowedActions
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue string) {
+	// https://docs.github.com/en/enterprise-cloud@latest/rest/repos/custom-properties?apiVersion=2022-11-28#create-or-update-custom-property-values-for-a-repository
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/properties/values", g.organization, reponame),
+			"",
+			"PATCH",
+			map[string]interface{}{"properties": []map[string]interface{}{
+				{"property_name": propertyName, "value": propertyValue},
+			}},
+		)
+		if err != nil {
+			logrus.Errorf("failed to set repository custom property %s: %v. %s", propertyName, err, string(body))
+		}
+	}
+
+	if repo, ok := g.repositories[reponame]; ok {
+		if repo.CustomProperties == nil {
+			repo.CustomProperties = make(map[string]string)
+		}
+		repo.CustomProperties[propertyName] = propertyValue
+	}
+}
+
+func (g *GoliacRemoteImpl) UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string) {
+	// https://docs.github.com/en/enterprise-cloud@latest/rest/repos/custom-properties?apiVersion=2022-11-28#create-or-update-custom-property-values-for-a-repository
+	// (setting the value to null removes it)
+	if !dryrun {
+		body, err := g.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/properties/values", g.organization, reponame),
+			"",
+			"PATCH",
+			map[string]interface{}{"properties": []map[string]interface{}{
+				{"property_name": propertyName, "value": nil},
+			}},
+		)
+		if err != nil {
+			logrus.Errorf("failed to remove repository custom property %s: %v. %s", propertyName, err, string(body))
+		}
+	}
+
+	if repo, ok := g.repositories[reponame]; ok {
+		delete(repo.CustomProperties, propertyName)
+	}
+}
+
 func (g *GoliacRemoteImpl) UpdateRepositorySetExternalUser(ctx context.Context, dryrun bool, reponame string, githubid string, permission string) {
 	// https://docs.github.com/en/rest/collaborators/collaborators?apiVersion=2022-11-28#add-a-repository-collaborator
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("repos/%s/%s/collaborators/%s", config.Config.GithubAppOrganization, reponame, githubid),
+			fmt.Sprintf("repos/%s/%s/collaborators/%s", g.organization, reponame, githubid),
 			"",
 			"PUT",
 			map[string]interface{}{"permission": permission},
@@ -2269,9 +5005,12 @@ func (g *GoliacRemoteImpl) UpdateRepositorySetExternalUser(ctx context.Context,
 	}
 
 	if repo, ok := g.repositories[reponame]; ok {
-		if permission == "push" {
+		switch permission {
+		case "admin":
+			repo.ExternalUsers[githubid] = "ADMIN"
+		case "push":
 			repo.ExternalUsers[githubid] = "WRITE"
-		} else {
+		default:
 			repo.ExternalUsers[githubid] = "READ"
 		}
 	}
@@ -2282,7 +5021,7 @@ func (g *GoliacRemoteImpl) updateRepositoryRemoveUser(ctx context.Context, dryru
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("repos/%s/%s/collaborators/%s", config.Config.GithubAppOrganization, reponame, githubid),
+			fmt.Sprintf("repos/%s/%s/collaborators/%s", g.organization, reponame, githubid),
 			"",
 			"DELETE",
 			nil,
@@ -2305,13 +5044,14 @@ func (g *GoliacRemoteImpl) UpdateRepositoryRemoveInternalUser(ctx context.Contex
 	g.updateRepositoryRemoveUser(ctx, dryrun, reponame, githubid)
 }
 
-func (g *GoliacRemoteImpl) DeleteRepository(ctx context.Context, dryrun bool, reponame string) {
+func (g *GoliacRemoteImpl) DeleteRepository(ctx context.Context, dryrun bool, reponame string, reason string) {
 	// delete repo
 	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#delete-a-repository
 	if !dryrun {
+		logrus.WithFields(map[string]interface{}{"repositoryname": reponame, "reason": reason}).Info("deleting repository")
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/repos/%s/%s", config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("/repos/%s/%s", g.organization, reponame),
 			"",
 			"DELETE",
 			nil,
@@ -2334,7 +5074,7 @@ func (g *GoliacRemoteImpl) RenameRepository(ctx context.Context, dryrun bool, re
 	if !dryrun {
 		body, err := g.client.CallRestAPI(
 			ctx,
-			fmt.Sprintf("/repos/%s/%s", config.Config.GithubAppOrganization, reponame),
+			fmt.Sprintf("/repos/%s/%s", g.organization, reponame),
 			"",
 			"PATCH",
 			map[string]interface{}{"name": newname},
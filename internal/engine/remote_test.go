@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Alayacare/goliac/internal/config"
@@ -121,63 +122,19 @@ func (m *MockGithubClient) reposNodes(first, after string, args ast.ArgumentList
 		if searchPrivate {
 			block["isPrivate"] = index%10 == 0 // let's pretend each 10 repo is a private repo
 		}
-		index++
-		if index > maxToFake { // let's pretend we have maxToFake repos
-			hasNext = false
-			endCursor = nil
-			break
-		}
-		data = append(data, block)
-	}
-
-	m.cursorValue = r
-	m.cursorPosition = index
-
-	return data, hasNext, endCursor, totalCount
-}
-
-/*
- * Returns:
- * - nodes
- * - hasNextPage
- * - endCursor
- * - totalCount
- */
-func (m *MockGithubClient) reposEdges(first, after string, args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}, maxToFake int) ([]map[string]interface{}, bool, *string, int) {
-	data := make([]map[string]interface{}, 0)
-
-	iFirst, err := strconv.Atoi(first)
-	if err != nil {
-		iFirst = 0
-	}
-	iAfter := 0
-	if after == m.cursorValue {
-		iAfter = m.cursorPosition
-	}
-
-	searchPermission, _ := hasChild("permission", children)
-	searchNode, nodeField := hasChild("node", children)
-
-	index := iAfter
-	totalCount := 0
-	hasNext := true
-	var endCursor *string
-	r := (randStringRunes(12))
-	endCursor = &r
-	for totalCount = 0; totalCount < iFirst; totalCount++ {
-		block := make(map[string]interface{})
-		if searchPermission {
-			block["permission"] = "push"
-		}
-		if searchNode {
-			node := make(map[string]interface{})
-			if c, _ := hasChild("name", nodeField.SelectionSet); c {
-				node["name"] = fmt.Sprintf("repo_%d", index)
+		for _, c := range children {
+			if s, ok := c.(*ast.Field); ok && s.Name == "collaborators" {
+				block[s.Alias] = m.repocollaborators(index, s.Arguments, s.SelectionSet, variables)
 			}
-			if c, _ := hasChild("id", nodeField.SelectionSet); c {
-				node["id"] = fmt.Sprintf("id_%d", index)
+		}
+		// the repository rulesets also list the organization rulesets applied to the repository
+		if searchRulesets, _ := hasChild("rulesets", children); searchRulesets && index == 0 {
+			block["rulesets"] = map[string]interface{}{
+				"nodes": []map[string]interface{}{
+					{"databaseId": 1, "source": map[string]interface{}{"name": "repo_0"}, "name": "shared", "enforcement": "ACTIVE"},
+					{"databaseId": 2, "source": map[string]interface{}{}, "name": "shared", "enforcement": "EVALUATE"},
+				},
 			}
-			block["node"] = node
 		}
 		index++
 		if index > maxToFake { // let's pretend we have maxToFake repos
@@ -259,6 +216,9 @@ func (m *MockGithubClient) teamsNodes(first, after string, args ast.ArgumentList
 		if searchSlug {
 			block["slug"] = fmt.Sprintf("slug-%d", index)
 		}
+		if c, s := hasChild("repositories", children); c {
+			block["repositories"] = m.teamrepositories(index, s.Arguments, s.SelectionSet, variables)
+		}
 		index++
 		if index > 122 { // let's pretend we have 133 teams
 			hasNext = false
@@ -303,30 +263,152 @@ func (m *MockGithubClient) teams(args ast.ArgumentList, children ast.SelectionSe
 	return data
 }
 
-func (m *MockGithubClient) teamrepositories(args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}) map[string]interface{} {
+/*
+ * Returns the collaborators of a repository (with their permissions)
+ * - the repo_N (with N%50 == 5) has 150 outside collaborators
+ * - the repo_N (with N%50 == 7) has 120 direct collaborators
+ * The cursor is the index of the next collaborator to return
+ */
+func (m *MockGithubClient) repocollaborators(repoIndex int, args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}) map[string]interface{} {
 	data := make(map[string]interface{})
+	affiliation := extractVariable("affiliation", args, variables)
 	first := extractVariable("first", args, variables)
 	after := extractVariable("after", args, variables)
 
-	var hasNextPage bool
-	var endCursor *string
-	var totalCount int
-	if c, s := hasChild("edges", children); c {
-		data["edges"], hasNextPage, endCursor, totalCount = m.reposEdges(first, after, s.Arguments, s.SelectionSet, variables, 2)
+	collaborators := []string{}
+	if affiliation == "OUTSIDE" && repoIndex%50 == 5 {
+		for i := 0; i < 150; i++ {
+			collaborators = append(collaborators, fmt.Sprintf("outside_%d", i))
+		}
+	}
+	if affiliation == "DIRECT" && repoIndex%50 == 7 {
+		for i := 0; i < 120; i++ {
+			collaborators = append(collaborators, fmt.Sprintf("direct_%d", i))
+		}
+	}
+
+	iFirst, err := strconv.Atoi(first)
+	if err != nil {
+		iFirst = 0
+	}
+	iAfter, err := strconv.Atoi(after)
+	if err != nil {
+		iAfter = 0
+	}
+
+	end := iAfter + iFirst
+	if end > len(collaborators) {
+		end = len(collaborators)
+	}
+
+	if c, _ := hasChild("edges", children); c {
+		edges := make([]map[string]interface{}, 0)
+		for _, login := range collaborators[iAfter:end] {
+			edges = append(edges, map[string]interface{}{
+				"permission": "READ",
+				"node": map[string]interface{}{
+					"login": login,
+				},
+			})
+		}
+		data["edges"] = edges
 	}
 	if c, _ := hasChild("pageInfo", children); c {
 		block := make(map[string]interface{})
-		block["hasNextPage"] = hasNextPage
-		if endCursor == nil {
+		block["hasNextPage"] = end < len(collaborators)
+		if end < len(collaborators) {
+			block["endCursor"] = strconv.Itoa(end)
+		} else {
 			block["endCursor"] = nil
+		}
+
+		data["pageInfo"] = block
+	}
+
+	return data
+}
+
+func (m *MockGithubClient) repository(args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+	name := extractVariable("name", args, variables)
+	repoIndex, err := strconv.Atoi(strings.TrimPrefix(name, "repo_"))
+	if err != nil {
+		repoIndex = -1
+	}
+
+	if c, s := hasChild("collaborators", children); c {
+		data["collaborators"] = m.repocollaborators(repoIndex, s.Arguments, s.SelectionSet, variables)
+	}
+
+	return data
+}
+
+/*
+ * Returns the repositories of a team (with their permissions)
+ * - the team_N (with N%50 == 2) has access to all (133) repositories
+ * - the team_N has access to repo_N
+ * The cursor is the index of the next repository to return
+ */
+func (m *MockGithubClient) teamrepositories(teamIndex int, args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+	first := extractVariable("first", args, variables)
+	after := extractVariable("after", args, variables)
+
+	repos := []int{teamIndex}
+	if teamIndex%50 == 2 {
+		repos = []int{}
+		for i := 0; i < 133; i++ {
+			repos = append(repos, i)
+		}
+	}
+
+	iFirst, err := strconv.Atoi(first)
+	if err != nil {
+		iFirst = 0
+	}
+	iAfter, err := strconv.Atoi(after)
+	if err != nil {
+		iAfter = 0
+	}
+
+	end := iAfter + iFirst
+	if end > len(repos) {
+		end = len(repos)
+	}
+
+	edges := make([]map[string]interface{}, 0)
+	if c, s := hasChild("edges", children); c {
+		searchNode, nodeField := hasChild("node", s.SelectionSet)
+		searchPermission, _ := hasChild("permission", s.SelectionSet)
+		for _, r := range repos[iAfter:end] {
+			block := make(map[string]interface{})
+			if searchPermission {
+				block["permission"] = "WRITE"
+			}
+			if searchNode {
+				node := make(map[string]interface{})
+				if c, _ := hasChild("name", nodeField.SelectionSet); c {
+					node["name"] = fmt.Sprintf("repo_%d", r)
+				}
+				block["node"] = node
+			}
+			edges = append(edges, block)
+		}
+		data["edges"] = edges
+	}
+	if c, _ := hasChild("pageInfo", children); c {
+		block := make(map[string]interface{})
+		block["hasNextPage"] = end < len(repos)
+		if end < len(repos) {
+			block["endCursor"] = strconv.Itoa(end)
 		} else {
-			block["endCursor"] = *endCursor
+			block["endCursor"] = nil
 		}
 
 		data["pageInfo"] = block
 	}
 	if c, _ := hasChild("totalCount", children); c {
-		data["totalCount"] = totalCount
+		data["totalCount"] = len(repos)
 	}
 
 	return data
@@ -334,10 +416,14 @@ func (m *MockGithubClient) teamrepositories(args ast.ArgumentList, children ast.
 
 func (m *MockGithubClient) team(args ast.ArgumentList, children ast.SelectionSet, variables map[string]interface{}) map[string]interface{} {
 	data := make(map[string]interface{})
-	//	slug := extractVariable("slug", args, variables)
+	slug := extractVariable("slug", args, variables)
+	teamIndex, err := strconv.Atoi(strings.TrimPrefix(slug, "slug-"))
+	if err != nil {
+		teamIndex = -1
+	}
 
 	if c, s := hasChild("repositories", children); c {
-		data["repositories"] = m.teamrepositories(s.Arguments, s.SelectionSet, variables)
+		data["repositories"] = m.teamrepositories(teamIndex, s.Arguments, s.SelectionSet, variables)
 	}
 
 	return data
@@ -355,6 +441,9 @@ func (m *MockGithubClient) organization(args ast.ArgumentList, children ast.Sele
 	if c, s := hasChild("team", children); c {
 		data["team"] = m.team(s.Arguments, s.SelectionSet, variables)
 	}
+	if c, s := hasChild("repository", children); c {
+		data["repository"] = m.repository(s.Arguments, s.SelectionSet, variables)
+	}
 	return data
 }
 
@@ -439,7 +528,7 @@ func TestRemoteRepository(t *testing.T) {
 		// MockGithubClient doesn't support concurrent access
 		client := MockGithubClient{}
 
-		remoteImpl := NewGoliacRemoteImpl(&client)
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
 
 		ctx := context.TODO()
 		repositories, _, err := remoteImpl.loadRepositories(ctx)
@@ -449,12 +538,16 @@ func TestRemoteRepository(t *testing.T) {
 		assert.Equal(t, true, repositories["repo_3"].BoolProperties["archived"])
 		assert.Equal(t, false, repositories["repo_1"].BoolProperties["private"])
 		assert.Equal(t, true, repositories["repo_10"].BoolProperties["private"])
+		// an organization ruleset with the same name doesn't collide with the repository one
+		assert.Equal(t, 1, len(repositories["repo_0"].RuleSets))
+		assert.Equal(t, 1, repositories["repo_0"].RuleSets["shared"].Id)
+		assert.Equal(t, "active", repositories["repo_0"].RuleSets["shared"].Enforcement)
 	})
 	t.Run("happy path: load remote teams", func(t *testing.T) {
 		// MockGithubClient doesn't support concurrent access
 		client := MockGithubClient{}
 
-		remoteImpl := NewGoliacRemoteImpl(&client)
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
 
 		ctx := context.TODO()
 		teams, _, err := remoteImpl.loadTeams(ctx)
@@ -467,26 +560,76 @@ func TestRemoteRepository(t *testing.T) {
 		// MockGithubClient doesn't support concurrent access
 		client := MockGithubClient{}
 
-		remoteImpl := NewGoliacRemoteImpl(&client)
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
 
 		ctx := context.TODO()
-		repos, err := remoteImpl.loadTeamRepos(ctx, "repo_0")
+		repos := make(map[string]*GithubTeamRepo)
+		err := remoteImpl.loadTeamRepositories(ctx, "slug-2", "", repos)
 		assert.Nil(t, err)
-		assert.Equal(t, 2, len(repos))
-		assert.Equal(t, "WRITE", repos["slug-0"].Permission)
+		assert.Equal(t, 133, len(repos))
+		assert.Equal(t, "WRITE", repos["repo_0"].Permission)
+	})
+
+	t.Run("happy path: team's repos keep their exact permission", func(t *testing.T) {
+		var page GraphQLTeamRepositories
+		err := json.Unmarshal([]byte(`{"edges":[
+			{"permission":"ADMIN","node":{"name":"repo_admin"}},
+			{"permission":"MAINTAIN","node":{"name":"repo_maintain"}},
+			{"permission":"WRITE","node":{"name":"repo_write"}},
+			{"permission":"TRIAGE","node":{"name":"repo_triage"}},
+			{"permission":"READ","node":{"name":"repo_read"}}
+		]}`), &page)
+		assert.Nil(t, err)
+
+		repos := make(map[string]*GithubTeamRepo)
+		addTeamRepositories(repos, &page)
+		assert.Equal(t, 5, len(repos))
+		assert.Equal(t, "ADMIN", repos["repo_admin"].Permission)
+		assert.Equal(t, "MAINTAIN", repos["repo_maintain"].Permission)
+		assert.Equal(t, "WRITE", repos["repo_write"].Permission)
+		assert.Equal(t, "TRIAGE", repos["repo_triage"].Permission)
+		assert.Equal(t, "READ", repos["repo_read"].Permission)
 	})
 
 	t.Run("happy path: load remote teams and team's repos", func(t *testing.T) {
 		// MockGithubClient doesn't support concurrent access
 		client := MockGithubClient{}
 
-		remoteImpl := NewGoliacRemoteImpl(&client)
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
 
 		ctx := context.TODO()
 		err := remoteImpl.Load(ctx, false)
 		assert.Nil(t, err)
 		assert.Equal(t, 122, len(remoteImpl.teams))
 		assert.Equal(t, 1, len(remoteImpl.teamRepos["slug-1"]))
+		assert.Equal(t, 133, len(remoteImpl.teamRepos["slug-2"]))
+	})
+
+	t.Run("happy path: concurrent load is identical to the serial one", func(t *testing.T) {
+		previousThreads := config.Config.GithubConcurrentThreads
+		defer func() { config.Config.GithubConcurrentThreads = previousThreads }()
+
+		load := func(threads int64) (map[string]*GithubRepository, map[string]map[string]*GithubTeamRepo) {
+			config.Config.GithubConcurrentThreads = threads
+			client := MockGithubClient{}
+			remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
+
+			ctx := context.TODO()
+			repositories, _, err := remoteImpl.loadRepositories(ctx)
+			assert.Nil(t, err)
+			teamRepos, err := remoteImpl.loadTeamsRepositories(ctx)
+			assert.Nil(t, err)
+			return repositories, teamRepos
+		}
+
+		serialRepositories, serialTeamRepos := load(1)
+		concurrentRepositories, concurrentTeamRepos := load(4)
+
+		assert.Equal(t, 150, len(serialRepositories["repo_5"].ExternalUsers))
+		assert.Equal(t, 120, len(serialRepositories["repo_57"].InternalUsers))
+		assert.Equal(t, 133, len(serialTeamRepos["slug-52"]))
+		assert.Equal(t, serialRepositories, concurrentRepositories)
+		assert.Equal(t, serialTeamRepos, concurrentTeamRepos)
 	})
 }
 
@@ -616,3 +759,235 @@ func TestIsEnterprise(t *testing.T) {
 		}
 	})
 }
+
+type GitHubClientCreateTeamMock struct {
+	mu          sync.Mutex
+	memberships []string
+}
+
+func (g *GitHubClientCreateTeamMock) QueryGraphQLAPI(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	return []byte(""), nil
+}
+func (g *GitHubClientCreateTeamMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	if method == "POST" && strings.HasSuffix(endpoint, "/teams") {
+		return []byte(`{"name":"everyone","slug":"everyone"}`), nil
+	}
+	if method == "PUT" && strings.Contains(endpoint, "/memberships/") {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.memberships = append(g.memberships, endpoint[strings.LastIndex(endpoint, "/")+1:])
+	}
+	return []byte(""), nil
+}
+func (g *GitHubClientCreateTeamMock) GetAccessToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (g *GitHubClientCreateTeamMock) GetAppSlug() string {
+	return ""
+}
+
+func TestCreateTeam(t *testing.T) {
+
+	t.Run("happy path: create a large team", func(t *testing.T) {
+		client := GitHubClientCreateTeamMock{}
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
+
+		members := []string{}
+		for i := 0; i < 500; i++ {
+			members = append(members, fmt.Sprintf("user%d", i))
+		}
+
+		ctx := context.TODO()
+		remoteImpl.CreateTeam(ctx, false, "everyone", "everyone", nil, members)
+
+		assert.ElementsMatch(t, members, client.memberships)
+		assert.Equal(t, 500, len(remoteImpl.teams["everyone"].Members))
+	})
+
+	t.Run("happy path: dryrun doesn't call github", func(t *testing.T) {
+		client := GitHubClientCreateTeamMock{}
+		remoteImpl := NewGoliacRemoteImpl(&client, config.Config.GithubAppOrganization)
+
+		ctx := context.TODO()
+		remoteImpl.CreateTeam(ctx, true, "everyone", "everyone", nil, []string{"user1", "user2"})
+
+		assert.Equal(t, 0, len(client.memberships))
+		assert.Equal(t, "everyone", remoteImpl.teamSlugByName["everyone"])
+	})
+}
+
+type GitHubClientForkRepositoryMock struct {
+	calls map[string]map[string]interface{} // "METHOD endpoint" -> body
+}
+
+func (g *GitHubClientForkRepositoryMock) QueryGraphQLAPI(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	return []byte(""), nil
+}
+func (g *GitHubClientForkRepositoryMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	g.calls[method+" "+endpoint] = body
+	if method == "POST" && strings.HasSuffix(endpoint, "/forks") {
+		return []byte(`{"id":42,"node_id":"R_fork"}`), nil
+	}
+	return []byte(""), nil
+}
+func (g *GitHubClientForkRepositoryMock) GetAccessToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (g *GitHubClientForkRepositoryMock) GetAppSlug() string {
+	return ""
+}
+
+func TestCreateRepositoryFork(t *testing.T) {
+
+	t.Run("happy path: fork the upstream repository", func(t *testing.T) {
+		client := GitHubClientForkRepositoryMock{
+			calls: make(map[string]map[string]interface{}),
+		}
+		remoteImpl := NewGoliacRemoteImpl(&client, "myorg")
+
+		ctx := context.TODO()
+		remoteImpl.CreateRepositoryFork(ctx, false, "myfork", "upstream-org/upstream-repo", []string{"writers"}, []string{}, map[string]bool{"private": true, "delete_branch_on_merge": true})
+
+		assert.Equal(t, map[string]interface{}{"organization": "myorg", "name": "myfork", "default_branch_only": false}, client.calls["POST /repos/upstream-org/upstream-repo/forks"])
+		// the visibility of a fork cannot be changed
+		assert.Equal(t, map[string]interface{}{"delete_branch_on_merge": true}, client.calls["PATCH /repos/myorg/myfork"])
+		assert.Equal(t, 42, remoteImpl.repositories["myfork"].Id)
+		assert.Equal(t, "WRITE", remoteImpl.teamRepos["writers"]["myfork"].Permission)
+	})
+
+	t.Run("happy path: dryrun doesn't call github", func(t *testing.T) {
+		client := GitHubClientForkRepositoryMock{
+			calls: make(map[string]map[string]interface{}),
+		}
+		remoteImpl := NewGoliacRemoteImpl(&client, "myorg")
+
+		ctx := context.TODO()
+		remoteImpl.CreateRepositoryFork(ctx, true, "myfork", "upstream-org/upstream-repo", []string{}, []string{}, map[string]bool{})
+
+		_, forked := client.calls["POST /repos/upstream-org/upstream-repo/forks"]
+		assert.False(t, forked)
+		assert.NotNil(t, remoteImpl.repositories["myfork"])
+	})
+}
+
+type GitHubClientDependabotMock struct {
+	mutex     sync.Mutex
+	restCalls int
+}
+
+// the repositories repo_<even> have the vulnerability alerts, and repo_<multiple of 4> the security updates
+func (g *GitHubClientDependabotMock) QueryGraphQLAPI(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	data := make(map[string]interface{})
+	for key, value := range variables {
+		if key == "orgLogin" {
+			continue
+		}
+		index, _ := strconv.Atoi(strings.TrimPrefix(value.(string), "repo_"))
+		data[key] = map[string]interface{}{"hasVulnerabilityAlertsEnabled": index%2 == 0}
+	}
+	return json.Marshal(map[string]interface{}{"data": data})
+}
+func (g *GitHubClientDependabotMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	if !strings.HasSuffix(endpoint, "/automated-security-fixes") {
+		return []byte("{}"), nil
+	}
+	g.mutex.Lock()
+	g.restCalls++
+	g.mutex.Unlock()
+	reponame := strings.TrimSuffix(strings.TrimPrefix(endpoint, "/repos/myorg/"), "/automated-security-fixes")
+	index, _ := strconv.Atoi(strings.TrimPrefix(reponame, "repo_"))
+	return json.Marshal(AutomatedSecurityFixes{Enabled: index%4 == 0})
+}
+func (g *GitHubClientDependabotMock) GetAccessToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (g *GitHubClientDependabotMock) GetAppSlug() string {
+	return ""
+}
+
+func TestRepositoriesDependabot(t *testing.T) {
+
+	t.Run("happy path: concurrent load is identical to the serial one", func(t *testing.T) {
+		previousThreads := config.Config.GithubConcurrentThreads
+		defer func() { config.Config.GithubConcurrentThreads = previousThreads }()
+
+		reponames := []string{}
+		for i := 0; i < 2*FILES_REPOSITORIES_PER_QUERY+5; i++ {
+			reponames = append(reponames, fmt.Sprintf("repo_%d", i))
+		}
+
+		load := func(threads int64) (map[string]*GithubRepositoryDependabot, int) {
+			config.Config.GithubConcurrentThreads = threads
+			client := GitHubClientDependabotMock{}
+			remoteImpl := NewGoliacRemoteImpl(&client, "myorg")
+
+			dependabot, err := remoteImpl.RepositoriesDependabot(context.TODO(), reponames)
+			assert.Nil(t, err)
+			return dependabot, client.restCalls
+		}
+
+		serial, serialCalls := load(1)
+		concurrent, concurrentCalls := load(4)
+
+		assert.Equal(t, len(reponames), len(serial))
+		assert.Equal(t, &GithubRepositoryDependabot{Alerts: true, SecurityUpdates: true}, serial["repo_4"])
+		assert.Equal(t, &GithubRepositoryDependabot{Alerts: true, SecurityUpdates: false}, serial["repo_2"])
+		assert.Equal(t, &GithubRepositoryDependabot{Alerts: false, SecurityUpdates: false}, serial["repo_1"])
+		// the security updates are only fetched for the repositories with the vulnerability alerts
+		assert.Equal(t, (len(reponames)+1)/2, serialCalls)
+		assert.Equal(t, serial, concurrent)
+		assert.Equal(t, serialCalls, concurrentCalls)
+	})
+}
+
+// the membership of user_1 fails
+type GitHubClientMembershipsMock struct {
+	mutex   sync.Mutex
+	members []string
+}
+
+func (g *GitHubClientMembershipsMock) QueryGraphQLAPI(ctx context.Context, query string, variables map[string]interface{}) ([]byte, error) {
+	return []byte("{}"), nil
+}
+func (g *GitHubClientMembershipsMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	if !strings.HasPrefix(endpoint, "/orgs/myorg/teams/everyone/memberships/") {
+		return []byte("{}"), nil
+	}
+	member := strings.TrimPrefix(endpoint, "/orgs/myorg/teams/everyone/memberships/")
+	g.mutex.Lock()
+	g.members = append(g.members, member)
+	g.mutex.Unlock()
+	if member == "user_1" {
+		return nil, fmt.Errorf("not able to update the membership")
+	}
+	return []byte("{}"), nil
+}
+func (g *GitHubClientMembershipsMock) GetAccessToken(ctx context.Context) (string, error) {
+	return "", nil
+}
+func (g *GitHubClientMembershipsMock) GetAppSlug() string {
+	return ""
+}
+
+func TestUpdateTeamMembershipsConcurrently(t *testing.T) {
+
+	t.Run("happy path: a failing member doesn't stop the other updates", func(t *testing.T) {
+		previousThreads := config.Config.GithubConcurrentThreads
+		defer func() { config.Config.GithubConcurrentThreads = previousThreads }()
+
+		members := []string{}
+		for i := 0; i < 20; i++ {
+			members = append(members, fmt.Sprintf("user_%d", i))
+		}
+
+		for _, threads := range []int64{1, 4} {
+			config.Config.GithubConcurrentThreads = threads
+			client := GitHubClientMembershipsMock{}
+			remoteImpl := NewGoliacRemoteImpl(&client, "myorg")
+
+			remoteImpl.updateTeamMembershipsConcurrently(context.TODO(), "everyone", members, "PUT", "member")
+
+			assert.ElementsMatch(t, members, client.members)
+		}
+	})
+}
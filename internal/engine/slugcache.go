@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/gosimple/slug"
+)
+
+/*
+ * slugCache memoizes slug.Make results, so a name (team, owner, ...)
+ * is only slugified once per reconciliation run.
+ * It is safe for concurrent use.
+ */
+type slugCache struct {
+	mu    sync.RWMutex
+	slugs map[string]string
+}
+
+func newSlugCache() *slugCache {
+	return &slugCache{
+		slugs: make(map[string]string),
+	}
+}
+
+/*
+ * Make returns slug.Make(name), computing it only the first time
+ */
+func (c *slugCache) Make(name string) string {
+	c.mu.RLock()
+	s, ok := c.slugs[name]
+	c.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	s = slug.Make(name)
+	c.mu.Lock()
+	c.slugs[name] = s
+	c.mu.Unlock()
+	return s
+}
+
+/*
+ * Team returns the slug of the Github team of a Goliac team
+ * (see GithubTeamName), computing it only the first time
+ */
+func (c *slugCache) Team(teamname string) string {
+	return c.Make(GithubTeamName(teamname))
+}
+
+/*
+ * GithubTeamName returns the name of the Github team of a Goliac team:
+ * the team name with the GOLIAC_TEAM_NAME_PREFIX and GOLIAC_TEAM_NAME_SUFFIX
+ */
+func GithubTeamName(teamname string) string {
+	return config.Config.GoliacTeamNamePrefix + teamname + config.Config.GoliacTeamNameSuffix
+}
+
+/*
+ * TeamSlug returns the slug of the Github team of a Goliac team
+ */
+func TeamSlug(teamname string) string {
+	return slug.Make(GithubTeamName(teamname))
+}
+
+/*
+ * isManagedTeamSlug returns true if a Github team (or its owners team) has the
+ * GOLIAC_TEAM_NAME_PREFIX and GOLIAC_TEAM_NAME_SUFFIX: the other teams
+ * (like the ones synced by an identity provider) are ignored by Goliac
+ */
+func isManagedTeamSlug(teamslug string) bool {
+	// the prefix and suffix are slugified like in the team slugs (like "T " becoming "t-"):
+	// they are slugified next to a letter, so their separators are not trimmed
+	prefix := strings.TrimSuffix(slug.Make(config.Config.GoliacTeamNamePrefix+"x"), "x")
+	suffix := strings.TrimPrefix(slug.Make("x"+config.Config.GoliacTeamNameSuffix), "x")
+	if !strings.HasPrefix(teamslug, prefix) {
+		return false
+	}
+	teamslug = strings.TrimSuffix(teamslug, config.Config.GoliacTeamOwnerSuffix)
+	return strings.HasSuffix(teamslug, suffix)
+}
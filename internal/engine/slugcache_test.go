@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/gosimple/slug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugCache(t *testing.T) {
+	t.Run("happy path: same result as slug.Make", func(t *testing.T) {
+		c := newSlugCache()
+		for _, name := range []string{"Team 1", "team-1", "Équipe Été", "ops_team"} {
+			assert.Equal(t, slug.Make(name), c.Make(name))
+			// second call comes from the cache
+			assert.Equal(t, slug.Make(name), c.Make(name))
+		}
+		assert.Equal(t, 4, len(c.slugs))
+	})
+
+	t.Run("happy path: concurrent access", func(t *testing.T) {
+		c := newSlugCache()
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				name := fmt.Sprintf("Team %d", i%10)
+				assert.Equal(t, slug.Make(name), c.Make(name))
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 10, len(c.slugs))
+	})
+}
+
+func TestTeamSlug(t *testing.T) {
+	t.Run("happy path: without prefix nor suffix", func(t *testing.T) {
+		assert.Equal(t, "team-1", TeamSlug("Team 1"))
+		assert.True(t, isManagedTeamSlug("team-1"))
+	})
+
+	t.Run("happy path: with a prefix and a suffix", func(t *testing.T) {
+		config.Config.GoliacTeamNamePrefix = "t-"
+		config.Config.GoliacTeamNameSuffix = "-eng"
+		defer func() {
+			config.Config.GoliacTeamNamePrefix = ""
+			config.Config.GoliacTeamNameSuffix = ""
+		}()
+
+		assert.Equal(t, "t-Team 1-eng", GithubTeamName("Team 1"))
+		assert.Equal(t, "t-team-1-eng", TeamSlug("Team 1"))
+		assert.Equal(t, "t-team-1-eng", newSlugCache().Team("Team 1"))
+
+		assert.True(t, isManagedTeamSlug("t-team-1-eng"))
+		assert.True(t, isManagedTeamSlug("t-team-1-eng"+config.Config.GoliacTeamOwnerSuffix))
+		assert.False(t, isManagedTeamSlug("team-1-eng"))
+		assert.False(t, isManagedTeamSlug("t-team-1"))
+	})
+
+	t.Run("happy path: with a prefix and a suffix to slugify", func(t *testing.T) {
+		config.Config.GoliacTeamNamePrefix = "T "
+		config.Config.GoliacTeamNameSuffix = " (Eng)"
+		defer func() {
+			config.Config.GoliacTeamNamePrefix = ""
+			config.Config.GoliacTeamNameSuffix = ""
+		}()
+
+		assert.Equal(t, "t-team-1-eng", TeamSlug("Team 1"))
+
+		assert.True(t, isManagedTeamSlug(TeamSlug("Team 1")))
+		assert.True(t, isManagedTeamSlug(TeamSlug("Team 1")+config.Config.GoliacTeamOwnerSuffix))
+		// the separator is part of the prefix
+		assert.False(t, isManagedTeamSlug("team-1-eng"))
+		assert.False(t, isManagedTeamSlug("t-team-1"))
+	})
+}
+
+// names reused across repositories, as team names are in reconciliateRepositories
+func benchmarkSlugNames() []string {
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("Platform Team %d", i)
+	}
+	return names
+}
+
+func BenchmarkSlugMake(b *testing.B) {
+	names := benchmarkSlugNames()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			slug.Make(name)
+		}
+	}
+}
+
+func BenchmarkSlugCacheMake(b *testing.B) {
+	names := benchmarkSlugNames()
+	c := newSlugCache()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			c.Make(name)
+		}
+	}
+}
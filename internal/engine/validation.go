@@ -0,0 +1,546 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/Alayacare/goliac/internal/utils"
+	"github.com/go-git/go-billy/v5"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ * ValidationError is a cross-reference error found in a file
+ * of the teams repository
+ */
+type ValidationError struct {
+	Filename string
+	Message  string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Filename, e.Message)
+}
+
+// Github Actions allowlist pattern: OWNER/REPOSITORY[/PATH][@REF], with * wildcards
+// (like "monalisa/octocat@v2", "monalisa/*" or "monalisa/octocat/.github/workflows/ci.yml@main")
+var allowedActionsPattern = regexp.MustCompile(`^[A-Za-z0-9_.*-]+/[A-Za-z0-9_.*/-]+(@[A-Za-z0-9_.*/+-]+)?$`)
+
+func newValidationError(filename string, format string, args ...interface{}) error {
+	return &ValidationError{
+		Filename: filename,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+/*
+ * Validate runs the cross-reference checks on an already loaded teams repository,
+ * without any access to Github:
+ * - team owners and members exist as users
+ * - team names don't collide once slugified
+ * - repository owners and teams exist as teams
+ * - repository external users exist as external users
+ * - rulesets (and the admin team) referenced in goliac.yaml exist
+ * repoconfig can be nil, if there is no goliac.yaml to check.
+ */
+func Validate(local GoliacLocalResources, repoconfig *config.RepositoryConfig) []error {
+	errors := []error{}
+
+	teams := local.Teams()
+	users := local.Users()
+	externalUsers := local.ExternalUsers()
+
+	teamnames := make([]string, 0, len(teams))
+	for teamname := range teams {
+		teamnames = append(teamnames, teamname)
+	}
+	sort.Strings(teamnames)
+
+	slugs := make(map[string]string)
+	for _, teamname := range teamnames {
+		team := teams[teamname]
+		filename := teamFilename(teams, teamname)
+
+		for _, owner := range team.Spec.Owners {
+			if _, ok := users[owner]; !ok {
+				errors = append(errors, newValidationError(filename, "owner %s doesn't exist", owner))
+			}
+		}
+		for _, member := range team.Spec.Members {
+			if _, ok := users[member]; !ok {
+				errors = append(errors, newValidationError(filename, "member %s doesn't exist", member))
+			}
+		}
+
+		// the Github slug (with the GOLIAC_TEAM_NAME_PREFIX and GOLIAC_TEAM_NAME_SUFFIX)
+		teamslug := TeamSlug(teamname)
+		if other, ok := slugs[teamslug]; ok {
+			errors = append(errors, newValidationError(filename, "team %s collides with team %s (same slug %s)", teamname, other, teamslug))
+		} else {
+			slugs[teamslug] = teamname
+		}
+	}
+
+	repositories := local.Repositories()
+	reponames := make([]string, 0, len(repositories))
+	for reponame := range repositories {
+		reponames = append(reponames, reponame)
+	}
+	sort.Strings(reponames)
+
+	for _, reponame := range reponames {
+		repo := repositories[reponame]
+		filename := filepath.Join(repo.DirectoryPath, reponame+".yaml")
+
+		if repo.Owner != nil {
+			if _, ok := teams[*repo.Owner]; !ok {
+				errors = append(errors, newValidationError(filename, "owner team %s doesn't exist", *repo.Owner))
+			}
+		}
+
+		for _, refs := range []struct {
+			kind  string
+			teams []string
+		}{
+			{"writer", repo.Spec.Writers},
+			{"reader", repo.Spec.Readers},
+			{"admin", repo.Spec.Admins},
+			{"maintainer", repo.Spec.Maintainers},
+			{"triager", repo.Spec.Triagers},
+		} {
+			for _, t := range refs.teams {
+				if _, ok := teams[t]; !ok {
+					errors = append(errors, newValidationError(filename, "%s team %s doesn't exist", refs.kind, t))
+				}
+			}
+		}
+
+		for _, eReader := range repo.Spec.ExternalUserReaders {
+			if _, ok := externalUsers[eReader]; !ok {
+				errors = append(errors, newValidationError(filename, "external user reader %s doesn't exist", eReader))
+			}
+		}
+		for _, eWriter := range repo.Spec.ExternalUserWriters {
+			if _, ok := externalUsers[eWriter]; !ok {
+				errors = append(errors, newValidationError(filename, "external user writer %s doesn't exist", eWriter))
+			}
+		}
+		for _, eAdmin := range repo.Spec.ExternalUserAdmins {
+			if _, ok := externalUsers[eAdmin]; !ok {
+				errors = append(errors, newValidationError(filename, "external user admin %s doesn't exist", eAdmin))
+			}
+		}
+	}
+
+	if repoconfig != nil {
+		if repoconfig.AdminTeam != "" {
+			if _, ok := teams[repoconfig.AdminTeam]; !ok {
+				errors = append(errors, newValidationError("goliac.yaml", "admin team %s doesn't exist", repoconfig.AdminTeam))
+			}
+		}
+		if e := repoconfig.TeamMinimumOwners.Enforcement; e != "" && e != "warn" && e != "fail" {
+			errors = append(errors, newValidationError("goliac.yaml", "invalid team_minimum_owners enforcement %s (expected warn or fail)", e))
+		}
+		if p := repoconfig.TeamsRepositoryOwnersPermission; p != "" {
+			if _, ok := teamRepoPermissionLevels[p]; !ok {
+				errors = append(errors, newValidationError("goliac.yaml", "invalid teams_repository_owners_permission %s (expected pull, triage, push, maintain or admin)", p))
+			}
+		}
+		if n := repoconfig.EveryoneTeamNotificationSetting; n != "" && n != "notifications_enabled" && n != "notifications_disabled" {
+			errors = append(errors, newValidationError("goliac.yaml", "invalid everyone_team_notification_setting %s (expected notifications_enabled or notifications_disabled)", n))
+		}
+		errors = append(errors, validateOrganizationSettings("", &repoconfig.OrganizationPolicies, &repoconfig.OrgProfile)...)
+		for organization, settings := range repoconfig.Organizations {
+			errors = append(errors, validateOrganizationSettings(organization, &settings.OrganizationPolicies, &settings.OrgProfile)...)
+		}
+		for _, ls := range repoconfig.Labels {
+			if _, err := regexp.Compile(ls.Pattern); err != nil {
+				errors = append(errors, newValidationError("goliac.yaml", "invalid labels pattern %s: %v", ls.Pattern, err))
+			}
+			for _, label := range ls.Labels {
+				if err := entity.ValidateLabel(label); err != nil {
+					errors = append(errors, newValidationError("goliac.yaml", "%v", err))
+				}
+			}
+		}
+		for _, excluded := range []struct {
+			name     string
+			patterns []string
+		}{
+			{"excluded_teams", repoconfig.ExcludedTeams},
+			{"excluded_repositories", repoconfig.ExcludedRepositories},
+		} {
+			for _, pattern := range excluded.patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					errors = append(errors, newValidationError("goliac.yaml", "invalid %s pattern %s: %v", excluded.name, pattern, err))
+				}
+			}
+		}
+		// an excluded team or repository cannot be managed
+		for _, teamname := range teamnames {
+			if isExcluded(repoconfig.ExcludedTeams, TeamSlug(teamname)) {
+				errors = append(errors, newValidationError(teamFilename(teams, teamname), "team %s is excluded (excluded_teams in goliac.yaml)", teamname))
+			}
+		}
+		repositories := local.Repositories()
+		reponames := make([]string, 0, len(repositories))
+		for reponame := range repositories {
+			reponames = append(reponames, reponame)
+		}
+		sort.Strings(reponames)
+		for _, reponame := range reponames {
+			if isExcluded(repoconfig.ExcludedRepositories, reponame) {
+				filename := filepath.Join(repositories[reponame].DirectoryPath, reponame+".yaml")
+				errors = append(errors, newValidationError(filename, "repository %s is excluded (excluded_repositories in goliac.yaml)", reponame))
+			}
+		}
+		rulesets := local.RuleSets()
+		for _, rs := range repoconfig.Rulesets {
+			if _, err := regexp.Compile(rs.Pattern); err != nil {
+				errors = append(errors, newValidationError("goliac.yaml", "invalid ruleset pattern %s: %v", rs.Pattern, err))
+			}
+			if _, ok := rulesets[rs.Ruleset]; !ok {
+				errors = append(errors, newValidationError("goliac.yaml", "ruleset %s doesn't exist", rs.Ruleset))
+			}
+		}
+	}
+
+	return errors
+}
+
+/*
+ValidateTeamsOwners checks that each team (not externally managed) has at least
+the minimum number of owners defined in goliac.yaml (team_minimum_owners).
+Depending on the enforcement, deficient teams are reported as errors (fail)
+or warnings (warn).
+*/
+func ValidateTeamsOwners(teams map[string]*entity.Team, repoconfig *config.RepositoryConfig) ([]error, []entity.Warning) {
+	errors := []error{}
+	warnings := []entity.Warning{}
+
+	minimum := repoconfig.TeamMinimumOwners.Count
+	if minimum <= 0 {
+		return errors, warnings
+	}
+
+	teamnames := make([]string, 0, len(teams))
+	for teamname := range teams {
+		teamnames = append(teamnames, teamname)
+	}
+	sort.Strings(teamnames)
+
+	for _, teamname := range teamnames {
+		team := teams[teamname]
+		if team.Spec.ExternallyManaged || len(team.Spec.Owners) >= minimum {
+			continue
+		}
+		filename := teamFilename(teams, teamname)
+		if repoconfig.TeamMinimumOwners.Enforcement == "fail" {
+			errors = append(errors, newValidationError(filename, "team %s has %d owner(s), at least %d required", teamname, len(team.Spec.Owners), minimum))
+		} else {
+			warnings = append(warnings, fmt.Errorf("not enough owners for team filename %s", filename))
+		}
+	}
+	return errors, warnings
+}
+
+/*
+UnusedTeams returns the (sorted) teams that are neither owner, reader nor
+writer of any repository, and that are not referenced as a parent team (nor
+as a security manager team in goliac.yaml). They are only reported, never
+removed automatically
+*/
+func UnusedTeams(teams map[string]*entity.Team, repositories map[string]*entity.Repository, repoconfig *config.RepositoryConfig) []string {
+	used := make(map[string]bool)
+	for _, repo := range repositories {
+		if repo.Owner != nil {
+			used[*repo.Owner] = true
+		}
+		for _, teams := range [][]string{repo.Spec.Readers, repo.Spec.Writers, repo.Spec.Admins, repo.Spec.Maintainers, repo.Spec.Triagers} {
+			for _, teamname := range teams {
+				used[teamname] = true
+			}
+		}
+	}
+	for _, team := range teams {
+		if team.ParentTeam != nil {
+			used[*team.ParentTeam] = true
+		}
+	}
+	for _, teamname := range repoconfig.SecurityManagerTeams {
+		used[teamname] = true
+	}
+	for _, settings := range repoconfig.Organizations {
+		for _, teamname := range settings.SecurityManagerTeams {
+			used[teamname] = true
+		}
+	}
+
+	unused := []string{}
+	for teamname := range teams {
+		if !used[teamname] {
+			unused = append(unused, teamname)
+		}
+	}
+	sort.Strings(unused)
+	return unused
+}
+
+/*
+validateOrganizationSettings checks the org-level settings of the main
+organization (organization is empty), or of an additional one
+*/
+func validateOrganizationSettings(organization string, policies *config.OrganizationPolicies, profile *config.OrgProfile) []error {
+	errors := []error{}
+	prefix := ""
+	if organization != "" {
+		prefix = "organizations " + organization + ": "
+	}
+	if p := policies.DefaultWorkflowTokenPermissions; p != "" && p != "read" && p != "write" {
+		errors = append(errors, newValidationError("goliac.yaml", "%sinvalid default_workflow_token_permissions %s (expected read or write)", prefix, p))
+	}
+	// Github requires a billing email: it can't be cleared
+	if e := profile.BillingEmail; e != nil && !strings.Contains(*e, "@") {
+		errors = append(errors, newValidationError("goliac.yaml", "%sinvalid org_profile billing_email %q (expected an email address)", prefix, *e))
+	}
+	for _, pattern := range policies.AllowedActions.PatternsAllowed {
+		if !allowedActionsPattern.MatchString(pattern) {
+			errors = append(errors, newValidationError("goliac.yaml", "%sinvalid allowed_actions pattern %s (expected OWNER/REPOSITORY[/PATH][@REF], like monalisa/octocat@v2 or monalisa/*)", prefix, pattern))
+		}
+	}
+	return errors
+}
+
+/*
+ValidateNames checks that the teams and the (not archived) repositories names match
+the naming conventions defined in goliac.yaml (team_name_pattern and repository_name_pattern)
+*/
+func ValidateNames(teams map[string]*entity.Team, repositories map[string]*entity.Repository, repoconfig *config.RepositoryConfig) []error {
+	errors := []error{}
+
+	if repoconfig.TeamNamePattern != "" {
+		pattern, err := compileNamePattern(repoconfig.TeamNamePattern)
+		if err != nil {
+			return append(errors, newValidationError("goliac.yaml", "invalid team_name_pattern %s: %v", repoconfig.TeamNamePattern, err))
+		}
+		teamnames := make([]string, 0, len(teams))
+		for teamname := range teams {
+			teamnames = append(teamnames, teamname)
+		}
+		sort.Strings(teamnames)
+		for _, teamname := range teamnames {
+			if !pattern.MatchString(teamname) {
+				errors = append(errors, newValidationError(teamFilename(teams, teamname), "team name %s doesn't match the team_name_pattern %s", teamname, repoconfig.TeamNamePattern))
+			}
+		}
+	}
+
+	if repoconfig.RepositoryNamePattern != "" {
+		pattern, err := compileNamePattern(repoconfig.RepositoryNamePattern)
+		if err != nil {
+			return append(errors, newValidationError("goliac.yaml", "invalid repository_name_pattern %s: %v", repoconfig.RepositoryNamePattern, err))
+		}
+		reponames := make([]string, 0, len(repositories))
+		for reponame := range repositories {
+			reponames = append(reponames, reponame)
+		}
+		sort.Strings(reponames)
+		for _, reponame := range reponames {
+			repo := repositories[reponame]
+			// archived repositories keep their (legacy) name
+			if repo.Archived {
+				continue
+			}
+			if !pattern.MatchString(reponame) {
+				errors = append(errors, newValidationError(filepath.Join(repo.DirectoryPath, reponame+".yaml"), "repository name %s doesn't match the repository_name_pattern %s", reponame, repoconfig.RepositoryNamePattern))
+			}
+		}
+	}
+
+	return errors
+}
+
+/*
+ValidateMergeStrategies checks that the (not archived) repositories keep the merge
+strategies of the default_merge_strategy of goliac.yaml, unless they explicitly
+set merge_strategy_override
+*/
+func ValidateMergeStrategies(repositories map[string]*entity.Repository, repoconfig *config.RepositoryConfig) []error {
+	errors := []error{}
+
+	defaults := repoconfig.MergeStrategyDefaults()
+	if len(defaults) == 0 {
+		return errors
+	}
+
+	reponames := make([]string, 0, len(repositories))
+	for reponame := range repositories {
+		reponames = append(reponames, reponame)
+	}
+	sort.Strings(reponames)
+	for _, reponame := range reponames {
+		repo := repositories[reponame]
+		if repo.Archived || repo.Spec.MergeStrategyOverride {
+			continue
+		}
+		values := map[string]*bool{
+			"allow_squash_merge": repo.Spec.AllowSquashMerge,
+			"allow_merge_commit": repo.Spec.AllowMergeCommit,
+			"allow_rebase_merge": repo.Spec.AllowRebaseMerge,
+		}
+		for _, name := range []string{"allow_squash_merge", "allow_merge_commit", "allow_rebase_merge"} {
+			dv, ok := defaults[name]
+			if !ok || values[name] == nil || *values[name] == dv {
+				continue
+			}
+			errors = append(errors, newValidationError(filepath.Join(repo.DirectoryPath, reponame+".yaml"), "repository %s sets %s:%v, overriding the default_merge_strategy of goliac.yaml requires merge_strategy_override", reponame, name, *values[name]))
+		}
+	}
+
+	return errors
+}
+
+/*
+ValidateRequiredTeams checks the required_teams of goliac.yaml: each entry needs a
+topic or a custom property, and its teams must exist
+*/
+func ValidateRequiredTeams(teams map[string]*entity.Team, repoconfig *config.RepositoryConfig) []error {
+	errors := []error{}
+
+	for i, required := range repoconfig.RequiredTeams {
+		if required.Topic == "" && required.CustomProperty.Name == "" {
+			errors = append(errors, newValidationError("goliac.yaml", "required_teams entry %d needs a topic or a custom_property", i))
+		}
+		for _, teamname := range append(append([]string{}, required.Readers...), required.Writers...) {
+			if _, ok := teams[teamname]; !ok {
+				errors = append(errors, newValidationError("goliac.yaml", "required_teams team %s (%s) doesn't exist", teamname, required.Condition()))
+			}
+		}
+	}
+
+	return errors
+}
+
+/*
+ValidateProjects checks that the projects linked to the (not archived) repositories
+exist in the organization. Unlike the other checks, it needs Github: the
+organization projects are only fetched if a repository sets projects
+*/
+func ValidateProjects(ctx context.Context, repositories map[string]*entity.Repository, remote GoliacRemote) []error {
+	errors := []error{}
+
+	reponames := []string{}
+	for reponame, repo := range repositories {
+		if !repo.Archived && len(repo.Spec.Projects) > 0 {
+			reponames = append(reponames, reponame)
+		}
+	}
+	if len(reponames) == 0 {
+		return errors
+	}
+	sort.Strings(reponames)
+
+	// a (transient) Github failure doesn't block the apply: the reconciliation skips the unknown projects
+	orgProjects, err := remote.OrgProjects(ctx)
+	if err != nil {
+		logrus.Warnf("not able to fetch the organization projects (the repositories projects are not checked): %v", err)
+		return errors
+	}
+	for _, reponame := range reponames {
+		repo := repositories[reponame]
+		for _, title := range repo.Spec.Projects {
+			if _, ok := orgProjects[title]; !ok {
+				errors = append(errors, newValidationError(filepath.Join(repo.DirectoryPath, reponame+".yaml"), "project %s doesn't exist in the organization", title))
+			}
+		}
+	}
+
+	return errors
+}
+
+// compileNamePattern compiles a naming convention, that must match the whole name
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// teamFilename returns the team.yaml path of a team (following its parent teams)
+func teamFilename(teams map[string]*entity.Team, teamname string) string {
+	path := teamname
+	for team := teams[teamname]; team != nil && team.ParentTeam != nil && *team.ParentTeam != ""; team = teams[*team.ParentTeam] {
+		path = filepath.Join(*team.ParentTeam, path)
+	}
+	return filepath.Join("teams", path, "team.yaml")
+}
+
+/*
+ * ValidateSchema checks the files of the teams repository against their
+ * schema, before any semantic check: an unknown field (like a `readrs:` typo)
+ * or a wrong type is an error, reported with its file and line
+ */
+func ValidateSchema(fs billy.Filesystem) []error {
+	errors := []error{}
+	for _, dirname := range []string{filepath.Join("users", "protected"), filepath.Join("users", "org"), filepath.Join("users", "external")} {
+		errors = append(errors, validateSchemaDirectory(fs, dirname, false, func(string) string { return "User" })...)
+	}
+	errors = append(errors, validateSchemaDirectory(fs, "rulesets", false, func(string) string { return "Ruleset" })...)
+	errors = append(errors, validateSchemaDirectory(fs, "archived", false, func(string) string { return "Repository" })...)
+	errors = append(errors, validateSchemaDirectory(fs, "teams", true, func(filename string) string {
+		switch filepath.Base(filename) {
+		case "team.yaml":
+			return "Team"
+		case entity.DefaultsFilename:
+			return "Defaults"
+		default:
+			return "Repository"
+		}
+	})...)
+	return errors
+}
+
+func validateSchemaDirectory(fs billy.Filesystem, dirname string, recursive bool, kind func(filename string) string) []error {
+	errors := []error{}
+	exist, err := utils.Exists(fs, dirname)
+	if err != nil {
+		return []error{err}
+	}
+	if !exist {
+		return errors
+	}
+	entries, err := fs.ReadDir(dirname)
+	if err != nil {
+		return []error{err}
+	}
+	for _, e := range entries {
+		// skipping files (and directories) starting with '.'
+		if e.Name()[0] == '.' {
+			continue
+		}
+		filename := filepath.Join(dirname, e.Name())
+		if e.IsDir() {
+			if recursive {
+				errors = append(errors, validateSchemaDirectory(fs, filename, recursive, kind)...)
+			}
+			continue
+		}
+		if filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		filecontent, err := utils.ReadFile(fs, filename)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		errors = append(errors, entity.ValidateSchema(filename, filecontent, kind(filename))...)
+	}
+	return errors
+}
@@ -0,0 +1,448 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/Alayacare/goliac/internal/utils"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidationLocalMock() *GoliacLocalMock {
+	local := &GoliacLocalMock{
+		users:     make(map[string]*entity.User),
+		externals: make(map[string]*entity.User),
+		teams:     make(map[string]*entity.Team),
+		repos:     make(map[string]*entity.Repository),
+		rulesets:  make(map[string]*entity.RuleSet),
+	}
+
+	user := &entity.User{}
+	user.Name = "user1"
+	local.users["user1"] = user
+
+	external := &entity.User{}
+	external.Name = "external1"
+	local.externals["external1"] = external
+
+	admin := &entity.Team{}
+	admin.Name = "admin"
+	admin.Spec.Owners = []string{"user1"}
+	local.teams["admin"] = admin
+
+	parent := "admin"
+	team := &entity.Team{}
+	team.Name = "team1"
+	team.Spec.Members = []string{"user1"}
+	team.ParentTeam = &parent
+	local.teams["team1"] = team
+
+	owner := "team1"
+	repo := &entity.Repository{}
+	repo.Name = "repo1"
+	repo.Owner = &owner
+	repo.DirectoryPath = "teams/admin/team1"
+	repo.Spec.Readers = []string{"admin"}
+	repo.Spec.ExternalUserWriters = []string{"external1"}
+	local.repos["repo1"] = repo
+
+	ruleset := &entity.RuleSet{}
+	ruleset.Name = "default"
+	local.rulesets["default"] = ruleset
+
+	return local
+}
+
+func TestValidate(t *testing.T) {
+	repoconfig := &config.RepositoryConfig{
+		AdminTeam: "admin",
+		Rulesets: []struct {
+			Pattern  string
+			Ruleset  string
+			Priority int
+			Topic    string
+		}{
+			{Pattern: ".*", Ruleset: "default"},
+		},
+	}
+
+	t.Run("happy path: valid teams repository", func(t *testing.T) {
+		local := newValidationLocalMock()
+
+		errs := Validate(local, repoconfig)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("happy path: without goliac.yaml", func(t *testing.T) {
+		local := newValidationLocalMock()
+		delete(local.rulesets, "default")
+
+		errs := Validate(local, nil)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: broken references", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.teams["team1"].Spec.Owners = []string{"unknown_user"}
+		local.repos["repo1"].Spec.Writers = []string{"unknown_team"}
+		local.repos["repo1"].Spec.ExternalUserReaders = []string{"unknown_external"}
+		delete(local.rulesets, "default")
+
+		errs := Validate(local, repoconfig)
+		assert.Equal(t, 4, len(errs))
+
+		filenames := []string{}
+		for _, err := range errs {
+			verr, ok := err.(*ValidationError)
+			assert.True(t, ok)
+			filenames = append(filenames, verr.Filename)
+		}
+		assert.Equal(t, []string{
+			"teams/admin/team1/team.yaml",
+			"teams/admin/team1/repo1.yaml",
+			"teams/admin/team1/repo1.yaml",
+			"goliac.yaml",
+		}, filenames)
+		assert.Equal(t, "teams/admin/team1/team.yaml: owner unknown_user doesn't exist", errs[0].Error())
+	})
+
+	t.Run("not happy path: invalid teams repository owners permission", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		conf.TeamsRepositoryOwnersPermission = "write"
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "goliac.yaml: invalid teams_repository_owners_permission write (expected pull, triage, push, maintain or admin)", errs[0].Error())
+	})
+
+	t.Run("not happy path: invalid everyone team notification setting", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		conf.EveryoneTeamNotificationSetting = "disabled"
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "goliac.yaml: invalid everyone_team_notification_setting disabled (expected notifications_enabled or notifications_disabled)", errs[0].Error())
+	})
+
+	t.Run("not happy path: invalid default workflow token permissions", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		conf.OrganizationPolicies.DefaultWorkflowTokenPermissions = "admin"
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "goliac.yaml: invalid default_workflow_token_permissions admin (expected read or write)", errs[0].Error())
+	})
+
+	t.Run("not happy path: invalid org profile billing email", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		billingEmail := ""
+		conf.OrgProfile.BillingEmail = &billingEmail
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, `goliac.yaml: invalid org_profile billing_email "" (expected an email address)`, errs[0].Error())
+	})
+
+	t.Run("not happy path: invalid allowed actions patterns", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		conf.OrganizationPolicies.AllowedActions.PatternsAllowed = []string{
+			"monalisa/octocat@v2",
+			"monalisa/*",
+			"monalisa/octocat/.github/workflows/ci.yml@main",
+			"octocat",
+			"monalisa/octocat@v2, monalisa/other@v1",
+		}
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "goliac.yaml: invalid allowed_actions pattern octocat (expected OWNER/REPOSITORY[/PATH][@REF], like monalisa/octocat@v2 or monalisa/*)", errs[0].Error())
+		assert.Equal(t, "goliac.yaml: invalid allowed_actions pattern monalisa/octocat@v2, monalisa/other@v1 (expected OWNER/REPOSITORY[/PATH][@REF], like monalisa/octocat@v2 or monalisa/*)", errs[1].Error())
+	})
+
+	t.Run("not happy path: excluded team and repository", func(t *testing.T) {
+		local := newValidationLocalMock()
+		conf := *repoconfig
+		conf.ExcludedTeams = []string{"legacy-*", "TEAM1"}
+		conf.ExcludedRepositories = []string{"repo[1-2]", "["}
+
+		errs := Validate(local, &conf)
+		assert.Equal(t, 3, len(errs))
+		assert.Equal(t, "goliac.yaml: invalid excluded_repositories pattern [: syntax error in pattern", errs[0].Error())
+		assert.Equal(t, "teams/admin/team1/team.yaml: team team1 is excluded (excluded_teams in goliac.yaml)", errs[1].Error())
+		assert.Equal(t, "teams/admin/team1/repo1.yaml: repository repo1 is excluded (excluded_repositories in goliac.yaml)", errs[2].Error())
+	})
+
+	t.Run("not happy path: team slug collision", func(t *testing.T) {
+		local := newValidationLocalMock()
+		team := &entity.Team{}
+		team.Name = "Team1"
+		local.teams["Team1"] = team
+
+		errs := Validate(local, repoconfig)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/admin/team1/team.yaml: team team1 collides with team Team1 (same slug team1)", errs[0].Error())
+	})
+
+	t.Run("not happy path: team slug collision with a team name prefix", func(t *testing.T) {
+		config.Config.GoliacTeamNamePrefix = "T "
+		defer func() { config.Config.GoliacTeamNamePrefix = "" }()
+
+		local := newValidationLocalMock()
+		team := &entity.Team{}
+		team.Name = "Team1"
+		local.teams["Team1"] = team
+
+		errs := Validate(local, repoconfig)
+		assert.Equal(t, 1, len(errs))
+		// the slug compared is the Github one
+		assert.Equal(t, "teams/admin/team1/team.yaml: team team1 collides with team Team1 (same slug t-team1)", errs[0].Error())
+	})
+}
+
+func TestValidateTeamsOwners(t *testing.T) {
+	t.Run("happy path: deficient teams are reported as warnings", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamMinimumOwners.Count = 1
+		repoconfig.TeamMinimumOwners.Enforcement = "warn"
+
+		errs, warns := ValidateTeamsOwners(local.Teams(), repoconfig)
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 1, len(warns))
+		assert.Equal(t, "not enough owners for team filename teams/admin/team1/team.yaml", warns[0].Error())
+	})
+
+	t.Run("not happy path: deficient teams are reported as errors", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamMinimumOwners.Count = 2
+		repoconfig.TeamMinimumOwners.Enforcement = "fail"
+
+		errs, warns := ValidateTeamsOwners(local.Teams(), repoconfig)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, "teams/admin/team.yaml: team admin has 1 owner(s), at least 2 required", errs[0].Error())
+		assert.Equal(t, "teams/admin/team1/team.yaml: team team1 has 0 owner(s), at least 2 required", errs[1].Error())
+	})
+
+	t.Run("happy path: check disabled", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamMinimumOwners.Count = 0
+		repoconfig.TeamMinimumOwners.Enforcement = "fail"
+
+		errs, warns := ValidateTeamsOwners(local.Teams(), repoconfig)
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+	})
+}
+
+func TestUnusedTeams(t *testing.T) {
+	t.Run("happy path: all the teams are used", func(t *testing.T) {
+		local := newValidationLocalMock()
+
+		assert.Equal(t, []string{}, UnusedTeams(local.Teams(), local.Repositories(), &config.RepositoryConfig{}))
+	})
+
+	t.Run("happy path: teams without repository nor subteam are reported", func(t *testing.T) {
+		local := newValidationLocalMock()
+		for _, teamname := range []string{"unused2", "unused1", "secmanagers"} {
+			team := &entity.Team{}
+			team.Name = teamname
+			local.teams[teamname] = team
+		}
+		// a parent team is used (through its subteams)
+		local.repos["repo1"].Spec.Readers = []string{}
+		repoconfig := &config.RepositoryConfig{
+			SecurityManagerTeams: []string{"secmanagers"},
+		}
+
+		assert.Equal(t, []string{"unused1", "unused2"}, UnusedTeams(local.Teams(), local.Repositories(), repoconfig))
+	})
+}
+
+func TestValidateNames(t *testing.T) {
+	t.Run("happy path: names matching the conventions", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamNamePattern = "[a-z0-9-]+"
+		repoconfig.RepositoryNamePattern = "[a-z0-9-]+"
+
+		errs := ValidateNames(local.Teams(), local.Repositories(), repoconfig)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: names not matching the conventions", func(t *testing.T) {
+		local := newValidationLocalMock()
+		legacy := &entity.Repository{}
+		legacy.Name = "Legacy_Repo"
+		legacy.Archived = true
+		local.repos["Legacy_Repo"] = legacy
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.TeamNamePattern = "team[0-9]+"
+		// the whole name must match
+		repoconfig.RepositoryNamePattern = "team1-[a-z]+"
+
+		errs := ValidateNames(local.Teams(), local.Repositories(), repoconfig)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "teams/admin/team.yaml: team name admin doesn't match the team_name_pattern team[0-9]+", errs[0].Error())
+		assert.Equal(t, "teams/admin/team1/repo1.yaml: repository name repo1 doesn't match the repository_name_pattern team1-[a-z]+", errs[1].Error())
+	})
+
+	t.Run("not happy path: invalid pattern", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.RepositoryNamePattern = "[a-z"
+
+		errs := ValidateNames(local.Teams(), local.Repositories(), repoconfig)
+		assert.Equal(t, 1, len(errs))
+		assert.Contains(t, errs[0].Error(), "goliac.yaml: invalid repository_name_pattern [a-z")
+	})
+}
+
+func TestValidateRequiredTeams(t *testing.T) {
+	t.Run("happy path: existing teams", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{
+			RequiredTeams: []config.RequiredTeams{
+				{Topic: "pii", Readers: []string{"team1"}, Writers: []string{"admin"}},
+			},
+		}
+		errs := ValidateRequiredTeams(local.Teams(), repoconfig)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: unknown team and missing condition", func(t *testing.T) {
+		local := newValidationLocalMock()
+		repoconfig := &config.RepositoryConfig{
+			RequiredTeams: []config.RequiredTeams{
+				{Topic: "pii", Readers: []string{"unknown"}},
+				{Writers: []string{"team1"}},
+			},
+		}
+		errs := ValidateRequiredTeams(local.Teams(), repoconfig)
+		assert.Equal(t, 2, len(errs))
+		assert.Contains(t, errs[0].Error(), "required_teams team unknown (topic pii) doesn't exist")
+		assert.Contains(t, errs[1].Error(), "required_teams entry 1 needs a topic or a custom_property")
+	})
+}
+
+func TestValidateMergeStrategies(t *testing.T) {
+	allowed := true
+	notAllowed := false
+
+	t.Run("happy path: no default merge strategy", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.AllowMergeCommit = &allowed
+
+		errs := ValidateMergeStrategies(local.Repositories(), &config.RepositoryConfig{})
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("happy path: explicit override of the default merge strategy", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.AllowMergeCommit = &allowed
+		local.repos["repo1"].Spec.MergeStrategyOverride = true
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.DefaultMergeStrategy.AllowMergeCommit = &notAllowed
+
+		errs := ValidateMergeStrategies(local.Repositories(), repoconfig)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: override without merge_strategy_override", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.AllowMergeCommit = &allowed
+		repoconfig := &config.RepositoryConfig{}
+		repoconfig.DefaultMergeStrategy.AllowMergeCommit = &notAllowed
+
+		errs := ValidateMergeStrategies(local.Repositories(), repoconfig)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/admin/team1/repo1.yaml: repository repo1 sets allow_merge_commit:true, overriding the default_merge_strategy of goliac.yaml requires merge_strategy_override", errs[0].Error())
+	})
+}
+
+func TestValidateProjects(t *testing.T) {
+	remote := &GoliacRemoteMock{
+		projects: map[string]*GithubProject{
+			"Roadmap": {Id: "PVT_1", Number: 1, Title: "Roadmap"},
+		},
+	}
+
+	t.Run("happy path: existing projects", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.Projects = []string{"Roadmap"}
+
+		errs := ValidateProjects(context.TODO(), local.Repositories(), remote)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("happy path: the organization projects are not fetched without projects", func(t *testing.T) {
+		local := newValidationLocalMock()
+
+		// a nil remote would panic if it was called
+		errs := ValidateProjects(context.TODO(), local.Repositories(), nil)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: unknown project", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.Projects = []string{"Roadmap", "Unknown"}
+
+		errs := ValidateProjects(context.TODO(), local.Repositories(), remote)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/admin/team1/repo1.yaml: project Unknown doesn't exist in the organization", errs[0].Error())
+	})
+
+	t.Run("happy path: the check is skipped if the organization projects can't be fetched", func(t *testing.T) {
+		local := newValidationLocalMock()
+		local.repos["repo1"].Spec.Projects = []string{"Roadmap", "Unknown"}
+
+		failingRemote := &GoliacRemoteMock{
+			projectsErr: fmt.Errorf("github is down"),
+		}
+		errs := ValidateProjects(context.TODO(), local.Repositories(), failingRemote)
+		assert.Equal(t, 0, len(errs))
+	})
+}
+
+func TestValidateSchema(t *testing.T) {
+
+	t.Run("happy path: basic structure", func(t *testing.T) {
+		fs := memfs.New()
+		assert.Nil(t, createBasicStructure(fs))
+
+		errs := ValidateSchema(fs)
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: typo in a repository, before the semantic checks", func(t *testing.T) {
+		fs := memfs.New()
+		assert.Nil(t, createBasicStructure(fs))
+		assert.Nil(t, utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  readrs:
+  - unknown-team
+`), 0644))
+
+		errs := ValidateSchema(fs)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/team1/repo1.yaml:5: unknown field spec.readrs", errs[0].Error())
+
+		g := NewGoliacLocalImpl()
+		errs, _ = g.LoadAndValidateLocal(fs, nil)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/team1/repo1.yaml:5: unknown field spec.readrs", errs[0].Error())
+	})
+}
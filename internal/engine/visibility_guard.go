@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"sync"
+)
+
+/*
+VisibilityGuard remembers the repositories visibility changes applied by the
+reconciliations, to detect a visibility flip-flop: the visibility of a
+repository changed by a reconciliation, that the very next reconciliation
+wants to change back (like 2 definitions disagreeing about it).
+The visibility applied last is then held, until the plan stops changing it back.
+*/
+type VisibilityGuard struct {
+	mutex    sync.Mutex
+	previous map[string]bool // visibility (private) applied by the last reconciliation, by repository
+	held     map[string]bool // visibility (private) held after a flip-flop, by repository
+
+	// the current reconciliation
+	applied   map[string]bool
+	newHolds  map[string]bool
+	stillHeld map[string]bool
+	released  map[string]bool
+}
+
+func NewVisibilityGuard() *VisibilityGuard {
+	g := &VisibilityGuard{
+		previous: make(map[string]bool),
+		held:     make(map[string]bool),
+	}
+	g.begin()
+	return g
+}
+
+// begin starts a reconciliation
+func (g *VisibilityGuard) begin() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.applied = make(map[string]bool)
+	g.newHolds = make(map[string]bool)
+	g.stillHeld = make(map[string]bool)
+	g.released = make(map[string]bool)
+}
+
+/*
+check returns if the visibility of a repository can be changed (to private or
+public), and if a flip-flop has just been detected (the last reconciliation
+changed it the other way). If not allowed, the held visibility is returned
+*/
+func (g *VisibilityGuard) check(reponame string, private bool) (allowed bool, flipflop bool, held bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if h, ok := g.held[reponame]; ok {
+		if h != private {
+			g.stillHeld[reponame] = h
+			return false, false, h
+		}
+		g.released[reponame] = true
+		return true, false, h
+	}
+	if previous, ok := g.previous[reponame]; ok && previous != private {
+		g.newHolds[reponame] = previous
+		return false, true, previous
+	}
+	return true, false, private
+}
+
+// record remembers a visibility change applied by the current reconciliation
+func (g *VisibilityGuard) record(reponame string, private bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.applied[reponame] = private
+}
+
+/*
+end ends a reconciliation (nothing is remembered for a dryrun one).
+A complete reconciliation (not restricted to a scope) replaces the changes
+remembered, and releases the holds it didn't need anymore
+*/
+func (g *VisibilityGuard) end(dryrun bool, complete bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if dryrun {
+		return
+	}
+
+	if complete {
+		g.previous = g.applied
+		g.held = g.stillHeld
+	} else {
+		for reponame, private := range g.applied {
+			g.previous[reponame] = private
+		}
+		for reponame := range g.released {
+			delete(g.held, reponame)
+		}
+	}
+	for reponame, private := range g.newHolds {
+		g.held[reponame] = private
+		delete(g.previous, reponame)
+	}
+	g.applied = make(map[string]bool)
+	g.newHolds = make(map[string]bool)
+	g.stillHeld = make(map[string]bool)
+	g.released = make(map[string]bool)
+}
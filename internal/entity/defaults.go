@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Alayacare/goliac/internal/utils"
+	"github.com/go-git/go-billy/v5"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultsFilename = "_defaults.yaml"
+
+/*
+ * Defaults are the specs shared by all the teams and repositories definitions.
+ * They are declared once in the teams/_defaults.yaml file, like
+ *
+ * repository:
+ *   delete_branch_on_merge: true
+ *   readers:
+ *   - security
+ * team:
+ *   reviewAssignment:
+ *     algorithm: round_robin
+ *     teamMembersCount: 1
+ *
+ * and merged into the spec of each definition when it is loaded (a value set
+ * in the definition wins over the default one)
+ */
+type Defaults struct {
+	Repository map[string]interface{} `yaml:"repository,omitempty"`
+	Team       map[string]interface{} `yaml:"team,omitempty"`
+}
+
+/*
+ * ReadDefaults reads the _defaults.yaml file of the dirname directory (if any)
+ */
+func ReadDefaults(fs billy.Filesystem, dirname string) (*Defaults, error) {
+	defaults := &Defaults{}
+	filename := filepath.Join(dirname, DefaultsFilename)
+
+	exist, err := utils.Exists(fs, filename)
+	if err != nil {
+		return defaults, err
+	}
+	if !exist {
+		return defaults, nil
+	}
+
+	filecontent, err := utils.ReadFile(fs, filename)
+	if err != nil {
+		return defaults, err
+	}
+	err = yaml.Unmarshal(filecontent, defaults)
+	if err != nil {
+		return defaults, fmt.Errorf("not able to parse %s: %v", filename, err)
+	}
+	return defaults, nil
+}
+
+/*
+ * unmarshalWithDefaults parses a definition into out, after merging the
+ * defaults into its spec
+ */
+func unmarshalWithDefaults(filecontent []byte, defaults map[string]interface{}, out interface{}) error {
+	if len(defaults) == 0 {
+		return yaml.Unmarshal(filecontent, out)
+	}
+
+	definition := make(map[string]interface{})
+	err := yaml.Unmarshal(filecontent, &definition)
+	if err != nil {
+		return err
+	}
+	spec, _ := definition["spec"].(map[string]interface{})
+	definition["spec"] = mergeDefaults(defaults, spec)
+
+	// the merged definition is parsed as if it was written as is
+	merged, err := yaml.Marshal(definition)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(merged, out)
+}
+
+/*
+ * mergeDefaults merges recursively the maps, values wins over the defaults
+ * (lists are not merged: a list in values replaces the default one)
+ */
+func mergeDefaults(defaults map[string]interface{}, values map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(values))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range values {
+		defaultMap, isDefaultMap := merged[k].(map[string]interface{})
+		valueMap, isValueMap := v.(map[string]interface{})
+		if isDefaultMap && isValueMap {
+			merged[k] = mergeDefaults(defaultMap, valueMap)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -3,6 +3,8 @@ package entity
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/Alayacare/goliac/internal/utils"
 	"github.com/go-git/go-billy/v5"
@@ -20,47 +22,84 @@ type RuleSetParameters struct {
 	// RequiredStatusChecksParameters
 	RequiredStatusChecks             []string `yaml:"requiredStatusChecks,omitempty"`
 	StrictRequiredStatusChecksPolicy bool     `yaml:"strictRequiredStatusChecksPolicy,omitempty"`
+	// resolve the integration (the Github App reporting it) of each required status check,
+	// from the checks of the default branch of the matched repositories
+	ResolveStatusChecksIntegrations bool `yaml:"resolveStatusChecksIntegrations,omitempty"`
+	// integration id of the required status checks (the key is the context), when bound to one:
+	// resolved per matched repository, or loaded from Github (never set in the yaml)
+	RequiredStatusChecksIntegrations map[string]int `yaml:"-"`
+}
+
+/*
+statusCheckTuples returns the required status checks as (sorted) "context"
+or "context (integration <id>)" tuples
+*/
+func statusCheckTuples(p RuleSetParameters) []string {
+	tuples := make([]string, 0, len(p.RequiredStatusChecks))
+	for _, context := range p.RequiredStatusChecks {
+		if id := p.RequiredStatusChecksIntegrations[context]; id != 0 {
+			tuples = append(tuples, fmt.Sprintf("%s (integration %d)", context, id))
+		} else {
+			tuples = append(tuples, context)
+		}
+	}
+	sort.Strings(tuples)
+	return tuples
 }
 
 func CompareRulesetParameters(ruletype string, left RuleSetParameters, right RuleSetParameters) bool {
+	return len(DiffRulesetParameters(ruletype, left, right)) == 0
+}
+
+/*
+ * DiffRulesetParameters returns the parameters of a rule that differ,
+ * like "requiredApprovingReviewCount: 1 -> 2" (from the right value to the left one)
+ * An unknown rule type is always reported as different
+ */
+func DiffRulesetParameters(ruletype string, left RuleSetParameters, right RuleSetParameters) []string {
+	diff := []string{}
 	switch ruletype {
-	case "required_signatures":
-		return true
-	case "creation":
-		return true
-	case "update":
-		return true
-	case "deletion":
-		return true
-	case "non_fast_forward":
-		return true
+	case "required_signatures", "creation", "update", "deletion", "non_fast_forward":
+		// no parameters
 	case "pull_request":
 		if left.DismissStaleReviewsOnPush != right.DismissStaleReviewsOnPush {
-			return false
+			diff = append(diff, fmt.Sprintf("dismissStaleReviewsOnPush: %v -> %v", right.DismissStaleReviewsOnPush, left.DismissStaleReviewsOnPush))
 		}
 		if left.RequireCodeOwnerReview != right.RequireCodeOwnerReview {
-			return false
+			diff = append(diff, fmt.Sprintf("requireCodeOwnerReview: %v -> %v", right.RequireCodeOwnerReview, left.RequireCodeOwnerReview))
 		}
 		if left.RequiredApprovingReviewCount != right.RequiredApprovingReviewCount {
-			return false
+			diff = append(diff, fmt.Sprintf("requiredApprovingReviewCount: %v -> %v", right.RequiredApprovingReviewCount, left.RequiredApprovingReviewCount))
 		}
 		if left.RequiredReviewThreadResolution != right.RequiredReviewThreadResolution {
-			return false
+			diff = append(diff, fmt.Sprintf("requiredReviewThreadResolution: %v -> %v", right.RequiredReviewThreadResolution, left.RequiredReviewThreadResolution))
 		}
 		if left.RequireLastPushApproval != right.RequireLastPushApproval {
-			return false
+			diff = append(diff, fmt.Sprintf("requireLastPushApproval: %v -> %v", right.RequireLastPushApproval, left.RequireLastPushApproval))
 		}
-		return true
 	case "required_status_checks":
-		if res, _, _ := StringArrayEquivalent(left.RequiredStatusChecks, right.RequiredStatusChecks); !res {
-			return false
+		// the (context, integration) tuples are compared, whatever their order
+		if res, rightOnly, leftOnly := StringArrayEquivalent(statusCheckTuples(left), statusCheckTuples(right)); !res {
+			sort.Strings(leftOnly)
+			sort.Strings(rightOnly)
+			if len(leftOnly) > 0 {
+				diff = append(diff, fmt.Sprintf("requiredStatusChecks added: %s", strings.Join(leftOnly, ",")))
+			}
+			if len(rightOnly) > 0 {
+				diff = append(diff, fmt.Sprintf("requiredStatusChecks removed: %s", strings.Join(rightOnly, ",")))
+			}
+			if len(leftOnly) == 0 && len(rightOnly) == 0 {
+				// same checks, but duplicated
+				diff = append(diff, "requiredStatusChecks changed")
+			}
 		}
 		if left.StrictRequiredStatusChecksPolicy != right.StrictRequiredStatusChecksPolicy {
-			return false
+			diff = append(diff, fmt.Sprintf("strictRequiredStatusChecksPolicy: %v -> %v", right.StrictRequiredStatusChecksPolicy, left.StrictRequiredStatusChecksPolicy))
 		}
-		return true
+	default:
+		diff = append(diff, fmt.Sprintf("unknown rule type %s", ruletype))
 	}
-	return false
+	return diff
 }
 
 type RuleSetDefinition struct {
@@ -190,9 +229,11 @@ func (r *RuleSet) Validate(filename string) error {
 		}
 	}
 
-	if r.Spec.Enforcement != "disable" && r.Spec.Enforcement != "active" && r.Spec.Enforcement != "evaluate" {
+	enforcement, ok := normalizeEnforcement(r.Spec.Enforcement)
+	if !ok {
 		return fmt.Errorf("invalid enforcement: %s for ruleset filename %s", r.Spec.Enforcement, filename)
 	}
+	r.Spec.Enforcement = enforcement
 
 	for _, ba := range r.Spec.BypassApps {
 		if ba.Mode != "always" && ba.Mode != "pull_request" {
@@ -212,3 +253,18 @@ func (r *RuleSet) Validate(filename string) error {
 
 	return nil
 }
+
+/*
+normalizeEnforcement returns the enforcement of a ruleset as Github expects it,
+and false if it is not valid. "disable" (the spelling checked by the previous
+versions) is still accepted, as "disabled".
+*/
+func normalizeEnforcement(enforcement string) (string, bool) {
+	switch enforcement {
+	case "disable", "disabled":
+		return "disabled", true
+	case "active", "evaluate":
+		return enforcement, true
+	}
+	return enforcement, false
+}
@@ -69,6 +69,48 @@ func TestRuleset(t *testing.T) {
 		assert.Equal(t, 2, len(rulesets))
 
 	})
+
+	t.Run("happy path: the disable spelling is still accepted", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("rulesets", 0755)
+		err := utils.WriteFile(fs, "rulesets/ruleset1.yaml", []byte(`
+apiVersion: v1
+kind: Ruleset
+name: ruleset1
+spec:
+  enforcement: disable
+  conditions:
+    include: 
+    - "~DEFAULT_BRANCH"
+
+  rules:
+    - ruletype: required_signatures
+`), 0644)
+		assert.Nil(t, err)
+
+		rulesets, errs, warns := ReadRuleSetDirectory(fs, "rulesets")
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, "disabled", rulesets["ruleset1"].Spec.Enforcement)
+	})
+
+	t.Run("not happy path: unknown enforcement", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("rulesets", 0755)
+		err := utils.WriteFile(fs, "rulesets/ruleset1.yaml", []byte(`
+apiVersion: v1
+kind: Ruleset
+name: ruleset1
+spec:
+  enforcement: enabled
+  rules:
+    - ruletype: required_signatures
+`), 0644)
+		assert.Nil(t, err)
+
+		_, errs, _ := ReadRuleSetDirectory(fs, "rulesets")
+		assert.Equal(t, 1, len(errs))
+	})
 }
 
 func TestRulesetParametersComparison(t *testing.T) {
@@ -94,3 +136,68 @@ func TestRulesetParametersComparison(t *testing.T) {
 		assert.True(t, res)
 	})
 }
+
+func TestDiffRulesetParameters(t *testing.T) {
+	t.Run("happy path: the parameters that differ are reported", func(t *testing.T) {
+		local := RuleSetParameters{
+			RequiredApprovingReviewCount: 2,
+			RequireCodeOwnerReview:       true,
+		}
+		remote := RuleSetParameters{
+			RequiredApprovingReviewCount: 1,
+			RequireCodeOwnerReview:       true,
+		}
+		assert.Equal(t, []string{"requiredApprovingReviewCount: 1 -> 2"}, DiffRulesetParameters("pull_request", local, remote))
+		assert.Equal(t, []string{}, DiffRulesetParameters("pull_request", local, local))
+	})
+
+	t.Run("happy path: the status checks added and removed are reported", func(t *testing.T) {
+		local := RuleSetParameters{
+			RequiredStatusChecks: []string{"lint", "test", "build"},
+		}
+		remote := RuleSetParameters{
+			RequiredStatusChecks:             []string{"test", "jenkins check"},
+			StrictRequiredStatusChecksPolicy: true,
+		}
+		assert.Equal(t, []string{
+			"requiredStatusChecks added: build,lint",
+			"requiredStatusChecks removed: jenkins check",
+			"strictRequiredStatusChecksPolicy: true -> false",
+		}, DiffRulesetParameters("required_status_checks", local, remote))
+	})
+
+	t.Run("happy path: toggling only the strict status checks policy is a change", func(t *testing.T) {
+		local := RuleSetParameters{
+			RequiredStatusChecks:             []string{"build"},
+			StrictRequiredStatusChecksPolicy: true,
+		}
+		remote := RuleSetParameters{
+			RequiredStatusChecks: []string{"build"},
+		}
+		assert.False(t, CompareRulesetParameters("required_status_checks", local, remote))
+		assert.Equal(t, []string{"strictRequiredStatusChecksPolicy: false -> true"}, DiffRulesetParameters("required_status_checks", local, remote))
+	})
+
+	t.Run("happy path: the status checks integrations are compared, whatever the order", func(t *testing.T) {
+		local := RuleSetParameters{
+			RequiredStatusChecks:             []string{"test", "build"},
+			RequiredStatusChecksIntegrations: map[string]int{"build": 15368, "test": 42},
+		}
+		remote := RuleSetParameters{
+			RequiredStatusChecks:             []string{"build", "test"},
+			RequiredStatusChecksIntegrations: map[string]int{"test": 42, "build": 15368},
+		}
+		assert.True(t, CompareRulesetParameters("required_status_checks", local, remote))
+
+		remote.RequiredStatusChecksIntegrations = map[string]int{"build": 15368}
+		assert.Equal(t, []string{
+			"requiredStatusChecks added: test (integration 42)",
+			"requiredStatusChecks removed: test",
+		}, DiffRulesetParameters("required_status_checks", local, remote))
+	})
+
+	t.Run("not happy path: unknown rule type", func(t *testing.T) {
+		assert.Equal(t, 1, len(DiffRulesetParameters("unknown", RuleSetParameters{}, RuleSetParameters{})))
+		assert.False(t, CompareRulesetParameters("unknown", RuleSetParameters{}, RuleSetParameters{}))
+	})
+}
@@ -3,35 +3,68 @@ package entity
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/utils"
 	"github.com/go-git/go-billy/v5"
-	"gopkg.in/yaml.v3"
 )
 
 type Repository struct {
 	Entity `yaml:",inline"`
 	Spec   struct {
-		Writers             []string            `yaml:"writers,omitempty"`
-		Readers             []string            `yaml:"readers,omitempty"`
-		ExternalUserReaders []string            `yaml:"externalUserReaders,omitempty"`
-		ExternalUserWriters []string            `yaml:"externalUserWriters,omitempty"`
-		IsPublic            bool                `yaml:"public,omitempty"`
-		AllowAutoMerge      bool                `yaml:"allow_auto_merge,omitempty"`
-		DeleteBranchOnMerge bool                `yaml:"delete_branch_on_merge,omitempty"`
-		AllowUpdateBranch   bool                `yaml:"allow_update_branch,omitempty"`
-		Rulesets            []RepositoryRuleSet `yaml:"rulesets,omitempty"`
+		Writers                   []string            `yaml:"writers,omitempty"`     // push permission
+		Readers                   []string            `yaml:"readers,omitempty"`     // pull permission
+		Admins                    []string            `yaml:"admins,omitempty"`      // admin permission
+		Maintainers               []string            `yaml:"maintainers,omitempty"` // maintain permission
+		Triagers                  []string            `yaml:"triagers,omitempty"`    // triage permission
+		ExternalUserReaders       []string            `yaml:"externalUserReaders,omitempty"`
+		ExternalUserWriters       []string            `yaml:"externalUserWriters,omitempty"`
+		ExternalUserAdmins        []string            `yaml:"externalUserAdmins,omitempty"`
+		ExternalUsersExpiresAt    map[string]string   `yaml:"externalUsersExpiresAt,omitempty"` // external user -> date (YYYY-MM-DD) its access expires
+		IsPublic                  bool                `yaml:"public,omitempty"`
+		AllowAutoMerge            bool                `yaml:"allow_auto_merge,omitempty"`
+		DeleteBranchOnMerge       bool                `yaml:"delete_branch_on_merge,omitempty"`
+		AllowUpdateBranch         bool                `yaml:"allow_update_branch,omitempty"`
+		HasIssues                 *bool               `yaml:"has_issues,omitempty"`                  // nil: left untouched
+		HasWiki                   *bool               `yaml:"has_wiki,omitempty"`                    // nil: left untouched
+		HasProjects               *bool               `yaml:"has_projects,omitempty"`                // nil: left untouched
+		AllowForking              *bool               `yaml:"allow_forking,omitempty"`               // nil: left untouched (only for private repositories)
+		AllowSquashMerge          *bool               `yaml:"allow_squash_merge,omitempty"`          // nil: left untouched (seeded by the default_merge_strategy of goliac.yaml)
+		AllowMergeCommit          *bool               `yaml:"allow_merge_commit,omitempty"`          // nil: left untouched
+		AllowRebaseMerge          *bool               `yaml:"allow_rebase_merge,omitempty"`          // nil: left untouched
+		MergeStrategyOverride     bool                `yaml:"merge_strategy_override,omitempty"`     // allow to deviate from the default_merge_strategy of goliac.yaml
+		SquashMergeCommitTitle    string              `yaml:"squash_merge_commit_title,omitempty"`   // PR_TITLE or COMMIT_OR_PR_TITLE (empty: left untouched)
+		SquashMergeCommitMessage  string              `yaml:"squash_merge_commit_message,omitempty"` // PR_BODY, COMMIT_MESSAGES or BLANK
+		MergeCommitTitle          string              `yaml:"merge_commit_title,omitempty"`          // PR_TITLE or MERGE_MESSAGE
+		MergeCommitMessage        string              `yaml:"merge_commit_message,omitempty"`        // PR_BODY, PR_TITLE or BLANK
+		Rulesets                  []RepositoryRuleSet `yaml:"rulesets,omitempty"`
+		CustomProperties          map[string]string   `yaml:"custom_properties,omitempty"`           // Enterprise only
+		AllowVisibilityReduction  bool                `yaml:"allow_visibility_reduction,omitempty"`  // allow to go from public to private (forks are detached)
+		ManageCodeowners          bool                `yaml:"manageCodeowners,omitempty"`            // Goliac commits a .github/CODEOWNERS file granting the review to the owner team
+		Labels                    []config.Label      `yaml:"labels,omitempty"`                      // override the labels (of the same name) of goliac.yaml
+		DependabotAlerts          *bool               `yaml:"dependabot_alerts,omitempty"`           // vulnerability alerts (not managed if not set)
+		DependabotSecurityUpdates *bool               `yaml:"dependabot_security_updates,omitempty"` // automated security fixes (not managed if not set)
+		ForkOf                    string              `yaml:"forkOf,omitempty"`                      // upstream repository (owner/repo) to fork when creating the repository
+		Projects                  []string            `yaml:"projects,omitempty"`                    // titles of the (v2) organization projects linked to the repository (not managed if not set)
+		AllowInheritedAccess      *bool               `yaml:"allowInheritedAccess,omitempty"`        // child teams inherit the access of their parent team (nil: true). Github can't prevent it: it is only reported
 	} `yaml:"spec,omitempty"`
-	Archived      bool    `yaml:"archived,omitempty"` // implicit: will be set by Goliac
-	Owner         *string `yaml:"-"`                  // implicit. team name owning the repo (if any)
-	RenameTo      string  `yaml:"renameTo,omitempty"`
-	DirectoryPath string  `yaml:"-"` // used to know where to rename the repository
+	Archived       bool       `yaml:"archived,omitempty"`       // implicit: will be set by Goliac
+	Immutable      bool       `yaml:"immutable,omitempty"`      // listed, but never changed by Goliac
+	ArchivedAt     *time.Time `yaml:"archivedAt,omitempty"`     // set by Goliac when archiving a deleted repository
+	DeletionReason string     `yaml:"deletionReason,omitempty"` // reported when the archived repository is deleted
+	Owner          *string    `yaml:"-"`                        // implicit. team name owning the repo (if any)
+	RenameTo       string     `yaml:"renameTo,omitempty"`
+	DirectoryPath  string     `yaml:"-"` // used to know where to rename the repository
 }
 
 type RepositoryRuleSet struct {
 	RuleSetDefinition `yaml:",inline"`
 	Name              string `yaml:"name"`
+	OwnerBypass       *bool  `yaml:"ownerBypass,omitempty"` // the owner team can bypass the ruleset (nil: true)
 }
 
 /*
@@ -39,13 +72,21 @@ type RepositoryRuleSet struct {
  * The next step is to validate the Repository object using the Validate method
  */
 func NewRepository(fs billy.Filesystem, filename string) (*Repository, error) {
+	return newRepositoryWithDefaults(fs, filename, nil)
+}
+
+/*
+ * newRepositoryWithDefaults reads a file and returns a Repository object,
+ * with the defaults merged into its spec
+ */
+func newRepositoryWithDefaults(fs billy.Filesystem, filename string, defaults map[string]interface{}) (*Repository, error) {
 	filecontent, err := utils.ReadFile(fs, filename)
 	if err != nil {
 		return nil, err
 	}
 
 	repository := &Repository{}
-	err = yaml.Unmarshal(filecontent, repository)
+	err = unmarshalWithDefaults(filecontent, defaults, repository)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +103,15 @@ func NewRepository(fs billy.Filesystem, filename string) (*Repository, error) {
  * - a slice of warning that must not stop the validation process
  */
 func ReadRepositories(fs billy.Filesystem, archivedDirname string, teamDirname string, teams map[string]*Team, externalUsers map[string]*User) (map[string]*Repository, []error, []Warning) {
+	return ReadRepositoriesWithDefaults(fs, archivedDirname, teamDirname, teams, externalUsers, nil)
+}
+
+/**
+ * ReadRepositoriesWithDefaults is the same as ReadRepositories, with org-wide
+ * defaults (the repository_defaults of goliac.yaml) merged below the ones of
+ * the teams/_defaults.yaml file
+ */
+func ReadRepositoriesWithDefaults(fs billy.Filesystem, archivedDirname string, teamDirname string, teams map[string]*Team, externalUsers map[string]*User, orgDefaults map[string]interface{}) (map[string]*Repository, []error, []Warning) {
 	errors := []error{}
 	warning := []Warning{}
 	repos := make(map[string]*Repository)
@@ -114,6 +164,15 @@ func ReadRepositories(fs billy.Filesystem, archivedDirname string, teamDirname s
 		return repos, errors, warning
 	}
 
+	// the defaults only apply to the repositories owned by a team
+	defaults, err := ReadDefaults(fs, teamDirname)
+	if err != nil {
+		errors = append(errors, err)
+		return repos, errors, warning
+	}
+
+	repositoryDefaults := mergeDefaults(orgDefaults, defaults.Repository)
+
 	// Parse all the repositories in the teamDirname directory
 	entries, err := fs.ReadDir(teamDirname)
 	if err != nil {
@@ -123,7 +182,7 @@ func ReadRepositories(fs billy.Filesystem, archivedDirname string, teamDirname s
 
 	for _, team := range entries {
 		if team.IsDir() {
-			suberrs, subwarns := recursiveReadRepositories(fs, archivedDirname, filepath.Join(teamDirname, team.Name()), team.Name(), repos, teams, externalUsers)
+			suberrs, subwarns := recursiveReadRepositories(fs, archivedDirname, filepath.Join(teamDirname, team.Name()), team.Name(), repositoryDefaults, repos, teams, externalUsers)
 			errors = append(errors, suberrs...)
 			warning = append(warning, subwarns...)
 		}
@@ -132,7 +191,7 @@ func ReadRepositories(fs billy.Filesystem, archivedDirname string, teamDirname s
 	return repos, errors, warning
 }
 
-func recursiveReadRepositories(fs billy.Filesystem, archivedDirPath string, teamDirPath string, teamName string, repos map[string]*Repository, teams map[string]*Team, externalUsers map[string]*User) ([]error, []Warning) {
+func recursiveReadRepositories(fs billy.Filesystem, archivedDirPath string, teamDirPath string, teamName string, defaults map[string]interface{}, repos map[string]*Repository, teams map[string]*Team, externalUsers map[string]*User) ([]error, []Warning) {
 	errors := []error{}
 	warnings := []Warning{}
 
@@ -143,12 +202,12 @@ func recursiveReadRepositories(fs billy.Filesystem, archivedDirPath string, team
 	}
 	for _, sube := range subentries {
 		if sube.IsDir() && sube.Name()[0] != '.' {
-			suberrs, subwarns := recursiveReadRepositories(fs, archivedDirPath, filepath.Join(teamDirPath, sube.Name()), sube.Name(), repos, teams, externalUsers)
+			suberrs, subwarns := recursiveReadRepositories(fs, archivedDirPath, filepath.Join(teamDirPath, sube.Name()), sube.Name(), defaults, repos, teams, externalUsers)
 			errors = append(errors, suberrs...)
 			warnings = append(warnings, subwarns...)
 		}
 		if !sube.IsDir() && filepath.Ext(sube.Name()) == ".yaml" && sube.Name() != "team.yaml" {
-			repo, err := NewRepository(fs, filepath.Join(teamDirPath, sube.Name()))
+			repo, err := newRepositoryWithDefaults(fs, filepath.Join(teamDirPath, sube.Name()), defaults)
 			if err != nil {
 				errors = append(errors, err)
 			} else {
@@ -204,6 +263,21 @@ func (r *Repository) Validate(filename string, teams map[string]*Team, externalU
 			return fmt.Errorf("invalid reader: %s doesn't exist (check repository filename %s)", reader, filename)
 		}
 	}
+	for _, admin := range r.Spec.Admins {
+		if _, ok := teams[admin]; !ok {
+			return fmt.Errorf("invalid admin: %s doesn't exist (check repository filename %s)", admin, filename)
+		}
+	}
+	for _, maintainer := range r.Spec.Maintainers {
+		if _, ok := teams[maintainer]; !ok {
+			return fmt.Errorf("invalid maintainer: %s doesn't exist (check repository filename %s)", maintainer, filename)
+		}
+	}
+	for _, triager := range r.Spec.Triagers {
+		if _, ok := teams[triager]; !ok {
+			return fmt.Errorf("invalid triager: %s doesn't exist (check repository filename %s)", triager, filename)
+		}
+	}
 
 	for _, externalUserReader := range r.Spec.ExternalUserReaders {
 		if _, ok := externalUsers[externalUserReader]; !ok {
@@ -217,23 +291,148 @@ func (r *Repository) Validate(filename string, teams map[string]*Team, externalU
 		}
 	}
 
+	for _, externalUserAdmin := range r.Spec.ExternalUserAdmins {
+		if _, ok := externalUsers[externalUserAdmin]; !ok {
+			return fmt.Errorf("invalid externalUserAdmin: %s doesn't exist in repository filename %s", externalUserAdmin, filename)
+		}
+	}
+
+	for externalUser, expiresAt := range r.Spec.ExternalUsersExpiresAt {
+		if !slices.Contains(r.Spec.ExternalUserReaders, externalUser) &&
+			!slices.Contains(r.Spec.ExternalUserWriters, externalUser) &&
+			!slices.Contains(r.Spec.ExternalUserAdmins, externalUser) {
+			return fmt.Errorf("invalid externalUsersExpiresAt: %s is not an external user of the repository (check repository filename %s)", externalUser, filename)
+		}
+		if _, err := time.Parse(ExpiresAtLayout, expiresAt); err != nil {
+			return fmt.Errorf("invalid externalUsersExpiresAt: %s for %s, it must be a date like 2024-12-31 (check repository filename %s)", expiresAt, externalUser, filename)
+		}
+	}
+
 	rulesetname := make(map[string]bool)
-	for _, ruleset := range r.Spec.Rulesets {
+	for i, ruleset := range r.Spec.Rulesets {
 		if ruleset.Name == "" {
 			return fmt.Errorf("invalid ruleset: each ruleset must have a name")
 		}
-		if ruleset.Enforcement != "disable" && ruleset.Enforcement != "active" && ruleset.Enforcement != "evaluate" {
-			return fmt.Errorf("invalid ruleset %s enforcement: it must be 'disable','active' or 'evaluate'", ruleset.Name)
+		enforcement, ok := normalizeEnforcement(ruleset.Enforcement)
+		if !ok {
+			return fmt.Errorf("invalid ruleset %s enforcement: it must be 'disabled','active' or 'evaluate'", ruleset.Name)
 		}
+		r.Spec.Rulesets[i].Enforcement = enforcement
 		if _, ok := rulesetname[ruleset.Name]; ok {
 			return fmt.Errorf("invalid ruleset: each ruleset must have a uniq name, found 2 times %s", ruleset.Name)
 		}
 		rulesetname[ruleset.Name] = true
 	}
 
+	// an immutable repository is never changed by Goliac (renamed included)
+	if r.Immutable && r.RenameTo != "" {
+		return fmt.Errorf("invalid renameTo: an immutable repository cannot be renamed (check repository filename %s)", filename)
+	}
+
+	// the security updates need the vulnerability alerts
+	if r.Spec.DependabotSecurityUpdates != nil && *r.Spec.DependabotSecurityUpdates &&
+		r.Spec.DependabotAlerts != nil && !*r.Spec.DependabotAlerts {
+		return fmt.Errorf("invalid dependabot settings: dependabot_security_updates cannot be enabled without dependabot_alerts (check repository filename %s)", filename)
+	}
+
+	if r.Spec.ForkOf != "" && !forkOfRegexp.MatchString(r.Spec.ForkOf) {
+		return fmt.Errorf("invalid forkOf: %s, it must be an upstream repository like owner/repo (check repository filename %s)", r.Spec.ForkOf, filename)
+	}
+
+	projects := make(map[string]bool)
+	for _, project := range r.Spec.Projects {
+		if project == "" {
+			return fmt.Errorf("invalid projects: a project title cannot be empty (check repository filename %s)", filename)
+		}
+		if projects[project] {
+			return fmt.Errorf("invalid projects: found 2 times the project %s (check repository filename %s)", project, filename)
+		}
+		projects[project] = true
+	}
+
+	labelnames := make(map[string]bool)
+	for _, label := range r.Spec.Labels {
+		if err := ValidateLabel(label); err != nil {
+			return fmt.Errorf("%v (check repository filename %s)", err, filename)
+		}
+		// label names are case insensitive
+		if labelnames[strings.ToLower(label.Name)] {
+			return fmt.Errorf("invalid label: each label must have a uniq name, found 2 times %s (check repository filename %s)", label.Name, filename)
+		}
+		labelnames[strings.ToLower(label.Name)] = true
+	}
+
+	// Github requires at least one merge strategy
+	if r.Spec.AllowSquashMerge != nil && !*r.Spec.AllowSquashMerge &&
+		r.Spec.AllowMergeCommit != nil && !*r.Spec.AllowMergeCommit &&
+		r.Spec.AllowRebaseMerge != nil && !*r.Spec.AllowRebaseMerge {
+		return fmt.Errorf("invalid merge strategies: at least one of allow_squash_merge, allow_merge_commit or allow_rebase_merge must be enabled (check repository filename %s)", filename)
+	}
+
+	mergeCommitValues := []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"squash_merge_commit_title", r.Spec.SquashMergeCommitTitle, []string{"PR_TITLE", "COMMIT_OR_PR_TITLE"}},
+		{"squash_merge_commit_message", r.Spec.SquashMergeCommitMessage, []string{"PR_BODY", "COMMIT_MESSAGES", "BLANK"}},
+		{"merge_commit_title", r.Spec.MergeCommitTitle, []string{"PR_TITLE", "MERGE_MESSAGE"}},
+		{"merge_commit_message", r.Spec.MergeCommitMessage, []string{"PR_BODY", "PR_TITLE", "BLANK"}},
+	}
+	for _, m := range mergeCommitValues {
+		if m.value != "" && !slices.Contains(m.allowed, m.value) {
+			return fmt.Errorf("invalid %s: %s, it must be one of %s (check repository filename %s)", m.name, m.value, strings.Join(m.allowed, ","), filename)
+		}
+	}
+
 	if utils.GithubAnsiString(r.Name) != r.Name {
 		return fmt.Errorf("invalid name: %s will be changed to %s (check repository filename %s)", r.Name, utils.GithubAnsiString(r.Name), filename)
 	}
 
 	return nil
 }
+
+// ExpiresAtLayout is the (date) layout of the external users access expirations
+const ExpiresAtLayout = "2006-01-02"
+
+/*
+ * ExternalUserExpiresAt returns when the access of the external user to the
+ * repository expires (at the beginning of the day, UTC), nil if it never expires
+ */
+func (r *Repository) ExternalUserExpiresAt(externalUser string) *time.Time {
+	expiresAt, ok := r.Spec.ExternalUsersExpiresAt[externalUser]
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(ExpiresAtLayout, expiresAt)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+/*
+ * IsExternalUserExpired returns true if the access of the external user to
+ * the repository is expired
+ */
+func (r *Repository) IsExternalUserExpired(externalUser string, now time.Time) bool {
+	expiresAt := r.ExternalUserExpiresAt(externalUser)
+	return expiresAt != nil && !now.Before(*expiresAt)
+}
+
+var forkOfRegexp = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+var labelColorRegexp = regexp.MustCompile("^#?[0-9a-fA-F]{6}$")
+
+/*
+ * ValidateLabel checks a label definition (of a repository or of goliac.yaml)
+ */
+func ValidateLabel(label config.Label) error {
+	if label.Name == "" {
+		return fmt.Errorf("invalid label: each label must have a name")
+	}
+	if !labelColorRegexp.MatchString(label.Color) {
+		return fmt.Errorf("invalid label %s color: %s, it must be an hexadecimal color like d73a4a", label.Name, label.Color)
+	}
+	return nil
+}
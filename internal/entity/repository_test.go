@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Alayacare/goliac/internal/utils"
 	"github.com/go-git/go-billy/v5"
@@ -214,4 +215,430 @@ name: repo1
 		assert.NotNil(t, repos)
 		assert.Equal(t, len(repos), 1)
 	})
+
+	t.Run("not happy path: wrong merge commit message", func(t *testing.T) {
+		// create a new user
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  squash_merge_commit_title: PR_TITLE
+  squash_merge_commit_message: PR_TITLE
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+		assert.NotNil(t, users)
+
+		teams, errs, warns := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+		assert.NotNil(t, teams)
+
+		_, errs, warns = ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, len(errs), 1)
+		assert.Equal(t, len(warns), 0)
+	})
+
+	t.Run("happy path: repository labels", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  labels:
+  - name: bug
+    color: "#D73A4A"
+    description: Something isn't working
+  - name: feature
+    color: a2eeef
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+
+		teams, errs, warns := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+
+		repos, errs, warns := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+		assert.Equal(t, 2, len(repos["repo1"].Spec.Labels))
+		assert.Equal(t, "#D73A4A", repos["repo1"].Spec.Labels[0].Color)
+	})
+
+	t.Run("not happy path: immutable repository renamed", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+immutable: true
+renameTo: repo2
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+		_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("not happy path: dependabot security updates without alerts", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  dependabot_alerts: false
+  dependabot_security_updates: true
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+		_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("not happy path: invalid forkOf upstream", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  forkOf: upstream-without-owner
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+		_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("not happy path: duplicated project", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  projects:
+    - Roadmap
+    - Roadmap
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+		_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("happy path: external user access expiration", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  externalUserReaders:
+    - partner1
+    - partner2
+  externalUsersExpiresAt:
+    partner1: "2024-12-31"
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+		externalUsers := map[string]*User{"partner1": {}, "partner2": {}}
+
+		repos, errs, _ := ReadRepositories(fs, "archived", "teams", teams, externalUsers)
+		assert.Equal(t, 0, len(errs))
+		repo := repos["repo1"]
+		assert.NotNil(t, repo)
+		assert.Equal(t, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), *repo.ExternalUserExpiresAt("partner1"))
+		assert.Nil(t, repo.ExternalUserExpiresAt("partner2"))
+		assert.False(t, repo.IsExternalUserExpired("partner1", time.Date(2024, 12, 30, 23, 59, 0, 0, time.UTC)))
+		assert.True(t, repo.IsExternalUserExpired("partner1", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)))
+		assert.False(t, repo.IsExternalUserExpired("partner2", time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("not happy path: invalid external user access expiration", func(t *testing.T) {
+		for _, expiresAt := range []string{
+			`partner2: "2024-12-31"`, // not an external user of the repository
+			`partner1: "31/12/2024"`,
+		} {
+			fs := memfs.New()
+			fixtureCreateUserTeam(t, fs)
+
+			err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  externalUserWriters:
+    - partner1
+  externalUsersExpiresAt:
+    `+expiresAt+`
+`), 0644)
+			assert.Nil(t, err)
+			users, _, _ := ReadUserDirectory(fs, "users")
+			teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+			externalUsers := map[string]*User{"partner1": {}, "partner2": {}}
+
+			_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, externalUsers)
+			assert.Equal(t, 1, len(errs), expiresAt)
+		}
+	})
+
+	t.Run("not happy path: all merge strategies disabled", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  allow_squash_merge: false
+  allow_merge_commit: false
+  allow_rebase_merge: false
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+		_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
+
+	t.Run("not happy path: invalid repository labels", func(t *testing.T) {
+		for _, labels := range []string{
+			"  - name: bug\n    color: red\n",
+			"  - name: bug\n    color: d73a4a\n  - name: Bug\n    color: d73a4a\n",
+			"  - color: d73a4a\n",
+		} {
+			fs := memfs.New()
+			fixtureCreateUserTeam(t, fs)
+
+			err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  labels:
+`+labels), 0644)
+			assert.Nil(t, err)
+			users, _, _ := ReadUserDirectory(fs, "users")
+			teams, _, _ := ReadTeamDirectory(fs, "teams", users)
+
+			_, errs, _ := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+			assert.Equal(t, 1, len(errs), labels)
+		}
+	})
+
+	t.Run("happy path: disabled repository ruleset", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  rulesets:
+  - name: myruleset
+    enforcement: disabled
+    conditions:
+      include:
+      - "~DEFAULT_BRANCH"
+    rules:
+    - ruletype: required_signatures
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, _ := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+
+		repos, errs, warns := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, "disabled", repos["repo1"].Spec.Rulesets[0].Enforcement)
+	})
+
+	t.Run("happy path: the disable spelling of a repository ruleset is still accepted", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  rulesets:
+  - name: myruleset
+    enforcement: disable
+    conditions:
+      include:
+      - "~DEFAULT_BRANCH"
+    rules:
+    - ruletype: required_signatures
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, _ := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+
+		repos, errs, warns := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, "disabled", repos["repo1"].Spec.Rulesets[0].Enforcement)
+	})
+
+	t.Run("happy path: defaults merged into the repositories", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/_defaults.yaml", []byte(`
+merge: &merge
+  squash_merge_commit_title: PR_TITLE
+  squash_merge_commit_message: PR_BODY
+repository:
+  <<: *merge
+  delete_branch_on_merge: true
+  readers:
+  - team1
+`), 0644)
+		assert.Nil(t, err)
+		err = utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+`), 0644)
+		assert.Nil(t, err)
+		err = utils.WriteFile(fs, "teams/team1/repo2.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo2
+spec:
+  squash_merge_commit_message: BLANK
+  readers: []
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+
+		teams, errs, warns := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+
+		repos, errs, warns := ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, 2, len(repos))
+
+		assert.True(t, repos["repo1"].Spec.DeleteBranchOnMerge)
+		assert.Equal(t, []string{"team1"}, repos["repo1"].Spec.Readers)
+		assert.Equal(t, "PR_TITLE", repos["repo1"].Spec.SquashMergeCommitTitle)
+		assert.Equal(t, "PR_BODY", repos["repo1"].Spec.SquashMergeCommitMessage)
+
+		// the definition wins over the defaults
+		assert.True(t, repos["repo2"].Spec.DeleteBranchOnMerge)
+		assert.Equal(t, 0, len(repos["repo2"].Spec.Readers))
+		assert.Equal(t, "BLANK", repos["repo2"].Spec.SquashMergeCommitMessage)
+		assert.Equal(t, "team1", *repos["repo2"].Owner)
+	})
+
+	t.Run("happy path: org-wide defaults merged into the repositories", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/_defaults.yaml", []byte(`
+repository:
+  squash_merge_commit_title: PR_TITLE
+`), 0644)
+		assert.Nil(t, err)
+		err = utils.WriteFile(fs, "teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+`), 0644)
+		assert.Nil(t, err)
+		err = utils.WriteFile(fs, "teams/team1/repo2.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo2
+spec:
+  delete_branch_on_merge: false
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, 0, len(errs))
+
+		orgDefaults := map[string]interface{}{
+			"delete_branch_on_merge":    true,
+			"squash_merge_commit_title": "COMMIT_OR_PR_TITLE",
+			"rulesets": []interface{}{
+				map[string]interface{}{"name": "default", "enforcement": "active"},
+			},
+		}
+		repos, errs, warns := ReadRepositoriesWithDefaults(fs, "archived", "teams", teams, map[string]*User{}, orgDefaults)
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 0, len(warns))
+		assert.Equal(t, 2, len(repos))
+
+		assert.True(t, repos["repo1"].Spec.DeleteBranchOnMerge)
+		assert.Equal(t, 1, len(repos["repo1"].Spec.Rulesets))
+		assert.Equal(t, "default", repos["repo1"].Spec.Rulesets[0].Name)
+		// teams/_defaults.yaml wins over the org-wide defaults
+		assert.Equal(t, "PR_TITLE", repos["repo1"].Spec.SquashMergeCommitTitle)
+		// the definition wins over the defaults
+		assert.False(t, repos["repo2"].Spec.DeleteBranchOnMerge)
+		assert.Equal(t, "PR_TITLE", repos["repo2"].Spec.SquashMergeCommitTitle)
+	})
+
+	t.Run("not happy path: invalid defaults", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/_defaults.yaml", []byte(`
+repository:
+  - readers
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, 1, len(errs))
+
+		_, errs, _ = ReadRepositories(fs, "archived", "teams", teams, map[string]*User{})
+		assert.Equal(t, 1, len(errs))
+	})
 }
@@ -0,0 +1,264 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+ * The schema of the teams repository files is derived from the Go structures
+ * (through their yaml tags), so it can't drift from what Goliac actually reads:
+ * - JSONSchema returns the JSON schema of a kind (for editors and CI)
+ * - ValidateSchema checks a file against it, reporting the unknown fields
+ *   and the wrong types with their line
+ */
+
+var schemaKinds = map[string]reflect.Type{
+	"User":       reflect.TypeOf(User{}),
+	"Team":       reflect.TypeOf(Team{}),
+	"Repository": reflect.TypeOf(Repository{}),
+	"Ruleset":    reflect.TypeOf(RuleSet{}),
+}
+
+// the teams/_defaults.yaml file: the specs of a repository and of a team
+var defaultsSchema = reflect.StructOf([]reflect.StructField{
+	{Name: "Repository", Type: specType(Repository{}), Tag: `yaml:"repository,omitempty"`},
+	{Name: "Team", Type: specType(Team{}), Tag: `yaml:"team,omitempty"`},
+})
+
+func specType(entity interface{}) reflect.Type {
+	field, _ := reflect.TypeOf(entity).FieldByName("Spec")
+	return field.Type
+}
+
+/*
+ * SchemaError is a schema violation (unknown field, wrong type) found in a file
+ */
+type SchemaError struct {
+	Filename string
+	Line     int
+	Message  string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.Filename, e.Line, e.Message)
+}
+
+/*
+ * SchemaKinds returns the (sorted) kinds having a schema
+ */
+func SchemaKinds() []string {
+	kinds := make([]string, 0, len(schemaKinds))
+	for kind := range schemaKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+/*
+ * JSONSchema returns the (indented) JSON schema (draft-07) of a kind
+ * (User, Team, Repository, Ruleset)
+ */
+func JSONSchema(kind string) ([]byte, error) {
+	t, ok := schemaKinds[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown kind %s (expected one of %s)", kind, strings.Join(SchemaKinds(), ", "))
+	}
+	schema := jsonSchemaType(t)
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "Goliac " + kind
+	schema["required"] = []string{"apiVersion", "kind", "name"}
+	schema["properties"].(map[string]interface{})["kind"] = map[string]interface{}{"const": kind}
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+type schemaField struct {
+	name string
+	typ  reflect.Type
+}
+
+/*
+ * schemaFields returns the fields of a structure, as read by yaml.v3
+ * (inlined structures are flattened, untagged fields are lowercased)
+ */
+func schemaFields(t reflect.Type) []schemaField {
+	fields := []schemaField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if strings.Contains(options, "inline") {
+			fields = append(fields, schemaFields(f.Type)...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, schemaField{name: name, typ: f.Type})
+	}
+	return fields
+}
+
+func jsonSchemaType(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": jsonSchemaType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchemaType(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for _, f := range schemaFields(t) {
+			properties[f.name] = jsonSchemaType(f.typ)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "additionalProperties": false}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+/*
+ * ValidateSchema checks the content of a file against the schema of a kind
+ * (User, Team, Repository, Ruleset, or Defaults for a _defaults.yaml file)
+ * and returns the schema violations: unknown fields and wrong types
+ */
+func ValidateSchema(filename string, filecontent []byte, kind string) []error {
+	t, ok := schemaKinds[kind]
+	if kind == "Defaults" {
+		t, ok = defaultsSchema, true
+	}
+	if !ok {
+		return []error{fmt.Errorf("%s: unknown kind %s", filename, kind)}
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(filecontent, &document); err != nil {
+		return []error{fmt.Errorf("not able to parse %s: %v", filename, err)}
+	}
+	if len(document.Content) == 0 {
+		return []error{}
+	}
+
+	errors := []error{}
+	validateSchemaNode(filename, document.Content[0], t, "", &errors)
+	return errors
+}
+
+func validateSchemaNode(filename string, node *yaml.Node, t reflect.Type, path string, errors *[]error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	expect := func(expected string, ok bool) bool {
+		if !ok {
+			field := path
+			if field == "" {
+				field = "document"
+			}
+			*errors = append(*errors, &SchemaError{
+				Filename: filename,
+				Line:     node.Line,
+				Message:  fmt.Sprintf("%s: expected %s, got %s", field, expected, schemaNodeDescription(node)),
+			})
+		}
+		return ok
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		expect("a date", node.Kind == yaml.ScalarNode)
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		expect("a string", node.Kind == yaml.ScalarNode)
+	case reflect.Bool:
+		expect("a boolean", node.Kind == yaml.ScalarNode && node.Tag == "!!bool")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		expect("an integer", node.Kind == yaml.ScalarNode && node.Tag == "!!int")
+	case reflect.Slice:
+		if expect("a list", node.Kind == yaml.SequenceNode) {
+			for i, item := range node.Content {
+				validateSchemaNode(filename, item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errors)
+			}
+		}
+	case reflect.Map:
+		if expect("an object", node.Kind == yaml.MappingNode) {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				validateSchemaNode(filename, node.Content[i+1], t.Elem(), schemaPath(path, node.Content[i].Value), errors)
+			}
+		}
+	case reflect.Struct:
+		if expect("an object", node.Kind == yaml.MappingNode) {
+			fields := make(map[string]reflect.Type)
+			for _, f := range schemaFields(t) {
+				fields[f.name] = f.typ
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i]
+				ft, ok := fields[key.Value]
+				if !ok {
+					*errors = append(*errors, &SchemaError{
+						Filename: filename,
+						Line:     key.Line,
+						Message:  fmt.Sprintf("unknown field %s", schemaPath(path, key.Value)),
+					})
+					continue
+				}
+				validateSchemaNode(filename, node.Content[i+1], ft, schemaPath(path, key.Value), errors)
+			}
+		}
+	}
+}
+
+func schemaPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func schemaNodeDescription(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchema(t *testing.T) {
+
+	t.Run("happy path: valid repository", func(t *testing.T) {
+		errs := ValidateSchema("teams/team1/repo1.yaml", []byte(`
+apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  readers:
+  - team2
+  public: true
+  has_wiki: false
+  rulesets:
+  - name: default
+    enforcement: active
+    rules:
+    - ruletype: pull_request
+      parameters:
+        requiredApprovingReviewCount: 1
+  custom_properties:
+    cost_center: "123"
+`), "Repository")
+		assert.Equal(t, 0, len(errs))
+	})
+
+	t.Run("not happy path: unknown field", func(t *testing.T) {
+		errs := ValidateSchema("teams/team1/repo1.yaml", []byte(`apiVersion: v1
+kind: Repository
+name: repo1
+spec:
+  readrs:
+  - team2
+`), "Repository")
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/team1/repo1.yaml:5: unknown field spec.readrs", errs[0].Error())
+	})
+
+	t.Run("not happy path: wrong types", func(t *testing.T) {
+		errs := ValidateSchema("teams/team1/team.yaml", []byte(`apiVersion: v1
+kind: Team
+name: team1
+spec:
+  owners: user1
+  reviewAssignment:
+    algorithm: round_robin
+    teamMembersCount: two
+`), "Team")
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, `teams/team1/team.yaml:5: spec.owners: expected a list, got "user1"`, errs[0].Error())
+		assert.Equal(t, `teams/team1/team.yaml:8: spec.reviewAssignment.teamMembersCount: expected an integer, got "two"`, errs[1].Error())
+	})
+
+	t.Run("not happy path: unknown field in the defaults", func(t *testing.T) {
+		errs := ValidateSchema("teams/_defaults.yaml", []byte(`repository:
+  delete_branch_on_merge: true
+team:
+  owner:
+  - user1
+`), "Defaults")
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "teams/_defaults.yaml:4: unknown field team.owner", errs[0].Error())
+	})
+}
+
+func TestJSONSchema(t *testing.T) {
+
+	t.Run("happy path: repository schema", func(t *testing.T) {
+		content, err := JSONSchema("Repository")
+		assert.Nil(t, err)
+
+		schema := make(map[string]interface{})
+		assert.Nil(t, json.Unmarshal(content, &schema))
+		assert.Equal(t, map[string]interface{}{"const": "Repository"}, schema["properties"].(map[string]interface{})["kind"])
+		spec := schema["properties"].(map[string]interface{})["spec"].(map[string]interface{})
+		assert.Equal(t, false, spec["additionalProperties"])
+		assert.Contains(t, spec["properties"], "externalUserReaders")
+	})
+
+	t.Run("not happy path: unknown kind", func(t *testing.T) {
+		_, err := JSONSchema("Foobar")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("happy path: the shipped schemas are up to date", func(t *testing.T) {
+		for _, kind := range SchemaKinds() {
+			content, err := JSONSchema(kind)
+			assert.Nil(t, err)
+			shipped, err := os.ReadFile(filepath.Join("..", "..", "docs", "schemas", strings.ToLower(kind)+".schema.json"))
+			assert.Nil(t, err)
+			assert.Equal(t, string(content), string(shipped), "run 'goliac schema docs/schemas' to regenerate the %s schema", kind)
+		}
+	})
+}
@@ -14,25 +14,45 @@ import (
 type Team struct {
 	Entity `yaml:",inline"`
 	Spec   struct {
-		ExternallyManaged bool     `yaml:"externallyManaged,omitempty"`
-		Owners            []string `yaml:"owners,omitempty"`
-		Members           []string `yaml:"members,omitempty"`
+		ExternallyManaged   bool                  `yaml:"externallyManaged,omitempty"`
+		Owners              []string              `yaml:"owners,omitempty"`
+		Members             []string              `yaml:"members,omitempty"`
+		ReviewAssignment    *TeamReviewAssignment `yaml:"reviewAssignment,omitempty"`
+		NotificationSetting string                `yaml:"notificationSetting,omitempty"` // notifications_enabled or notifications_disabled (empty: left untouched)
 	} `yaml:"spec"`
 	ParentTeam *string `yaml:"-"`
 }
 
+/*
+ * TeamReviewAssignment is the code review assignment of a team: when the team
+ * is requested for a review, Github picks some of its members as reviewers
+ */
+type TeamReviewAssignment struct {
+	Algorithm        string `yaml:"algorithm"`            // round_robin, load_balance
+	TeamMembersCount int    `yaml:"teamMembersCount"`     // number of members to request
+	NotifyTeam       bool   `yaml:"notifyTeam,omitempty"` // notify the whole team as well
+}
+
 /*
  * NewTeam reads a file and returns a Team object
  * The next step is to validate the Team object using the Validate method
  */
 func NewTeam(fs billy.Filesystem, filename string, parent *string) (*Team, error) {
+	return newTeamWithDefaults(fs, filename, parent, nil)
+}
+
+/*
+ * newTeamWithDefaults reads a file and returns a Team object, with the
+ * defaults merged into its spec
+ */
+func newTeamWithDefaults(fs billy.Filesystem, filename string, parent *string, defaults map[string]interface{}) (*Team, error) {
 	filecontent, err := utils.ReadFile(fs, filename)
 	if err != nil {
 		return nil, err
 	}
 
 	team := &Team{}
-	err = yaml.Unmarshal(filecontent, team)
+	err = unmarshalWithDefaults(filecontent, defaults, team)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +83,12 @@ func ReadTeamDirectory(fs billy.Filesystem, dirname string, users map[string]*Us
 	if !exist {
 		return teams, errors, warning
 	}
+	defaults, err := ReadDefaults(fs, dirname)
+	if err != nil {
+		errors = append(errors, err)
+		return teams, errors, warning
+	}
+
 	// Parse all the teams in the dirname directory
 	entries, err := fs.ReadDir(dirname)
 	if err != nil {
@@ -79,14 +105,14 @@ func ReadTeamDirectory(fs billy.Filesystem, dirname string, users map[string]*Us
 			continue
 		}
 
-		recursiveReadTeamDirectory(fs, filepath.Join(dirname, e.Name()), nil, users, teams, &errors, &warning)
+		recursiveReadTeamDirectory(fs, filepath.Join(dirname, e.Name()), nil, defaults.Team, users, teams, &errors, &warning)
 	}
 	return teams, errors, warning
 }
 
-func recursiveReadTeamDirectory(fs billy.Filesystem, dirname string, parentTeam *string, users map[string]*User, teams map[string]*Team, errors *[]error, warning *[]Warning) {
+func recursiveReadTeamDirectory(fs billy.Filesystem, dirname string, parentTeam *string, defaults map[string]interface{}, users map[string]*User, teams map[string]*Team, errors *[]error, warning *[]Warning) {
 
-	team, err := NewTeam(fs, filepath.Join(dirname, "team.yaml"), parentTeam)
+	team, err := newTeamWithDefaults(fs, filepath.Join(dirname, "team.yaml"), parentTeam, defaults)
 	if err != nil {
 		*errors = append(*errors, err)
 		return
@@ -123,7 +149,7 @@ func recursiveReadTeamDirectory(fs billy.Filesystem, dirname string, parentTeam
 			continue
 		}
 
-		recursiveReadTeamDirectory(fs, filepath.Join(dirname, e.Name()), &parent, users, teams, errors, warning)
+		recursiveReadTeamDirectory(fs, filepath.Join(dirname, e.Name()), &parent, defaults, users, teams, errors, warning)
 	}
 }
 
@@ -164,6 +190,19 @@ func (t *Team) Validate(dirname string, users map[string]*User) (error, []Warnin
 		}
 	}
 
+	if t.Spec.ReviewAssignment != nil {
+		if t.Spec.ReviewAssignment.Algorithm != "round_robin" && t.Spec.ReviewAssignment.Algorithm != "load_balance" {
+			return fmt.Errorf("invalid reviewAssignment.algorithm: %s (round_robin or load_balance) for team filename %s/team.yaml", t.Spec.ReviewAssignment.Algorithm, dirname), warnings
+		}
+		if t.Spec.ReviewAssignment.TeamMembersCount < 1 {
+			return fmt.Errorf("invalid reviewAssignment.teamMembersCount: %d (must be at least 1) for team filename %s/team.yaml", t.Spec.ReviewAssignment.TeamMembersCount, dirname), warnings
+		}
+	}
+
+	if t.Spec.NotificationSetting != "" && t.Spec.NotificationSetting != "notifications_enabled" && t.Spec.NotificationSetting != "notifications_disabled" {
+		return fmt.Errorf("invalid notificationSetting: %s (notifications_enabled or notifications_disabled) for team filename %s/team.yaml", t.Spec.NotificationSetting, dirname), warnings
+	}
+
 	for _, owner := range t.Spec.Owners {
 		if _, ok := users[owner]; !ok {
 			return fmt.Errorf("invalid owner: %s doesn't exist in team filename %s/team.yaml", owner, dirname), warnings
@@ -176,11 +215,8 @@ func (t *Team) Validate(dirname string, users map[string]*User) (error, []Warnin
 		}
 	}
 
-	// warnings
-
-	if len(t.Spec.Owners) < 2 && !t.Spec.ExternallyManaged {
-		warnings = append(warnings, fmt.Errorf("not enough owners for team filename %s/team.yaml", dirname))
-	}
+	// (the minimum number of owners is checked at the organization level,
+	// see engine.ValidateTeamsOwners)
 
 	return nil, warnings
 }
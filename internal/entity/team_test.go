@@ -83,12 +83,85 @@ spec:
 		assert.Equal(t, len(warns), 0)
 		assert.NotNil(t, users)
 
+		// the minimum number of owners is checked at the organization level
 		teams, errs, warns := ReadTeamDirectory(fs, "teams", users)
 		assert.Equal(t, len(errs), 0)
-		assert.Equal(t, len(warns), 1)
+		assert.Equal(t, len(warns), 0)
 		assert.NotNil(t, teams)
 	})
 
+	t.Run("happy path: review assignment", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUser(t, fs)
+		fs.MkdirAll("teams/team1", 0755)
+
+		err := utils.WriteFile(fs, "teams/team1/team.yaml", []byte(`
+apiVersion: v1
+kind: Team
+name: team1
+spec:
+  owners:
+  - user1
+  - user2
+  reviewAssignment:
+    algorithm: load_balance
+    teamMembersCount: 2
+    notifyTeam: true
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, &TeamReviewAssignment{Algorithm: "load_balance", TeamMembersCount: 2, NotifyTeam: true}, teams["team1"].Spec.ReviewAssignment)
+	})
+
+	t.Run("not happy path: invalid review assignment", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUser(t, fs)
+		fs.MkdirAll("teams/team1", 0755)
+
+		err := utils.WriteFile(fs, "teams/team1/team.yaml", []byte(`
+apiVersion: v1
+kind: Team
+name: team1
+spec:
+  owners:
+  - user1
+  - user2
+  reviewAssignment:
+    algorithm: random
+    teamMembersCount: 1
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+
+		_, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 1)
+	})
+
+	t.Run("not happy path: invalid notification setting", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUser(t, fs)
+		fs.MkdirAll("teams/team1", 0755)
+
+		err := utils.WriteFile(fs, "teams/team1/team.yaml", []byte(`
+apiVersion: v1
+kind: Team
+name: team1
+spec:
+  owners:
+  - user1
+  - user2
+  notificationSetting: email
+`), 0644)
+		assert.Nil(t, err)
+		users, _, _ := ReadUserDirectory(fs, "users")
+
+		_, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, len(errs), 1)
+	})
+
 	t.Run("not happy path: not team directory", func(t *testing.T) {
 		// create a new user
 		fs := memfs.New()
@@ -323,6 +396,72 @@ func TestAdjustTeam(t *testing.T) {
 	})
 }
 
+func TestTeamDefaults(t *testing.T) {
+	t.Run("happy path: defaults merged into the teams", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/_defaults.yaml", []byte(`
+team:
+  reviewAssignment:
+    algorithm: round_robin
+    teamMembersCount: 1
+`), 0644)
+		assert.Nil(t, err)
+		fs.MkdirAll("teams/team1/team2", 0755)
+		err = utils.WriteFile(fs, "teams/team1/team2/team.yaml", []byte(`
+apiVersion: v1
+kind: Team
+name: team2
+spec:
+  owners:
+  - user1
+  - user2
+  reviewAssignment:
+    teamMembersCount: 2
+`), 0644)
+		assert.Nil(t, err)
+
+		users, errs, _ := ReadUserDirectory(fs, "users")
+		assert.Equal(t, 0, len(errs))
+		teams, errs, _ := ReadTeamDirectory(fs, "teams", users)
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, 2, len(teams))
+
+		assert.NotNil(t, teams["team1"].Spec.ReviewAssignment)
+		assert.Equal(t, "round_robin", teams["team1"].Spec.ReviewAssignment.Algorithm)
+		assert.Equal(t, 1, teams["team1"].Spec.ReviewAssignment.TeamMembersCount)
+		assert.Equal(t, 2, len(teams["team1"].Spec.Owners))
+
+		// maps are merged
+		assert.Equal(t, "round_robin", teams["team2"].Spec.ReviewAssignment.Algorithm)
+		assert.Equal(t, 2, teams["team2"].Spec.ReviewAssignment.TeamMembersCount)
+		assert.Equal(t, "team1", *teams["team2"].ParentTeam)
+	})
+
+	t.Run("happy path: the defaults are not written back", func(t *testing.T) {
+		fs := memfs.New()
+		fixtureCreateUserTeam(t, fs)
+
+		err := utils.WriteFile(fs, "teams/_defaults.yaml", []byte(`
+team:
+  reviewAssignment:
+    algorithm: round_robin
+    teamMembersCount: 1
+`), 0644)
+		assert.Nil(t, err)
+
+		users, errs, _ := ReadUserDirectory(fs, "users")
+		assert.Equal(t, 0, len(errs))
+		_, err = ReadAndAdjustTeamDirectory(fs, "teams", users)
+		assert.Nil(t, err)
+
+		team, err := NewTeam(fs, "teams/team1/team.yaml", nil)
+		assert.Nil(t, err)
+		assert.Nil(t, team.Spec.ReviewAssignment)
+	})
+}
+
 func TestReadAndAdjustTeam(t *testing.T) {
 	t.Run("happy path: no team, no problem", func(t *testing.T) {
 		fs := memfs.New()
@@ -3,6 +3,7 @@ package entity
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/Alayacare/goliac/internal/utils"
@@ -14,6 +15,11 @@ type User struct {
 	Entity `yaml:",inline"`
 	Spec   struct {
 		GithubID string `yaml:"githubID"`
+		// organization role: member (default) or admin (organization owner)
+		OrgRole string `yaml:"orgRole,omitempty"`
+		// external users only: read or write access on all repositories matching RepoPattern
+		DefaultAccess string `yaml:"defaultAccess,omitempty"`
+		RepoPattern   string `yaml:"repoPattern,omitempty"`
 	} `yaml:"spec"`
 }
 
@@ -113,6 +119,22 @@ func (u *User) Validate(filename string) error {
 		return fmt.Errorf("spec.githubID is empty for user filename %s", filename)
 	}
 
+	if u.Spec.OrgRole != "" && u.Spec.OrgRole != "member" && u.Spec.OrgRole != "admin" {
+		return fmt.Errorf("invalid spec.orgRole: %s (expected member or admin) for user filename %s", u.Spec.OrgRole, filename)
+	}
+
+	if u.Spec.DefaultAccess != "" || u.Spec.RepoPattern != "" {
+		if u.Spec.DefaultAccess != "read" && u.Spec.DefaultAccess != "write" {
+			return fmt.Errorf("invalid spec.defaultAccess: %s (expected read or write) for user filename %s", u.Spec.DefaultAccess, filename)
+		}
+		if u.Spec.RepoPattern == "" {
+			return fmt.Errorf("spec.repoPattern is empty (but spec.defaultAccess is set) for user filename %s", filename)
+		}
+		if _, err := regexp.Compile(u.Spec.RepoPattern); err != nil {
+			return fmt.Errorf("invalid spec.repoPattern %s: %v for user filename %s", u.Spec.RepoPattern, err, filename)
+		}
+	}
+
 	return nil
 }
 
@@ -129,6 +151,25 @@ func (u *User) Equals(a *User) bool {
 	if u.Spec.GithubID != a.Spec.GithubID {
 		return false
 	}
+	if u.Spec.DefaultAccess != a.Spec.DefaultAccess {
+		return false
+	}
+	if u.Spec.RepoPattern != a.Spec.RepoPattern {
+		return false
+	}
+	if u.Spec.OrgRole != a.Spec.OrgRole {
+		return false
+	}
 
 	return true
 }
+
+/*
+GetOrgRole returns the organization role of the user ("member" by default)
+*/
+func (u *User) GetOrgRole() string {
+	if u.Spec.OrgRole == "" {
+		return "member"
+	}
+	return u.Spec.OrgRole
+}
@@ -72,6 +72,87 @@ apiVersion: v1
 kind: User
 spec:
   githubID: github1
+`), 0644)
+		assert.Nil(t, err)
+		_, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 1)
+		assert.Equal(t, len(warns), 0)
+	})
+
+	t.Run("happy path: external user with a default access", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("users", 0755)
+		err := utils.WriteFile(fs, "users/partner.yaml", []byte(`
+apiVersion: v1
+kind: User
+name: partner
+spec:
+  githubID: partner_github
+  defaultAccess: read
+  repoPattern: ^partner-.*
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+		assert.Equal(t, "read", users["partner"].Spec.DefaultAccess)
+		assert.Equal(t, "^partner-.*", users["partner"].Spec.RepoPattern)
+	})
+
+	t.Run("not happy path: default access without repository pattern", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("users", 0755)
+		err := utils.WriteFile(fs, "users/partner.yaml", []byte(`
+apiVersion: v1
+kind: User
+name: partner
+spec:
+  githubID: partner_github
+  defaultAccess: admin
+`), 0644)
+		assert.Nil(t, err)
+		_, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 1)
+		assert.Equal(t, len(warns), 0)
+	})
+
+	t.Run("happy path: organization admin", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("users", 0755)
+		err := utils.WriteFile(fs, "users/admin.yaml", []byte(`
+apiVersion: v1
+kind: User
+name: admin
+spec:
+  githubID: admin_github
+  orgRole: admin
+`), 0644)
+		assert.Nil(t, err)
+		err = utils.WriteFile(fs, "users/member.yaml", []byte(`
+apiVersion: v1
+kind: User
+name: member
+spec:
+  githubID: member_github
+`), 0644)
+		assert.Nil(t, err)
+		users, errs, warns := ReadUserDirectory(fs, "users")
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+		assert.Equal(t, "admin", users["admin"].GetOrgRole())
+		assert.Equal(t, "member", users["member"].GetOrgRole())
+	})
+
+	t.Run("not happy path: invalid organization role", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("users", 0755)
+		err := utils.WriteFile(fs, "users/admin.yaml", []byte(`
+apiVersion: v1
+kind: User
+name: admin
+spec:
+  githubID: admin_github
+  orgRole: owner
 `), 0644)
 		assert.Nil(t, err)
 		_, errs, warns := ReadUserDirectory(fs, "users")
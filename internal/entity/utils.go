@@ -1,5 +1,7 @@
 package entity
 
+import "strings"
+
 /*
  * Compare 2 string arrays to see if they contains the same elements
  * Returns
@@ -8,16 +10,29 @@ package entity
  * - rigght only
  */
 func StringArrayEquivalent(a, b []string) (bool, []string, []string) {
+	return stringArrayEquivalent(a, b, func(s string) string { return s })
+}
+
+/*
+ * Same as StringArrayEquivalent, but case insensitive: to be used to compare
+ * Github logins (Github returns them with its own case)
+ * The left only and right only elements are returned as is
+ */
+func StringArrayEquivalentFold(a, b []string) (bool, []string, []string) {
+	return stringArrayEquivalent(a, b, strings.ToLower)
+}
+
+func stringArrayEquivalent(a, b []string, key func(string) string) (bool, []string, []string) {
 	leftOnly := []string{}
 	rightOnly := []string{}
-	lefts := make(map[string]bool)
+	lefts := make(map[string]string)
 	for _, m := range a {
-		lefts[m] = true
+		lefts[key(m)] = m
 	}
 
-	rights := make(map[string]bool)
+	rights := make(map[string]string)
 	for _, m := range b {
-		rights[m] = true
+		rights[key(m)] = m
 	}
 
 	result := true
@@ -26,14 +41,14 @@ func StringArrayEquivalent(a, b []string) (bool, []string, []string) {
 		result = false
 	}
 
-	for r := range rights {
-		if _, ok := lefts[r]; !ok {
+	for k, r := range rights {
+		if _, ok := lefts[k]; !ok {
 			leftOnly = append(leftOnly, r)
 			result = false
 		}
 	}
-	for l := range lefts {
-		if _, ok := rights[l]; !ok {
+	for k, l := range lefts {
+		if _, ok := rights[k]; !ok {
 			rightOnly = append(rightOnly, l)
 			result = false
 		}
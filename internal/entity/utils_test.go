@@ -34,3 +34,27 @@ func TestStringArrayEquivalent(t *testing.T) {
 
 	})
 }
+
+func TestStringArrayEquivalentFold(t *testing.T) {
+	t.Run("StringArrayEquivalentFold: no change", func(t *testing.T) {
+		res, added, removed := StringArrayEquivalentFold([]string{"JohnDoe", "bb"}, []string{"bb", "johndoe"})
+
+		assert.Equal(t, true, res)
+		assert.Equal(t, 0, len(added))
+		assert.Equal(t, 0, len(removed))
+	})
+
+	t.Run("StringArrayEquivalentFold: added and removed as is", func(t *testing.T) {
+		res, added, removed := StringArrayEquivalentFold([]string{"JohnDoe", "bb"}, []string{"bb", "JaneDoe"})
+
+		assert.Equal(t, false, res)
+		assert.Equal(t, []string{"JaneDoe"}, added)
+		assert.Equal(t, []string{"JohnDoe"}, removed)
+	})
+
+	t.Run("StringArrayEquivalent: case sensitive", func(t *testing.T) {
+		res, _, _ := StringArrayEquivalent([]string{"JohnDoe"}, []string{"johndoe"})
+
+		assert.Equal(t, false, res)
+	})
+}
@@ -134,6 +134,94 @@ func NewGitHubClientImpl(githubServer, organizationName string, appID int64, pri
 	return client, nil
 }
 
+type PersonalAccessTokenTransport struct {
+	token string
+}
+
+func (t *PersonalAccessTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.Header.Add("Authorization", "Bearer "+t.token)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+/**
+ * NewGitHubClientPersonalAccessToken
+ * An alternative to the Github App (for small organizations, or for testing):
+ * the client is authenticated with a (classic or fine-grained) personal access
+ * token, whose capabilities are checked (and reported as warnings)
+ * @param {string} githubServer usually https://api.github.com
+ * @param {string} organizationName
+ * @param {string} token
+ * @return {GitHubClient} client
+ * @return {error} error
+ */
+func NewGitHubClientPersonalAccessToken(githubServer, organizationName string, token string) (GitHubClient, error) {
+	if token == "" {
+		return nil, fmt.Errorf("personal access token not set (GOLIAC_GITHUB_PERSONAL_ACCESS_TOKEN)")
+	}
+
+	client := &GitHubClientImpl{
+		gitHubServer: githubServer,
+		accessToken:  token,
+		// a personal access token is not refreshed
+		tokenExpiration: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
+		httpClient: &http.Client{
+			Transport: &PersonalAccessTokenTransport{token: token},
+		},
+	}
+
+	// check the token can access the organization
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/orgs/%s", githubServer, organizationName), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("personal access token not able to access the organization %s: %s", organizationName, resp.Status)
+	}
+
+	for _, warning := range personalAccessTokenWarnings(resp.Header.Values("X-OAuth-Scopes")) {
+		logrus.Warn(warning)
+	}
+
+	return client, nil
+}
+
+/*
+personalAccessTokenWarnings returns the capabilities missing (or that cannot be
+checked) with a personal access token, from the scopes of a classic token
+(a fine-grained token has no scopes header)
+*/
+func personalAccessTokenWarnings(scopesHeader []string) []string {
+	warnings := []string{
+		"authenticated with a personal access token: the Github App is recommended (the changes are done on behalf of the token owner, and there is no Goliac app to bypass the rulesets)",
+		"with a personal access token, the organization rulesets endpoints may be unavailable",
+	}
+
+	if len(scopesHeader) == 0 {
+		warnings = append(warnings, "fine-grained personal access token: its permissions cannot be checked (the organization Administration and Members, and the repositories Administration permissions are needed)")
+		return warnings
+	}
+
+	scopes := make(map[string]bool)
+	for _, header := range scopesHeader {
+		for _, scope := range strings.Split(header, ",") {
+			scopes[strings.TrimSpace(scope)] = true
+		}
+	}
+	for _, scope := range []string{"admin:org", "repo", "delete_repo"} {
+		if !scopes[scope] {
+			warnings = append(warnings, fmt.Sprintf("the personal access token misses the %s scope", scope))
+		}
+	}
+	return warnings
+}
+
 // waitRateLimit helps dealing with rate limits
 // cf https://docs.github.com/en/rest/guides/best-practices-for-integrators?apiVersion=2022-11-28#dealing-with-rate-limits
 func waitRateLimit(resetTimeStr string) error {
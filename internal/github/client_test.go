@@ -47,3 +47,63 @@ func TestQueryGraphQLAPI(t *testing.T) {
 		t.Errorf("expected 'octocat' in the result, got %s", result)
 	}
 }
+
+func TestPersonalAccessTokenWarnings(t *testing.T) {
+	t.Run("happy path: classic token with all the scopes", func(t *testing.T) {
+		warnings := personalAccessTokenWarnings([]string{"admin:org, delete_repo, repo"})
+		if len(warnings) != 2 {
+			t.Errorf("expected only the generic warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("happy path: classic token missing scopes", func(t *testing.T) {
+		warnings := personalAccessTokenWarnings([]string{"repo"})
+		if len(warnings) != 4 {
+			t.Errorf("expected 4 warnings, got %v", warnings)
+		}
+		if warnings[2] != "the personal access token misses the admin:org scope" {
+			t.Errorf("unexpected warning: %s", warnings[2])
+		}
+	})
+
+	t.Run("happy path: fine-grained token", func(t *testing.T) {
+		warnings := personalAccessTokenWarnings(nil)
+		if len(warnings) != 3 || !strings.Contains(warnings[2], "fine-grained") {
+			t.Errorf("expected the fine-grained warning, got %v", warnings)
+		}
+	})
+}
+
+func TestNewGitHubClientPersonalAccessToken(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mytoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "admin:org, delete_repo, repo")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"login": "myorg"}`))
+	}))
+	defer testServer.Close()
+
+	t.Run("happy path: the token is used", func(t *testing.T) {
+		client, err := NewGitHubClientPersonalAccessToken(testServer.URL, "myorg", "mytoken")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		token, err := client.GetAccessToken(context.TODO())
+		if err != nil || token != "mytoken" {
+			t.Errorf("expected the personal access token, got %s (%v)", token, err)
+		}
+		if client.GetAppSlug() != "" {
+			t.Errorf("expected no app slug, got %s", client.GetAppSlug())
+		}
+	})
+
+	t.Run("not happy path: token refused", func(t *testing.T) {
+		_, err := NewGitHubClientPersonalAccessToken(testServer.URL, "myorg", "wrongtoken")
+		if err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
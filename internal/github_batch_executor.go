@@ -28,16 +28,18 @@ type GithubCommand interface {
  * gal.Commit()
  */
 type GithubBatchExecutor struct {
-	client        engine.ReconciliatorExecutor
-	maxChangesets int
-	commands      []GithubCommand
+	client                   engine.ReconciliatorExecutor
+	maxChangesets            int
+	maxDestructiveOperations int // 0: no limit
+	commands                 []GithubCommand
 }
 
-func NewGithubBatchExecutor(client engine.ReconciliatorExecutor, maxChangesets int) *GithubBatchExecutor {
+func NewGithubBatchExecutor(client engine.ReconciliatorExecutor, maxChangesets int, maxDestructiveOperations int) *GithubBatchExecutor {
 	gal := GithubBatchExecutor{
-		client:        client,
-		maxChangesets: maxChangesets,
-		commands:      make([]GithubCommand, 0),
+		client:                   client,
+		maxChangesets:            maxChangesets,
+		maxDestructiveOperations: maxDestructiveOperations,
+		commands:                 make([]GithubCommand, 0),
 	}
 	return &gal
 }
@@ -51,13 +53,30 @@ func (g *GithubBatchExecutor) AddUserToOrg(ctx context.Context, dryrun bool, ghu
 }
 
 func (g *GithubBatchExecutor) RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
-	g.commands = append(g.commands, &GithubCommandAddUserToOrg{
+	g.commands = append(g.commands, &GithubCommandRemoveUserFromOrg{
 		client:   g.client,
 		dryrun:   dryrun,
 		ghuserid: ghuserid,
 	})
 }
 
+func (g *GithubBatchExecutor) CancelOrgInvitation(ctx context.Context, dryrun bool, invitation *engine.GithubOrgInvitation) {
+	g.commands = append(g.commands, &GithubCommandCancelOrgInvitation{
+		client:     g.client,
+		dryrun:     dryrun,
+		invitation: invitation,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateUserOrgRole(ctx context.Context, dryrun bool, ghuserid string, role string) {
+	g.commands = append(g.commands, &GithubCommandUpdateUserOrgRole{
+		client:   g.client,
+		dryrun:   dryrun,
+		ghuserid: ghuserid,
+		role:     role,
+	})
+}
+
 func (g *GithubBatchExecutor) CreateTeam(ctx context.Context, dryrun bool, teamname string, description string, parentTeam *int, members []string) {
 	g.commands = append(g.commands, &GithubCommandCreateTeam{
 		client:      g.client,
@@ -100,6 +119,43 @@ func (g *GithubBatchExecutor) UpdateTeamRemoveMember(ctx context.Context, dryrun
 	})
 }
 
+// role = member or maintainer (usually we use member)
+func (g *GithubBatchExecutor) UpdateTeamAddMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string, role string) {
+	g.commands = append(g.commands, &GithubCommandUpdateTeamAddMembers{
+		client:   g.client,
+		dryrun:   dryrun,
+		teamslug: teamslug,
+		members:  usernames,
+		role:     role,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string) {
+	g.commands = append(g.commands, &GithubCommandUpdateTeamRemoveMembers{
+		client:   g.client,
+		dryrun:   dryrun,
+		teamslug: teamslug,
+		members:  usernames,
+	})
+}
+
+func (g *GithubBatchExecutor) RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	g.commands = append(g.commands, &GithubCommandRemoveOutsideCollaboratorFromOrg{
+		client:   g.client,
+		dryrun:   dryrun,
+		ghuserid: ghuserid,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, teamslug string, reviewAssignment *engine.GithubTeamReviewAssignment) {
+	g.commands = append(g.commands, &GithubCommandUpdateTeamReviewAssignment{
+		client:           g.client,
+		dryrun:           dryrun,
+		teamslug:         teamslug,
+		reviewAssignment: reviewAssignment,
+	})
+}
+
 func (g *GithubBatchExecutor) UpdateTeamSetParent(ctx context.Context, dryrun bool, teamslug string, parentTeam *int) {
 	g.commands = append(g.commands, &GithubCommandUpdateTeamSetParent{
 		client:     g.client,
@@ -109,11 +165,12 @@ func (g *GithubBatchExecutor) UpdateTeamSetParent(ctx context.Context, dryrun bo
 	})
 }
 
-func (g *GithubBatchExecutor) DeleteTeam(ctx context.Context, dryrun bool, teamslug string) {
+func (g *GithubBatchExecutor) DeleteTeam(ctx context.Context, dryrun bool, teamslug string, reason string) {
 	g.commands = append(g.commands, &GithubCommandDeleteTeam{
 		client:   g.client,
 		dryrun:   dryrun,
 		teamslug: teamslug,
+		reason:   reason,
 	})
 }
 
@@ -129,6 +186,18 @@ func (g *GithubBatchExecutor) CreateRepository(ctx context.Context, dryrun bool,
 	})
 }
 
+func (g *GithubBatchExecutor) CreateRepositoryFork(ctx context.Context, dryrun bool, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) {
+	g.commands = append(g.commands, &GithubCommandCreateRepositoryFork{
+		client:         g.client,
+		dryrun:         dryrun,
+		reponame:       reponame,
+		upstream:       upstream,
+		readers:        readers,
+		writers:        writers,
+		boolProperties: boolProperties,
+	})
+}
+
 func (g *GithubBatchExecutor) UpdateRepositoryAddTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string) {
 	g.commands = append(g.commands, &GithubCommandUpdateRepositoryAddTeamAccess{
 		client:     g.client,
@@ -158,6 +227,153 @@ func (g *GithubBatchExecutor) UpdateRepositoryRemoveTeamAccess(ctx context.Conte
 	})
 }
 
+func (g *GithubBatchExecutor) AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *engine.GithubLabel) {
+	g.commands = append(g.commands, &GithubCommandAddRepositoryLabel{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		label:    label,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *engine.GithubLabel) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryLabel{
+		client:    g.client,
+		dryrun:    dryrun,
+		reponame:  reponame,
+		labelname: labelname,
+		label:     label,
+	})
+}
+
+func (g *GithubBatchExecutor) DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string) {
+	g.commands = append(g.commands, &GithubCommandDeleteRepositoryLabel{
+		client:    g.client,
+		dryrun:    dryrun,
+		reponame:  reponame,
+		labelname: labelname,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryDependabotAlerts{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		enabled:  enabled,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryDependabotSecurityUpdates{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		enabled:  enabled,
+	})
+}
+
+func (g *GithubBatchExecutor) LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *engine.GithubProject) {
+	g.commands = append(g.commands, &GithubCommandLinkRepositoryProject{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		project:  project,
+	})
+}
+
+func (g *GithubBatchExecutor) UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *engine.GithubProject) {
+	g.commands = append(g.commands, &GithubCommandUnlinkRepositoryProject{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		project:  project,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgSetting(ctx context.Context, dryrun bool, settingName string, settingValue bool) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgSetting{
+		client:       g.client,
+		dryrun:       dryrun,
+		settingName:  settingName,
+		settingValue: settingValue,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, settingValue string) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgActionsSetting{
+		client:       g.client,
+		dryrun:       dryrun,
+		settingName:  settingName,
+		settingValue: settingValue,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *engine.GithubOrgAllowedActions) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgAllowedActions{
+		client:         g.client,
+		dryrun:         dryrun,
+		allowedActions: allowedActions,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, fieldValue string) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgProfileField{
+		client:     g.client,
+		dryrun:     dryrun,
+		fieldName:  fieldName,
+		fieldValue: fieldValue,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, branchName string) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgDefaultBranchName{
+		client:     g.client,
+		dryrun:     dryrun,
+		branchName: branchName,
+	})
+}
+
+func (g *GithubBatchExecutor) AddOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	g.commands = append(g.commands, &GithubCommandAddOrgWebhook{
+		client:  g.client,
+		dryrun:  dryrun,
+		webhook: webhook,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	g.commands = append(g.commands, &GithubCommandUpdateOrgWebhook{
+		client:  g.client,
+		dryrun:  dryrun,
+		webhook: webhook,
+	})
+}
+
+func (g *GithubBatchExecutor) DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	g.commands = append(g.commands, &GithubCommandDeleteOrgWebhook{
+		client:  g.client,
+		dryrun:  dryrun,
+		webhook: webhook,
+	})
+}
+
+func (g *GithubBatchExecutor) AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	g.commands = append(g.commands, &GithubCommandAddOrgSecurityManagerTeam{
+		client:   g.client,
+		dryrun:   dryrun,
+		teamslug: teamslug,
+	})
+}
+
+func (g *GithubBatchExecutor) RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	g.commands = append(g.commands, &GithubCommandRemoveOrgSecurityManagerTeam{
+		client:   g.client,
+		dryrun:   dryrun,
+		teamslug: teamslug,
+	})
+}
+
 func (g *GithubBatchExecutor) UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue bool) {
 	g.commands = append(g.commands, &GithubCommandUpdateRepositoryUpdateBoolProperty{
 		client:        g.client,
@@ -168,6 +384,45 @@ func (g *GithubBatchExecutor) UpdateRepositoryUpdateBoolProperty(ctx context.Con
 	})
 }
 
+func (g *GithubBatchExecutor) UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, reponame string, properties map[string]string) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryUpdateStringProperties{
+		client:     g.client,
+		dryrun:     dryrun,
+		reponame:   reponame,
+		properties: properties,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue string) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositorySetCustomProperty{
+		client:        g.client,
+		dryrun:        dryrun,
+		reponame:      reponame,
+		propertyName:  propertyName,
+		propertyValue: propertyValue,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryRemoveCustomProperty{
+		client:       g.client,
+		dryrun:       dryrun,
+		reponame:     reponame,
+		propertyName: propertyName,
+	})
+}
+
+func (g *GithubBatchExecutor) UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) {
+	g.commands = append(g.commands, &GithubCommandUpdateRepositoryFile{
+		client:   g.client,
+		dryrun:   dryrun,
+		reponame: reponame,
+		filename: filename,
+		content:  content,
+		sha:      sha,
+	})
+}
+
 func (g *GithubBatchExecutor) UpdateRepositorySetExternalUser(ctx context.Context, dryrun bool, reponame string, githubid string, permission string) {
 	g.commands = append(g.commands, &GithubCommandUpdateRepositorySetExternalUser{
 		client:     g.client,
@@ -196,11 +451,12 @@ func (g *GithubBatchExecutor) UpdateRepositoryRemoveInternalUser(ctx context.Con
 	})
 }
 
-func (g *GithubBatchExecutor) DeleteRepository(ctx context.Context, dryrun bool, reponame string) {
+func (g *GithubBatchExecutor) DeleteRepository(ctx context.Context, dryrun bool, reponame string, reason string) {
 	g.commands = append(g.commands, &GithubCommandDeleteRepository{
 		client:   g.client,
 		dryrun:   dryrun,
 		reponame: reponame,
+		reason:   reason,
 	})
 }
 
@@ -271,8 +527,11 @@ func (g *GithubBatchExecutor) Rollback(dryrun bool, err error) {
 	g.commands = make([]GithubCommand, 0)
 }
 func (g *GithubBatchExecutor) Commit(ctx context.Context, dryrun bool) error {
-	if len(g.commands) > g.maxChangesets && !config.Config.MaxChangesetsOverride {
-		return fmt.Errorf("more than %d changesets to apply (total of %d), this is suspicious. Aborting (see Goliac troubleshooting guide for help)", g.maxChangesets, len(g.commands))
+	if nbChangesets := g.changesets(); nbChangesets > g.maxChangesets && !config.Config.MaxChangesetsOverride {
+		return fmt.Errorf("more than %d changesets to apply (total of %d), this is suspicious. Aborting (see Goliac troubleshooting guide for help)", g.maxChangesets, nbChangesets)
+	}
+	if nbDestructive := g.destructiveOperations(); g.maxDestructiveOperations > 0 && nbDestructive > g.maxDestructiveOperations && !config.Config.MaxChangesetsOverride {
+		return fmt.Errorf("more than %d destructive operations to apply (total of %d), this is suspicious. Aborting (see Goliac troubleshooting guide for help)", g.maxDestructiveOperations, nbDestructive)
 	}
 	for _, c := range g.commands {
 		c.Apply(ctx)
@@ -281,6 +540,57 @@ func (g *GithubBatchExecutor) Commit(ctx context.Context, dryrun bool) error {
 	return nil
 }
 
+/*
+ * changesets returns the number of changes to apply
+ * (a batched team membership command counts for each of its members)
+ */
+func (g *GithubBatchExecutor) changesets() int {
+	nb := 0
+	for _, c := range g.commands {
+		switch cmd := c.(type) {
+		case *GithubCommandUpdateTeamAddMembers:
+			nb += len(cmd.members)
+		case *GithubCommandUpdateTeamRemoveMembers:
+			nb += len(cmd.members)
+		default:
+			nb++
+		}
+	}
+	return nb
+}
+
+/*
+ * destructiveOperations returns the number of deletions/removals to apply
+ * (a batched team membership removal counts for each of its members)
+ */
+func (g *GithubBatchExecutor) destructiveOperations() int {
+	nb := 0
+	for _, c := range g.commands {
+		switch cmd := c.(type) {
+		case *GithubCommandUpdateTeamRemoveMembers:
+			nb += len(cmd.members)
+		case *GithubCommandRemoveUserFromOrg,
+			*GithubCommandCancelOrgInvitation,
+			*GithubCommandRemoveOutsideCollaboratorFromOrg,
+			*GithubCommandDeleteTeam,
+			*GithubCommandUpdateTeamRemoveMember,
+			*GithubCommandDeleteRepository,
+			*GithubCommandUpdateRepositoryRemoveTeamAccess,
+			*GithubCommandUpdateRepositoryRemoveExternalUser,
+			*GithubCommandUpdateRepositoryRemoveInternalUser,
+			*GithubCommandDeleteRepositoryLabel,
+			*GithubCommandUnlinkRepositoryProject,
+			*GithubCommandUpdateRepositoryRemoveCustomProperty,
+			*GithubCommandDeleteOrgWebhook,
+			*GithubCommandRemoveOrgSecurityManagerTeam,
+			*GithubCommandDeleteRepositoryRuletset,
+			*GithubCommandDeleteRuletset:
+			nb++
+		}
+	}
+	return nb
+}
+
 type GithubCommandAddUserToOrg struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
@@ -305,6 +615,20 @@ func (g *GithubCommandCreateRepository) Apply(ctx context.Context) {
 	g.client.CreateRepository(ctx, g.dryrun, g.reponame, g.description, g.writers, g.readers, g.boolProperties)
 }
 
+type GithubCommandCreateRepositoryFork struct {
+	client         engine.ReconciliatorExecutor
+	dryrun         bool
+	reponame       string
+	upstream       string
+	writers        []string
+	readers        []string
+	boolProperties map[string]bool
+}
+
+func (g *GithubCommandCreateRepositoryFork) Apply(ctx context.Context) {
+	g.client.CreateRepositoryFork(ctx, g.dryrun, g.reponame, g.upstream, g.writers, g.readers, g.boolProperties)
+}
+
 type GithubCommandCreateTeam struct {
 	client      engine.ReconciliatorExecutor
 	dryrun      bool
@@ -322,10 +646,11 @@ type GithubCommandDeleteRepository struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
 	reponame string
+	reason   string
 }
 
 func (g *GithubCommandDeleteRepository) Apply(ctx context.Context) {
-	g.client.DeleteRepository(ctx, g.dryrun, g.reponame)
+	g.client.DeleteRepository(ctx, g.dryrun, g.reponame, g.reason)
 }
 
 type GithubCommandRenameRepository struct {
@@ -343,10 +668,21 @@ type GithubCommandDeleteTeam struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
 	teamslug string
+	reason   string
 }
 
 func (g *GithubCommandDeleteTeam) Apply(ctx context.Context) {
-	g.client.DeleteTeam(ctx, g.dryrun, g.teamslug)
+	g.client.DeleteTeam(ctx, g.dryrun, g.teamslug, g.reason)
+}
+
+type GithubCommandCancelOrgInvitation struct {
+	client     engine.ReconciliatorExecutor
+	dryrun     bool
+	invitation *engine.GithubOrgInvitation
+}
+
+func (g *GithubCommandCancelOrgInvitation) Apply(ctx context.Context) {
+	g.client.CancelOrgInvitation(ctx, g.dryrun, g.invitation)
 }
 
 type GithubCommandRemoveUserFromOrg struct {
@@ -359,6 +695,17 @@ func (g *GithubCommandRemoveUserFromOrg) Apply(ctx context.Context) {
 	g.client.RemoveUserFromOrg(ctx, g.dryrun, g.ghuserid)
 }
 
+type GithubCommandUpdateUserOrgRole struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	ghuserid string
+	role     string
+}
+
+func (g *GithubCommandUpdateUserOrgRole) Apply(ctx context.Context) {
+	g.client.UpdateUserOrgRole(ctx, g.dryrun, g.ghuserid, g.role)
+}
+
 type GithubCommandUpdateRepositoryRemoveTeamAccess struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
@@ -428,6 +775,187 @@ func (g *GithubCommandUpdateRepositoryRemoveInternalUser) Apply(ctx context.Cont
 	g.client.UpdateRepositoryRemoveInternalUser(ctx, g.dryrun, g.reponame, g.githubid)
 }
 
+type GithubCommandAddRepositoryLabel struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	label    *engine.GithubLabel
+}
+
+func (g *GithubCommandAddRepositoryLabel) Apply(ctx context.Context) {
+	g.client.AddRepositoryLabel(ctx, g.dryrun, g.reponame, g.label)
+}
+
+type GithubCommandUpdateRepositoryLabel struct {
+	client    engine.ReconciliatorExecutor
+	dryrun    bool
+	reponame  string
+	labelname string
+	label     *engine.GithubLabel
+}
+
+func (g *GithubCommandUpdateRepositoryLabel) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryLabel(ctx, g.dryrun, g.reponame, g.labelname, g.label)
+}
+
+type GithubCommandDeleteRepositoryLabel struct {
+	client    engine.ReconciliatorExecutor
+	dryrun    bool
+	reponame  string
+	labelname string
+}
+
+func (g *GithubCommandDeleteRepositoryLabel) Apply(ctx context.Context) {
+	g.client.DeleteRepositoryLabel(ctx, g.dryrun, g.reponame, g.labelname)
+}
+
+type GithubCommandUpdateRepositoryDependabotAlerts struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	enabled  bool
+}
+
+func (g *GithubCommandUpdateRepositoryDependabotAlerts) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryDependabotAlerts(ctx, g.dryrun, g.reponame, g.enabled)
+}
+
+type GithubCommandUpdateRepositoryDependabotSecurityUpdates struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	enabled  bool
+}
+
+func (g *GithubCommandUpdateRepositoryDependabotSecurityUpdates) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryDependabotSecurityUpdates(ctx, g.dryrun, g.reponame, g.enabled)
+}
+
+type GithubCommandLinkRepositoryProject struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	project  *engine.GithubProject
+}
+
+func (g *GithubCommandLinkRepositoryProject) Apply(ctx context.Context) {
+	g.client.LinkRepositoryProject(ctx, g.dryrun, g.reponame, g.project)
+}
+
+type GithubCommandUnlinkRepositoryProject struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	project  *engine.GithubProject
+}
+
+func (g *GithubCommandUnlinkRepositoryProject) Apply(ctx context.Context) {
+	g.client.UnlinkRepositoryProject(ctx, g.dryrun, g.reponame, g.project)
+}
+
+type GithubCommandUpdateOrgSetting struct {
+	client       engine.ReconciliatorExecutor
+	dryrun       bool
+	settingName  string
+	settingValue bool
+}
+
+func (g *GithubCommandUpdateOrgSetting) Apply(ctx context.Context) {
+	g.client.UpdateOrgSetting(ctx, g.dryrun, g.settingName, g.settingValue)
+}
+
+type GithubCommandUpdateOrgActionsSetting struct {
+	client       engine.ReconciliatorExecutor
+	dryrun       bool
+	settingName  string
+	settingValue string
+}
+
+func (g *GithubCommandUpdateOrgActionsSetting) Apply(ctx context.Context) {
+	g.client.UpdateOrgActionsSetting(ctx, g.dryrun, g.settingName, g.settingValue)
+}
+
+type GithubCommandUpdateOrgAllowedActions struct {
+	client         engine.ReconciliatorExecutor
+	dryrun         bool
+	allowedActions *engine.GithubOrgAllowedActions
+}
+
+func (g *GithubCommandUpdateOrgAllowedActions) Apply(ctx context.Context) {
+	g.client.UpdateOrgAllowedActions(ctx, g.dryrun, g.allowedActions)
+}
+
+type GithubCommandUpdateOrgProfileField struct {
+	client     engine.ReconciliatorExecutor
+	dryrun     bool
+	fieldName  string
+	fieldValue string
+}
+
+func (g *GithubCommandUpdateOrgProfileField) Apply(ctx context.Context) {
+	g.client.UpdateOrgProfileField(ctx, g.dryrun, g.fieldName, g.fieldValue)
+}
+
+type GithubCommandUpdateOrgDefaultBranchName struct {
+	client     engine.ReconciliatorExecutor
+	dryrun     bool
+	branchName string
+}
+
+func (g *GithubCommandUpdateOrgDefaultBranchName) Apply(ctx context.Context) {
+	g.client.UpdateOrgDefaultBranchName(ctx, g.dryrun, g.branchName)
+}
+
+type GithubCommandAddOrgWebhook struct {
+	client  engine.ReconciliatorExecutor
+	dryrun  bool
+	webhook *engine.GithubOrgWebhook
+}
+
+func (g *GithubCommandAddOrgWebhook) Apply(ctx context.Context) {
+	g.client.AddOrgWebhook(ctx, g.dryrun, g.webhook)
+}
+
+type GithubCommandUpdateOrgWebhook struct {
+	client  engine.ReconciliatorExecutor
+	dryrun  bool
+	webhook *engine.GithubOrgWebhook
+}
+
+func (g *GithubCommandUpdateOrgWebhook) Apply(ctx context.Context) {
+	g.client.UpdateOrgWebhook(ctx, g.dryrun, g.webhook)
+}
+
+type GithubCommandDeleteOrgWebhook struct {
+	client  engine.ReconciliatorExecutor
+	dryrun  bool
+	webhook *engine.GithubOrgWebhook
+}
+
+func (g *GithubCommandDeleteOrgWebhook) Apply(ctx context.Context) {
+	g.client.DeleteOrgWebhook(ctx, g.dryrun, g.webhook)
+}
+
+type GithubCommandAddOrgSecurityManagerTeam struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	teamslug string
+}
+
+func (g *GithubCommandAddOrgSecurityManagerTeam) Apply(ctx context.Context) {
+	g.client.AddOrgSecurityManagerTeam(ctx, g.dryrun, g.teamslug)
+}
+
+type GithubCommandRemoveOrgSecurityManagerTeam struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	teamslug string
+}
+
+func (g *GithubCommandRemoveOrgSecurityManagerTeam) Apply(ctx context.Context) {
+	g.client.RemoveOrgSecurityManagerTeam(ctx, g.dryrun, g.teamslug)
+}
+
 type GithubCommandUpdateRepositoryUpdateBoolProperty struct {
 	client        engine.ReconciliatorExecutor
 	dryrun        bool
@@ -440,6 +968,53 @@ func (g *GithubCommandUpdateRepositoryUpdateBoolProperty) Apply(ctx context.Cont
 	g.client.UpdateRepositoryUpdateBoolProperty(ctx, g.dryrun, g.reponame, g.propertyName, g.propertyValue)
 }
 
+type GithubCommandUpdateRepositoryUpdateStringProperties struct {
+	client     engine.ReconciliatorExecutor
+	dryrun     bool
+	reponame   string
+	properties map[string]string
+}
+
+func (g *GithubCommandUpdateRepositoryUpdateStringProperties) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryUpdateStringProperties(ctx, g.dryrun, g.reponame, g.properties)
+}
+
+type GithubCommandUpdateRepositorySetCustomProperty struct {
+	client        engine.ReconciliatorExecutor
+	dryrun        bool
+	reponame      string
+	propertyName  string
+	propertyValue string
+}
+
+func (g *GithubCommandUpdateRepositorySetCustomProperty) Apply(ctx context.Context) {
+	g.client.UpdateRepositorySetCustomProperty(ctx, g.dryrun, g.reponame, g.propertyName, g.propertyValue)
+}
+
+type GithubCommandUpdateRepositoryRemoveCustomProperty struct {
+	client       engine.ReconciliatorExecutor
+	dryrun       bool
+	reponame     string
+	propertyName string
+}
+
+func (g *GithubCommandUpdateRepositoryRemoveCustomProperty) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryRemoveCustomProperty(ctx, g.dryrun, g.reponame, g.propertyName)
+}
+
+type GithubCommandUpdateRepositoryFile struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	reponame string
+	filename string
+	content  string
+	sha      string
+}
+
+func (g *GithubCommandUpdateRepositoryFile) Apply(ctx context.Context) {
+	g.client.UpdateRepositoryFile(ctx, g.dryrun, g.reponame, g.filename, g.content, g.sha)
+}
+
 type GithubCommandUpdateTeamAddMember struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
@@ -463,6 +1038,29 @@ func (g *GithubCommandUpdateTeamRemoveMember) Apply(ctx context.Context) {
 	g.client.UpdateTeamRemoveMember(ctx, g.dryrun, g.teamslug, g.member)
 }
 
+type GithubCommandUpdateTeamAddMembers struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	teamslug string
+	members  []string
+	role     string
+}
+
+func (g *GithubCommandUpdateTeamAddMembers) Apply(ctx context.Context) {
+	g.client.UpdateTeamAddMembers(ctx, g.dryrun, g.teamslug, g.members, g.role)
+}
+
+type GithubCommandUpdateTeamRemoveMembers struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	teamslug string
+	members  []string
+}
+
+func (g *GithubCommandUpdateTeamRemoveMembers) Apply(ctx context.Context) {
+	g.client.UpdateTeamRemoveMembers(ctx, g.dryrun, g.teamslug, g.members)
+}
+
 type GithubCommandUpdateTeamUpdateMember struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
@@ -486,6 +1084,47 @@ func (g *GithubCommandUpdateTeamSetParent) Apply(ctx context.Context) {
 	g.client.UpdateTeamSetParent(ctx, g.dryrun, g.teamslug, g.parentTeam)
 }
 
+type GithubCommandRemoveOutsideCollaboratorFromOrg struct {
+	client   engine.ReconciliatorExecutor
+	dryrun   bool
+	ghuserid string
+}
+
+func (g *GithubBatchExecutor) UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, teamslug string, notificationSetting string) {
+	g.commands = append(g.commands, &GithubCommandUpdateTeamNotificationSetting{
+		client:              g.client,
+		dryrun:              dryrun,
+		teamslug:            teamslug,
+		notificationSetting: notificationSetting,
+	})
+}
+
+func (g *GithubCommandRemoveOutsideCollaboratorFromOrg) Apply(ctx context.Context) {
+	g.client.RemoveOutsideCollaboratorFromOrg(ctx, g.dryrun, g.ghuserid)
+}
+
+type GithubCommandUpdateTeamReviewAssignment struct {
+	client           engine.ReconciliatorExecutor
+	dryrun           bool
+	teamslug         string
+	reviewAssignment *engine.GithubTeamReviewAssignment
+}
+
+func (g *GithubCommandUpdateTeamReviewAssignment) Apply(ctx context.Context) {
+	g.client.UpdateTeamReviewAssignment(ctx, g.dryrun, g.teamslug, g.reviewAssignment)
+}
+
+type GithubCommandUpdateTeamNotificationSetting struct {
+	client              engine.ReconciliatorExecutor
+	dryrun              bool
+	teamslug            string
+	notificationSetting string
+}
+
+func (g *GithubCommandUpdateTeamNotificationSetting) Apply(ctx context.Context) {
+	g.client.UpdateTeamNotificationSetting(ctx, g.dryrun, g.teamslug, g.notificationSetting)
+}
+
 type GithubCommandAddRepositoryRuletset struct {
 	client   engine.ReconciliatorExecutor
 	dryrun   bool
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithubBatchExecutorDestructiveOperations(t *testing.T) {
+	t.Run("happy path: destructive operations under the limit", func(t *testing.T) {
+		client := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		ga := NewGithubBatchExecutor(client, 50, 3)
+		ctx := context.TODO()
+
+		ga.Begin(false)
+		ga.CreateTeam(ctx, false, "team1", "", nil, []string{"user1", "user2", "user3", "user4"})
+		ga.UpdateTeamRemoveMembers(ctx, false, "team2", []string{"user1", "user2"})
+		ga.DeleteRepository(ctx, false, "repo1", "removed")
+
+		assert.Equal(t, 3, ga.destructiveOperations())
+		err := ga.Commit(ctx, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, client.nbChanges) // 1 team created + 2 members removed + 1 repository deleted
+	})
+
+	t.Run("happy path: no limit", func(t *testing.T) {
+		client := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		ga := NewGithubBatchExecutor(client, 50, 0)
+		ctx := context.TODO()
+
+		ga.Begin(false)
+		for _, teamslug := range []string{"team1", "team2", "team3", "team4"} {
+			ga.DeleteTeam(ctx, false, teamslug, "removed")
+		}
+
+		err := ga.Commit(ctx, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, client.nbChanges)
+	})
+
+	t.Run("not happy path: too many destructive operations", func(t *testing.T) {
+		client := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		ga := NewGithubBatchExecutor(client, 50, 2)
+		ctx := context.TODO()
+
+		ga.Begin(false)
+		ga.CreateTeam(ctx, false, "team1", "", nil, []string{})
+		ga.DeleteTeam(ctx, false, "team3", "removed")
+		ga.UpdateRepositoryRemoveTeamAccess(ctx, false, "repo1", "team2")
+		ga.DeleteRuleset(ctx, false, 1)
+
+		err := ga.Commit(ctx, false)
+		assert.NotNil(t, err)
+		// nothing is applied, not even the team creation
+		assert.Equal(t, 0, client.nbChanges)
+	})
+
+	t.Run("happy path: too many destructive operations, but overridden", func(t *testing.T) {
+		config.Config.MaxChangesetsOverride = true
+		defer func() { config.Config.MaxChangesetsOverride = false }()
+
+		client := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		ga := NewGithubBatchExecutor(client, 50, 1)
+		ctx := context.TODO()
+
+		ga.Begin(false)
+		ga.DeleteTeam(ctx, false, "team1", "removed")
+		ga.DeleteTeam(ctx, false, "team2", "removed")
+
+		err := ga.Commit(ctx, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, client.nbChanges)
+	})
+}
+
+func TestGithubBatchExecutorRemoveUserFromOrg(t *testing.T) {
+	t.Run("happy path: the user is removed from the organization", func(t *testing.T) {
+		client := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		ga := NewGithubBatchExecutor(client, 50, 0)
+		ctx := context.TODO()
+
+		ga.Begin(false)
+		ga.RemoveUserFromOrg(ctx, false, "user1")
+
+		assert.Equal(t, 1, len(ga.commands))
+		assert.IsType(t, &GithubCommandRemoveUserFromOrg{}, ga.commands[0])
+		assert.Equal(t, 1, ga.destructiveOperations())
+
+		err := ga.Commit(ctx, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"user1"}, client.usersRemoved)
+	})
+}
@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/Alayacare/goliac/internal/github"
+)
+
+// hidden marker identifying the (single) Goliac plan comment of a pull request
+const PullRequestPlanCommentMarker = "<!-- goliac-plan -->"
+
+/*
+PullRequestCommentPoster posts the plan of a pull request of the teams
+repository as a comment. The comment is updated at each push (instead of
+adding a new one), it is identified by a hidden marker
+*/
+type PullRequestCommentPoster interface {
+	UpsertPlanComment(ctx context.Context, prNumber int, errs []error, operations []config.GoliacOperation) error
+}
+
+type PullRequestCommentPosterImpl struct {
+	client       github.GitHubClient
+	organization string
+	repository   string
+}
+
+func NewPullRequestCommentPosterImpl(client github.GitHubClient, organization string, repository string) PullRequestCommentPoster {
+	return &PullRequestCommentPosterImpl{
+		client:       client,
+		organization: organization,
+		repository:   repository,
+	}
+}
+
+type pullRequestComment struct {
+	Id   int    `json:"id"`
+	Body string `json:"body"`
+}
+
+func (p *PullRequestCommentPosterImpl) UpsertPlanComment(ctx context.Context, prNumber int, errs []error, operations []config.GoliacOperation) error {
+	body := RenderPlanComment(errs, operations)
+
+	commentId, err := p.findPlanComment(ctx, prNumber)
+	if err != nil {
+		return err
+	}
+
+	if commentId != 0 {
+		// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#update-an-issue-comment
+		response, err := p.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/issues/comments/%d", p.organization, p.repository, commentId),
+			"",
+			"PATCH",
+			map[string]interface{}{"body": body},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update the plan comment of the pull request %d: %v. %s", prNumber, err, string(response))
+		}
+		return nil
+	}
+
+	// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#create-an-issue-comment
+	response, err := p.client.CallRestAPI(
+		ctx,
+		fmt.Sprintf("/repos/%s/%s/issues/%d/comments", p.organization, p.repository, prNumber),
+		"",
+		"POST",
+		map[string]interface{}{"body": body},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to comment the pull request %d: %v. %s", prNumber, err, string(response))
+	}
+	return nil
+}
+
+/*
+findPlanComment returns the id of the plan comment of a pull request
+(0 if there is none yet)
+*/
+func (p *PullRequestCommentPosterImpl) findPlanComment(ctx context.Context, prNumber int) (int, error) {
+	// https://docs.github.com/en/rest/issues/comments?apiVersion=2022-11-28#list-issue-comments
+	for page := 1; ; page++ {
+		body, err := p.client.CallRestAPI(
+			ctx,
+			fmt.Sprintf("/repos/%s/%s/issues/%d/comments", p.organization, p.repository, prNumber),
+			fmt.Sprintf("page=%d&per_page=100", page),
+			"GET",
+			nil,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list the comments of the pull request %d: %v", prNumber, err)
+		}
+
+		var comments []pullRequestComment
+		if err := json.Unmarshal(body, &comments); err != nil {
+			return 0, fmt.Errorf("failed to parse the comments of the pull request %d: %v", prNumber, err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, PullRequestPlanCommentMarker) {
+				return comment.Id, nil
+			}
+		}
+		if len(comments) < 100 {
+			return 0, nil
+		}
+	}
+}
+
+/*
+RenderPlanComment renders the plan as a (markdown) comment, listing only the
+changes: additions (+), removals (-) and updates (!), and the operations
+Goliac refuses to apply (#)
+*/
+func RenderPlanComment(errs []error, operations []config.GoliacOperation) string {
+	var sb strings.Builder
+	sb.WriteString(PullRequestPlanCommentMarker + "\n")
+	sb.WriteString("### Goliac plan\n\n")
+
+	if len(errs) > 0 {
+		sb.WriteString("The teams repository is not valid:\n\n")
+		for _, err := range errs {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", err.Error()))
+		}
+		return sb.String()
+	}
+
+	if len(operations) == 0 {
+		sb.WriteString("No changes.\n")
+		return sb.String()
+	}
+
+	nbChanges := 0
+	for _, o := range operations {
+		if !o.Skipped {
+			nbChanges++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%d changes:\n\n", nbChanges))
+	sb.WriteString("```diff\n")
+	for _, o := range operations {
+		line := fmt.Sprintf("%s %s: %s", planCommentPrefix(o.Command), o.Command, o.Detail)
+		if o.Skipped {
+			line = fmt.Sprintf("# %s: %s (skipped)", o.Command, o.Detail)
+		} else if o.Simulated {
+			line += " (simulated)"
+		}
+		sb.WriteString(line + "\n")
+		for _, change := range o.Changes {
+			sb.WriteString(fmt.Sprintf("!   %s\n", change))
+		}
+	}
+	sb.WriteString("```\n")
+	return sb.String()
+}
+
+func planCommentPrefix(command string) string {
+	switch {
+	case strings.HasPrefix(command, "create_") || strings.HasPrefix(command, "add_"):
+		return "+"
+	case strings.HasPrefix(command, "delete_") || strings.HasPrefix(command, "remove_"):
+		return "-"
+	default:
+		return "!"
+	}
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type GitHubClientCommentsMock struct {
+	GitHubClientMock
+	comments string   // existing comments (json)
+	calls    []string // method endpoint
+	bodies   []string
+}
+
+func (c *GitHubClientCommentsMock) CallRestAPI(ctx context.Context, endpoint, parameters, method string, body map[string]interface{}) ([]byte, error) {
+	c.calls = append(c.calls, method+" "+endpoint)
+	if method == "GET" {
+		return []byte(c.comments), nil
+	}
+	c.bodies = append(c.bodies, fmt.Sprintf("%v", body["body"]))
+	return []byte(`{}`), nil
+}
+
+func TestPullRequestCommentPoster(t *testing.T) {
+	operations := []config.GoliacOperation{
+		{Command: "create_team", Detail: "teamname: team3"},
+		{Command: "update_team_add_member", Detail: "teamslug: team3 username: user1 role: member"},
+		{Command: "remove_repository_team", Detail: "repositoryname: repo1 teamslug: team1"},
+		{Command: "update_repository_update_bool_property", Detail: "repositoryname: repo1 private:false", Simulated: true},
+		{Command: "update_team_remove_members", Detail: "teamslug: team2-goliac-owners ghuserid: user2, refusing to remove the last owner of the team", Skipped: true},
+	}
+
+	t.Run("happy path: first comment", func(t *testing.T) {
+		client := &GitHubClientCommentsMock{comments: `[{"id": 1, "body": "LGTM"}]`}
+		poster := NewPullRequestCommentPosterImpl(client, "myorg", "teams")
+
+		err := poster.UpsertPlanComment(context.TODO(), 42, nil, operations)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"GET /repos/myorg/teams/issues/42/comments",
+			"POST /repos/myorg/teams/issues/42/comments",
+		}, client.calls)
+		assert.True(t, strings.HasPrefix(client.bodies[0], PullRequestPlanCommentMarker))
+		assert.Contains(t, client.bodies[0], "4 changes")
+		assert.Contains(t, client.bodies[0], "+ create_team: teamname: team3\n")
+		assert.Contains(t, client.bodies[0], "! update_team_add_member: ")
+		assert.Contains(t, client.bodies[0], "- remove_repository_team: repositoryname: repo1 teamslug: team1\n")
+		assert.Contains(t, client.bodies[0], "private:false (simulated)\n")
+		assert.Contains(t, client.bodies[0], "# update_team_remove_members: teamslug: team2-goliac-owners ghuserid: user2, refusing to remove the last owner of the team (skipped)\n")
+	})
+
+	t.Run("happy path: the existing comment is updated", func(t *testing.T) {
+		client := &GitHubClientCommentsMock{comments: `[{"id": 1, "body": "LGTM"}, {"id": 7, "body": "` + PullRequestPlanCommentMarker + `\nold plan"}]`}
+		poster := NewPullRequestCommentPosterImpl(client, "myorg", "teams")
+
+		err := poster.UpsertPlanComment(context.TODO(), 42, nil, []config.GoliacOperation{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"GET /repos/myorg/teams/issues/42/comments",
+			"PATCH /repos/myorg/teams/issues/comments/7",
+		}, client.calls)
+		assert.Contains(t, client.bodies[0], "No changes.")
+	})
+
+	t.Run("happy path: invalid teams repository", func(t *testing.T) {
+		client := &GitHubClientCommentsMock{comments: `[]`}
+		poster := NewPullRequestCommentPosterImpl(client, "myorg", "teams")
+
+		err := poster.UpsertPlanComment(context.TODO(), 42, []error{fmt.Errorf("team team3 has no owner")}, nil)
+		assert.Nil(t, err)
+		assert.Contains(t, client.bodies[0], "The teams repository is not valid:")
+		assert.Contains(t, client.bodies[0], "- `team team3 has no owner`")
+	})
+}
@@ -16,10 +16,14 @@ import (
 
 type GithubWebhookServerCallback func()
 
+// called with the number and the head commit sha of a pull request targeting the main branch
+type GithubWebhookServerPullRequestCallback func(prNumber int, headSha string)
+
 /*
 GithubWebhookServer is the interface for the webhook server
 It will wait for a Github webhook event and call the callback function
-when a merge event is received on the main branch
+when a merge event is received on the main branch (and the pull request
+callback when a pull request targeting the main branch is opened or updated)
 */
 type GithubWebhookServer interface {
 	// Start the server
@@ -35,9 +39,10 @@ type GithubWebhookServerImpl struct {
 	server               *http.Server
 	mainBranch           string
 	callback             GithubWebhookServerCallback
+	prCallback           GithubWebhookServerPullRequestCallback // optional
 }
 
-func NewGithubWebhookServerImpl(httpaddr string, httpport int, webhookPath string, secret string, mainBranch string, callback GithubWebhookServerCallback) GithubWebhookServer {
+func NewGithubWebhookServerImpl(httpaddr string, httpport int, webhookPath string, secret string, mainBranch string, callback GithubWebhookServerCallback, prCallback GithubWebhookServerPullRequestCallback) GithubWebhookServer {
 	return &GithubWebhookServerImpl{
 		webhookServerAddress: httpaddr,
 		webhookServerPort:    httpport,
@@ -46,6 +51,7 @@ func NewGithubWebhookServerImpl(httpaddr string, httpport int, webhookPath strin
 		server:               nil,
 		mainBranch:           mainBranch,
 		callback:             callback,
+		prCallback:           prCallback,
 	}
 }
 
@@ -77,6 +83,19 @@ type PushEvent struct {
 	Ref string `json:"ref"`
 }
 
+type PullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Head struct {
+			Sha string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+}
+
 func (s *GithubWebhookServerImpl) WebhookHandler(w http.ResponseWriter, r *http.Request) {
 	logrus.Debugf("Received webhook event")
 	// handle the github webhook
@@ -126,6 +145,8 @@ func (s *GithubWebhookServerImpl) WebhookHandler(w http.ResponseWriter, r *http.
 		s.handlePingEvent(w)
 	case "push":
 		s.handlePushEvent(w, body)
+	case "pull_request":
+		s.handlePullRequestEvent(w, body)
 	default:
 		logrus.Debugf("Event type %s not supported", eventType)
 		w.WriteHeader(http.StatusOK)
@@ -155,3 +176,22 @@ func (s *GithubWebhookServerImpl) handlePushEvent(w http.ResponseWriter, body []
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (s *GithubWebhookServerImpl) handlePullRequestEvent(w http.ResponseWriter, body []byte) {
+	var pullRequestEvent PullRequestEvent
+
+	err := json.Unmarshal(body, &pullRequestEvent)
+	if err != nil {
+		http.Error(w, "Failed to parse pull request event", http.StatusBadRequest)
+		return
+	}
+
+	// only the pull requests targeting the main branch, when their content changes
+	if s.prCallback != nil &&
+		pullRequestEvent.PullRequest.Base.Ref == s.mainBranch &&
+		(pullRequestEvent.Action == "opened" || pullRequestEvent.Action == "synchronize" || pullRequestEvent.Action == "reopened") {
+		s.prCallback(pullRequestEvent.Number, pullRequestEvent.PullRequest.Head.Sha)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -19,7 +19,7 @@ func TestWebhookHandler(t *testing.T) {
 		callback := func() {
 			callbackreceived = true
 		}
-		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback).(*GithubWebhookServerImpl)
+		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback, nil).(*GithubWebhookServerImpl)
 
 		body := `{
 			"zen": "testing",
@@ -48,7 +48,7 @@ func TestWebhookHandler(t *testing.T) {
 		callback := func() {
 			callbackreceived = true
 		}
-		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback).(*GithubWebhookServerImpl)
+		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback, nil).(*GithubWebhookServerImpl)
 
 		body := `{
 			"ref": "refs/heads/main"
@@ -71,12 +71,65 @@ func TestWebhookHandler(t *testing.T) {
 		assert.Equal(t, true, callbackreceived)
 	})
 
+	t.Run("happy path: test pull request webhook", func(t *testing.T) {
+		prNumber := 0
+		prSha := ""
+		prCallback := func(number int, sha string) {
+			prNumber = number
+			prSha = sha
+		}
+		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", func() {}, prCallback).(*GithubWebhookServerImpl)
+
+		for _, tc := range []struct {
+			action string
+			base   string
+			called bool
+		}{
+			{"opened", "main", true},
+			{"synchronize", "main", true},
+			{"closed", "main", false},
+			{"opened", "feature", false},
+		} {
+			prNumber = 0
+			prSha = ""
+			body := `{
+			"action": "` + tc.action + `",
+			"number": 42,
+			"pull_request": {
+				"head": {"sha": "abcdef"},
+				"base": {"ref": "` + tc.base + `"}
+			}
+		}`
+
+			bodyReader := strings.NewReader(body)
+			req := httptest.NewRequest("POST", "/webhook", bodyReader)
+			sign := hmac.New(sha256.New, []byte("secret"))
+			sign.Write([]byte(body))
+			req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(sign.Sum(nil)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-GitHub-Event", "pull_request")
+
+			w := httptest.NewRecorder()
+			wh.WebhookHandler(w, req)
+
+			resp := w.Result()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			if tc.called {
+				assert.Equal(t, 42, prNumber)
+				assert.Equal(t, "abcdef", prSha)
+			} else {
+				assert.Equal(t, 0, prNumber)
+			}
+		}
+	})
+
 	t.Run("not happy path: unsigned webhook", func(t *testing.T) {
 		callbackreceived := false
 		callback := func() {
 			callbackreceived = true
 		}
-		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback).(*GithubWebhookServerImpl)
+		wh := NewGithubWebhookServerImpl("localhost", 8080, "/web", "secret", "main", callback, nil).(*GithubWebhookServerImpl)
 
 		body := `{
 			"zen": "testing",
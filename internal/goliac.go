@@ -6,7 +6,10 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/engine"
@@ -20,6 +23,8 @@ import (
 
 const (
 	GOLIAC_GIT_TAG = "goliac"
+	// commit message trailer giving the reason of the (destructive) changes
+	GOLIAC_REASON_TRAILER = "goliac-reason:"
 )
 
 type GoliacObservability interface {
@@ -37,6 +42,11 @@ type Goliac interface {
 	// it returns an error if something went wrong, and a detailed list of errors and warnings
 	Apply(ctx context.Context, fs billy.Filesystem, dryrun bool, repositoryUrl, branch string) (error, []error, []entity.Warning, *engine.UnmanagedResources)
 
+	// same as Apply, but only reconciliates the teams and repositories changed in the teams
+	// repository since a git ref (like the "goliac" tag of the last applied commit). It falls
+	// back to a full reconciliation if the changes cannot be mapped to teams and repositories
+	ApplySince(ctx context.Context, fs billy.Filesystem, dryrun bool, repositoryUrl, branch, since string) (error, []error, []entity.Warning, *engine.UnmanagedResources)
+
 	// will clone run the user-plugin to sync users, and will commit to the team repository, return true if a change was done
 	UsersUpdate(ctx context.Context, fs billy.Filesystem, repositoryUrl, branch string, dryrun bool, force bool) (bool, error)
 
@@ -45,6 +55,65 @@ type Goliac interface {
 
 	GetLocal() engine.GoliacLocalResources
 	GetRemote() engine.GoliacRemoteResources
+
+	// check (read-only) the required files (from goliac.yaml) of each managed repository,
+	// and return the missing ones per repository
+	CheckCompliance(ctx context.Context) (map[string][]string, error)
+
+	// compare (read-only) a repository definition with its (cached) Github state,
+	// and return the differing fields
+	GetRepositoryDrift(ctx context.Context, repositoryUrl string, reponame string) ([]engine.RepositoryDriftField, error)
+
+	// return the (sorted) teams of the last loaded teams repository without any repository
+	// access nor subteam
+	GetUnusedTeams() []string
+
+	// return the external users accesses (of the last loaded teams repository) expiring
+	// within the window, and the expired ones still declared
+	GetExpiringAccesses(window time.Duration) []engine.ExternalAccessExpiration
+
+	// compute (dry-run) the changes the last loaded teams repository would apply to the
+	// (cached) Github state, and return them grouped by the entity differing from it
+	GetDrift(ctx context.Context, repositoryUrl string) ([]engine.EntityDrift, error)
+
+	// reload a team from Github, and sync it (its members and its repositories access)
+	// against the last loaded teams repository
+	ResyncTeam(ctx context.Context, repositoryUrl string, teamslug string) error
+
+	// compute (dry-run) the changes the teams repository at a git ref (a branch, a tag
+	// or a commit sha) would apply, without touching the last loaded teams repository
+	PlanFromRef(ctx context.Context, fs billy.Filesystem, repositoryUrl, ref string) (error, []error, []entity.Warning, []config.GoliacOperation)
+
+	// compute (dry-run) the plans of 2 git refs of the teams repository against the same
+	// (cached) Github state, and return the operations only in one of them
+	ComparePlans(ctx context.Context, fs billy.Filesystem, repositoryUrl, baseRef, headRef string) (error, []error, []entity.Warning, *PlanComparison)
+
+	// plan a pull request of the teams repository (at its head commit sha), and
+	// post (or update) the plan as a comment of the pull request
+	CommentPlanOnPullRequest(ctx context.Context, fs billy.Filesystem, repositoryUrl string, prNumber int, ref string) error
+
+	// return the error of the last reconciliation (nil if it succeeded) of each
+	// additional Github organization (GOLIAC_GITHUB_APP_ORGANIZATIONS)
+	GetOrganizationsErrors() map[string]error
+
+	// check (without cloning it) that the teams repository is reachable
+	CheckTeamsRepository(ctx context.Context, repositoryUrl string) error
+
+	// check that the Github API is reachable, and accepts the Github App token
+	CheckGithubAPI(ctx context.Context) error
+}
+
+/*
+ * goliacOrganization is an additional Github organization, reconciled
+ * with the same teams repository than the main organization
+ */
+type goliacOrganization struct {
+	name            string
+	githubClient    github.GitHubClient
+	remote          engine.GoliacRemoteExecutor
+	lastError       error                   // error of the last reconciliation
+	reconciled      bool                    // if reconciled by the current (or last) run
+	visibilityGuard *engine.VisibilityGuard // repositories visibility changes of the last reconciliations
 }
 
 type GoliacImpl struct {
@@ -54,11 +123,38 @@ type GoliacImpl struct {
 	remoteGithubClient github.GitHubClient // github client for admin operations
 	repoconfig         *config.RepositoryConfig
 	feedback           observability.RemoteObservability // mostly used for UI progressbar
+	organizations      []*goliacOrganization             // the additional Github organizations
+	organizationsMutex sync.Mutex
+	visibilityGuard    *engine.VisibilityGuard // repositories visibility changes of the last reconciliations
+}
+
+/*
+ * newGithubClient returns a Github client for the organization, authenticated
+ * (depending on GOLIAC_GITHUB_AUTH) either with the Github App, or with a
+ * personal access token
+ */
+func newGithubClient(organization string, appID int64, privateKeyFile string) (github.GitHubClient, error) {
+	switch config.Config.GithubAuth {
+	case "", "app":
+		return github.NewGitHubClientImpl(
+			config.Config.GithubServer,
+			organization,
+			appID,
+			privateKeyFile,
+		)
+	case "pat":
+		return github.NewGitHubClientPersonalAccessToken(
+			config.Config.GithubServer,
+			organization,
+			config.Config.GithubPersonalAccessToken,
+		)
+	default:
+		return nil, fmt.Errorf("unknown Github authentication %s (GOLIAC_GITHUB_AUTH should be app or pat)", config.Config.GithubAuth)
+	}
 }
 
 func NewGoliacImpl() (Goliac, error) {
-	remoteGithubClient, err := github.NewGitHubClientImpl(
-		config.Config.GithubServer,
+	remoteGithubClient, err := newGithubClient(
 		config.Config.GithubAppOrganization,
 		config.Config.GithubAppID,
 		config.Config.GithubAppPrivateKeyFile,
@@ -67,8 +163,7 @@ func NewGoliacImpl() (Goliac, error) {
 		return nil, err
 	}
 
-	localGithubClient, err := github.NewGitHubClientImpl(
-		config.Config.GithubServer,
+	localGithubClient, err := newGithubClient(
 		config.Config.GithubAppOrganization,
 		config.Config.GithubTeamAppID,
 		config.Config.GithubTeamAppPrivateKeyFile,
@@ -77,7 +172,29 @@ func NewGoliacImpl() (Goliac, error) {
 		return nil, err
 	}
 
-	remote := engine.NewGoliacRemoteImpl(remoteGithubClient)
+	remote := engine.NewGoliacRemoteImpl(remoteGithubClient, config.Config.GithubAppOrganization)
+
+	// the Github app must be installed on each additional organization
+	organizations := []*goliacOrganization{}
+	for _, organization := range config.Config.GithubAppOrganizations {
+		if organization == "" || strings.EqualFold(organization, config.Config.GithubAppOrganization) {
+			continue
+		}
+		githubClient, err := newGithubClient(
+			organization,
+			config.Config.GithubAppID,
+			config.Config.GithubAppPrivateKeyFile,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("organization %s: %v", organization, err)
+		}
+		organizations = append(organizations, &goliacOrganization{
+			name:            organization,
+			githubClient:    githubClient,
+			remote:          engine.NewGoliacRemoteImpl(githubClient, organization),
+			visibilityGuard: engine.NewVisibilityGuard(),
+		})
+	}
 
 	usersync.InitPlugins(remoteGithubClient)
 
@@ -88,6 +205,8 @@ func NewGoliacImpl() (Goliac, error) {
 		remote:             remote,
 		repoconfig:         &config.RepositoryConfig{},
 		feedback:           nil,
+		organizations:      organizations,
+		visibilityGuard:    engine.NewVisibilityGuard(),
 	}, nil
 }
 
@@ -99,6 +218,212 @@ func (g *GoliacImpl) GetRemote() engine.GoliacRemoteResources {
 	return g.remote
 }
 
+func (g *GoliacImpl) CheckCompliance(ctx context.Context) (map[string][]string, error) {
+	if len(g.repoconfig.RequiredFiles) == 0 {
+		return make(map[string][]string), nil
+	}
+	repositories := make([]string, 0, len(g.local.Repositories()))
+	for reponame, repo := range g.local.Repositories() {
+		if repo.Archived {
+			continue
+		}
+		repositories = append(repositories, reponame)
+	}
+	sort.Strings(repositories)
+
+	missing, err := engine.MissingRequiredFiles(ctx, g.remoteGithubClient, config.Config.GithubAppOrganization, repositories, g.repoconfig.RequiredFiles)
+	if err != nil {
+		return missing, err
+	}
+
+	// the repositories of the additional organizations are reported by their full name (<organization>/<repository>)
+	for _, organization := range g.organizations {
+		orgMissing, err := engine.MissingRequiredFiles(ctx, organization.githubClient, organization.name, repositories, g.repoconfig.RequiredFiles)
+		if err != nil {
+			return missing, fmt.Errorf("organization %s: %v", organization.name, err)
+		}
+		for reponame, files := range orgMissing {
+			missing[organization.name+"/"+reponame] = files
+		}
+	}
+	return missing, nil
+}
+
+func (g *GoliacImpl) GetRepositoryDrift(ctx context.Context, repositoryUrl string, reponame string) ([]engine.RepositoryDriftField, error) {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", repositoryUrl, err)
+	}
+	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
+
+	return engine.RepositoryDrift(ctx, g.local, g.remote, g.repoconfig, teamreponame, reponame)
+}
+
+func (g *GoliacImpl) GetUnusedTeams() []string {
+	return engine.UnusedTeams(g.local.Teams(), g.local.Repositories(), g.repoconfig)
+}
+
+func (g *GoliacImpl) GetExpiringAccesses(window time.Duration) []engine.ExternalAccessExpiration {
+	now := time.Now()
+	return engine.ExpiringExternalAccesses(g.local.Repositories(), g.local.ExternalUsers(), now.Add(window), now)
+}
+
+func (g *GoliacImpl) GetDrift(ctx context.Context, repositoryUrl string) ([]engine.EntityDrift, error) {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", repositoryUrl, err)
+	}
+	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
+
+	// a refused operation (like a visibility reduction) is a drift too
+	changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+	ctx = context.WithValue(ctx, config.ContextKeyChanges, &changes)
+
+	// without executor, nothing is sent to Github (nor updated in the remote cache)
+	reconciliator := engine.NewGoliacReconciliatorImpl(nil, g.repoconfig)
+	_, err = reconciliator.Reconciliate(ctx, g.local, g.remote, teamreponame, true, g.repoconfig.AdminTeam, make(map[string]*engine.GithubRepoComparable), make(map[string]*entity.Repository), make(map[string]bool))
+	if err != nil {
+		return nil, fmt.Errorf("error when reconciliating: %v", err)
+	}
+	return engine.GroupDrift(changes.Operations), nil
+}
+
+func (g *GoliacImpl) ResyncTeam(ctx context.Context, repositoryUrl string, teamslug string) error {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", repositoryUrl, err)
+	}
+	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
+
+	found := false
+	for teamname := range g.local.Teams() {
+		if engine.TeamSlug(teamname) == teamslug {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("team %s not found", teamslug)
+	}
+
+	err = g.remote.RefreshTeam(ctx, teamslug)
+	if err != nil {
+		return fmt.Errorf("error when reloading the team %s: %v", teamslug, err)
+	}
+
+	ga := NewGithubBatchExecutor(g.remote, g.repoconfig.MaxChangesets, g.repoconfig.MaxDestructiveOperations)
+	reconciliator := engine.NewGoliacReconciliatorImpl(ga, g.repoconfig)
+
+	err = reconciliator.ReconciliateTeam(ctx, g.local, g.remote, teamreponame, false, teamslug)
+	if err != nil {
+		return fmt.Errorf("error when reconciliating the team %s: %v", teamslug, err)
+	}
+	return nil
+}
+
+func (g *GoliacImpl) PlanFromRef(ctx context.Context, fs billy.Filesystem, repositoryUrl, ref string) (error, []error, []entity.Warning, []config.GoliacOperation) {
+	return g.planFromRef(ctx, fs, repositoryUrl, ref, true)
+}
+
+/*
+ComparePlans plans the base ref and the head ref against the same Github state
+(it is loaded once, before the plans, and not refreshed in between), and diffs
+the 2 plans
+*/
+func (g *GoliacImpl) ComparePlans(ctx context.Context, fs billy.Filesystem, repositoryUrl, baseRef, headRef string) (error, []error, []entity.Warning, *PlanComparison) {
+	err := g.remote.Load(ctx, false)
+	if err != nil {
+		return fmt.Errorf("error when fetching data from Github: %v", err), nil, nil, nil
+	}
+
+	err, errs, warns, baseOperations := g.planFromRef(ctx, fs, repositoryUrl, baseRef, false)
+	if err != nil {
+		return err, errs, warns, nil
+	}
+	err, errs, warns, headOperations := g.planFromRef(ctx, fs, repositoryUrl, headRef, false)
+	if err != nil {
+		return err, errs, warns, nil
+	}
+	return nil, errs, warns, diffPlans(baseOperations, headOperations)
+}
+
+// planFromRef plans a git ref, loading (or not, to keep the same Github state) the remote first
+func (g *GoliacImpl) planFromRef(ctx context.Context, fs billy.Filesystem, repositoryUrl, ref string, loadRemote bool) (error, []error, []entity.Warning, []config.GoliacOperation) {
+	if !strings.HasPrefix(repositoryUrl, "https://") &&
+		!strings.HasPrefix(repositoryUrl, "inmemory:///") { // <- only for testing purposes
+		return fmt.Errorf("you must specify the https url of the remote team git repository"), nil, nil, nil
+	}
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", repositoryUrl, err), nil, nil, nil
+	}
+	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
+
+	accessToken := ""
+	if strings.HasPrefix(repositoryUrl, "https://") {
+		accessToken, err = g.localGithubClient.GetAccessToken(ctx)
+		if err != nil {
+			return err, nil, nil, nil
+		}
+	}
+
+	// the ref is loaded apart, to keep the last loaded teams repository (GetLocal)
+	local := engine.NewGoliacLocalImpl()
+	err = local.CloneRef(fs, accessToken, repositoryUrl, ref)
+	defer local.Close(fs)
+	if err != nil {
+		return fmt.Errorf("unable to clone %s: %v", ref, err), nil, nil, nil
+	}
+	repoconfig, err := local.LoadRepoConfig()
+	if err != nil {
+		return fmt.Errorf("unable to read goliac.yaml config file: %v", err), nil, nil, nil
+	}
+	errs, warns := local.LoadAndValidate(repoconfig)
+	if len(errs) == 0 {
+		errs = engine.ValidateProjects(ctx, local.Repositories(), g.remote)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("not able to load and validate the goliac organization at %s", ref), errs, warns, nil
+	}
+
+	if loadRemote {
+		err = g.remote.Load(ctx, false)
+		if err != nil {
+			return fmt.Errorf("error when fetching data from Github: %v", err), errs, warns, nil
+		}
+	}
+
+	changes := config.GoliacChanges{Dryrun: true, ReportSkipped: true}
+	ctx = context.WithValue(ctx, config.ContextKeyChanges, &changes)
+
+	// without executor, nothing is sent to Github (nor updated in the remote cache)
+	reconciliator := engine.NewGoliacReconciliatorImpl(nil, repoconfig)
+	_, err = reconciliator.Reconciliate(ctx, local, g.remote, teamreponame, true, repoconfig.AdminTeam, make(map[string]*engine.GithubRepoComparable), make(map[string]*entity.Repository), make(map[string]bool))
+	if err != nil {
+		return fmt.Errorf("error when reconciliating: %v", err), errs, warns, nil
+	}
+	return nil, errs, warns, changes.Operations
+}
+
+func (g *GoliacImpl) CommentPlanOnPullRequest(ctx context.Context, fs billy.Filesystem, repositoryUrl string, prNumber int, ref string) error {
+	u, err := url.Parse(repositoryUrl)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %v", repositoryUrl, err)
+	}
+	// https://github.com/<organization>/<teams repository>.git
+	organization := path.Base(path.Dir(u.Path))
+	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
+
+	err, errs, _, operations := g.PlanFromRef(ctx, fs, repositoryUrl, ref)
+	// an invalid teams repository is reported in the comment
+	if err != nil && len(errs) == 0 {
+		return err
+	}
+
+	poster := NewPullRequestCommentPosterImpl(g.localGithubClient, organization, teamreponame)
+	return poster.UpsertPlanComment(ctx, prNumber, errs, operations)
+}
+
 func (g *GoliacImpl) SetRemoteObservability(feedback observability.RemoteObservability) error {
 	g.feedback = feedback
 	g.remote.SetRemoteObservability(feedback)
@@ -115,9 +440,72 @@ func (g *GoliacImpl) SetRemoteObservability(feedback observability.RemoteObserva
 
 func (g *GoliacImpl) FlushCache() {
 	g.remote.FlushCache()
+	for _, organization := range g.organizations {
+		organization.remote.FlushCache()
+	}
+}
+
+func (g *GoliacImpl) GetOrganizationsErrors() map[string]error {
+	g.organizationsMutex.Lock()
+	defer g.organizationsMutex.Unlock()
+
+	errors := make(map[string]error, len(g.organizations))
+	for _, organization := range g.organizations {
+		errors[organization.name] = organization.lastError
+	}
+	return errors
+}
+
+func (g *GoliacImpl) CheckTeamsRepository(ctx context.Context, repositoryUrl string) error {
+	accessToken := ""
+	if strings.HasPrefix(repositoryUrl, "https://") {
+		var err error
+		accessToken, err = g.localGithubClient.GetAccessToken(ctx)
+		if err != nil {
+			return fmt.Errorf("not able to get a Github App token: %v", err)
+		}
+	} else if !strings.HasPrefix(repositoryUrl, "inmemory:///") { // <- only for testing purposes
+		return fmt.Errorf("you must specify the https url of the remote team git repository")
+	}
+	return engine.CheckRemoteRepository(ctx, accessToken, repositoryUrl)
+}
+
+func (g *GoliacImpl) CheckGithubAPI(ctx context.Context) error {
+	// https://docs.github.com/en/rest/rate-limit/rate-limit?apiVersion=2022-11-28 (it doesn't count against the rate limit)
+	body, err := g.remoteGithubClient.CallRestAPI(ctx, "/rate_limit", "", "GET", nil)
+	if err != nil {
+		return fmt.Errorf("%v. %s", err, string(body))
+	}
+	return nil
 }
 
 func (g *GoliacImpl) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool, repositoryUrl, branch string) (error, []error, []entity.Warning, *engine.UnmanagedResources) {
+	return g.ApplySince(ctx, fs, dryrun, repositoryUrl, branch, "")
+}
+
+func (g *GoliacImpl) ApplySince(ctx context.Context, fs billy.Filesystem, dryrun bool, repositoryUrl, branch, since string) (error, []error, []entity.Warning, *engine.UnmanagedResources) {
+	g.organizationsMutex.Lock()
+	for _, organization := range g.organizations {
+		organization.reconciled = false
+	}
+	g.organizationsMutex.Unlock()
+
+	err, errs, warns, unmanaged := g.applySince(ctx, fs, dryrun, repositoryUrl, branch, since)
+
+	// the additional organizations are not reconciled if the main organization failed before
+	if err != nil {
+		g.organizationsMutex.Lock()
+		for _, organization := range g.organizations {
+			if !organization.reconciled {
+				organization.lastError = fmt.Errorf("not reconciled: %v", err)
+			}
+		}
+		g.organizationsMutex.Unlock()
+	}
+	return err, errs, warns, unmanaged
+}
+
+func (g *GoliacImpl) applySince(ctx context.Context, fs billy.Filesystem, dryrun bool, repositoryUrl, branch, since string) (error, []error, []entity.Warning, *engine.UnmanagedResources) {
 	err, errs, warns := g.loadAndValidateGoliacOrganization(ctx, fs, repositoryUrl, branch)
 	defer g.local.Close(fs)
 	if err != nil {
@@ -135,6 +523,11 @@ func (g *GoliacImpl) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool
 
 	teamreponame := strings.TrimSuffix(path.Base(u.Path), filepath.Ext(path.Base(u.Path)))
 
+	// attribute the changes to the author of the last commit
+	ctx = context.WithValue(ctx, config.KeyAuthor, g.getChangesAuthor())
+	ctx = context.WithValue(ctx, config.KeyOrganization, config.Config.GithubAppOrganization)
+	ctx = context.WithValue(ctx, config.KeyReason, g.getChangesReason())
+
 	// ensure that the team repo is configured to only allow squash and merge
 	if !dryrun {
 		err := g.forceSquashMergeOnTeamsRepo(ctx, teamreponame, branch)
@@ -143,7 +536,7 @@ func (g *GoliacImpl) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool
 		}
 	}
 
-	unmanaged, err := g.applyToGithub(ctx, dryrun, config.Config.GithubAppOrganization, teamreponame, branch, config.Config.SyncUsersBeforeApply)
+	unmanaged, err := g.applyToGithub(ctx, dryrun, config.Config.GithubAppOrganization, teamreponame, branch, config.Config.SyncUsersBeforeApply, since)
 	for _, warn := range warns {
 		logrus.Warn(warn)
 	}
@@ -154,6 +547,52 @@ func (g *GoliacImpl) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool
 	return nil, errs, warns, unmanaged
 }
 
+/*
+getChangesAuthor returns the author of the last commit of the teams repository
+or "scheduled" if there is no new commit since the last apply
+*/
+func (g *GoliacImpl) getChangesAuthor() string {
+	commits, err := g.local.ListCommitsFromTag(GOLIAC_GIT_TAG)
+	if err != nil || len(commits) == 0 {
+		return "scheduled"
+	}
+	lastCommit := commits[len(commits)-1]
+	if lastCommit.Author.Email != "" {
+		return lastCommit.Author.Email
+	}
+	return lastCommit.Author.Name
+}
+
+/*
+getChangesReason returns the reason given (via a "goliac-reason:" trailer)
+in the most recent commit of the teams repository since the last apply
+*/
+func (g *GoliacImpl) getChangesReason() string {
+	commits, err := g.local.ListCommitsFromTag(GOLIAC_GIT_TAG)
+	if err != nil {
+		return ""
+	}
+	for i := len(commits) - 1; i >= 0; i-- {
+		if reason := parseReasonTrailer(commits[i].Message); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+/*
+parseReasonTrailer extracts the "goliac-reason:" trailer of a commit message
+*/
+func parseReasonTrailer(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > len(GOLIAC_REASON_TRAILER) && strings.EqualFold(line[:len(GOLIAC_REASON_TRAILER)], GOLIAC_REASON_TRAILER) {
+			return strings.TrimSpace(line[len(GOLIAC_REASON_TRAILER):])
+		}
+	}
+	return ""
+}
+
 func (g *GoliacImpl) loadAndValidateGoliacOrganization(ctx context.Context, fs billy.Filesystem, repositoryUrl, branch string) (error, []error, []entity.Warning) {
 	var errs []error
 	var warns []entity.Warning
@@ -177,14 +616,25 @@ func (g *GoliacImpl) loadAndValidateGoliacOrganization(ctx context.Context, fs b
 		}
 		g.repoconfig = repoconfig
 
-		errs, warns = g.local.LoadAndValidate()
+		errs, warns = g.local.LoadAndValidate(repoconfig)
+		if len(errs) == 0 {
+			errs = engine.ValidateProjects(ctx, g.local.Repositories(), g.remote)
+		}
 	} else {
 		// Local
 		subfs, err := fs.Chroot(repositoryUrl)
 		if err != nil {
 			return fmt.Errorf("unable to chroot to %s: %v", repositoryUrl, err), nil, nil
 		}
-		errs, warns = g.local.LoadAndValidateLocal(subfs)
+		// without goliac.yaml, the default configuration is used (nil)
+		var repoconfig *config.RepositoryConfig
+		if _, err := subfs.Stat("goliac.yaml"); err == nil {
+			repoconfig, err = engine.LoadRepoConfigFromFs(subfs)
+			if err != nil {
+				return fmt.Errorf("unable to read goliac.yaml config file: %v", err), nil, nil
+			}
+		}
+		errs, warns = g.local.LoadAndValidateLocal(subfs, repoconfig)
 	}
 
 	for _, warn := range warns {
@@ -260,7 +710,7 @@ Apply the changes to the github team repository:
   - apply the changes
   - update the codeowners file
 */
-func (g *GoliacImpl) applyToGithub(ctx context.Context, dryrun bool, githubOrganization string, teamreponame string, branch string, syncusersbeforeapply bool) (*engine.UnmanagedResources, error) {
+func (g *GoliacImpl) applyToGithub(ctx context.Context, dryrun bool, githubOrganization string, teamreponame string, branch string, syncusersbeforeapply bool, since string) (*engine.UnmanagedResources, error) {
 	err := g.remote.Load(ctx, false)
 	if err != nil {
 		return nil, fmt.Errorf("error when fetching data from Github: %v", err)
@@ -286,6 +736,9 @@ func (g *GoliacImpl) applyToGithub(ctx context.Context, dryrun bool, githubOrgan
 			}
 			if change {
 				g.remote.FlushCacheUsersTeamsOnly()
+				for _, organization := range g.organizations {
+					organization.remote.FlushCacheUsersTeamsOnly()
+				}
 			}
 		}
 	}
@@ -295,7 +748,7 @@ func (g *GoliacImpl) applyToGithub(ctx context.Context, dryrun bool, githubOrgan
 	//
 
 	// we apply the changes to the github team repository
-	unmanaged, err := g.applyCommitsToGithub(ctx, dryrun, teamreponame, branch)
+	unmanaged, err := g.applyCommitsToGithub(ctx, dryrun, teamreponame, branch, since)
 	if err != nil {
 		return unmanaged, fmt.Errorf("error when applying to github: %v", err)
 	}
@@ -319,17 +772,23 @@ func (g *GoliacImpl) applyToGithub(ctx context.Context, dryrun bool, githubOrgan
 	return unmanaged, nil
 }
 
-func (g *GoliacImpl) applyCommitsToGithub(ctx context.Context, dryrun bool, teamreponame string, branch string) (*engine.UnmanagedResources, error) {
+func (g *GoliacImpl) applyCommitsToGithub(ctx context.Context, dryrun bool, teamreponame string, branch string, since string) (*engine.UnmanagedResources, error) {
 
 	// if the repo was just archived in a previous commit and we "resume it"
 	// so we keep a track of all repos that we want to archive until the end of the process
 	reposToArchive := make(map[string]*engine.GithubRepoComparable)
 	// map[directory]*entity.Repository
 	reposToRename := make(map[string]*entity.Repository)
+	// archived repositories deleted once their grace period elapsed
+	reposToDelete := make(map[string]bool)
 	var unmanaged *engine.UnmanagedResources
 
-	ga := NewGithubBatchExecutor(g.remote, g.repoconfig.MaxChangesets)
+	ga := NewGithubBatchExecutor(g.remote, g.repoconfig.MaxChangesets, g.repoconfig.MaxDestructiveOperations)
 	reconciliator := engine.NewGoliacReconciliatorImpl(ga, g.repoconfig)
+	reconciliator.SetVisibilityGuard(g.visibilityGuard)
+	if since != "" {
+		reconciliator.SetScope(g.incrementalScope(since))
+	}
 
 	commit, err := g.local.GetHeadCommit()
 	if err != nil {
@@ -338,11 +797,26 @@ func (g *GoliacImpl) applyCommitsToGithub(ctx context.Context, dryrun bool, team
 
 	// the repo has already been cloned (to HEAD) and validated (see loadAndValidateGoliacOrganization)
 	// we can now apply the changes to the github team repository
-	unmanaged, err = reconciliator.Reconciliate(ctx, g.local, g.remote, teamreponame, dryrun, g.repoconfig.AdminTeam, reposToArchive, reposToRename)
+	unmanaged, err = reconciliator.Reconciliate(ctx, g.local, g.remote, teamreponame, dryrun, g.repoconfig.AdminTeam, reposToArchive, reposToRename, reposToDelete)
 	if err != nil {
 		return unmanaged, fmt.Errorf("error when reconciliating: %v", err)
 	}
 
+	// the additional organizations get the same definitions. An organization
+	// failing doesn't stop the others (see GetOrganizationsErrors).
+	// They are always fully reconciliated (the last applied commit is the one of the main organization)
+	// Their repositories archived, renamed or deleted are persisted with the ones of the main organization
+	for _, organization := range g.organizations {
+		err := g.reconciliateOrganization(ctx, organization, dryrun, reposToArchive, reposToRename, reposToDelete)
+		if err != nil {
+			logrus.Errorf("error when reconciliating the organization %s: %v", organization.name, err)
+		}
+		g.organizationsMutex.Lock()
+		organization.lastError = err
+		organization.reconciled = true
+		g.organizationsMutex.Unlock()
+	}
+
 	if !dryrun {
 		accessToken, err := g.localGithubClient.GetAccessToken(ctx)
 		if err != nil {
@@ -356,13 +830,18 @@ func (g *GoliacImpl) applyCommitsToGithub(ctx context.Context, dryrun bool, team
 		return unmanaged, err
 	}
 
-	// if we have repos to create as archived or to rename
-	if (len(reposToArchive) > 0 || len(reposToRename) > 0) && !dryrun {
+	// if we have repos to create as archived, to rename or to remove from the archived ones
+	// (not if the repositories operations are only simulated)
+	if (len(reposToArchive) > 0 || len(reposToRename) > 0 || len(reposToDelete) > 0) && !dryrun && !g.repoconfig.DryrunOperations.DryrunRepositories {
 		reposToArchiveList := make([]string, 0)
 		for reponame := range reposToArchive {
 			reposToArchiveList = append(reposToArchiveList, reponame)
 		}
-		err = g.local.UpdateRepos(reposToArchiveList, reposToRename, accessToken, branch, GOLIAC_GIT_TAG)
+		reposToDeleteList := make([]string, 0)
+		for reponame := range reposToDelete {
+			reposToDeleteList = append(reposToDeleteList, reponame)
+		}
+		err = g.local.UpdateRepos(reposToArchiveList, reposToRename, reposToDeleteList, accessToken, branch, GOLIAC_GIT_TAG)
 		if err != nil {
 			return unmanaged, fmt.Errorf("error when archiving repos: %v", err)
 		}
@@ -370,6 +849,70 @@ func (g *GoliacImpl) applyCommitsToGithub(ctx context.Context, dryrun bool, team
 	return unmanaged, nil
 }
 
+/*
+incrementalScope returns the teams and repositories defined by the files changed
+in the teams repository since the git ref, or nil (a full reconciliation) if the
+changes cannot be listed, or mapped to teams and repositories
+*/
+func (g *GoliacImpl) incrementalScope(since string) *engine.ReconciliationScope {
+	files, err := g.local.ChangedFilesSince(since)
+	if err != nil {
+		logrus.Warnf("not able to list the changes since %s (full reconciliation): %v", since, err)
+		return nil
+	}
+	scope := engine.NewReconciliationScope(files)
+	if scope == nil {
+		logrus.Infof("the changes since %s are not limited to teams and repositories: full reconciliation", since)
+		return nil
+	}
+	logrus.Infof("incremental reconciliation since %s: %d team(s) and %d repositories", since, len(scope.Teams), len(scope.Repositories))
+	return scope
+}
+
+/*
+reconciliateOrganization reconciles an additional Github organization with
+the (already loaded) teams repository, and its own org-level settings (see
+RepositoryConfig.ForOrganization). The teams repository itself lives (and
+is only updated, for the archived or renamed repositories) in the main organization:
+the repositories the organization archives, renames or deletes are added to
+the ones of the main organization (that win over them)
+*/
+func (g *GoliacImpl) reconciliateOrganization(ctx context.Context, organization *goliacOrganization, dryrun bool, reposToArchive map[string]*engine.GithubRepoComparable, reposToRename map[string]*entity.Repository, reposToDelete map[string]bool) error {
+	err := organization.remote.Load(ctx, false)
+	if err != nil {
+		return fmt.Errorf("error when fetching data from Github: %v", err)
+	}
+
+	ctx = context.WithValue(ctx, config.KeyOrganization, organization.name)
+	repoconfig := g.repoconfig.ForOrganization(organization.name)
+	ga := NewGithubBatchExecutor(organization.remote, repoconfig.MaxChangesets, repoconfig.MaxDestructiveOperations)
+	reconciliator := engine.NewGoliacReconciliatorImpl(ga, repoconfig)
+	reconciliator.SetVisibilityGuard(organization.visibilityGuard)
+
+	orgReposToArchive := make(map[string]*engine.GithubRepoComparable)
+	orgReposToRename := make(map[string]*entity.Repository)
+	orgReposToDelete := make(map[string]bool)
+	_, err = reconciliator.Reconciliate(ctx, g.local, organization.remote, "", dryrun, repoconfig.AdminTeam, orgReposToArchive, orgReposToRename, orgReposToDelete)
+	if err != nil {
+		return fmt.Errorf("error when reconciliating: %v", err)
+	}
+
+	for reponame, repo := range orgReposToArchive {
+		if _, ok := reposToArchive[reponame]; !ok {
+			reposToArchive[reponame] = repo
+		}
+	}
+	for directory, repo := range orgReposToRename {
+		if _, ok := reposToRename[directory]; !ok {
+			reposToRename[directory] = repo
+		}
+	}
+	for reponame := range orgReposToDelete {
+		reposToDelete[reponame] = true
+	}
+	return nil
+}
+
 func (g *GoliacImpl) UsersUpdate(ctx context.Context, fs billy.Filesystem, repositoryUrl, branch string, dryrun bool, force bool) (bool, error) {
 	accessToken, err := g.localGithubClient.GetAccessToken(ctx)
 	if err != nil {
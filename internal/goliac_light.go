@@ -5,8 +5,11 @@ import (
 
 	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/engine"
+	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/Alayacare/goliac/internal/utils"
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
 /*
@@ -33,7 +36,28 @@ func NewGoliacLightImpl() (GoliacLight, error) {
 
 func (g *GoliacLightImpl) Validate(path string) error {
 	fs := osfs.New(path)
-	errs, warns := g.local.LoadAndValidateLocal(fs)
+
+	// the goliac.yaml file is optional (the default configuration is used without it)
+	var repoconfig *config.RepositoryConfig
+	var errs []error
+	var warns []entity.Warning
+	if content, err := utils.ReadFile(fs, "goliac.yaml"); err == nil {
+		// a fresh configuration: nothing is kept from a previous validation
+		repoconfig = &config.RepositoryConfig{}
+		if err := yaml.Unmarshal(content, repoconfig); err != nil {
+			errs = append(errs, fmt.Errorf("not able to unmarshall the /goliac.yaml configuration file: %v", err))
+		}
+		g.repoconfig = repoconfig
+	}
+
+	if len(errs) == 0 {
+		errs, warns = g.local.LoadAndValidateLocal(fs, repoconfig)
+	}
+
+	// cross-references checks (including goliac.yaml if any)
+	if len(errs) == 0 {
+		errs = append(errs, engine.Validate(g.local, repoconfig)...)
+	}
 
 	for _, warn := range warns {
 		logrus.Warn(warn)
@@ -2,10 +2,16 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -20,11 +26,14 @@ import (
 	"github.com/Alayacare/goliac/swagger_gen/restapi/operations/health"
 	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/go-openapi/loads"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
-	"github.com/gosimple/slug"
 	"github.com/sirupsen/logrus"
 )
 
+// errApplyVetoed is returned when the pre-apply hook vetoes the apply (it is not a failed apply)
+var errApplyVetoed = errors.New("apply aborted by the pre-apply hook")
+
 /*
  * GoliacServer is here to run as a serve that
  * - sync/reconciliate periodically
@@ -34,20 +43,39 @@ type GoliacServer interface {
 	Serve()
 	GetLiveness(health.GetLivenessParams) middleware.Responder
 	GetReadiness(health.GetReadinessParams) middleware.Responder
+	GetHealth(health.GetHealthParams) middleware.Responder
 	PostFlushCache(app.PostFlushCacheParams) middleware.Responder
 	PostResync(app.PostResyncParams) middleware.Responder
+	PostApply(app.PostApplyParams) middleware.Responder
+	PostResyncTeam(app.PostResyncTeamParams) middleware.Responder
+	PostPause(app.PostPauseParams) middleware.Responder
+	PostResume(app.PostResumeParams) middleware.Responder
 	GetStatus(app.GetStatusParams) middleware.Responder
 
 	GetUsers(app.GetUsersParams) middleware.Responder
 	GetUser(app.GetUserParams) middleware.Responder
+	GetUserRepositoryAccess(app.GetUserRepositoryAccessParams) middleware.Responder
 	GetCollaborators(app.GetCollaboratorsParams) middleware.Responder
 	GetCollaborator(app.GetCollaboratorParams) middleware.Responder
 	GetTeams(app.GetTeamsParams) middleware.Responder
+	GetUnusedTeams(app.GetUnusedTeamsParams) middleware.Responder
 	GetTeam(app.GetTeamParams) middleware.Responder
 	GetRepositories(app.GetRepositoriesParams) middleware.Responder
 	GetRepository(app.GetRepositoryParams) middleware.Responder
 	GetStatistics(app.GetStatiticsParams) middleware.Responder
 	GetUnmanaged(app.GetUnmanagedParams) middleware.Responder
+	GetLastChanges(app.GetLastChangesParams) middleware.Responder
+	GetHistory(app.GetHistoryParams) middleware.Responder
+	GetSeatReport(app.GetSeatReportParams) middleware.Responder
+	GetComplianceReport(app.GetComplianceReportParams) middleware.Responder
+	GetDrift(app.GetDriftParams) middleware.Responder
+	GetExpiringAccesses(app.GetExpiringAccessesParams) middleware.Responder
+}
+
+// AppliedChanges are the operations applied by a (successful) run
+type AppliedChanges struct {
+	Timestamp time.Time
+	Changes   config.GoliacChanges
 }
 
 type GoliacServerImpl struct {
@@ -67,7 +95,31 @@ type GoliacServerImpl struct {
 	maxStatistics       config.GoliacStatistics
 	lastTimeToApply     time.Duration
 	maxTimeToApply      time.Duration
+	lastOperations      int64                       // number of operations applied by the last run
+	lastOperationsCount map[string]map[string]int64 // entity type -> created/updated/deleted -> count
+	lastOperationsOrgs  map[string]int64            // number of operations applied by the last run, per organization
 	lastUnmanaged       *engine.UnmanagedResources
+	lastChangesMutex    sync.Mutex
+	lastChanges         []*AppliedChanges // ring buffer of the last runs with changes
+	historyMutex        sync.Mutex        // to serialize the accesses to the history file
+	lastComplianceMutex sync.Mutex        // written by the apply runs, read by the compliance report
+	lastComplianceTime  *time.Time
+	lastCompliance      map[string][]string           // missing required files per repository
+	paused              atomic.Bool                   // when paused, the apply runs are skipped
+	pausedSkipped       atomic.Bool                   // if an apply run was skipped while paused
+	shuttingDown        atomic.Bool                   // when stopping, no new apply run is started
+	consecutiveFailures atomic.Int64                  // number of consecutive failed apply runs
+	circuitOpen         atomic.Bool                   // when open, the automatic apply runs are stopped
+	lastObservedPlan    string                        // observe mode (or outside the apply windows): the last drift notified
+	applyWindows        *ApplyWindows                 // nil: the changes are applied at any time
+	errorNotifications  map[string]*errorNotification // per error message, to not spam the same error
+	errorNotifyMutex    sync.Mutex
+}
+
+// errorNotification tracks when an error was last notified
+type errorNotification struct {
+	lastNotified time.Time
+	suppressed   int // occurrences not notified since lastNotified
 }
 
 func NewGoliacServer(goliac Goliac, notificationService notification.NotificationService) GoliacServer {
@@ -78,6 +130,13 @@ func NewGoliacServer(goliac Goliac, notificationService notification.Notificatio
 		notificationService: notificationService,
 	}
 	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+	server.paused.Store(config.Config.ServerPaused)
+
+	applyWindows, err := ParseApplyWindows(config.Config.ServerApplyWindows, config.Config.ServerApplyWindowsTimezone)
+	if err != nil {
+		logrus.Fatalf("invalid GOLIAC_SERVER_APPLY_WINDOWS: %v", err)
+	}
+	server.applyWindows = applyWindows
 
 	return &server
 }
@@ -106,14 +165,410 @@ func (g *GoliacServerImpl) GetUnmanaged(app.GetUnmanagedParams) middleware.Respo
 		for r := range g.lastUnmanaged.RuleSets {
 			rulesets = append(rulesets, r)
 		}
+		outsideCollaborators := make([]string, 0, len(g.lastUnmanaged.OutsideCollaborators))
+		for u := range g.lastUnmanaged.OutsideCollaborators {
+			outsideCollaborators = append(outsideCollaborators, u)
+		}
 		return app.NewGetUnmanagedOK().WithPayload(&models.Unmanaged{
 			Repos:                  repos,
 			ExternallyManagedTeams: externallyManagedTeams,
 			Teams:                  teams,
 			Users:                  users,
 			Rulesets:               rulesets,
+			OutsideCollaborators:   outsideCollaborators,
+		})
+	}
+}
+
+/*
+operationTimestamp formats the time an operation was recorded
+(empty if unknown, like for the runs persisted by an older version)
+*/
+func operationTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (g *GoliacServerImpl) GetLastChanges(app.GetLastChangesParams) middleware.Responder {
+	g.lastChangesMutex.Lock()
+	defer g.lastChangesMutex.Unlock()
+
+	changes := make(models.Changes, 0, len(g.lastChanges))
+	// most recent first
+	for i := len(g.lastChanges) - 1; i >= 0; i-- {
+		c := g.lastChanges[i]
+		operations := make([]*models.ChangeOperation, 0, len(c.Changes.Operations))
+		for _, o := range c.Changes.Operations {
+			operations = append(operations, &models.ChangeOperation{
+				Command:      o.Command,
+				Detail:       o.Detail,
+				Changes:      o.Changes,
+				Organization: o.Organization,
+				Simulated:    o.Simulated,
+				Timestamp:    operationTimestamp(o.Timestamp),
+			})
+		}
+		changes = append(changes, &models.Change{
+			Author:     c.Changes.Author,
+			Dryrun:     c.Changes.Dryrun,
+			Timestamp:  c.Timestamp.Format(time.RFC3339),
+			Operations: operations,
+		})
+	}
+	return app.NewGetLastChangesOK().WithPayload(changes)
+}
+
+/*
+GetHistory returns the runs (with changes) persisted in the history file
+(GOLIAC_SERVER_HISTORY_FILE), most recent first
+*/
+func (g *GoliacServerImpl) GetHistory(app.GetHistoryParams) middleware.Responder {
+	if config.Config.ServerHistoryFile == "" {
+		return app.NewGetHistoryOK().WithPayload(models.Changes{})
+	}
+
+	g.historyMutex.Lock()
+	entries, err := readHistory(config.Config.ServerHistoryFile)
+	g.historyMutex.Unlock()
+	if err != nil {
+		message := err.Error()
+		return app.NewGetHistoryDefault(500).WithPayload(&models.Error{Message: &message})
+	}
+
+	changes := make(models.Changes, 0, len(entries))
+	// most recent first
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		operations := make([]*models.ChangeOperation, 0, len(e.Operations))
+		for _, o := range e.Operations {
+			operations = append(operations, &models.ChangeOperation{
+				Command:      o.Command,
+				Detail:       o.Detail,
+				Changes:      o.Changes,
+				Organization: o.Organization,
+				Simulated:    o.Simulated,
+				Timestamp:    operationTimestamp(o.Timestamp),
+			})
+		}
+		changes = append(changes, &models.Change{
+			Author:     e.Author,
+			Dryrun:     e.Dryrun,
+			Timestamp:  e.Timestamp.Format(time.RFC3339),
+			Operations: operations,
 		})
 	}
+	return app.NewGetHistoryOK().WithPayload(changes)
+}
+
+/*
+notifyDestructiveOperations sends a notification for each team or repository
+deleted by a run, with the reason given for it
+*/
+func (g *GoliacServerImpl) notifyDestructiveOperations(changes *config.GoliacChanges) {
+	for _, o := range changes.Operations {
+		if !o.Destructive {
+			continue
+		}
+		reason := o.Reason
+		if reason == "" {
+			reason = "no reason given"
+		}
+		if err := g.notificationService.SendNotification(fmt.Sprintf("Goliac %s (%s)%s by %s: %s", o.Command, o.Detail, g.operationOrganization(o), changes.Author, reason)); err != nil {
+			logrus.Error(err)
+		}
+	}
+}
+
+/*
+notifyWarnings sends a notification for each warning raised by a run
+(like a visibility flip-flop)
+*/
+func (g *GoliacServerImpl) notifyWarnings(changes *config.GoliacChanges) {
+	for _, w := range changes.Warnings {
+		if err := g.notificationService.SendNotification(fmt.Sprintf("Goliac warning: %s", w)); err != nil {
+			logrus.Error(err)
+		}
+	}
+}
+
+/*
+operationOrganization returns " in <organization>" for the operations of a
+Goliac reconciling several Github organizations, and "" else
+*/
+func (g *GoliacServerImpl) operationOrganization(o config.GoliacOperation) string {
+	if o.Organization == "" || len(g.goliac.GetOrganizationsErrors()) == 0 {
+		return ""
+	}
+	return " in " + o.Organization
+}
+
+/*
+notifyObservedDrift sends a notification with the plan of a run in observe
+mode (or outside the apply windows), unless the same plan has already been
+notified by a previous run
+*/
+func (g *GoliacServerImpl) notifyObservedDrift(changes *config.GoliacChanges, outsideWindow bool) {
+	lines := make([]string, 0, len(changes.Operations))
+	for _, o := range changes.Operations {
+		lines = append(lines, fmt.Sprintf("- %s (%s)%s", o.Command, o.Detail, g.operationOrganization(o)))
+	}
+	plan := strings.Join(lines, "\n")
+	if plan == g.lastObservedPlan {
+		return
+	}
+	g.lastObservedPlan = plan
+	if len(lines) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Goliac (observe mode) detected %d changes, not applied:\n%s", len(lines), plan)
+	if outsideWindow {
+		message = fmt.Sprintf("Goliac detected %d changes outside the apply windows, queued until the next window (%s):\n%s", len(lines), g.applyWindows.Next(time.Now()).Format(time.RFC3339), plan)
+	}
+	if err := g.notificationService.SendNotification(message); err != nil {
+		logrus.Error(err)
+	}
+}
+
+/*
+addLastChanges keeps the changes applied by a run, in a ring buffer
+of GOLIAC_SERVER_CHANGES_HISTORY runs (and appends them to the
+GOLIAC_SERVER_HISTORY_FILE history file if set)
+*/
+func (g *GoliacServerImpl) addLastChanges(timestamp time.Time, changes *config.GoliacChanges) {
+	if len(changes.Operations) == 0 {
+		return
+	}
+	if config.Config.ServerHistoryFile != "" {
+		g.historyMutex.Lock()
+		if err := appendHistory(config.Config.ServerHistoryFile, timestamp, changes); err != nil {
+			logrus.Error(err)
+		}
+		g.historyMutex.Unlock()
+	}
+	if config.Config.ServerChangesHistory <= 0 {
+		return
+	}
+	g.lastChangesMutex.Lock()
+	defer g.lastChangesMutex.Unlock()
+
+	g.lastChanges = append(g.lastChanges, &AppliedChanges{
+		Timestamp: timestamp,
+		Changes:   *changes,
+	})
+	if len(g.lastChanges) > config.Config.ServerChangesHistory {
+		g.lastChanges = g.lastChanges[len(g.lastChanges)-config.Config.ServerChangesHistory:]
+	}
+}
+
+/*
+operationsBreakdown counts the operations applied by a run,
+per entity type (user, team, repository, ruleset, organization)
+and per action (created, updated, deleted)
+*/
+func operationsBreakdown(operations []config.GoliacOperation) map[string]map[string]int64 {
+	breakdown := make(map[string]map[string]int64)
+	for _, o := range operations {
+		var action string
+		switch strings.SplitN(o.Command, "_", 2)[0] {
+		case "add", "create":
+			action = "created"
+		case "delete", "remove":
+			action = "deleted"
+		default:
+			action = "updated"
+		}
+
+		var entityType string
+		switch {
+		case strings.Contains(o.Command, "ruleset"):
+			entityType = "ruleset"
+		case strings.Contains(o.Command, "repository"):
+			entityType = "repository"
+		case strings.Contains(o.Command, "team"):
+			entityType = "team"
+		case strings.Contains(o.Command, "user"):
+			entityType = "user"
+		default:
+			entityType = "organization"
+		}
+
+		if _, ok := breakdown[entityType]; !ok {
+			breakdown[entityType] = map[string]int64{"created": 0, "updated": 0, "deleted": 0}
+		}
+		breakdown[entityType][action]++
+	}
+	return breakdown
+}
+
+/*
+usersTeams returns, for each user, the teams it belongs to (as owner or member).
+Members of externally managed teams are fetched from the remote.
+Orphaned users (users not part of any team) are not in the map
+*/
+func usersTeams(local engine.GoliacLocalResources, remote engine.GoliacRemoteResources) map[string][]string {
+	githubidToUser := make(map[string]string)
+	for username, u := range local.Users() {
+		githubidToUser[u.Spec.GithubID] = username
+	}
+	var rTeams map[string]*engine.GithubTeam
+	if remote != nil {
+		rTeams = remote.Teams(context.TODO(), true)
+	}
+
+	userTeams := make(map[string][]string)
+	for teamname, team := range local.Teams() {
+		members := make(map[string]bool)
+		for _, owner := range team.Spec.Owners {
+			members[owner] = true
+		}
+		for _, member := range team.Spec.Members {
+			members[member] = true
+		}
+		if team.Spec.ExternallyManaged {
+			if t, ok := rTeams[engine.TeamSlug(teamname)]; ok {
+				for _, githubid := range t.Members {
+					if username, ok := githubidToUser[githubid]; ok {
+						members[username] = true
+					}
+				}
+			}
+		}
+		for username := range members {
+			if _, ok := local.Users()[username]; ok {
+				userTeams[username] = append(userTeams[username], teamname)
+			}
+		}
+	}
+	return userTeams
+}
+
+/*
+GetSeatReport returns the number of seats consumed by the users managed by Goliac,
+the number of seats per team, and the inactive users (that are not part of any team)
+*/
+func (g *GoliacServerImpl) GetSeatReport(app.GetSeatReportParams) middleware.Responder {
+	local := g.goliac.GetLocal()
+	userTeams := usersTeams(local, g.goliac.GetRemote())
+
+	report := models.SeatReport{
+		TotalSeats:    int64(len(local.Users())),
+		InactiveUsers: make([]string, 0),
+		Teams:         make([]*models.SeatReportTeam, 0),
+	}
+
+	teamSeats := make(map[string]int64)
+	for username := range local.Users() {
+		teams, ok := userTeams[username]
+		if !ok {
+			report.InactiveUsers = append(report.InactiveUsers, username)
+			continue
+		}
+		for _, teamname := range teams {
+			teamSeats[teamname]++
+		}
+	}
+	sort.Strings(report.InactiveUsers)
+	report.InactiveSeats = int64(len(report.InactiveUsers))
+	report.ActiveSeats = report.TotalSeats - report.InactiveSeats
+
+	for teamname := range local.Teams() {
+		report.Teams = append(report.Teams, &models.SeatReportTeam{
+			Name:  teamname,
+			Seats: teamSeats[teamname],
+		})
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		return report.Teams[i].Name < report.Teams[j].Name
+	})
+
+	return app.NewGetSeatReportOK().WithPayload(&report)
+}
+
+func (g *GoliacServerImpl) GetComplianceReport(app.GetComplianceReportParams) middleware.Responder {
+	g.lastComplianceMutex.Lock()
+	defer g.lastComplianceMutex.Unlock()
+
+	report := models.ComplianceReport{
+		Repositories: make([]*models.ComplianceRepository, 0, len(g.lastCompliance)),
+	}
+	if g.lastComplianceTime != nil {
+		report.LastCheckTime = g.lastComplianceTime.UTC().Format("2006-01-02T15:04:05")
+	}
+	for reponame, missingFiles := range g.lastCompliance {
+		report.Repositories = append(report.Repositories, &models.ComplianceRepository{
+			Name:         reponame,
+			MissingFiles: missingFiles,
+		})
+	}
+	sort.Slice(report.Repositories, func(i, j int) bool {
+		return report.Repositories[i].Name < report.Repositories[j].Name
+	})
+	return app.NewGetComplianceReportOK().WithPayload(&report)
+}
+
+/*
+GetDrift returns the managed entities differing from the teams repository: the
+reconciliation is run (dry-run) against the cached Github state. It is rejected
+while a reconciliation is running (it shares the Github cache)
+*/
+func (g *GoliacServerImpl) GetDrift(params app.GetDriftParams) middleware.Responder {
+	if !g.ready {
+		message := "Not yet ready, loading local state"
+		return app.NewGetDriftDefault(503).WithPayload(&models.Error{Message: &message})
+	}
+
+	g.applyLobbyMutex.Lock()
+	if g.applyCurrent {
+		g.applyLobbyMutex.Unlock()
+		message := "a reconciliation is currently running"
+		return app.NewGetDriftDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+	g.applyCurrent = true
+	g.applyLobbyMutex.Unlock()
+	defer g.releaseApply()
+
+	drifts, err := g.goliac.GetDrift(context.Background(), config.Config.ServerGitRepository)
+	if err != nil {
+		message := fmt.Sprintf("Not able to compute the drift: %v", err)
+		return app.NewGetDriftDefault(500).WithPayload(&models.Error{Message: &message})
+	}
+
+	// the drift can be rendered as a unified-diff-like text (for a code review)
+	if params.HTTPRequest != nil && middleware.NegotiateContentType(params.HTTPRequest, []string{runtime.JSONMime, runtime.TextMime}, runtime.JSONMime) == runtime.TextMime {
+		diff := engine.RenderDriftDiff(drifts)
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+			rw.Header().Set(runtime.HeaderContentType, runtime.TextMime)
+			rw.WriteHeader(http.StatusOK)
+			if _, err := rw.Write([]byte(diff)); err != nil {
+				logrus.Errorf("not able to write the drift: %v", err)
+			}
+		})
+	}
+
+	payload := make(models.Drift, 0, len(drifts))
+	for _, d := range drifts {
+		operations := make([]*models.ChangeOperation, 0, len(d.Operations))
+		for _, o := range d.Operations {
+			operations = append(operations, &models.ChangeOperation{
+				Command:      o.Command,
+				Detail:       o.Detail,
+				Changes:      o.Changes,
+				Organization: o.Organization,
+				Simulated:    o.Simulated,
+				Skipped:      o.Skipped,
+				Timestamp:    operationTimestamp(o.Timestamp),
+			})
+		}
+		payload = append(payload, &models.EntityDrift{
+			EntityType: d.EntityType,
+			Name:       d.Name,
+			Operations: operations,
+		})
+	}
+	return app.NewGetDriftOK().WithPayload(payload)
 }
 
 func (g *GoliacServerImpl) GetStatistics(app.GetStatiticsParams) middleware.Responder {
@@ -133,9 +588,10 @@ func (g *GoliacServerImpl) GetRepositories(app.GetRepositoriesParams) middleware
 
 	for _, r := range local.Repositories() {
 		repo := models.Repository{
-			Name:     r.Name,
-			Public:   r.Spec.IsPublic,
-			Archived: r.Archived,
+			Name:      r.Name,
+			Public:    r.Spec.IsPublic,
+			Archived:  r.Archived,
+			Immutable: r.Immutable,
 		}
 		repositories = append(repositories, &repo)
 	}
@@ -195,6 +651,14 @@ func (g *GoliacServerImpl) GetRepository(params app.GetRepositoryParams) middlew
 		collaborators = append(collaborators, &collaborator)
 	}
 
+	for _, r := range repository.Spec.ExternalUserAdmins {
+		collaborator := models.RepositoryDetailsCollaboratorsItems0{
+			Name:   r,
+			Access: "admin",
+		}
+		collaborators = append(collaborators, &collaborator)
+	}
+
 	repositoryDetails := models.RepositoryDetails{
 		Name:                repository.Name,
 		Public:              repository.Spec.IsPublic,
@@ -202,10 +666,31 @@ func (g *GoliacServerImpl) GetRepository(params app.GetRepositoryParams) middlew
 		DeleteBranchOnMerge: repository.Spec.DeleteBranchOnMerge,
 		AllowUpdateBranch:   repository.Spec.AllowUpdateBranch,
 		Archived:            repository.Archived,
+		Immutable:           repository.Immutable,
 		Teams:               teams,
 		Collaborators:       collaborators,
 	}
 
+	if params.IncludeDrift != nil && *params.IncludeDrift {
+		drift, err := g.goliac.GetRepositoryDrift(context.TODO(), config.Config.ServerGitRepository, params.RepositoryID)
+		if err != nil {
+			message := fmt.Sprintf("Not able to compute the drift of the repository %s: %v", params.RepositoryID, err)
+			return app.NewGetRepositoryDefault(500).WithPayload(&models.Error{Message: &message})
+		}
+		fields := make([]*models.RepositoryDriftFieldsItems0, 0, len(drift))
+		for _, f := range drift {
+			fields = append(fields, &models.RepositoryDriftFieldsItems0{
+				Name:   f.Name,
+				Local:  f.Local,
+				Remote: f.Remote,
+			})
+		}
+		repositoryDetails.Drift = &models.RepositoryDrift{
+			InSync: len(fields) == 0,
+			Fields: fields,
+		}
+	}
+
 	return app.NewGetRepositoryOK().WithPayload(&repositoryDetails)
 }
 
@@ -233,7 +718,7 @@ func (g *GoliacServerImpl) GetTeams(app.GetTeamsParams) middleware.Responder {
 		if team.Spec.ExternallyManaged {
 			rteams := remote.Teams(context.TODO(), true)
 			if rteams != nil {
-				teamSlug := slug.Make(team.Name)
+				teamSlug := engine.TeamSlug(team.Name)
 				if team, ok := rteams[teamSlug]; ok {
 					for _, u := range team.Members {
 						// u is the githubid
@@ -261,6 +746,112 @@ func (g *GoliacServerImpl) GetTeams(app.GetTeamsParams) middleware.Responder {
 	return app.NewGetTeamsOK().WithPayload(teams)
 }
 
+/*
+ * GetExpiringAccesses returns the external users accesses to the repositories
+ * expiring within the next days (30 by default), to renew them before they are
+ * removed. The expired ones still declared are returned too
+ */
+func (g *GoliacServerImpl) GetExpiringAccesses(params app.GetExpiringAccessesParams) middleware.Responder {
+	days := int64(30)
+	if params.Days != nil {
+		days = *params.Days
+	}
+
+	accesses := make(models.ExpiringAccesses, 0)
+	for _, e := range g.goliac.GetExpiringAccesses(time.Duration(days) * 24 * time.Hour) {
+		accesses = append(accesses, &models.ExpiringAccess{
+			Repository: e.Repository,
+			Username:   e.Username,
+			Githubid:   e.GithubID,
+			Permission: e.Permission,
+			ExpiresAt:  e.ExpiresAt.Format(entity.ExpiresAtLayout),
+			Expired:    e.Expired,
+		})
+	}
+	return app.NewGetExpiringAccessesOK().WithPayload(accesses)
+}
+
+/*
+ * GetUnusedTeams returns the local teams without any repository access (neither
+ * owner, reader nor writer) nor subteam. They are only reported, not removed
+ */
+func (g *GoliacServerImpl) GetUnusedTeams(app.GetUnusedTeamsParams) middleware.Responder {
+	local := g.goliac.GetLocal()
+
+	teams := make(models.Teams, 0)
+	for _, teamname := range g.goliac.GetUnusedTeams() {
+		team, ok := local.Teams()[teamname]
+		if !ok {
+			continue
+		}
+		t := models.Team{
+			Name:    teamname,
+			Members: team.Spec.Members,
+			Owners:  team.Spec.Owners,
+			Path:    teamname,
+		}
+
+		// prevent any issue, but it shoudn't happen
+		maxRec := 100
+		for team.ParentTeam != nil && maxRec > 0 {
+			parentName := *team.ParentTeam
+			team = local.Teams()[parentName]
+			t.Path = parentName + "/" + t.Path
+			maxRec--
+		}
+		teams = append(teams, &t)
+	}
+	return app.NewGetUnusedTeamsOK().WithPayload(teams)
+}
+
+/*
+ * GetTeamsTree returns the local teams as a tree: the subteams are attached to
+ * their parent team, as well as the owners team Goliac creates for each team
+ */
+func (g *GoliacServerImpl) GetTeamsTree(app.GetTeamsTreeParams) middleware.Responder {
+	lTeams := g.goliac.GetLocal().Teams()
+
+	nodes := make(map[string]*models.TeamTreeNode)
+	teamnames := make([]string, 0, len(lTeams))
+	for teamname, team := range lTeams {
+		nodes[teamname] = &models.TeamTreeNode{
+			Name:              teamname,
+			Members:           team.Spec.Members,
+			Owners:            team.Spec.Owners,
+			ExternallyManaged: team.Spec.ExternallyManaged,
+			OwnersTeam:        engine.TeamSlug(teamname) + config.Config.GoliacTeamOwnerSuffix,
+			Children:          []*models.TeamTreeNode{},
+		}
+		teamnames = append(teamnames, teamname)
+	}
+	sort.Strings(teamnames)
+
+	tree := make(models.TeamsTree, 0)
+	for _, teamname := range teamnames {
+		node := nodes[teamname]
+		if parentTeam := lTeams[teamname].ParentTeam; parentTeam != nil {
+			if parent, ok := nodes[*parentTeam]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		tree = append(tree, node)
+	}
+	setTeamsTreePath(tree, "")
+
+	return app.NewGetTeamsTreeOK().WithPayload(tree)
+}
+
+func setTeamsTreePath(nodes []*models.TeamTreeNode, parentPath string) {
+	for _, node := range nodes {
+		node.Path = node.Name
+		if parentPath != "" {
+			node.Path = parentPath + "/" + node.Name
+		}
+		setTeamsTreePath(node.Children, node.Path)
+	}
+}
+
 func (g *GoliacServerImpl) GetTeam(params app.GetTeamParams) middleware.Responder {
 	local := g.goliac.GetLocal()
 
@@ -294,6 +885,7 @@ func (g *GoliacServerImpl) GetTeam(params app.GetTeamParams) middleware.Responde
 		r := models.Repository{
 			Name:                reponame,
 			Archived:            repo.Archived,
+			Immutable:           repo.Immutable,
 			Public:              repo.Spec.IsPublic,
 			AutoMergeAllowed:    repo.Spec.AllowAutoMerge,
 			DeleteBranchOnMerge: repo.Spec.DeleteBranchOnMerge,
@@ -360,7 +952,7 @@ func (g *GoliacServerImpl) GetTeam(params app.GetTeamParams) middleware.Responde
 	if team.Spec.ExternallyManaged {
 		teams := remote.Teams(context.TODO(), true)
 		if teams != nil {
-			teamSlug := slug.Make(team.Name)
+			teamSlug := engine.TeamSlug(team.Name)
 			if t, ok := teams[teamSlug]; ok {
 				for _, t := range t.Members {
 					// t is the githubid
@@ -431,18 +1023,30 @@ func (g *GoliacServerImpl) GetCollaborator(params app.GetCollaboratorParams) mid
 		for _, r := range repo.Spec.ExternalUserReaders {
 			if r == params.CollaboratorID {
 				collaboratordetails.Repositories = append(collaboratordetails.Repositories, &models.Repository{
-					Name:     repo.Name,
-					Public:   repo.Spec.IsPublic,
-					Archived: repo.Archived,
+					Name:      repo.Name,
+					Public:    repo.Spec.IsPublic,
+					Archived:  repo.Archived,
+					Immutable: repo.Immutable,
 				})
 			}
 		}
 		for _, r := range repo.Spec.ExternalUserWriters {
 			if r == params.CollaboratorID {
 				collaboratordetails.Repositories = append(collaboratordetails.Repositories, &models.Repository{
-					Name:     repo.Name,
-					Public:   repo.Spec.IsPublic,
-					Archived: repo.Archived,
+					Name:      repo.Name,
+					Public:    repo.Spec.IsPublic,
+					Archived:  repo.Archived,
+					Immutable: repo.Immutable,
+				})
+			}
+		}
+		for _, r := range repo.Spec.ExternalUserAdmins {
+			if r == params.CollaboratorID {
+				collaboratordetails.Repositories = append(collaboratordetails.Repositories, &models.Repository{
+					Name:      repo.Name,
+					Public:    repo.Spec.IsPublic,
+					Archived:  repo.Archived,
+					Immutable: repo.Immutable,
 				})
 			}
 		}
@@ -480,80 +1084,153 @@ func (g *GoliacServerImpl) GetUser(params app.GetUserParams) middleware.Responde
 		Repositories: make([]*models.Repository, 0),
 	}
 
-	// [teamname]team
-	userTeams := make(map[string]*models.Team)
-	for teamname, team := range local.Teams() {
-		for _, owner := range team.Spec.Owners {
-			if owner == params.UserID {
-				team := models.Team{
-					Name:    teamname,
-					Members: team.Spec.Members,
-					Owners:  team.Spec.Owners,
-				}
-				userTeams[teamname] = &team
-				break
-			}
+	for _, teamname := range userTeams(local, params.UserID) {
+		team := local.Teams()[teamname]
+		userdetails.Teams = append(userdetails.Teams, &models.Team{
+			Name:    teamname,
+			Members: team.Spec.Members,
+			Owners:  team.Spec.Owners,
+		})
+	}
+
+	// [reponame]repo
+	teamsRepos := teamsRepositoriesPermissions(local)
+	userRepos := make(map[string]*entity.Repository)
+	for _, team := range userdetails.Teams {
+		for reponame := range teamsRepos[team.Name] {
+			userRepos[reponame] = local.Repositories()[reponame]
 		}
-		for _, member := range team.Spec.Members {
-			if member == params.UserID {
-				team := models.Team{
-					Name:    teamname,
-					Members: team.Spec.Members,
-					Owners:  team.Spec.Owners,
-				}
-				userTeams[teamname] = &team
-				break
-			}
+	}
+
+	for _, r := range userRepos {
+		repo := models.Repository{
+			Name:      r.Name,
+			Public:    r.Spec.IsPublic,
+			Archived:  r.Archived,
+			Immutable: r.Immutable,
 		}
+		userdetails.Repositories = append(userdetails.Repositories, &repo)
 	}
 
-	for _, t := range userTeams {
-		userdetails.Teams = append(userdetails.Teams, t)
+	return app.NewGetUserOK().WithPayload(&userdetails)
+}
+
+/*
+ * userTeams returns the (sorted) teams the user is an owner or a member of
+ */
+func userTeams(local engine.GoliacLocalResources, username string) []string {
+	teams := []string{}
+	for teamname, team := range local.Teams() {
+		if slices.Contains(team.Spec.Owners, username) || slices.Contains(team.Spec.Members, username) {
+			teams = append(teams, teamname)
+		}
 	}
+	sort.Strings(teams)
+	return teams
+}
 
-	// let's sort repo per team
-	teamRepo := make(map[string]map[string]*entity.Repository)
-	for _, repo := range local.Repositories() {
+/*
+ * teamsRepositoriesPermissions returns the permission (admin, maintain, push,
+ * triage, pull) of the teams on the repositories, by team name and repository
+ * name. The owner team has the push permission (at least), and a team listed
+ * several times gets the strongest permission
+ */
+func teamsRepositoriesPermissions(local engine.GoliacLocalResources) map[string]map[string]string {
+	teamsRepos := make(map[string]map[string]string)
+	setPermission := func(teamname string, reponame string, permission string) {
+		if _, ok := teamsRepos[teamname]; !ok {
+			teamsRepos[teamname] = make(map[string]string)
+		}
+		if engine.TeamRepoPermissionLevel(permission) > engine.TeamRepoPermissionLevel(teamsRepos[teamname][reponame]) {
+			teamsRepos[teamname][reponame] = permission
+		}
+	}
+	for reponame, repo := range local.Repositories() {
 		if repo.Owner != nil {
-			if _, ok := teamRepo[*repo.Owner]; !ok {
-				teamRepo[*repo.Owner] = make(map[string]*entity.Repository)
-			}
-			teamRepo[*repo.Owner][repo.Name] = repo
+			setPermission(*repo.Owner, reponame, "push")
 		}
-		for _, r := range repo.Spec.Readers {
-			if _, ok := teamRepo[r]; !ok {
-				teamRepo[r] = make(map[string]*entity.Repository)
+		for permission, teams := range map[string][]string{
+			"admin":    repo.Spec.Admins,
+			"maintain": repo.Spec.Maintainers,
+			"push":     repo.Spec.Writers,
+			"triage":   repo.Spec.Triagers,
+			"pull":     repo.Spec.Readers,
+		} {
+			for _, teamname := range teams {
+				setPermission(teamname, reponame, permission)
 			}
-			teamRepo[r][repo.Name] = repo
 		}
-		for _, w := range repo.Spec.Writers {
-			if _, ok := teamRepo[w]; !ok {
-				teamRepo[w] = make(map[string]*entity.Repository)
-			}
-			teamRepo[w][repo.Name] = repo
+	}
+	return teamsRepos
+}
+
+/*
+ * GetUserRepositoryAccess returns the effective permission of a user (or an
+ * external user) on a repository: the strongest of the permissions granted to
+ * the teams it belongs to (directly, or through a parent team) and of the access
+ * granted to it as an external user. It only reads the local state
+ */
+func (g *GoliacServerImpl) GetUserRepositoryAccess(params app.GetUserRepositoryAccessParams) middleware.Responder {
+	local := g.goliac.GetLocal()
+
+	user, found := local.Users()[params.UserID]
+	if !found {
+		user, found = local.ExternalUsers()[params.UserID]
+	}
+	if !found {
+		message := fmt.Sprintf("User %s not found", params.UserID)
+		return app.NewGetUserRepositoryAccessDefault(404).WithPayload(&models.Error{Message: &message})
+	}
+	repo, found := local.Repositories()[params.RepositoryID]
+	if !found {
+		message := fmt.Sprintf("Repository %s not found", params.RepositoryID)
+		return app.NewGetUserRepositoryAccessDefault(404).WithPayload(&models.Error{Message: &message})
+	}
+
+	access := models.UserRepositoryAccess{
+		User:       params.UserID,
+		Repository: params.RepositoryID,
+		Permission: "none",
+		Grants:     make([]*models.UserRepositoryGrant, 0),
+	}
+	addGrant := func(source string, name string, permission string) {
+		access.Grants = append(access.Grants, &models.UserRepositoryGrant{
+			Source:     source,
+			Name:       name,
+			Permission: permission,
+		})
+		if engine.TeamRepoPermissionLevel(permission) > engine.TeamRepoPermissionLevel(access.Permission) {
+			access.Permission = permission
 		}
 	}
 
-	// [reponame]repo
-	userRepos := make(map[string]*entity.Repository)
-	for _, team := range userdetails.Teams {
-		if repositories, ok := teamRepo[team.Name]; ok {
-			for n, r := range repositories {
-				userRepos[n] = r
+	// the teams of the user (and their parent teams, as Github child teams inherit their accesses)
+	teamsRepos := teamsRepositoriesPermissions(local)
+	granted := make(map[string]bool)
+	for _, teamname := range userTeams(local, params.UserID) {
+		// (we limit the depth in case of a loop)
+		for depth := 0; teamname != "" && depth <= len(local.Teams()); depth++ {
+			if permission, ok := teamsRepos[teamname][params.RepositoryID]; ok && !granted[teamname] {
+				granted[teamname] = true
+				addGrant("team", teamname, permission)
+			}
+			team, ok := local.Teams()[teamname]
+			if !ok || team.ParentTeam == nil {
+				break
 			}
+			teamname = *team.ParentTeam
 		}
 	}
 
-	for _, r := range userRepos {
-		repo := models.Repository{
-			Name:     r.Name,
-			Public:   r.Spec.IsPublic,
-			Archived: r.Archived,
+	// the access as an external user
+	if _, ok := local.ExternalUsers()[params.UserID]; ok {
+		permissions := engine.RepositoryExternalUsersPermissions(local.ExternalUsers(), params.RepositoryID, repo)
+		if permission, ok := permissions[strings.ToLower(user.Spec.GithubID)]; ok {
+			addGrant("external", params.UserID, permission)
 		}
-		userdetails.Repositories = append(userdetails.Repositories, &repo)
 	}
 
-	return app.NewGetUserOK().WithPayload(&userdetails)
+	return app.NewGetUserRepositoryAccessOK().WithPayload(&access)
 }
 
 func (g *GoliacServerImpl) GetStatus(app.GetStatusParams) middleware.Responder {
@@ -564,6 +1241,9 @@ func (g *GoliacServerImpl) GetStatus(app.GetStatusParams) middleware.Responder {
 		NbTeams:          int64(len(g.goliac.GetLocal().Teams())),
 		NbUsers:          int64(len(g.goliac.GetLocal().Users())),
 		NbUsersExternal:  int64(len(g.goliac.GetLocal().ExternalUsers())),
+		Paused:           g.paused.Load(),
+		ObserveOnly:      config.Config.ServerObserveOnly,
+		CircuitOpen:      g.circuitOpen.Load(),
 		Version:          config.GoliacBuildVersion,
 		DetailedErrors:   make([]string, 0),
 		DetailedWarnings: make([]string, 0),
@@ -584,6 +1264,36 @@ func (g *GoliacServerImpl) GetStatus(app.GetStatusParams) middleware.Responder {
 	if g.lastSyncTime != nil {
 		s.LastSyncTime = g.lastSyncTime.UTC().Format("2006-01-02T15:04:05")
 	}
+	if g.lastOperationsCount != nil {
+		s.LastSyncOperations = g.lastOperations
+		s.LastSyncOperationsBreakdown = g.lastOperationsCount
+		s.LastSyncDurationMs = g.lastTimeToApply.Milliseconds()
+	}
+
+	// when several Github organizations are reconciled, the status of each one
+	organizationsErrors := g.goliac.GetOrganizationsErrors()
+	if len(organizationsErrors) > 0 {
+		s.Organizations = []*models.StatusOrganization{{
+			Name:               config.Config.GithubAppOrganization,
+			LastSyncError:      s.LastSyncError,
+			LastSyncOperations: g.lastOperationsOrgs[config.Config.GithubAppOrganization],
+		}}
+		organizations := make([]string, 0, len(organizationsErrors))
+		for organization := range organizationsErrors {
+			organizations = append(organizations, organization)
+		}
+		sort.Strings(organizations)
+		for _, organization := range organizations {
+			status := models.StatusOrganization{
+				Name:               organization,
+				LastSyncOperations: g.lastOperationsOrgs[organization],
+			}
+			if err := organizationsErrors[organization]; err != nil {
+				status.LastSyncError = err.Error()
+			}
+			s.Organizations = append(s.Organizations, &status)
+		}
+	}
 	return app.NewGetStatusOK().WithPayload(&s)
 }
 
@@ -600,20 +1310,249 @@ func (g *GoliacServerImpl) GetReadiness(params health.GetReadinessParams) middle
 	}
 }
 
+const (
+	HEALTH_OK      = "OK"
+	HEALTH_KO      = "KO"
+	HEALTH_UNKNOWN = "UNKNOWN"
+	// maximum time to wait for the teams repository and the Github API
+	HEALTH_CHECK_TIMEOUT = 10 * time.Second
+)
+
+func healthCheck(err error, okMessage string) *models.HealthCheck {
+	if err != nil {
+		return &models.HealthCheck{Status: HEALTH_KO, Message: err.Error()}
+	}
+	return &models.HealthCheck{Status: HEALTH_OK, Message: okMessage}
+}
+
+/*
+GetHealth reports the status of each dependency separately (the local state,
+the teams git repository, the Github API and the last apply), where the
+readiness only tells if the local state is loaded
+*/
+func (g *GoliacServerImpl) GetHealth(params health.GetHealthParams) middleware.Responder {
+	ctx, cancel := context.WithTimeout(context.Background(), HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+
+	h := models.HealthDetails{}
+
+	if g.ready {
+		h.LocalState = &models.HealthCheck{Status: HEALTH_OK, Message: "local state loaded"}
+	} else {
+		h.LocalState = &models.HealthCheck{Status: HEALTH_KO, Message: "not yet loaded"}
+	}
+
+	// the network checks are run concurrently
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		h.TeamsRepository = healthCheck(g.goliac.CheckTeamsRepository(ctx, config.Config.ServerGitRepository), "reachable")
+	}()
+	go func() {
+		defer wg.Done()
+		h.GithubAPI = healthCheck(g.goliac.CheckGithubAPI(ctx), "reachable")
+	}()
+	wg.Wait()
+
+	if g.lastSyncTime == nil {
+		h.LastApply = &models.HealthCheck{Status: HEALTH_UNKNOWN, Message: "no apply yet"}
+	} else {
+		h.LastApply = healthCheck(g.lastSyncError, "applied at "+g.lastSyncTime.UTC().Format("2006-01-02T15:04:05"))
+	}
+
+	h.Status = HEALTH_OK
+	for _, check := range []*models.HealthCheck{h.LocalState, h.TeamsRepository, h.GithubAPI, h.LastApply} {
+		if check.Status == HEALTH_KO {
+			h.Status = HEALTH_KO
+		}
+	}
+	if h.Status != HEALTH_OK {
+		return health.NewGetHealthServiceUnavailable().WithPayload(&h)
+	}
+	return health.NewGetHealthOK().WithPayload(&h)
+}
+
 func (g *GoliacServerImpl) PostFlushCache(app.PostFlushCacheParams) middleware.Responder {
 	g.goliac.FlushCache()
 	return app.NewPostFlushCacheOK()
 }
 
 func (g *GoliacServerImpl) PostResync(app.PostResyncParams) middleware.Responder {
+	g.closeCircuit()
 	go g.triggerApply()
 	return app.NewPostResyncOK()
 }
 
+/*
+PostApply runs an apply (queued in the lobby if one is running) and waits for it,
+returning the applied operations. With force, it applies even outside the apply windows.
+If it succeeds, it closes the circuit breaker
+*/
+func (g *GoliacServerImpl) PostApply(params app.PostApplyParams) middleware.Responder {
+	type applyResult struct {
+		err     error
+		errs    []error
+		warns   []entity.Warning
+		applied bool
+	}
+	changes := &config.GoliacChanges{}
+	// buffered: the apply can finish after the request timed out
+	result := make(chan applyResult, 1)
+	go func() {
+		force := params.Force != nil && *params.Force
+		err, errs, warns, applied := g.serveApplyChanges(changes, force)
+		g.recordApply(err, errs, warns, applied)
+		// a successful apply resumes the automatic applies
+		if err == nil && applied {
+			g.closeCircuit()
+		}
+		result <- applyResult{err, errs, warns, applied}
+	}()
+
+	timeout := time.Duration(config.Config.ServerSyncApplyTimeout) * time.Second
+	select {
+	case r := <-result:
+		if r.err != nil {
+			message := r.err.Error()
+			return app.NewPostApplyDefault(500).WithPayload(&models.Error{Message: &message})
+		}
+		if !r.applied {
+			message := "the apply was skipped (paused, stopping, or another apply is already queued)"
+			return app.NewPostApplyDefault(409).WithPayload(&models.Error{Message: &message})
+		}
+		operations := make([]*models.ChangeOperation, 0, len(changes.Operations))
+		for _, o := range changes.Operations {
+			operations = append(operations, &models.ChangeOperation{
+				Command:      o.Command,
+				Detail:       o.Detail,
+				Changes:      o.Changes,
+				Organization: o.Organization,
+				Reason:       o.Reason,
+				Simulated:    o.Simulated,
+				Skipped:      o.Skipped,
+				Timestamp:    operationTimestamp(o.Timestamp),
+			})
+		}
+		return app.NewPostApplyOK().WithPayload(&models.Change{
+			Author:     changes.Author,
+			Dryrun:     changes.Dryrun,
+			Timestamp:  time.Now().Format(time.RFC3339),
+			Operations: operations,
+		})
+	case <-time.After(timeout):
+		message := fmt.Sprintf("the apply didn't finish in %s (it is still running)", timeout)
+		return app.NewPostApplyDefault(504).WithPayload(&models.Error{Message: &message})
+	}
+}
+
+/*
+PostResyncTeam reloads one team from Github and syncs it (members and repositories access).
+It is rejected while a full apply is running (and a full apply waits for it)
+*/
+func (g *GoliacServerImpl) PostResyncTeam(params app.PostResyncTeamParams) middleware.Responder {
+	found := false
+	for teamname := range g.goliac.GetLocal().Teams() {
+		if engine.TeamSlug(teamname) == params.TeamID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		message := fmt.Sprintf("Team %s not found", params.TeamID)
+		return app.NewPostResyncTeamDefault(404).WithPayload(&models.Error{Message: &message})
+	}
+
+	if g.paused.Load() {
+		message := "reconciliation is paused"
+		return app.NewPostResyncTeamDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+
+	if config.Config.ServerObserveOnly {
+		message := "Goliac is in observe mode"
+		return app.NewPostResyncTeamDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+
+	if g.applyWindows != nil && !g.applyWindows.Contains(time.Now()) {
+		message := "Goliac is outside the apply windows"
+		return app.NewPostResyncTeamDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+
+	if g.shuttingDown.Load() {
+		message := "Goliac is stopping"
+		return app.NewPostResyncTeamDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+
+	g.applyLobbyMutex.Lock()
+	if g.applyCurrent {
+		g.applyLobbyMutex.Unlock()
+		message := "a reconciliation is currently running"
+		return app.NewPostResyncTeamDefault(409).WithPayload(&models.Error{Message: &message})
+	}
+	g.applyCurrent = true
+	g.applyLobbyMutex.Unlock()
+	defer g.releaseApply()
+
+	stats := config.GoliacStatistics{}
+	ctx := context.WithValue(context.Background(), config.ContextKeyStatistics, &stats)
+	changes := config.GoliacChanges{}
+	ctx = context.WithValue(ctx, config.ContextKeyChanges, &changes)
+	ctx = context.WithValue(ctx, config.KeyAuthor, "resync_team")
+
+	err := g.goliac.ResyncTeam(ctx, config.Config.ServerGitRepository, params.TeamID)
+	if err != nil {
+		logrus.Error(err)
+		if err := g.notificationService.SendNotification(fmt.Sprintf("Goliac error when syncing team %s: %s", params.TeamID, err)); err != nil {
+			logrus.Error(err)
+		}
+		message := err.Error()
+		return app.NewPostResyncTeamDefault(500).WithPayload(&models.Error{Message: &message})
+	}
+	g.addLastChanges(time.Now(), &changes)
+
+	return app.NewPostResyncTeamOK()
+}
+
+func (g *GoliacServerImpl) PostPause(app.PostPauseParams) middleware.Responder {
+	if !g.paused.Swap(true) {
+		logrus.Info("reconciliation paused")
+	}
+	return app.NewPostPauseOK()
+}
+
+func (g *GoliacServerImpl) PostResume(app.PostResumeParams) middleware.Responder {
+	if g.paused.Swap(false) {
+		logrus.Info("reconciliation resumed")
+	}
+	circuitClosed := g.closeCircuit()
+	// replay the runs (periodic or requested) skipped while paused
+	if g.pausedSkipped.Swap(false) || circuitClosed {
+		go g.triggerApply()
+	}
+	return app.NewPostResumeOK()
+}
+
+/*
+closeCircuit closes the circuit breaker (on an explicit resync or resume)
+and returns true if it was open
+*/
+func (g *GoliacServerImpl) closeCircuit() bool {
+	g.consecutiveFailures.Store(0)
+	if g.circuitOpen.Swap(false) {
+		logrus.Info("circuit breaker closed, resuming the automatic applies")
+		return true
+	}
+	return false
+}
+
 func (g *GoliacServerImpl) Serve() {
 	var wg sync.WaitGroup
 	stopCh := make(chan struct{})
 
+	if config.Config.ServerObserveOnly {
+		logrus.Warn("observe mode (GOLIAC_SERVER_OBSERVE_ONLY): the drift is reported but never applied to Github")
+	}
+
 	restserver, err := g.StartRESTApi()
 	if err != nil {
 		logrus.Fatal(err)
@@ -647,6 +1586,10 @@ func (g *GoliacServerImpl) Serve() {
 				// when receiving a Github webhook event
 				// let's start the apply process asynchronously
 				go g.triggerApply()
+			}, func(prNumber int, headSha string) {
+				// when a pull request of the teams repository is opened or updated
+				// let's comment its plan asynchronously
+				go g.commentPullRequestPlan(prNumber, headSha)
 			},
 		)
 		go func() {
@@ -691,8 +1634,94 @@ func (g *GoliacServerImpl) Serve() {
 	<-signalCh
 	logrus.Info("Received OS signal, stopping Goliac...")
 
-	close(stopCh)
-	wg.Wait()
+	// refuse new apply runs, and let the in-flight one finish
+	g.shuttingDown.Store(true)
+	timeout := time.Duration(config.Config.ServerShutdownTimeout) * time.Second
+	if g.waitForApply(timeout) {
+		close(stopCh)
+		wg.Wait()
+	} else {
+		logrus.Warnf("the in-flight apply did not finish within %v, stopping anyway", timeout)
+		restserver.Shutdown()
+		if webhookserver != nil {
+			webhookserver.Shutdown()
+		}
+	}
+}
+
+/*
+shouldNotifyError tells if a sync error must be notified: each distinct
+error message is notified at most once per GOLIAC_SERVER_ERROR_NOTIFICATION_COOLDOWN.
+It also returns the number of occurrences suppressed since the last notification
+*/
+func (g *GoliacServerImpl) shouldNotifyError(message string, now time.Time) (bool, int) {
+	g.errorNotifyMutex.Lock()
+	defer g.errorNotifyMutex.Unlock()
+
+	cooldown := time.Duration(config.Config.ServerErrorNotificationCooldown) * time.Second
+	if g.errorNotifications == nil {
+		g.errorNotifications = make(map[string]*errorNotification)
+	}
+
+	// forget the errors not seen for a while (and without pending occurrences)
+	for m, n := range g.errorNotifications {
+		if m != message && n.suppressed == 0 && now.Sub(n.lastNotified) >= cooldown {
+			delete(g.errorNotifications, m)
+		}
+	}
+
+	n, ok := g.errorNotifications[message]
+	if !ok {
+		g.errorNotifications[message] = &errorNotification{lastNotified: now}
+		return true, 0
+	}
+	if now.Sub(n.lastNotified) < cooldown {
+		n.suppressed++
+		return false, 0
+	}
+	suppressed := n.suppressed
+	n.lastNotified = now
+	n.suppressed = 0
+	return true, suppressed
+}
+
+/*
+updateCircuit counts the consecutive failed apply runs, and opens the
+circuit breaker (stopping the automatic applies) once the threshold is reached
+*/
+func (g *GoliacServerImpl) updateCircuit(err error) {
+	if err == nil {
+		g.consecutiveFailures.Store(0)
+		return
+	}
+	failures := g.consecutiveFailures.Add(1)
+	threshold := config.Config.ServerCircuitBreakerThreshold
+	if threshold > 0 && failures >= threshold && !g.circuitOpen.Swap(true) {
+		logrus.Errorf("circuit breaker open after %d consecutive failed applies, the automatic applies are stopped", failures)
+		if err := g.notificationService.SendNotification(fmt.Sprintf("Goliac circuit open after %d consecutive failed applies (last error: %s). Call /api/v1/resync or /api/v1/resume to retry", failures, err)); err != nil {
+			logrus.Error(err)
+		}
+	}
+}
+
+/*
+waitForApply waits (up to timeout) for the current apply run (and the one
+waiting in the lobby) to finish. It returns false if the timeout expired
+*/
+func (g *GoliacServerImpl) waitForApply(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		g.applyLobbyMutex.Lock()
+		running := g.applyCurrent || g.applyLobby
+		g.applyLobbyMutex.Unlock()
+		if !running {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
 }
 
 /*
@@ -702,28 +1731,110 @@ inside serverApply, it will check if the lobby is free
 - if the lobby is busy, it will do nothing
 */
 func (g *GoliacServerImpl) triggerApply() {
+	// after too many consecutive failures, wait for an explicit resync (or resume)
+	if g.circuitOpen.Load() {
+		g.syncInterval = config.Config.ServerApplyInterval
+		return
+	}
+
 	err, errs, warns, applied := g.serveApply()
+	g.recordApply(err, errs, warns, applied)
+}
+
+/*
+commentPullRequestPlan plans a pull request of the teams repository and posts
+(or updates) the plan as a comment of the pull request. As it loads the Github
+state, it waits for the current apply run to finish
+*/
+func (g *GoliacServerImpl) commentPullRequestPlan(prNumber int, headSha string) {
+	deadline := time.Now().Add(10 * time.Minute)
+	for {
+		g.applyLobbyMutex.Lock()
+		if !g.applyCurrent {
+			g.applyCurrent = true
+			g.applyLobbyMutex.Unlock()
+			break
+		}
+		g.applyLobbyMutex.Unlock()
+		if time.Now().After(deadline) {
+			logrus.Warnf("not able to plan the pull request %d: a reconciliation is still running", prNumber)
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	defer g.releaseApply()
+
+	fs := osfs.New("/")
+	err := g.goliac.CommentPlanOnPullRequest(context.Background(), fs, config.Config.ServerGitRepository, prNumber, headSha)
+	if err != nil {
+		logrus.Errorf("failed to comment the plan of the pull request %d: %v", prNumber, err)
+	}
+}
+
+/*
+recordApply records the result of an apply run (last sync time and errors,
+error notification and circuit breaker)
+*/
+func (g *GoliacServerImpl) recordApply(err error, errs []error, warns []entity.Warning, applied bool) {
 	if !applied && err == nil {
 		// the run was skipped
 		g.syncInterval = config.Config.ServerApplyInterval
 	} else {
 		now := time.Now()
 		g.lastSyncTime = &now
-		previousError := g.lastSyncError
 		g.lastSyncError = err
 		g.detailedErrors = errs
 		g.detailedWarnings = warns
-		// log the error only if it's a new one
-		if err != nil && (previousError == nil || err.Error() != previousError.Error()) {
-			logrus.Error(err)
-			if err := g.notificationService.SendNotification(fmt.Sprintf("Goliac error when syncing: %s", err)); err != nil {
+		// notify each distinct error at most once per cooldown window
+		if err != nil {
+			if notify, suppressed := g.shouldNotifyError(err.Error(), now); notify {
 				logrus.Error(err)
+				message := fmt.Sprintf("Goliac error when syncing: %s", err)
+				if suppressed > 0 {
+					message += fmt.Sprintf(" (%d more occurrences since the last notification)", suppressed)
+				}
+				if err := g.notificationService.SendNotification(message); err != nil {
+					logrus.Error(err)
+				}
 			}
 		}
+		g.notifyOrganizationsErrors(now)
+		// only the failed applies count for the circuit breaker
+		if !errors.Is(err, errApplyVetoed) {
+			g.updateCircuit(err)
+		}
 		g.syncInterval = config.Config.ServerApplyInterval
 	}
 }
 
+/*
+notifyOrganizationsErrors notifies the errors of the last reconciliation of
+the additional Github organizations (each distinct error at most once per cooldown window)
+*/
+func (g *GoliacServerImpl) notifyOrganizationsErrors(now time.Time) {
+	organizationsErrors := g.goliac.GetOrganizationsErrors()
+	organizations := make([]string, 0, len(organizationsErrors))
+	for organization, err := range organizationsErrors {
+		if err != nil {
+			organizations = append(organizations, organization)
+		}
+	}
+	sort.Strings(organizations)
+
+	for _, organization := range organizations {
+		message := fmt.Sprintf("Goliac error when syncing the organization %s: %s", organization, organizationsErrors[organization])
+		if notify, suppressed := g.shouldNotifyError(message, now); notify {
+			logrus.Error(message)
+			if suppressed > 0 {
+				message += fmt.Sprintf(" (%d more occurrences since the last notification)", suppressed)
+			}
+			if err := g.notificationService.SendNotification(message); err != nil {
+				logrus.Error(err)
+			}
+		}
+	}
+}
+
 func (g *GoliacServerImpl) StartRESTApi() (*restapi.Server, error) {
 	swaggerSpec, err := loads.Embedded(restapi.SwaggerJSON, restapi.FlatSwaggerJSON)
 	if err != nil {
@@ -737,19 +1848,33 @@ func (g *GoliacServerImpl) StartRESTApi() (*restapi.Server, error) {
 	// healthcheck
 	api.HealthGetLivenessHandler = health.GetLivenessHandlerFunc(g.GetLiveness)
 	api.HealthGetReadinessHandler = health.GetReadinessHandlerFunc(g.GetReadiness)
+	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(g.GetHealth)
 
 	api.AppPostFlushCacheHandler = app.PostFlushCacheHandlerFunc(g.PostFlushCache)
 	api.AppPostResyncHandler = app.PostResyncHandlerFunc(g.PostResync)
+	api.AppPostApplyHandler = app.PostApplyHandlerFunc(g.PostApply)
+	api.AppPostResyncTeamHandler = app.PostResyncTeamHandlerFunc(g.PostResyncTeam)
+	api.AppPostPauseHandler = app.PostPauseHandlerFunc(g.PostPause)
+	api.AppPostResumeHandler = app.PostResumeHandlerFunc(g.PostResume)
 	api.AppGetStatusHandler = app.GetStatusHandlerFunc(g.GetStatus)
 	api.AppGetStatiticsHandler = app.GetStatiticsHandlerFunc(g.GetStatistics)
 	api.AppGetUnmanagedHandler = app.GetUnmanagedHandlerFunc(g.GetUnmanaged)
+	api.AppGetLastChangesHandler = app.GetLastChangesHandlerFunc(g.GetLastChanges)
+	api.AppGetHistoryHandler = app.GetHistoryHandlerFunc(g.GetHistory)
+	api.AppGetSeatReportHandler = app.GetSeatReportHandlerFunc(g.GetSeatReport)
+	api.AppGetComplianceReportHandler = app.GetComplianceReportHandlerFunc(g.GetComplianceReport)
+	api.AppGetDriftHandler = app.GetDriftHandlerFunc(g.GetDrift)
+	api.AppGetExpiringAccessesHandler = app.GetExpiringAccessesHandlerFunc(g.GetExpiringAccesses)
 
 	api.AppGetUsersHandler = app.GetUsersHandlerFunc(g.GetUsers)
 	api.AppGetUserHandler = app.GetUserHandlerFunc(g.GetUser)
+	api.AppGetUserRepositoryAccessHandler = app.GetUserRepositoryAccessHandlerFunc(g.GetUserRepositoryAccess)
 	api.AppGetCollaboratorsHandler = app.GetCollaboratorsHandlerFunc(g.GetCollaborators)
 	api.AppGetCollaboratorHandler = app.GetCollaboratorHandlerFunc(g.GetCollaborator)
 	api.AppGetTeamsHandler = app.GetTeamsHandlerFunc(g.GetTeams)
 	api.AppGetTeamHandler = app.GetTeamHandlerFunc(g.GetTeam)
+	api.AppGetTeamsTreeHandler = app.GetTeamsTreeHandlerFunc(g.GetTeamsTree)
+	api.AppGetUnusedTeamsHandler = app.GetUnusedTeamsHandlerFunc(g.GetUnusedTeams)
 	api.AppGetRepositoriesHandler = app.GetRepositoriesHandlerFunc(g.GetRepositories)
 	api.AppGetRepositoryHandler = app.GetRepositoryHandlerFunc(g.GetRepository)
 
@@ -763,7 +1888,29 @@ func (g *GoliacServerImpl) StartRESTApi() (*restapi.Server, error) {
 	return server, nil
 }
 
+/*
+releaseApply frees the lobby (or just the current run) for the next run
+*/
+func (g *GoliacServerImpl) releaseApply() {
+	g.applyLobbyMutex.Lock()
+	if g.applyLobby {
+		g.applyLobby = false
+		g.applyLobbyCond.Signal()
+	} else {
+		g.applyCurrent = false
+	}
+	g.applyLobbyMutex.Unlock()
+}
+
 func (g *GoliacServerImpl) serveApply() (error, []error, []entity.Warning, bool) {
+	return g.serveApplyChanges(&config.GoliacChanges{}, false)
+}
+
+/*
+serveApplyChanges is serveApply, filling changes with the operations of the run.
+Outside the apply windows (unless forced), the changes are only planned and notified
+*/
+func (g *GoliacServerImpl) serveApplyChanges(changes *config.GoliacChanges, force bool) (error, []error, []entity.Warning, bool) {
 	// we want to run ApplyToGithub
 	// and queue one new run (the lobby) if a new run is asked
 	g.applyLobbyMutex.Lock()
@@ -783,17 +1930,12 @@ func (g *GoliacServerImpl) serveApply() (error, []error, []entity.Warning, bool)
 	}
 	g.applyLobbyMutex.Unlock()
 
-	// free the lobbdy (or just the current run) for the next run
-	defer func() {
-		g.applyLobbyMutex.Lock()
-		if g.applyLobby {
-			g.applyLobby = false
-			g.applyLobbyCond.Signal()
-		} else {
-			g.applyCurrent = false
-		}
-		g.applyLobbyMutex.Unlock()
-	}()
+	defer g.releaseApply()
+
+	if g.shuttingDown.Load() {
+		logrus.Debug("Goliac is stopping, skipping the apply")
+		return nil, nil, nil, false
+	}
 
 	repo := config.Config.ServerGitRepository
 	branch := config.Config.ServerGitBranch
@@ -808,17 +1950,81 @@ func (g *GoliacServerImpl) serveApply() (error, []error, []entity.Warning, bool)
 	// we are ready (to give local state, and to sync with remote)
 	g.ready = true
 
+	if g.paused.Load() {
+		logrus.Debug("reconciliation is paused, skipping the apply")
+		g.pausedSkipped.Store(true)
+		return nil, nil, nil, false
+	}
+
 	startTime := time.Now()
 	stats := config.GoliacStatistics{}
 	ctx := context.WithValue(context.Background(), config.ContextKeyStatistics, &stats)
+	// in observe mode, the plan is computed but never applied
+	// (and outside the apply windows, it is applied at the next window)
+	outsideWindow := !force && g.applyWindows != nil && !g.applyWindows.Contains(startTime)
+	observeOnly := config.Config.ServerObserveOnly || outsideWindow
+	changes.Dryrun = observeOnly
+	ctx = context.WithValue(ctx, config.ContextKeyChanges, changes)
+
+	if config.Config.ServerPreApplyHookURL != "" {
+		err := callApplyHook(ctx, config.Config.ServerPreApplyHookURL, &ApplyHookPayload{
+			Event:      "pre_apply",
+			Repository: repo,
+			Branch:     branch,
+			Operations: []config.GoliacOperation{},
+			Dryrun:     observeOnly,
+		})
+		if err != nil {
+			if config.Config.ServerPreApplyHookVeto {
+				return fmt.Errorf("%w: %v", errApplyVetoed, err), nil, nil, false
+			}
+			logrus.Warnf("pre-apply hook failed: %v", err)
+		}
+	}
 
 	fs := osfs.New("/")
-	err, errs, warns, unmanaged := g.goliac.Apply(ctx, fs, false, repo, branch)
+	err, errs, warns, unmanaged := g.goliac.Apply(ctx, fs, observeOnly, repo, branch)
+
+	if config.Config.ServerPostApplyHookURL != "" {
+		success := err == nil
+		payload := ApplyHookPayload{
+			Event:      "post_apply",
+			Repository: repo,
+			Branch:     branch,
+			Success:    &success,
+			Author:     changes.Author,
+			Operations: changes.Operations,
+			Dryrun:     observeOnly,
+		}
+		if err != nil {
+			payload.Error = err.Error()
+		}
+		if payload.Operations == nil {
+			payload.Operations = []config.GoliacOperation{}
+		}
+		if hookErr := callApplyHook(ctx, config.Config.ServerPostApplyHookURL, &payload); hookErr != nil {
+			logrus.Warnf("post-apply hook failed: %v", hookErr)
+		}
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to apply on branch %s: %s", branch, err), errs, warns, false
 	}
 	endTime := time.Now()
+	g.addLastChanges(endTime, changes)
+	if observeOnly {
+		g.notifyObservedDrift(changes, outsideWindow && !config.Config.ServerObserveOnly)
+	} else {
+		g.notifyDestructiveOperations(changes)
+	}
+	g.notifyWarnings(changes)
 	g.lastTimeToApply = endTime.Sub(startTime)
+	g.lastOperations = int64(len(changes.Operations))
+	g.lastOperationsCount = operationsBreakdown(changes.Operations)
+	g.lastOperationsOrgs = make(map[string]int64)
+	for _, o := range changes.Operations {
+		g.lastOperationsOrgs[o.Organization]++
+	}
 	g.lastStatistics.GithubApiCalls = stats.GithubApiCalls
 	g.lastStatistics.GithubThrottled = stats.GithubThrottled
 
@@ -838,5 +2044,17 @@ func (g *GoliacServerImpl) serveApply() (error, []error, []entity.Warning, bool)
 		g.lastUnmanaged = unmanaged
 	}
 
+	// read-only compliance check of the repositories content
+	compliance, err := g.goliac.CheckCompliance(ctx)
+	if err != nil {
+		logrus.Warnf("failed to check the repositories compliance: %v", err)
+	} else {
+		complianceTime := time.Now()
+		g.lastComplianceMutex.Lock()
+		g.lastComplianceTime = &complianceTime
+		g.lastCompliance = compliance
+		g.lastComplianceMutex.Unlock()
+	}
+
 	return nil, errs, warns, true
 }
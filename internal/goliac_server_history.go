@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+ * HistoryEntry is a run (with changes) appended as a json line
+ * to the history file (GOLIAC_SERVER_HISTORY_FILE)
+ */
+type HistoryEntry struct {
+	Timestamp  time.Time                `json:"timestamp"`
+	Author     string                   `json:"author"`
+	Dryrun     bool                     `json:"dryrun,omitempty"` // observe mode: nothing was applied
+	Operations []config.GoliacOperation `json:"operations"`
+}
+
+/*
+ * appendHistory appends the changes of a run to the history file
+ * (one json line per run). The file is created if it doesn't exist
+ */
+func appendHistory(filename string, timestamp time.Time, changes *config.GoliacChanges) error {
+	line, err := json.Marshal(HistoryEntry{
+		Timestamp:  timestamp,
+		Author:     changes.Author,
+		Dryrun:     changes.Dryrun,
+		Operations: changes.Operations,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open the history file %s: %v", filename, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write to the history file %s: %v", filename, err)
+	}
+	return nil
+}
+
+/*
+ * readHistory returns the runs of the history file, in the order
+ * they were appended. A missing file is an empty history, and the
+ * malformed lines (like a truncated last line) are skipped
+ */
+func readHistory(filename string) ([]*HistoryEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*HistoryEntry{}, nil
+		}
+		return nil, fmt.Errorf("failed to open the history file %s: %v", filename, err)
+	}
+	defer f.Close()
+
+	entries := []*HistoryEntry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	nbLine := 0
+	for scanner.Scan() {
+		nbLine++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logrus.Warnf("history file %s: skipping malformed line %d: %v", filename, nbLine, err)
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the history file %s: %v", filename, err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Alayacare/goliac/internal/config"
+)
+
+/*
+ * ApplyHookPayload is POSTed (as json) to the pre-apply and post-apply hooks
+ * (GOLIAC_SERVER_PRE_APPLY_HOOK_URL and GOLIAC_SERVER_POST_APPLY_HOOK_URL)
+ */
+type ApplyHookPayload struct {
+	Event      string                   `json:"event"` // pre_apply or post_apply
+	Repository string                   `json:"repository"`
+	Branch     string                   `json:"branch"`
+	Success    *bool                    `json:"success,omitempty"` // post_apply only (false if the apply failed)
+	Error      string                   `json:"error,omitempty"`   // post_apply only
+	Author     string                   `json:"author,omitempty"`  // post_apply only
+	Operations []config.GoliacOperation `json:"operations"`        // post_apply only: the plan applied
+	Dryrun     bool                     `json:"dryrun,omitempty"`  // observe mode: nothing is applied
+}
+
+/*
+ * callApplyHook POSTs the payload to the hook url, and returns an error
+ * if the hook cannot be reached or doesn't answer with a 2xx
+ */
+func callApplyHook(ctx context.Context, url string, payload *ApplyHookPayload) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.Config.ServerApplyHookTimeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received non-2xx response: %v", resp.Status)
+	}
+	return nil
+}
@@ -2,17 +2,31 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-git/go-billy/v5"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
 	"github.com/gosimple/slug"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/engine"
 	"github.com/Alayacare/goliac/internal/entity"
+	"github.com/Alayacare/goliac/internal/notification"
 	"github.com/Alayacare/goliac/internal/observability"
+	"github.com/Alayacare/goliac/swagger_gen/models"
 	"github.com/Alayacare/goliac/swagger_gen/restapi/operations/app"
+	"github.com/Alayacare/goliac/swagger_gen/restapi/operations/health"
 )
 
 type GoliacLocalMock struct {
@@ -135,11 +149,28 @@ func (g *GoliacRemoteMock) Teams(ctx context.Context, current bool) map[string]*
 }
 
 type GoliacMock struct {
-	local  engine.GoliacLocalResources
-	remote engine.GoliacRemoteResources
+	local      engine.GoliacLocalResources
+	remote     engine.GoliacRemoteResources
+	nbApply    int
+	compliance map[string][]string
+	drift      map[string][]engine.RepositoryDriftField
+	drifts     []engine.EntityDrift
+	resynced   []string                 // teams resynced
+	operations []config.GoliacOperation // operations recorded by an apply
+	warnings   []string                 // warnings raised by an apply
+	dryrun     bool                     // dryrun of the last apply
+	orgErrors  map[string]error         // errors of the additional organizations
+	gitErr     error                    // error of the teams repository check
+	githubErr  error                    // error of the Github API check
 }
 
 func (g *GoliacMock) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool, repo string, branch string) (error, []error, []entity.Warning, *engine.UnmanagedResources) {
+	g.nbApply++
+	g.dryrun = dryrun
+	if changes, ok := ctx.Value(config.ContextKeyChanges).(*config.GoliacChanges); ok {
+		changes.Operations = append(changes.Operations, g.operations...)
+		changes.Warnings = append(changes.Warnings, g.warnings...)
+	}
 	unmanaged := &engine.UnmanagedResources{
 		Users:        make(map[string]bool),
 		Teams:        make(map[string]bool),
@@ -149,6 +180,9 @@ func (g *GoliacMock) Apply(ctx context.Context, fs billy.Filesystem, dryrun bool
 	unmanaged.Users["unmanaged"] = true
 	return nil, nil, nil, unmanaged
 }
+func (g *GoliacMock) ApplySince(ctx context.Context, fs billy.Filesystem, dryrun bool, repo string, branch string, since string) (error, []error, []entity.Warning, *engine.UnmanagedResources) {
+	return g.Apply(ctx, fs, dryrun, repo, branch)
+}
 func (g *GoliacMock) UsersUpdate(ctx context.Context, fs billy.Filesystem, repositoryUrl, branch string, dryrun bool, force bool) (bool, error) {
 	return false, nil
 }
@@ -161,6 +195,45 @@ func (g *GoliacMock) GetLocal() engine.GoliacLocalResources {
 func (g *GoliacMock) GetRemote() engine.GoliacRemoteResources {
 	return g.remote
 }
+func (g *GoliacMock) CheckCompliance(ctx context.Context) (map[string][]string, error) {
+	return g.compliance, nil
+}
+func (g *GoliacMock) GetRepositoryDrift(ctx context.Context, repositoryUrl string, reponame string) ([]engine.RepositoryDriftField, error) {
+	return g.drift[reponame], nil
+}
+func (g *GoliacMock) GetUnusedTeams() []string {
+	return engine.UnusedTeams(g.local.Teams(), g.local.Repositories(), &config.RepositoryConfig{})
+}
+func (g *GoliacMock) GetExpiringAccesses(window time.Duration) []engine.ExternalAccessExpiration {
+	now := time.Now()
+	return engine.ExpiringExternalAccesses(g.local.Repositories(), g.local.ExternalUsers(), now.Add(window), now)
+}
+func (g *GoliacMock) GetDrift(ctx context.Context, repositoryUrl string) ([]engine.EntityDrift, error) {
+	return g.drifts, nil
+}
+func (g *GoliacMock) ResyncTeam(ctx context.Context, repositoryUrl string, teamslug string) error {
+	g.resynced = append(g.resynced, teamslug)
+	return nil
+}
+func (g *GoliacMock) PlanFromRef(ctx context.Context, fs billy.Filesystem, repositoryUrl, ref string) (error, []error, []entity.Warning, []config.GoliacOperation) {
+	return nil, nil, nil, g.operations
+}
+func (g *GoliacMock) ComparePlans(ctx context.Context, fs billy.Filesystem, repositoryUrl, baseRef, headRef string) (error, []error, []entity.Warning, *PlanComparison) {
+	return nil, nil, nil, &PlanComparison{Added: g.operations, Removed: []config.GoliacOperation{}}
+}
+
+func (g *GoliacMock) CommentPlanOnPullRequest(ctx context.Context, fs billy.Filesystem, repositoryUrl string, prNumber int, ref string) error {
+	return nil
+}
+func (g *GoliacMock) GetOrganizationsErrors() map[string]error {
+	return g.orgErrors
+}
+func (g *GoliacMock) CheckTeamsRepository(ctx context.Context, repositoryUrl string) error {
+	return g.gitErr
+}
+func (g *GoliacMock) CheckGithubAPI(ctx context.Context) error {
+	return g.githubErr
+}
 func (g *GoliacMock) SetRemoteObservability(feedback observability.RemoteObservability) error {
 	return nil
 }
@@ -206,6 +279,71 @@ func TestAppGetUsers(t *testing.T) {
 		assert.Equal(t, 2, len(payload.Payload.Repositories))
 	})
 }
+
+func TestAppGetUserRepositoryAccess(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	// a subteam of ateam (inheriting its accesses)
+	ateam := "ateam"
+	subteam := entity.Team{}
+	subteam.Name = "subteam"
+	subteam.ParentTeam = &ateam
+	subteam.Spec.Owners = []string{"user2"}
+	localfixture.teams["subteam"] = &subteam
+	// userE1 is also an external reader of repoB
+	localfixture.repositories["repoB"].Spec.ExternalUserReaders = []string{"userE1"}
+
+	goliac := NewGoliacMock(localfixture, remotefixture)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+
+	t.Run("happy path: the strongest permission of the user teams", func(t *testing.T) {
+		res := server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "user1", RepositoryID: "repoB"})
+		payload := res.(*app.GetUserRepositoryAccessOK)
+		assert.Equal(t, "push", payload.Payload.Permission)
+		assert.Equal(t, []*models.UserRepositoryGrant{
+			{Source: "team", Name: "ateam", Permission: "pull"},
+			{Source: "team", Name: "mixteam", Permission: "push"},
+		}, payload.Payload.Grants)
+	})
+
+	t.Run("happy path: access inherited from a parent team", func(t *testing.T) {
+		res := server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "user2", RepositoryID: "repoA"})
+		payload := res.(*app.GetUserRepositoryAccessOK)
+		assert.Equal(t, "push", payload.Payload.Permission)
+		assert.Equal(t, []*models.UserRepositoryGrant{
+			{Source: "team", Name: "ateam", Permission: "push"},
+		}, payload.Payload.Grants)
+	})
+
+	t.Run("happy path: external user", func(t *testing.T) {
+		res := server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "userE1", RepositoryID: "repoB"})
+		payload := res.(*app.GetUserRepositoryAccessOK)
+		assert.Equal(t, "push", payload.Payload.Permission)
+		assert.Equal(t, []*models.UserRepositoryGrant{
+			{Source: "team", Name: "mixteam", Permission: "push"},
+			{Source: "external", Name: "userE1", Permission: "pull"},
+		}, payload.Payload.Grants)
+	})
+
+	t.Run("happy path: no access", func(t *testing.T) {
+		res := server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "userE1", RepositoryID: "repoA"})
+		payload := res.(*app.GetUserRepositoryAccessOK)
+		assert.Equal(t, "none", payload.Payload.Permission)
+		assert.Equal(t, 0, len(payload.Payload.Grants))
+	})
+
+	t.Run("not happy path: unknown user or repository", func(t *testing.T) {
+		res := server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "unknown", RepositoryID: "repoA"})
+		payload := res.(*app.GetUserRepositoryAccessDefault)
+		assert.Equal(t, "User unknown not found", *payload.Payload.Message)
+
+		res = server.GetUserRepositoryAccess(app.GetUserRepositoryAccessParams{UserID: "user1", RepositoryID: "unknown"})
+		payload = res.(*app.GetUserRepositoryAccessDefault)
+		assert.Equal(t, "Repository unknown not found", *payload.Payload.Message)
+	})
+}
+
 func TestAppGetTeams(t *testing.T) {
 	localfixture, remotefixture := fixtureGoliacLocal()
 	goliac := NewGoliacMock(localfixture, remotefixture)
@@ -235,6 +373,39 @@ func TestAppGetTeams(t *testing.T) {
 		assert.NotZero(t, res.(*app.GetTeamDefault))
 	})
 
+	t.Run("happy path: get unused teams", func(t *testing.T) {
+		res := server.GetUnusedTeams(app.GetUnusedTeamsParams{})
+		payload := res.(*app.GetUnusedTeamsOK)
+		assert.Equal(t, 1, len(payload.Payload))
+		assert.Equal(t, "externallyManaged", payload.Payload[0].Name)
+	})
+
+	t.Run("happy path: get teams tree", func(t *testing.T) {
+		subteam := entity.Team{}
+		subteam.Name = "asubteam"
+		subteam.Spec.Owners = []string{"user1"}
+		parent := "ateam"
+		subteam.ParentTeam = &parent
+		localfixture.teams["asubteam"] = &subteam
+		defer delete(localfixture.teams, "asubteam")
+
+		res := server.GetTeamsTree(app.GetTeamsTreeParams{})
+		payload := res.(*app.GetTeamsTreeOK)
+		assert.Equal(t, 3, len(payload.Payload))
+
+		ateam := payload.Payload[0]
+		assert.Equal(t, "ateam", ateam.Name)
+		assert.Equal(t, "ateam"+config.Config.GoliacTeamOwnerSuffix, ateam.OwnersTeam)
+		assert.Equal(t, 1, len(ateam.Children))
+		assert.Equal(t, "asubteam", ateam.Children[0].Name)
+		assert.Equal(t, "ateam/asubteam", ateam.Children[0].Path)
+		assert.Equal(t, "asubteam"+config.Config.GoliacTeamOwnerSuffix, ateam.Children[0].OwnersTeam)
+
+		assert.Equal(t, "externallyManaged", payload.Payload[1].Name)
+		assert.True(t, payload.Payload[1].ExternallyManaged)
+		assert.Equal(t, "externallymanaged"+config.Config.GoliacTeamOwnerSuffix, payload.Payload[1].OwnersTeam)
+	})
+
 	t.Run("happy path: get externally managemed team members", func(t *testing.T) {
 		res := server.GetTeam(app.GetTeamParams{TeamID: "externallyManaged"})
 		payload := res.(*app.GetTeamOK)
@@ -272,4 +443,1067 @@ func TestAppGetRepositories(t *testing.T) {
 		res := server.GetRepository(app.GetRepositoryParams{RepositoryID: "repoC"})
 		assert.NotZero(t, res.(*app.GetRepositoryDefault))
 	})
+
+	t.Run("happy path: get repository with drift", func(t *testing.T) {
+		goliac.(*GoliacMock).drift = map[string][]engine.RepositoryDriftField{
+			"repoB": {{Name: "visibility", Local: "public", Remote: "private"}},
+		}
+		includeDrift := true
+
+		res := server.GetRepository(app.GetRepositoryParams{RepositoryID: "repoB", IncludeDrift: &includeDrift})
+		payload := res.(*app.GetRepositoryOK)
+		assert.NotNil(t, payload.Payload.Drift)
+		assert.Equal(t, false, payload.Payload.Drift.InSync)
+		assert.Equal(t, 1, len(payload.Payload.Drift.Fields))
+		assert.Equal(t, "visibility", payload.Payload.Drift.Fields[0].Name)
+
+		res = server.GetRepository(app.GetRepositoryParams{RepositoryID: "repoA", IncludeDrift: &includeDrift})
+		payload = res.(*app.GetRepositoryOK)
+		assert.Equal(t, true, payload.Payload.Drift.InSync)
+
+		res = server.GetRepository(app.GetRepositoryParams{RepositoryID: "repoB"})
+		payload = res.(*app.GetRepositoryOK)
+		assert.Nil(t, payload.Payload.Drift)
+	})
+}
+
+func TestAppGetSeatReport(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture)
+	now := time.Now()
+	server := GoliacServerImpl{
+		goliac:        goliac,
+		ready:         true,
+		lastSyncTime:  &now,
+		lastSyncError: nil,
+	}
+
+	t.Run("happy path: get seat report", func(t *testing.T) {
+		res := server.GetSeatReport(app.GetSeatReportParams{})
+		payload := res.(*app.GetSeatReportOK)
+		assert.Equal(t, int64(3), payload.Payload.TotalSeats)
+		assert.Equal(t, int64(2), payload.Payload.ActiveSeats)
+		assert.Equal(t, int64(1), payload.Payload.InactiveSeats)
+		assert.Equal(t, []string{"user2"}, payload.Payload.InactiveUsers)
+
+		// external users don't consume a seat
+		// and externally managed team members come from the remote
+		teamSeats := make(map[string]int64)
+		for _, team := range payload.Payload.Teams {
+			teamSeats[team.Name] = team.Seats
+		}
+		assert.Equal(t, map[string]int64{
+			"ateam":             2,
+			"mixteam":           1,
+			"externallyManaged": 1,
+		}, teamSeats)
+	})
+}
+
+func TestAppGetLastChanges(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture)
+	now := time.Now()
+	server := GoliacServerImpl{
+		goliac:        goliac,
+		ready:         true,
+		lastSyncTime:  &now,
+		lastSyncError: nil,
+	}
+
+	t.Run("happy path: no changes yet", func(t *testing.T) {
+		res := server.GetLastChanges(app.GetLastChangesParams{})
+		payload := res.(*app.GetLastChangesOK)
+		assert.Equal(t, 0, len(payload.Payload))
+	})
+
+	t.Run("happy path: runs without operations are not kept", func(t *testing.T) {
+		server.addLastChanges(time.Now(), &config.GoliacChanges{Author: "scheduled"})
+		res := server.GetLastChanges(app.GetLastChangesParams{})
+		payload := res.(*app.GetLastChangesOK)
+		assert.Equal(t, 0, len(payload.Payload))
+	})
+
+	t.Run("happy path: keep only the last runs", func(t *testing.T) {
+		history := config.Config.ServerChangesHistory
+		config.Config.ServerChangesHistory = 2
+		defer func() { config.Config.ServerChangesHistory = history }()
+
+		for _, author := range []string{"user1", "user2", "user3"} {
+			server.addLastChanges(time.Now(), &config.GoliacChanges{
+				Author: author,
+				Operations: []config.GoliacOperation{
+					{Command: "create_team", Detail: "teamname: foo"},
+				},
+			})
+		}
+
+		res := server.GetLastChanges(app.GetLastChangesParams{})
+		payload := res.(*app.GetLastChangesOK)
+		assert.Equal(t, 2, len(payload.Payload))
+		// most recent first
+		assert.Equal(t, "user3", payload.Payload[0].Author)
+		assert.Equal(t, "user2", payload.Payload[1].Author)
+		assert.Equal(t, "create_team", payload.Payload[0].Operations[0].Command)
+	})
+
+	t.Run("happy path: each operation has its own timestamp", func(t *testing.T) {
+		recorded := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+		server.addLastChanges(time.Now(), &config.GoliacChanges{
+			Author: "user4",
+			Operations: []config.GoliacOperation{
+				{Command: "create_team", Detail: "teamname: foo", Timestamp: recorded},
+				{Command: "create_team", Detail: "teamname: bar"},
+			},
+		})
+
+		res := server.GetLastChanges(app.GetLastChangesParams{})
+		payload := res.(*app.GetLastChangesOK)
+		assert.Equal(t, "user4", payload.Payload[0].Author)
+		assert.Equal(t, "2024-05-01T10:30:00Z", payload.Payload[0].Operations[0].Timestamp)
+		// unknown (like for the runs persisted by an older version)
+		assert.Equal(t, "", payload.Payload[0].Operations[1].Timestamp)
+	})
+}
+
+func TestAppGetHistory(t *testing.T) {
+	historyFile := config.Config.ServerHistoryFile
+	defer func() { config.Config.ServerHistoryFile = historyFile }()
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+
+	t.Run("happy path: no history file", func(t *testing.T) {
+		config.Config.ServerHistoryFile = ""
+		server.addLastChanges(time.Now(), &config.GoliacChanges{
+			Author:     "user1",
+			Operations: []config.GoliacOperation{{Command: "create_team", Detail: "teamname: foo"}},
+		})
+		res := server.GetHistory(app.GetHistoryParams{})
+		payload := res.(*app.GetHistoryOK)
+		assert.Equal(t, 0, len(payload.Payload))
+	})
+
+	t.Run("happy path: the runs are persisted", func(t *testing.T) {
+		config.Config.ServerHistoryFile = filepath.Join(t.TempDir(), "history.jsonl")
+
+		res := server.GetHistory(app.GetHistoryParams{})
+		payload := res.(*app.GetHistoryOK)
+		assert.Equal(t, 0, len(payload.Payload))
+
+		server.addLastChanges(time.Now(), &config.GoliacChanges{Author: "scheduled"})
+		server.addLastChanges(time.Now(), &config.GoliacChanges{
+			Author:     "user1",
+			Operations: []config.GoliacOperation{{Command: "create_team", Detail: "teamname: foo"}},
+		})
+		server.addLastChanges(time.Now(), &config.GoliacChanges{
+			Author:     "user2",
+			Dryrun:     true,
+			Operations: []config.GoliacOperation{{Command: "delete_team", Detail: "teamname: bar"}},
+		})
+
+		// a new server (after a restart) reads the same history
+		restarted := GoliacServerImpl{
+			goliac: goliac,
+		}
+		res = restarted.GetHistory(app.GetHistoryParams{})
+		payload = res.(*app.GetHistoryOK)
+		assert.Equal(t, 2, len(payload.Payload))
+		// most recent first
+		assert.Equal(t, "user2", payload.Payload[0].Author)
+		assert.True(t, payload.Payload[0].Dryrun)
+		assert.Equal(t, "delete_team", payload.Payload[0].Operations[0].Command)
+		assert.Equal(t, "user1", payload.Payload[1].Author)
+		assert.Equal(t, "teamname: foo", payload.Payload[1].Operations[0].Detail)
+	})
+
+	t.Run("happy path: malformed lines are skipped", func(t *testing.T) {
+		config.Config.ServerHistoryFile = filepath.Join(t.TempDir(), "history.jsonl")
+		err := os.WriteFile(config.Config.ServerHistoryFile, []byte(`{"timestamp":"2024-01-01T00:00:00Z","author":"user1","operations":[{"command":"create_team","detail":"teamname: foo"}]}
+{"timestamp":"2024-01-02T00:00:00Z","auth`), 0644)
+		assert.Nil(t, err)
+
+		res := server.GetHistory(app.GetHistoryParams{})
+		payload := res.(*app.GetHistoryOK)
+		assert.Equal(t, 1, len(payload.Payload))
+		assert.Equal(t, "2024-01-01T00:00:00Z", payload.Payload[0].Timestamp)
+	})
+}
+
+func TestAppGetComplianceReport(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() {
+		config.Config.ServerGitRepository = repository
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+
+	t.Run("happy path: not checked yet", func(t *testing.T) {
+		res := server.GetComplianceReport(app.GetComplianceReportParams{})
+		payload := res.(*app.GetComplianceReportOK)
+		assert.Equal(t, "", payload.Payload.LastCheckTime)
+		assert.Equal(t, 0, len(payload.Payload.Repositories))
+	})
+
+	t.Run("happy path: missing files are reported after an apply", func(t *testing.T) {
+		goliac.compliance = map[string][]string{
+			"repoB": {"SECURITY.md", "LICENSE"},
+			"repoA": {"LICENSE"},
+		}
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.True(t, applied)
+
+		res := server.GetComplianceReport(app.GetComplianceReportParams{})
+		payload := res.(*app.GetComplianceReportOK)
+		assert.NotEqual(t, "", payload.Payload.LastCheckTime)
+		assert.Equal(t, 2, len(payload.Payload.Repositories))
+		assert.Equal(t, "repoA", payload.Payload.Repositories[0].Name)
+		assert.Equal(t, []string{"SECURITY.md", "LICENSE"}, payload.Payload.Repositories[1].MissingFiles)
+	})
+
+	t.Run("happy path: the report is read while an apply runs", func(t *testing.T) {
+		done := make(chan bool)
+		go func() {
+			server.serveApply()
+			done <- true
+		}()
+		for running := true; running; {
+			select {
+			case <-done:
+				running = false
+			default:
+				res := server.GetComplianceReport(app.GetComplianceReportParams{})
+				assert.NotNil(t, res.(*app.GetComplianceReportOK).Payload)
+			}
+		}
+	})
+}
+
+func TestAppGetDrift(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("not happy path: not ready yet", func(t *testing.T) {
+		res := server.GetDrift(app.GetDriftParams{})
+		payload := res.(*app.GetDriftDefault)
+		assert.Equal(t, "Not yet ready, loading local state", *payload.Payload.Message)
+	})
+
+	t.Run("happy path: the entities out of sync are listed", func(t *testing.T) {
+		server.ready = true
+		goliac.drifts = []engine.EntityDrift{
+			{
+				EntityType: "repository",
+				Name:       "repoA",
+				Operations: []config.GoliacOperation{
+					{Command: "update_repository_update_bool_property", Detail: "repositoryname: repoA archived:false"},
+					{Command: "update_repository_update_bool_property", Detail: "repositoryname: repoA private:true, not changing the visibility from public to private (allow_visibility_reduction is not set)", Skipped: true},
+				},
+			},
+		}
+
+		res := server.GetDrift(app.GetDriftParams{})
+		payload := res.(*app.GetDriftOK)
+		assert.Equal(t, 1, len(payload.Payload))
+		assert.Equal(t, "repository", payload.Payload[0].EntityType)
+		assert.Equal(t, "repoA", payload.Payload[0].Name)
+		assert.Equal(t, "update_repository_update_bool_property", payload.Payload[0].Operations[0].Command)
+		assert.False(t, payload.Payload[0].Operations[0].Skipped)
+		assert.True(t, payload.Payload[0].Operations[1].Skipped)
+		// the apply lock is released
+		assert.False(t, server.applyCurrent)
+	})
+
+	t.Run("happy path: the drift as a unified-diff-like text", func(t *testing.T) {
+		server.ready = true
+		goliac.drifts = []engine.EntityDrift{
+			{
+				EntityType: "team",
+				Name:       "platform",
+				Operations: []config.GoliacOperation{
+					{Command: "update_team_add_member", Detail: "teamslug: platform, ghuserid: alice, role: member"},
+					{Command: "update_team_remove_member", Detail: "teamslug: platform, ghuserid: bob"},
+				},
+			},
+		}
+
+		req := httptest.NewRequest("GET", "/api/v1/drift", nil)
+		req.Header.Set("Accept", "text/plain")
+		res := server.GetDrift(app.GetDriftParams{HTTPRequest: req})
+		rec := httptest.NewRecorder()
+		res.WriteResponse(rec, runtime.TextProducer())
+
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "team platform:\n+ update_team_add_member ghuserid: alice, role: member\n- update_team_remove_member ghuserid: bob\n", rec.Body.String())
+	})
+
+	t.Run("not happy path: a reconciliation is running", func(t *testing.T) {
+		server.applyCurrent = true
+		defer func() { server.applyCurrent = false }()
+
+		res := server.GetDrift(app.GetDriftParams{})
+		payload := res.(*app.GetDriftDefault)
+		assert.Equal(t, "a reconciliation is currently running", *payload.Payload.Message)
+	})
+}
+
+func TestAppGetExpiringAccesses(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	in10days := time.Now().Add(10 * 24 * time.Hour).Format(entity.ExpiresAtLayout)
+	repoB := localfixture.repositories["repoB"]
+	repoB.Spec.ExternalUserWriters = []string{"userE1"}
+	repoB.Spec.ExternalUsersExpiresAt = map[string]string{"userE1": in10days}
+
+	goliac := NewGoliacMock(localfixture, remotefixture)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+
+	t.Run("happy path: access expiring within the (default) window", func(t *testing.T) {
+		res := server.GetExpiringAccesses(app.GetExpiringAccessesParams{})
+		payload := res.(*app.GetExpiringAccessesOK)
+		assert.Equal(t, models.ExpiringAccesses{
+			{Repository: "repoB", Username: "userE1", Githubid: "githubE1", Permission: "write", ExpiresAt: in10days, Expired: false},
+		}, payload.Payload)
+	})
+
+	t.Run("happy path: no access expiring within a shorter window", func(t *testing.T) {
+		days := int64(5)
+		res := server.GetExpiringAccesses(app.GetExpiringAccessesParams{Days: &days})
+		payload := res.(*app.GetExpiringAccessesOK)
+		assert.Equal(t, 0, len(payload.Payload))
+	})
+}
+
+func TestPauseResume(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() {
+		config.Config.ServerGitRepository = repository
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notification.NewNullNotificationService(),
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: no apply while paused", func(t *testing.T) {
+		server.PostPause(app.PostPauseParams{})
+
+		res := server.GetStatus(app.GetStatusParams{})
+		assert.True(t, res.(*app.GetStatusOK).Payload.Paused)
+
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.False(t, applied)
+		assert.Equal(t, 0, goliac.nbApply)
+	})
+
+	t.Run("happy path: the skipped run is replayed when resumed", func(t *testing.T) {
+		server.PostResume(app.PostResumeParams{})
+
+		res := server.GetStatus(app.GetStatusParams{})
+		assert.False(t, res.(*app.GetStatusOK).Payload.Paused)
+
+		assert.Eventually(t, func() bool {
+			server.applyLobbyMutex.Lock()
+			defer server.applyLobbyMutex.Unlock()
+			return goliac.nbApply == 1 && !server.applyCurrent
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+type NotificationServiceRecorder struct {
+	messages []string
+}
+
+func (n *NotificationServiceRecorder) SendNotification(message string) error {
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func TestAppGetStatusOperations(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() { config.Config.ServerGitRepository = repository }()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: &NotificationServiceRecorder{},
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: no apply yet", func(t *testing.T) {
+		res := server.GetStatus(app.GetStatusParams{})
+		payload := res.(*app.GetStatusOK).Payload
+		assert.Equal(t, int64(0), payload.LastSyncOperations)
+		assert.Nil(t, payload.LastSyncOperationsBreakdown)
+	})
+
+	t.Run("happy path: operations of the last apply", func(t *testing.T) {
+		goliac.operations = []config.GoliacOperation{
+			{Command: "create_team", Detail: "teamname: foo"},
+			{Command: "update_team_add_members", Detail: "teamslug: foo"},
+			{Command: "update_repository_add_team", Detail: "repositoryname: bar"},
+			{Command: "delete_ruleset", Detail: "ruleset: old"},
+			{Command: "add_user_to_org", Detail: "ghuserid: alice"},
+		}
+		server.triggerApply()
+
+		res := server.GetStatus(app.GetStatusParams{})
+		payload := res.(*app.GetStatusOK).Payload
+		assert.Equal(t, int64(5), payload.LastSyncOperations)
+		assert.Equal(t, map[string]map[string]int64{
+			"team":       {"created": 1, "updated": 1, "deleted": 0},
+			"repository": {"created": 0, "updated": 1, "deleted": 0},
+			"ruleset":    {"created": 0, "updated": 0, "deleted": 1},
+			"user":       {"created": 1, "updated": 0, "deleted": 0},
+		}, payload.LastSyncOperationsBreakdown)
+		assert.Equal(t, server.lastTimeToApply.Milliseconds(), payload.LastSyncDurationMs)
+	})
+
+	t.Run("happy path: an apply without operations resets the counters", func(t *testing.T) {
+		goliac.operations = nil
+		server.triggerApply()
+
+		res := server.GetStatus(app.GetStatusParams{})
+		payload := res.(*app.GetStatusOK).Payload
+		assert.Equal(t, int64(0), payload.LastSyncOperations)
+		assert.Equal(t, 0, len(payload.LastSyncOperationsBreakdown))
+	})
+}
+
+func TestApplyWarnings(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() { config.Config.ServerGitRepository = repository }()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	notifications := &NotificationServiceRecorder{}
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notifications,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: the warnings of an apply are notified", func(t *testing.T) {
+		goliac.warnings = []string{"visibility flip-flop on the repository myrepo"}
+		server.triggerApply()
+
+		assert.Equal(t, []string{"Goliac warning: visibility flip-flop on the repository myrepo"}, notifications.messages)
+	})
+}
+
+func TestMultipleOrganizations(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	organization := config.Config.GithubAppOrganization
+	defer func() {
+		config.Config.ServerGitRepository = repository
+		config.Config.GithubAppOrganization = organization
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+	config.Config.GithubAppOrganization = "myorg"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	notifications := &NotificationServiceRecorder{}
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notifications,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: a single organization has no organizations status", func(t *testing.T) {
+		res := server.GetStatus(app.GetStatusParams{})
+		assert.Nil(t, res.(*app.GetStatusOK).Payload.Organizations)
+	})
+
+	t.Run("happy path: the status and the notifications are per organization", func(t *testing.T) {
+		goliac.orgErrors = map[string]error{
+			"myorg3": fmt.Errorf("not installed"),
+			"myorg2": nil,
+		}
+		goliac.operations = []config.GoliacOperation{
+			{Command: "delete_team", Detail: "teamslug: foo", Destructive: true, Organization: "myorg"},
+			{Command: "delete_team", Detail: "teamslug: foo", Destructive: true, Organization: "myorg2"},
+			{Command: "create_team", Detail: "teamname: bar", Organization: "myorg2"},
+		}
+		server.triggerApply()
+
+		res := server.GetStatus(app.GetStatusParams{})
+		payload := res.(*app.GetStatusOK).Payload
+		assert.Equal(t, "", payload.LastSyncError)
+		assert.Equal(t, []*models.StatusOrganization{
+			{Name: "myorg", LastSyncOperations: 1},
+			{Name: "myorg2", LastSyncOperations: 2},
+			{Name: "myorg3", LastSyncError: "not installed"},
+		}, payload.Organizations)
+
+		assert.Equal(t, 3, len(notifications.messages))
+		assert.Contains(t, notifications.messages[0], "delete_team (teamslug: foo) in myorg by")
+		assert.Contains(t, notifications.messages[1], "delete_team (teamslug: foo) in myorg2 by")
+		assert.Equal(t, "Goliac error when syncing the organization myorg3: not installed", notifications.messages[2])
+	})
+}
+
+func TestGetHealth(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac: goliac,
+	}
+
+	t.Run("happy path: not yet loaded", func(t *testing.T) {
+		res := server.GetHealth(health.GetHealthParams{})
+		payload := res.(*health.GetHealthServiceUnavailable).Payload
+		assert.Equal(t, "KO", payload.Status)
+		assert.Equal(t, "KO", payload.LocalState.Status)
+		assert.Equal(t, "OK", payload.TeamsRepository.Status)
+		assert.Equal(t, "OK", payload.GithubAPI.Status)
+		assert.Equal(t, "UNKNOWN", payload.LastApply.Status)
+	})
+
+	t.Run("happy path: everything is healthy", func(t *testing.T) {
+		now := time.Now()
+		server.ready = true
+		server.lastSyncTime = &now
+
+		res := server.GetHealth(health.GetHealthParams{})
+		payload := res.(*health.GetHealthOK).Payload
+		assert.Equal(t, "OK", payload.Status)
+		assert.Equal(t, "OK", payload.LocalState.Status)
+		assert.Equal(t, "OK", payload.LastApply.Status)
+	})
+
+	t.Run("not happy path: each failing dependency is reported", func(t *testing.T) {
+		goliac.gitErr = fmt.Errorf("authentication required")
+		goliac.githubErr = fmt.Errorf("unexpected status: 401 Unauthorized")
+		server.lastSyncError = fmt.Errorf("not able to apply")
+		defer func() {
+			goliac.gitErr = nil
+			goliac.githubErr = nil
+			server.lastSyncError = nil
+		}()
+
+		res := server.GetHealth(health.GetHealthParams{})
+		payload := res.(*health.GetHealthServiceUnavailable).Payload
+		assert.Equal(t, "KO", payload.Status)
+		assert.Equal(t, "OK", payload.LocalState.Status)
+		assert.Equal(t, &models.HealthCheck{Status: "KO", Message: "authentication required"}, payload.TeamsRepository)
+		assert.Equal(t, &models.HealthCheck{Status: "KO", Message: "unexpected status: 401 Unauthorized"}, payload.GithubAPI)
+		assert.Equal(t, &models.HealthCheck{Status: "KO", Message: "not able to apply"}, payload.LastApply)
+	})
+}
+
+func TestObserveOnly(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	observeOnly := config.Config.ServerObserveOnly
+	defer func() {
+		config.Config.ServerGitRepository = repository
+		config.Config.ServerObserveOnly = observeOnly
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+	config.Config.ServerObserveOnly = true
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	notifications := &NotificationServiceRecorder{}
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notifications,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: the status shows the observe mode", func(t *testing.T) {
+		res := server.GetStatus(app.GetStatusParams{})
+		assert.True(t, res.(*app.GetStatusOK).Payload.ObserveOnly)
+	})
+
+	t.Run("happy path: the plan is recorded and notified, but not applied", func(t *testing.T) {
+		goliac.operations = []config.GoliacOperation{
+			{Command: "delete_team", Detail: "teamslug: foo", Destructive: true},
+		}
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.True(t, applied)
+		assert.True(t, goliac.dryrun)
+
+		res := server.GetLastChanges(app.GetLastChangesParams{})
+		payload := res.(*app.GetLastChangesOK).Payload
+		assert.Equal(t, 1, len(payload))
+		assert.True(t, payload[0].Dryrun)
+
+		// the drift is notified (not as an applied deletion)
+		assert.Equal(t, 1, len(notifications.messages))
+		assert.Contains(t, notifications.messages[0], "observe mode")
+		assert.Contains(t, notifications.messages[0], "delete_team (teamslug: foo)")
+	})
+
+	t.Run("happy path: the same drift is notified once", func(t *testing.T) {
+		server.serveApply()
+		assert.Equal(t, 1, len(notifications.messages))
+	})
+
+	t.Run("not happy path: a team cannot be resynced", func(t *testing.T) {
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "ateam"})
+		assert.NotNil(t, res.(*app.PostResyncTeamDefault))
+		assert.Equal(t, 0, len(goliac.resynced))
+	})
+}
+
+func TestApplyWindows(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() { config.Config.ServerGitRepository = repository }()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	now := time.Now().UTC()
+	// a window that is never now (the day after, in UTC)
+	day := strings.ToLower(now.AddDate(0, 0, 1).Weekday().String()[:3])
+	applyWindows, err := ParseApplyWindows([]string{day + " 00:00-01:00"}, "UTC")
+	assert.Nil(t, err)
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	notifications := &NotificationServiceRecorder{}
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notifications,
+		applyWindows:        applyWindows,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+	goliac.operations = []config.GoliacOperation{
+		{Command: "create_team", Detail: "teamname: foo"},
+	}
+
+	t.Run("happy path: outside the windows, the plan is notified but not applied", func(t *testing.T) {
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.True(t, applied)
+		assert.True(t, goliac.dryrun)
+
+		assert.Equal(t, 1, len(notifications.messages))
+		assert.Contains(t, notifications.messages[0], "outside the apply windows")
+		assert.Contains(t, notifications.messages[0], applyWindows.Next(now).Format(time.RFC3339))
+		assert.Contains(t, notifications.messages[0], "create_team (teamname: foo)")
+	})
+
+	t.Run("not happy path: a team cannot be resynced outside the windows", func(t *testing.T) {
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "ateam"})
+		assert.NotNil(t, res.(*app.PostResyncTeamDefault))
+		assert.Equal(t, 0, len(goliac.resynced))
+	})
+
+	t.Run("happy path: a forced apply ignores the windows", func(t *testing.T) {
+		force := true
+		res := server.PostApply(app.PostApplyParams{Force: &force})
+		assert.NotNil(t, res.(*app.PostApplyOK))
+		assert.False(t, goliac.dryrun)
+	})
+}
+
+func TestErrorNotificationCooldown(t *testing.T) {
+	cooldown := config.Config.ServerErrorNotificationCooldown
+	defer func() { config.Config.ServerErrorNotificationCooldown = cooldown }()
+	config.Config.ServerErrorNotificationCooldown = 600
+
+	server := GoliacServerImpl{}
+	now := time.Now()
+
+	t.Run("happy path: flapping errors are notified once per window", func(t *testing.T) {
+		notified := []string{}
+		for i, message := range []string{"A", "B", "A", "B", "A"} {
+			if notify, _ := server.shouldNotifyError(message, now.Add(time.Duration(i)*time.Minute)); notify {
+				notified = append(notified, message)
+			}
+		}
+		assert.Equal(t, []string{"A", "B"}, notified)
+	})
+
+	t.Run("happy path: the suppressed occurrences are reported after the window", func(t *testing.T) {
+		notify, suppressed := server.shouldNotifyError("A", now.Add(11*time.Minute))
+		assert.True(t, notify)
+		assert.Equal(t, 2, suppressed)
+
+		notify, suppressed = server.shouldNotifyError("B", now.Add(12*time.Minute))
+		assert.True(t, notify)
+		assert.Equal(t, 1, suppressed)
+	})
+
+	t.Run("happy path: no cooldown", func(t *testing.T) {
+		config.Config.ServerErrorNotificationCooldown = 0
+		notify, _ := server.shouldNotifyError("A", now.Add(11*time.Minute))
+		assert.True(t, notify)
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	threshold := config.Config.ServerCircuitBreakerThreshold
+	defer func() {
+		config.Config.ServerGitRepository = repository
+		config.Config.ServerCircuitBreakerThreshold = threshold
+	}()
+	// the applies fail without a teams repository
+	config.Config.ServerGitRepository = ""
+	config.Config.ServerCircuitBreakerThreshold = 3
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	notifications := &NotificationServiceRecorder{}
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notifications,
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("not happy path: the circuit opens after consecutive failures", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			server.triggerApply()
+		}
+		assert.True(t, server.circuitOpen.Load())
+		res := server.GetStatus(app.GetStatusParams{})
+		assert.True(t, res.(*app.GetStatusOK).Payload.CircuitOpen)
+		// the (same) error and the circuit opening
+		assert.Equal(t, 2, len(notifications.messages))
+
+		// no more automatic apply
+		server.triggerApply()
+		assert.Equal(t, int64(3), server.consecutiveFailures.Load())
+		assert.Equal(t, 2, len(notifications.messages))
+	})
+
+	t.Run("happy path: an explicit resync closes the circuit", func(t *testing.T) {
+		config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+		server.PostResync(app.PostResyncParams{})
+		assert.False(t, server.circuitOpen.Load())
+
+		assert.Eventually(t, func() bool {
+			server.applyLobbyMutex.Lock()
+			defer server.applyLobbyMutex.Unlock()
+			return goliac.nbApply == 1 && !server.applyCurrent
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+// responseStatus returns the HTTP status code written by a responder
+func responseStatus(res middleware.Responder) int {
+	w := httptest.NewRecorder()
+	res.WriteResponse(w, runtime.JSONProducer())
+	return w.Code
+}
+
+func TestAppPostApply(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	timeout := config.Config.ServerSyncApplyTimeout
+	defer func() {
+		config.Config.ServerGitRepository = repository
+		config.Config.ServerSyncApplyTimeout = timeout
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+	config.Config.ServerSyncApplyTimeout = 1
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notification.NewNullNotificationService(),
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: the applied operations are returned", func(t *testing.T) {
+		recorded := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+		goliac.operations = []config.GoliacOperation{
+			{Command: "create_team", Detail: "teamname: foo"},
+			{Command: "delete_team", Detail: "teamname: bar", Destructive: true, Reason: "team merged", Organization: "myorg", Timestamp: recorded},
+			{Command: "update_repository_update_bool_property", Detail: "repositoryname: baz", Skipped: true},
+		}
+		res := server.PostApply(app.PostApplyParams{})
+		payload := res.(*app.PostApplyOK).Payload
+		assert.Equal(t, 1, goliac.nbApply)
+		assert.False(t, payload.Dryrun)
+		assert.Equal(t, 3, len(payload.Operations))
+		assert.Equal(t, "create_team", payload.Operations[0].Command)
+		assert.Equal(t, "team merged", payload.Operations[1].Reason)
+		assert.Equal(t, "myorg", payload.Operations[1].Organization)
+		assert.Equal(t, "2024-05-01T10:30:00Z", payload.Operations[1].Timestamp)
+		assert.True(t, payload.Operations[2].Skipped)
+		assert.NotNil(t, server.lastSyncTime)
+	})
+
+	t.Run("not happy path: the apply is skipped while paused", func(t *testing.T) {
+		server.paused.Store(true)
+		defer server.paused.Store(false)
+
+		res := server.PostApply(app.PostApplyParams{})
+		assert.Equal(t, 409, responseStatus(res))
+		assert.Equal(t, 1, goliac.nbApply)
+	})
+
+	t.Run("not happy path: the apply fails", func(t *testing.T) {
+		config.Config.ServerGitRepository = ""
+		defer func() { config.Config.ServerGitRepository = "https://github.com/myorg/teams" }()
+
+		res := server.PostApply(app.PostApplyParams{})
+		assert.Equal(t, 500, responseStatus(res))
+		assert.NotNil(t, server.lastSyncError)
+	})
+
+	t.Run("happy path: only a successful apply closes the circuit", func(t *testing.T) {
+		server.circuitOpen.Store(true)
+		defer server.circuitOpen.Store(false)
+
+		config.Config.ServerGitRepository = ""
+		res := server.PostApply(app.PostApplyParams{})
+		config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+		assert.Equal(t, 500, responseStatus(res))
+		assert.True(t, server.circuitOpen.Load())
+
+		res = server.PostApply(app.PostApplyParams{})
+		assert.Equal(t, 200, responseStatus(res))
+		assert.False(t, server.circuitOpen.Load())
+	})
+
+	t.Run("not happy path: the apply is waiting for the current one", func(t *testing.T) {
+		server.applyLobbyMutex.Lock()
+		server.applyCurrent = true
+		server.applyLobbyMutex.Unlock()
+
+		res := server.PostApply(app.PostApplyParams{})
+		assert.Equal(t, 504, responseStatus(res))
+
+		// the queued apply runs once the current one is done
+		server.releaseApply()
+		assert.Eventually(t, func() bool {
+			server.applyLobbyMutex.Lock()
+			defer server.applyLobbyMutex.Unlock()
+			return goliac.nbApply == 3 && !server.applyCurrent
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	defer func() {
+		config.Config.ServerGitRepository = repository
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notification.NewNullNotificationService(),
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: wait for the in-flight apply", func(t *testing.T) {
+		server.applyCurrent = true
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			server.releaseApply()
+		}()
+
+		assert.True(t, server.waitForApply(5*time.Second))
+	})
+
+	t.Run("not happy path: the in-flight apply times out", func(t *testing.T) {
+		server.applyCurrent = true
+		defer server.releaseApply()
+
+		assert.False(t, server.waitForApply(200*time.Millisecond))
+	})
+
+	t.Run("happy path: no new apply once stopping", func(t *testing.T) {
+		server.shuttingDown.Store(true)
+
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.False(t, applied)
+		assert.Equal(t, 0, goliac.nbApply)
+
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "ateam"})
+		assert.NotNil(t, res.(*app.PostResyncTeamDefault))
+		assert.Equal(t, 0, len(goliac.resynced))
+	})
+}
+
+func TestAppPostResyncTeam(t *testing.T) {
+	localfixture, remotefixture := fixtureGoliacLocal()
+	goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+	server := GoliacServerImpl{
+		goliac:              goliac,
+		notificationService: notification.NewNullNotificationService(),
+	}
+	server.applyLobbyCond = sync.NewCond(&server.applyLobbyMutex)
+
+	t.Run("happy path: resync a team", func(t *testing.T) {
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "ateam"})
+		assert.NotNil(t, res.(*app.PostResyncTeamOK))
+		assert.Equal(t, []string{"ateam"}, goliac.resynced)
+		assert.False(t, server.applyCurrent)
+	})
+
+	t.Run("not happy path: team not found", func(t *testing.T) {
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "unknown"})
+		assert.NotNil(t, res.(*app.PostResyncTeamDefault))
+		assert.Equal(t, 1, len(goliac.resynced))
+	})
+
+	t.Run("not happy path: a full apply is running", func(t *testing.T) {
+		server.applyCurrent = true
+		defer func() { server.applyCurrent = false }()
+
+		res := server.PostResyncTeam(app.PostResyncTeamParams{TeamID: "ateam"})
+		assert.NotNil(t, res.(*app.PostResyncTeamDefault))
+		assert.Equal(t, 1, len(goliac.resynced))
+	})
+}
+
+func TestApplyHooks(t *testing.T) {
+	repository := config.Config.ServerGitRepository
+	preApplyHookURL := config.Config.ServerPreApplyHookURL
+	postApplyHookURL := config.Config.ServerPostApplyHookURL
+	preApplyHookVeto := config.Config.ServerPreApplyHookVeto
+	defer func() {
+		config.Config.ServerGitRepository = repository
+		config.Config.ServerPreApplyHookURL = preApplyHookURL
+		config.Config.ServerPostApplyHookURL = postApplyHookURL
+		config.Config.ServerPreApplyHookVeto = preApplyHookVeto
+	}()
+	config.Config.ServerGitRepository = "https://github.com/myorg/teams"
+
+	newServer := func() (*GoliacServerImpl, *GoliacMock) {
+		localfixture, remotefixture := fixtureGoliacLocal()
+		goliac := NewGoliacMock(localfixture, remotefixture).(*GoliacMock)
+		return &GoliacServerImpl{
+			goliac: goliac,
+		}, goliac
+	}
+
+	t.Run("happy path: pre and post apply hooks are called", func(t *testing.T) {
+		events := []ApplyHookPayload{}
+		hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload ApplyHookPayload
+			err := json.NewDecoder(r.Body).Decode(&payload)
+			assert.Nil(t, err)
+			events = append(events, payload)
+		}))
+		defer hook.Close()
+		config.Config.ServerPreApplyHookURL = hook.URL
+		config.Config.ServerPostApplyHookURL = hook.URL
+		config.Config.ServerPreApplyHookVeto = true
+
+		server, goliac := newServer()
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.True(t, applied)
+		assert.Equal(t, 1, goliac.nbApply)
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, "pre_apply", events[0].Event)
+		assert.Equal(t, "post_apply", events[1].Event)
+		// only the post apply event has a success
+		assert.Nil(t, events[0].Success)
+		assert.NotNil(t, events[1].Success)
+		assert.True(t, *events[1].Success)
+		assert.Equal(t, "https://github.com/myorg/teams", events[1].Repository)
+	})
+
+	t.Run("happy path: a failed apply is posted with success false", func(t *testing.T) {
+		failed := false
+		jsonPayload, err := json.Marshal(&ApplyHookPayload{
+			Event:   "post_apply",
+			Success: &failed,
+			Error:   "not able to apply",
+		})
+		assert.Nil(t, err)
+
+		var payload map[string]interface{}
+		assert.Nil(t, json.Unmarshal(jsonPayload, &payload))
+		success, ok := payload["success"]
+		assert.True(t, ok)
+		assert.Equal(t, false, success)
+
+		// the pre apply event has no success
+		jsonPayload, err = json.Marshal(&ApplyHookPayload{Event: "pre_apply"})
+		assert.Nil(t, err)
+		payload = map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(jsonPayload, &payload))
+		_, ok = payload["success"]
+		assert.False(t, ok)
+	})
+
+	t.Run("not happy path: the pre apply hook vetoes the apply", func(t *testing.T) {
+		hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer hook.Close()
+		config.Config.ServerPreApplyHookURL = hook.URL
+		config.Config.ServerPostApplyHookURL = ""
+		config.Config.ServerPreApplyHookVeto = true
+
+		server, goliac := newServer()
+		err, _, _, applied := server.serveApply()
+		assert.NotNil(t, err)
+		assert.False(t, applied)
+		assert.Equal(t, 0, goliac.nbApply)
+	})
+
+	t.Run("happy path: the pre apply hook vetoes don't open the circuit", func(t *testing.T) {
+		hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer hook.Close()
+		config.Config.ServerPreApplyHookURL = hook.URL
+		config.Config.ServerPostApplyHookURL = ""
+		config.Config.ServerPreApplyHookVeto = true
+		threshold := config.Config.ServerCircuitBreakerThreshold
+		defer func() { config.Config.ServerCircuitBreakerThreshold = threshold }()
+		config.Config.ServerCircuitBreakerThreshold = 2
+
+		server, goliac := newServer()
+		server.notificationService = notification.NewNullNotificationService()
+		for i := 0; i < 3; i++ {
+			server.triggerApply()
+		}
+		assert.Equal(t, 0, goliac.nbApply)
+		assert.Equal(t, int64(0), server.consecutiveFailures.Load())
+		assert.False(t, server.circuitOpen.Load())
+	})
+
+	t.Run("happy path: the pre apply hook failure is ignored without veto", func(t *testing.T) {
+		hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer hook.Close()
+		config.Config.ServerPreApplyHookURL = hook.URL
+		config.Config.ServerPostApplyHookURL = ""
+		config.Config.ServerPreApplyHookVeto = false
+
+		server, goliac := newServer()
+		err, _, _, applied := server.serveApply()
+		assert.Nil(t, err)
+		assert.True(t, applied)
+		assert.Equal(t, 1, goliac.nbApply)
+	})
 }
@@ -368,6 +368,8 @@ type GoliacRemoteExecutorMock struct {
 	teams1Members []string
 	teams2Members []string
 	nbChanges     int
+	nbLoads       int
+	usersRemoved  []string
 }
 
 // GoliacRemoteExecutorMock
@@ -380,6 +382,7 @@ func NewGoliacRemoteExecutorMock() engine.GoliacRemoteExecutor {
 }
 
 func (e *GoliacRemoteExecutorMock) Load(ctx context.Context, continueOnError bool) error {
+	e.nbLoads++
 	return nil
 }
 func (e *GoliacRemoteExecutorMock) FlushCache() {
@@ -545,9 +548,22 @@ func (e *GoliacRemoteExecutorMock) AddUserToOrg(ctx context.Context, dryrun bool
 	fmt.Println("*** AddUserToOrg", ghuserid)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) CancelOrgInvitation(ctx context.Context, dryrun bool, invitation *engine.GithubOrgInvitation) {
+	fmt.Println("*** CancelOrgInvitation", invitation.Login)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) RemoveUserFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
 	fmt.Println("*** RemoveUserFromOrg", ghuserid)
 	e.nbChanges++
+	e.usersRemoved = append(e.usersRemoved, ghuserid)
+}
+func (e *GoliacRemoteExecutorMock) RemoveOutsideCollaboratorFromOrg(ctx context.Context, dryrun bool, ghuserid string) {
+	fmt.Println("*** RemoveOutsideCollaboratorFromOrg", ghuserid)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateUserOrgRole(ctx context.Context, dryrun bool, ghuserid string, role string) {
+	fmt.Println("*** UpdateUserOrgRole", ghuserid, role)
+	e.nbChanges++
 }
 
 func (e *GoliacRemoteExecutorMock) CreateTeam(ctx context.Context, dryrun bool, teamname string, description string, parentTeam *int, members []string) {
@@ -566,11 +582,27 @@ func (e *GoliacRemoteExecutorMock) UpdateTeamRemoveMember(ctx context.Context, d
 	fmt.Println("*** UpdateTeamRemoveMember", teamslug, username)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) UpdateTeamAddMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string, role string) {
+	fmt.Println("*** UpdateTeamAddMembers", teamslug, usernames, role)
+	e.nbChanges += len(usernames)
+}
+func (e *GoliacRemoteExecutorMock) UpdateTeamRemoveMembers(ctx context.Context, dryrun bool, teamslug string, usernames []string) {
+	fmt.Println("*** UpdateTeamRemoveMembers", teamslug, usernames)
+	e.nbChanges += len(usernames)
+}
+func (e *GoliacRemoteExecutorMock) UpdateTeamReviewAssignment(ctx context.Context, dryrun bool, teamslug string, reviewAssignment *engine.GithubTeamReviewAssignment) {
+	fmt.Println("*** UpdateTeamReviewAssignment", teamslug, reviewAssignment)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateTeamNotificationSetting(ctx context.Context, dryrun bool, teamslug string, notificationSetting string) {
+	fmt.Println("*** UpdateTeamNotificationSetting", teamslug, notificationSetting)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) UpdateTeamSetParent(ctx context.Context, dryrun bool, teamslug string, parentTeam *int) {
 	fmt.Println("*** UpdateTeamSetParent", teamslug, parentTeam)
 	e.nbChanges++
 }
-func (e *GoliacRemoteExecutorMock) DeleteTeam(ctx context.Context, dryrun bool, teamslug string) {
+func (e *GoliacRemoteExecutorMock) DeleteTeam(ctx context.Context, dryrun bool, teamslug string, reason string) {
 	fmt.Println("*** DeleteTeam", teamslug)
 	e.nbChanges++
 }
@@ -579,10 +611,18 @@ func (e *GoliacRemoteExecutorMock) CreateRepository(ctx context.Context, dryrun
 	fmt.Println("*** CreateRepository", reponame, descrition, writers, readers, boolProperties)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) CreateRepositoryFork(ctx context.Context, dryrun bool, reponame string, upstream string, writers []string, readers []string, boolProperties map[string]bool) {
+	fmt.Println("*** CreateRepositoryFork", reponame, upstream, writers, readers, boolProperties)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) UpdateRepositoryUpdateBoolProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue bool) {
 	fmt.Println("*** UpdateRepositoryUpdateBoolProperty", reponame, propertyName, propertyValue)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryUpdateStringProperties(ctx context.Context, dryrun bool, reponame string, properties map[string]string) {
+	fmt.Println("*** UpdateRepositoryUpdateStringProperties", reponame, properties)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) UpdateRepositoryAddTeamAccess(ctx context.Context, dryrun bool, reponame string, teamslug string, permission string) {
 	fmt.Println("*** UpdateRepositoryAddTeamAccess", reponame, teamslug, permission)
 	e.nbChanges++
@@ -627,18 +667,146 @@ func (e *GoliacRemoteExecutorMock) UpdateRepositoryRemoveExternalUser(ctx contex
 	fmt.Println("*** UpdateRepositoryRemoveExternalUser", reponame, githubid)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) UpdateRepositorySetCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string, propertyValue string) {
+	fmt.Println("*** UpdateRepositorySetCustomProperty", reponame, propertyName, propertyValue)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryRemoveCustomProperty(ctx context.Context, dryrun bool, reponame string, propertyName string) {
+	fmt.Println("*** UpdateRepositoryRemoveCustomProperty", reponame, propertyName)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) UpdateRepositoryRemoveInternalUser(ctx context.Context, dryrun bool, reponame string, githubid string) {
 	fmt.Println("*** UpdateRepositoryRemoveInternalUser", reponame, githubid)
 	e.nbChanges++
 }
-func (e *GoliacRemoteExecutorMock) DeleteRepository(ctx context.Context, dryrun bool, reponame string) {
+func (e *GoliacRemoteExecutorMock) DeleteRepository(ctx context.Context, dryrun bool, reponame string, reason string) {
 	fmt.Println("*** DeleteRepository", reponame)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) OrgSettings(ctx context.Context) map[string]bool {
+	return map[string]bool{}
+}
+func (e *GoliacRemoteExecutorMock) OrgActionsSettings(ctx context.Context) map[string]string {
+	return map[string]string{}
+}
+func (e *GoliacRemoteExecutorMock) OrgAllowedActions(ctx context.Context) *engine.GithubOrgAllowedActions {
+	return nil
+}
+func (e *GoliacRemoteExecutorMock) OrgProfile(ctx context.Context) map[string]string {
+	return map[string]string{}
+}
+func (e *GoliacRemoteExecutorMock) OrgDefaultBranchName(ctx context.Context) string {
+	return ""
+}
+func (e *GoliacRemoteExecutorMock) OutsideCollaborators(ctx context.Context) map[string]bool {
+	return map[string]bool{}
+}
+func (e *GoliacRemoteExecutorMock) OrgWebhooks(ctx context.Context) map[string]*engine.GithubOrgWebhook {
+	return map[string]*engine.GithubOrgWebhook{}
+}
+func (e *GoliacRemoteExecutorMock) OrgInvitations(ctx context.Context) map[string]*engine.GithubOrgInvitation {
+	return map[string]*engine.GithubOrgInvitation{}
+}
+func (e *GoliacRemoteExecutorMock) OrgSecurityManagerTeams(ctx context.Context) map[string]bool {
+	return map[string]bool{}
+}
+func (e *GoliacRemoteExecutorMock) RepositoriesFile(ctx context.Context, reponames []string, filename string) (map[string]*engine.GithubFile, error) {
+	return map[string]*engine.GithubFile{}, nil
+}
+func (e *GoliacRemoteExecutorMock) RepositoriesLabels(ctx context.Context, reponames []string) (map[string]map[string]*engine.GithubLabel, error) {
+	return map[string]map[string]*engine.GithubLabel{}, nil
+}
+func (e *GoliacRemoteExecutorMock) RepositoriesDependabot(ctx context.Context, reponames []string) (map[string]*engine.GithubRepositoryDependabot, error) {
+	return map[string]*engine.GithubRepositoryDependabot{}, nil
+}
+func (e *GoliacRemoteExecutorMock) OrgProjects(ctx context.Context) (map[string]*engine.GithubProject, error) {
+	return map[string]*engine.GithubProject{}, nil
+}
+func (e *GoliacRemoteExecutorMock) RepositoriesProjects(ctx context.Context, reponames []string) (map[string]map[string]*engine.GithubProject, error) {
+	return map[string]map[string]*engine.GithubProject{}, nil
+}
+func (e *GoliacRemoteExecutorMock) RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error) {
+	return map[string]map[string]int{}, nil
+}
+func (e *GoliacRemoteExecutorMock) RefreshTeam(ctx context.Context, teamslug string) error {
+	return nil
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgSetting(ctx context.Context, dryrun bool, settingName string, settingValue bool) {
+	fmt.Println("*** UpdateOrgSetting", settingName, settingValue)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgActionsSetting(ctx context.Context, dryrun bool, settingName string, settingValue string) {
+	fmt.Println("*** UpdateOrgActionsSetting", settingName, settingValue)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgAllowedActions(ctx context.Context, dryrun bool, allowedActions *engine.GithubOrgAllowedActions) {
+	fmt.Println("*** UpdateOrgAllowedActions", allowedActions.AllowedActions, allowedActions.PatternsAllowed)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgProfileField(ctx context.Context, dryrun bool, fieldName string, fieldValue string) {
+	fmt.Println("*** UpdateOrgProfileField", fieldName, fieldValue)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgDefaultBranchName(ctx context.Context, dryrun bool, branchName string) {
+	fmt.Println("*** UpdateOrgDefaultBranchName", branchName)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) AddOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	fmt.Println("*** AddOrgWebhook", webhook.Url)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	fmt.Println("*** UpdateOrgWebhook", webhook.Url)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) DeleteOrgWebhook(ctx context.Context, dryrun bool, webhook *engine.GithubOrgWebhook) {
+	fmt.Println("*** DeleteOrgWebhook", webhook.Url)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) AddOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	fmt.Println("*** AddOrgSecurityManagerTeam", teamslug)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) RemoveOrgSecurityManagerTeam(ctx context.Context, dryrun bool, teamslug string) {
+	fmt.Println("*** RemoveOrgSecurityManagerTeam", teamslug)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) AddRepositoryLabel(ctx context.Context, dryrun bool, reponame string, label *engine.GithubLabel) {
+	fmt.Println("*** AddRepositoryLabel", reponame, label.Name)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string, label *engine.GithubLabel) {
+	fmt.Println("*** UpdateRepositoryLabel", reponame, labelname)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) DeleteRepositoryLabel(ctx context.Context, dryrun bool, reponame string, labelname string) {
+	fmt.Println("*** DeleteRepositoryLabel", reponame, labelname)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryDependabotAlerts(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	fmt.Println("*** UpdateRepositoryDependabotAlerts", reponame, enabled)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) LinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *engine.GithubProject) {
+	fmt.Println("*** LinkRepositoryProject", reponame, project.Title)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UnlinkRepositoryProject(ctx context.Context, dryrun bool, reponame string, project *engine.GithubProject) {
+	fmt.Println("*** UnlinkRepositoryProject", reponame, project.Title)
+	e.nbChanges++
+}
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryDependabotSecurityUpdates(ctx context.Context, dryrun bool, reponame string, enabled bool) {
+	fmt.Println("*** UpdateRepositoryDependabotSecurityUpdates", reponame, enabled)
+	e.nbChanges++
+}
 func (e *GoliacRemoteExecutorMock) RenameRepository(ctx context.Context, dryrun bool, reponame string, newname string) {
 	fmt.Println("*** RenameRepository", reponame, newname)
 	e.nbChanges++
 }
+func (e *GoliacRemoteExecutorMock) UpdateRepositoryFile(ctx context.Context, dryrun bool, reponame string, filename string, content string, sha string) {
+	fmt.Println("*** UpdateRepositoryFile", reponame, filename)
+	e.nbChanges++
+}
 
 func (e *GoliacRemoteExecutorMock) Begin(dryrun bool) {
 }
@@ -667,7 +835,9 @@ func TestGoliacApply(t *testing.T) {
 
 		local := engine.NewGoliacLocalImpl()
 
-		errs, warns := local.LoadAndValidateLocal(clonedFs)
+		repoconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, warns := local.LoadAndValidateLocal(clonedFs, repoconfig)
 		assert.Equal(t, len(errs), 0)
 		assert.Equal(t, len(warns), 0)
 
@@ -705,7 +875,9 @@ func TestGoliacApply(t *testing.T) {
 
 		local := engine.NewGoliacLocalImpl()
 
-		errs, warns := local.LoadAndValidateLocal(clonedFs)
+		repoconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, warns := local.LoadAndValidateLocal(clonedFs, repoconfig)
 		assert.Equal(t, len(errs), 0)
 		assert.Equal(t, len(warns), 0)
 
@@ -722,12 +894,147 @@ func TestGoliacApply(t *testing.T) {
 			repoconfig:         &config.RepositoryConfig{},
 		}
 
-		err, errs, warns, unmanaged := goliac.Apply(context.Background(), fs, false, "inmemory:///src", "master")
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.Background(), config.ContextKeyChanges, &changes)
+		err, errs, warns, unmanaged := goliac.Apply(ctx, fs, false, "inmemory:///src", "master")
 		assert.Nil(t, err)
 		assert.Equal(t, len(errs), 0)
 		assert.Equal(t, len(warns), 0)
 		assert.NotNil(t, unmanaged)
 		assert.Equal(t, 1, remote.nbChanges) // 1 team renamed
+		// the change is attributed to the last commit's author
+		assert.Equal(t, "goliac@example.com", changes.Author)
+	})
+
+	t.Run("happy path: additional organizations", func(t *testing.T) {
+		organization := config.Config.GithubAppOrganization
+		config.Config.GithubAppOrganization = "myorg"
+		defer func() { config.Config.GithubAppOrganization = organization }()
+
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)        // create a fake bare repository
+		fs.MkdirAll("teams", 0755)      // create a fake cloned repository
+		fs.MkdirAll(os.TempDir(), 0755) // need a tmp folder
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixtureRename)
+		assert.Nil(t, err)
+
+		local := engine.NewGoliacLocalImpl()
+
+		repoconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, warns := local.LoadAndValidateLocal(clonedFs, repoconfig)
+		assert.Equal(t, len(errs), 0)
+		assert.Equal(t, len(warns), 0)
+
+		githubClient := NewGitHubClientMock()
+		remote := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		remote2 := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+
+		usersync.InitPlugins(githubClient)
+
+		goliac := GoliacImpl{
+			local:              local,
+			remote:             remote,
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         &config.RepositoryConfig{},
+			organizations: []*goliacOrganization{
+				{name: "myorg2", remote: remote2},
+			},
+		}
+
+		changes := config.GoliacChanges{}
+		ctx := context.WithValue(context.Background(), config.ContextKeyChanges, &changes)
+		err, errs, _, _ = goliac.Apply(ctx, fs, false, "inmemory:///src", "master")
+		assert.Nil(t, err)
+		assert.Equal(t, len(errs), 0)
+		// the same definitions are applied to each organization
+		assert.Equal(t, 1, remote.nbChanges)
+		// (and the ruleset of the teams repository is updated, as it doesn't live in myorg2)
+		assert.Equal(t, 2, remote2.nbChanges)
+
+		operations := []string{}
+		for _, o := range changes.Operations {
+			operations = append(operations, o.Organization+" "+o.Command)
+		}
+		assert.Equal(t, []string{"myorg rename_repository", "myorg2 rename_repository", "myorg2 update_ruleset"}, operations)
+		assert.Equal(t, map[string]error{"myorg2": nil}, goliac.GetOrganizationsErrors())
+	})
+
+	t.Run("happy path: additional organizations with their own org-level settings", func(t *testing.T) {
+		organization := config.Config.GithubAppOrganization
+		config.Config.GithubAppOrganization = "myorg"
+		defer func() { config.Config.GithubAppOrganization = organization }()
+
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)        // create a fake bare repository
+		fs.MkdirAll("teams", 0755)      // create a fake cloned repository
+		fs.MkdirAll(os.TempDir(), 0755) // need a tmp folder
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixtureRename)
+		assert.Nil(t, err)
+
+		local := engine.NewGoliacLocalImpl()
+
+		localconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, _ := local.LoadAndValidateLocal(clonedFs, localconfig)
+		assert.Equal(t, len(errs), 0)
+
+		githubClient := NewGitHubClientMock()
+		remote := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		remote2 := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		remote3 := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+
+		usersync.InitPlugins(githubClient)
+
+		mainCompany := "main company"
+		org2Company := "org2 company"
+		repoconfig := &config.RepositoryConfig{
+			MaxChangesets: 50,
+			Organizations: map[string]config.OrganizationSettings{
+				"MyOrg2": {OrgProfile: config.OrgProfile{Company: &org2Company}},
+			},
+		}
+		repoconfig.OrgProfile.Company = &mainCompany
+
+		goliac := GoliacImpl{
+			local:              local,
+			remote:             remote,
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         repoconfig,
+			organizations: []*goliacOrganization{
+				{name: "myorg2", remote: remote2},
+				{name: "myorg3", remote: remote3},
+			},
+		}
+
+		changes := config.GoliacChanges{Dryrun: true}
+		ctx := context.WithValue(context.Background(), config.ContextKeyChanges, &changes)
+
+		// the archived/renamed/deleted repositories of an additional organization are persisted
+		reposToArchive := make(map[string]*engine.GithubRepoComparable)
+		reposToRename := make(map[string]*entity.Repository)
+		reposToDelete := make(map[string]bool)
+		err = goliac.reconciliateOrganization(ctx, goliac.organizations[0], true, reposToArchive, reposToRename, reposToDelete)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(reposToRename))
+
+		err = goliac.reconciliateOrganization(ctx, goliac.organizations[1], true, reposToArchive, reposToRename, reposToDelete)
+		assert.Nil(t, err)
+
+		// the org profile of the main organization is not copied to the additional ones
+		profiles := []string{}
+		for _, o := range changes.Operations {
+			if o.Command == "update_org_profile" {
+				profiles = append(profiles, o.Organization+" "+o.Detail)
+			}
+		}
+		assert.Equal(t, []string{`myorg2 setting: company "" -> "org2 company"`}, profiles)
 	})
 
 	t.Run("happy path: user4 to sync", func(t *testing.T) {
@@ -743,7 +1050,9 @@ func TestGoliacApply(t *testing.T) {
 
 		local := engine.NewGoliacLocalImpl()
 
-		errs, warns := local.LoadAndValidateLocal(clonedFs)
+		repoconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, warns := local.LoadAndValidateLocal(clonedFs, repoconfig)
 		assert.Equal(t, 0, len(errs))
 		assert.Equal(t, 1, len(warns))
 		assert.Equal(t, "not enough owners for team filename teams/team2/team.yaml", warns[0].Error())
@@ -770,3 +1079,253 @@ func TestGoliacApply(t *testing.T) {
 
 	})
 }
+
+func TestGoliacPlanFromRef(t *testing.T) {
+	t.Run("happy path: plan a branch", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)        // create a fake bare repository
+		fs.MkdirAll("teams", 0755)      // create a fake cloned repository
+		fs.MkdirAll(os.TempDir(), 0755) // need a tmp folder
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		srcRepo, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixture1)
+		assert.Nil(t, err)
+
+		// a branch adding a team
+		master, err := srcRepo.Head()
+		assert.Nil(t, err)
+		worktree, err := srcRepo.Worktree()
+		assert.Nil(t, err)
+		srcsFs.MkdirAll("teams/team3", 0755)
+		utils.WriteFile(srcsFs, "teams/team3/team.yaml", []byte(`apiVersion: v1
+kind: Team
+name: team3
+spec:
+  owners:
+    - user1
+    - user3
+`), 0644)
+		_, err = worktree.Add("teams/team3/team.yaml")
+		assert.Nil(t, err)
+		hash, err := worktree.Commit("add team3", &git.CommitOptions{
+			Author: &object.Signature{Name: "Goliac", Email: "goliac@example.com", When: time.Now()},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, srcRepo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("newteam"), hash)))
+		assert.Nil(t, srcRepo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, master.Hash())))
+
+		local := engine.NewGoliacLocalImpl()
+		repoconfig, err := engine.LoadRepoConfigFromFs(clonedFs)
+		assert.Nil(t, err)
+		errs, _ := local.LoadAndValidateLocal(clonedFs, repoconfig)
+		assert.Equal(t, 0, len(errs))
+
+		githubClient := NewGitHubClientMock()
+		remote := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+
+		goliac := GoliacImpl{
+			local:              local,
+			remote:             remote,
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         &config.RepositoryConfig{},
+		}
+
+		err, errs, _, operations := goliac.PlanFromRef(context.Background(), fs, "inmemory:///src", "newteam")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(errs))
+		// team3 (and its owners team) would be created
+		commands := []string{}
+		for _, o := range operations {
+			commands = append(commands, o.Command+" "+o.Detail)
+		}
+		assert.Contains(t, strings.Join(commands, "\n"), "create_team teamname: team3")
+		// nothing applied, and the loaded teams repository is untouched
+		assert.Equal(t, 0, remote.nbChanges)
+		assert.Equal(t, 2, len(goliac.GetLocal().Teams()))
+	})
+
+	t.Run("not happy path: unknown ref", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)
+		fs.MkdirAll("teams", 0755)
+		fs.MkdirAll(os.TempDir(), 0755)
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixture1)
+		assert.Nil(t, err)
+
+		githubClient := NewGitHubClientMock()
+		goliac := GoliacImpl{
+			local:              engine.NewGoliacLocalImpl(),
+			remote:             NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock),
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         &config.RepositoryConfig{},
+		}
+
+		err, _, _, operations := goliac.PlanFromRef(context.Background(), fs, "inmemory:///src", "unknown")
+		assert.NotNil(t, err)
+		assert.Nil(t, operations)
+	})
+}
+
+func TestGoliacComparePlans(t *testing.T) {
+	t.Run("happy path: compare master and a branch", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)
+		fs.MkdirAll("teams", 0755)
+		fs.MkdirAll(os.TempDir(), 0755)
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		srcRepo, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixture1)
+		assert.Nil(t, err)
+
+		// a branch adding a team
+		master, err := srcRepo.Head()
+		assert.Nil(t, err)
+		worktree, err := srcRepo.Worktree()
+		assert.Nil(t, err)
+		srcsFs.MkdirAll("teams/team3", 0755)
+		utils.WriteFile(srcsFs, "teams/team3/team.yaml", []byte(`apiVersion: v1
+kind: Team
+name: team3
+spec:
+  owners:
+    - user1
+    - user3
+`), 0644)
+		_, err = worktree.Add("teams/team3/team.yaml")
+		assert.Nil(t, err)
+		hash, err := worktree.Commit("add team3", &git.CommitOptions{
+			Author: &object.Signature{Name: "Goliac", Email: "goliac@example.com", When: time.Now()},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, srcRepo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("newteam"), hash)))
+		assert.Nil(t, srcRepo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, master.Hash())))
+
+		githubClient := NewGitHubClientMock()
+		remote := NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock)
+		goliac := GoliacImpl{
+			local:              engine.NewGoliacLocalImpl(),
+			remote:             remote,
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         &config.RepositoryConfig{},
+		}
+
+		err, errs, _, comparison := goliac.ComparePlans(context.Background(), fs, "inmemory:///src", "master", "newteam")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(errs))
+		added := []string{}
+		for _, o := range comparison.Added {
+			added = append(added, o.Command+" "+o.Detail)
+		}
+		assert.Contains(t, strings.Join(added, "\n"), "create_team teamname: team3")
+		assert.Equal(t, 0, len(comparison.Removed))
+		// the Github state is loaded once for both plans
+		assert.Equal(t, 1, remote.nbLoads)
+		assert.Equal(t, 0, remote.nbChanges)
+
+		// and the other way around
+		err, _, _, comparison = goliac.ComparePlans(context.Background(), fs, "inmemory:///src", "newteam", "master")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(comparison.Added))
+		assert.Equal(t, len(added), len(comparison.Removed))
+	})
+
+	t.Run("not happy path: unknown base ref", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)
+		fs.MkdirAll("teams", 0755)
+		fs.MkdirAll(os.TempDir(), 0755)
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixture1)
+		assert.Nil(t, err)
+
+		githubClient := NewGitHubClientMock()
+		goliac := GoliacImpl{
+			local:              engine.NewGoliacLocalImpl(),
+			remote:             NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock),
+			remoteGithubClient: githubClient,
+			localGithubClient:  githubClient,
+			repoconfig:         &config.RepositoryConfig{},
+		}
+
+		err, _, _, comparison := goliac.ComparePlans(context.Background(), fs, "inmemory:///src", "unknown", "master")
+		assert.NotNil(t, err)
+		assert.Nil(t, comparison)
+	})
+}
+
+func TestCheckTeamsRepository(t *testing.T) {
+	fs := memfs.New()
+	fs.MkdirAll("src", 0755)
+	fs.MkdirAll("teams", 0755)
+	fs.MkdirAll(os.TempDir(), 0755)
+	srcsFs, _ := fs.Chroot("src")
+	clonedFs, _ := fs.Chroot("teams")
+	_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixture1)
+	assert.Nil(t, err)
+
+	githubClient := NewGitHubClientMock()
+	goliac := GoliacImpl{
+		local:              engine.NewGoliacLocalImpl(),
+		remote:             NewGoliacRemoteExecutorMock().(*GoliacRemoteExecutorMock),
+		remoteGithubClient: githubClient,
+		localGithubClient:  githubClient,
+		repoconfig:         &config.RepositoryConfig{},
+	}
+
+	t.Run("happy path: the teams repository is reachable", func(t *testing.T) {
+		err := goliac.CheckTeamsRepository(context.Background(), "inmemory:///src")
+		assert.Nil(t, err)
+	})
+
+	t.Run("not happy path: the teams repository doesn't exist", func(t *testing.T) {
+		err := goliac.CheckTeamsRepository(context.Background(), "inmemory:///unknown")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("not happy path: not an https url", func(t *testing.T) {
+		err := goliac.CheckTeamsRepository(context.Background(), "git@github.com:myorg/teams.git")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestParseReasonTrailer(t *testing.T) {
+	t.Run("happy path: reason trailer", func(t *testing.T) {
+		reason := parseReasonTrailer("removing the legacy team\n\nGoliac-Reason: merged into platform\n")
+		assert.Equal(t, "merged into platform", reason)
+	})
+
+	t.Run("happy path: no reason trailer", func(t *testing.T) {
+		reason := parseReasonTrailer("removing the legacy team\n\nSigned-off-by: someone")
+		assert.Equal(t, "", reason)
+	})
+}
+
+func TestGoliacLoadAndValidateLocal(t *testing.T) {
+	t.Run("happy path: local directory without goliac.yaml", func(t *testing.T) {
+		fs := memfs.New()
+		fs.MkdirAll("src", 0755)        // create a fake bare repository
+		fs.MkdirAll("teams", 0755)      // create a fake cloned repository
+		fs.MkdirAll(os.TempDir(), 0755) // need a tmp folder
+		srcsFs, _ := fs.Chroot("src")
+		clonedFs, _ := fs.Chroot("teams")
+		_, _, err := helperCreateAndClone(fs, srcsFs, clonedFs, repoFixtureRename)
+		assert.Nil(t, err)
+		assert.Nil(t, clonedFs.Remove("goliac.yaml"))
+
+		goliac := GoliacImpl{
+			local:      engine.NewGoliacLocalImpl(),
+			repoconfig: &config.RepositoryConfig{},
+		}
+
+		// the default configuration is used
+		err, errs, _ := goliac.loadAndValidateGoliacOrganization(context.TODO(), fs, "teams", "master")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(errs))
+	})
+}
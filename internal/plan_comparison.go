@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Alayacare/goliac/internal/config"
+)
+
+/*
+PlanComparison is the difference between the plans of 2 refs of the teams
+repository, computed against the same Github state:
+  - Added are the operations only in the head plan (added by the head ref)
+  - Removed are the operations only in the base plan (removed by the head ref)
+*/
+type PlanComparison struct {
+	Added   []config.GoliacOperation `json:"added"`
+	Removed []config.GoliacOperation `json:"removed"`
+}
+
+// planOperationKey identifies an operation (the reason is not part of it)
+func planOperationKey(o config.GoliacOperation) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%t\x00%t\x00%s", o.Organization, o.Command, o.Detail, o.Simulated, o.Skipped, strings.Join(o.Changes, "\x00"))
+}
+
+/*
+diffPlans returns the operations of the head plan not in the base plan, and
+the ones of the base plan not in the head plan (keeping their order). An
+operation present n times in a plan and m times in the other one is
+reported |n-m| times
+*/
+func diffPlans(base, head []config.GoliacOperation) *PlanComparison {
+	comparison := &PlanComparison{
+		Added:   []config.GoliacOperation{},
+		Removed: []config.GoliacOperation{},
+	}
+
+	inBase := make(map[string]int)
+	for _, o := range base {
+		inBase[planOperationKey(o)]++
+	}
+	inHead := make(map[string]int)
+	for _, o := range head {
+		key := planOperationKey(o)
+		if inBase[key] > 0 {
+			inBase[key]--
+			inHead[key]++
+			continue
+		}
+		comparison.Added = append(comparison.Added, o)
+	}
+	for _, o := range base {
+		key := planOperationKey(o)
+		if inHead[key] > 0 {
+			inHead[key]--
+			continue
+		}
+		comparison.Removed = append(comparison.Removed, o)
+	}
+	return comparison
+}
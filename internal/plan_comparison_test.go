@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/Alayacare/goliac/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPlans(t *testing.T) {
+	createTeam := config.GoliacOperation{Command: "create_team", Detail: "team newteam"}
+	addMember := config.GoliacOperation{Command: "update_team_add_member", Detail: "team newteam, member user1"}
+	deleteRepo := config.GoliacOperation{Command: "delete_repository", Detail: "repository repo1", Destructive: true}
+
+	t.Run("happy path: identical plans", func(t *testing.T) {
+		comparison := diffPlans([]config.GoliacOperation{createTeam, addMember}, []config.GoliacOperation{addMember, createTeam})
+		assert.Equal(t, 0, len(comparison.Added))
+		assert.Equal(t, 0, len(comparison.Removed))
+	})
+
+	t.Run("happy path: operations added and removed", func(t *testing.T) {
+		comparison := diffPlans([]config.GoliacOperation{createTeam, deleteRepo}, []config.GoliacOperation{createTeam, addMember})
+		assert.Equal(t, []config.GoliacOperation{addMember}, comparison.Added)
+		assert.Equal(t, []config.GoliacOperation{deleteRepo}, comparison.Removed)
+	})
+
+	t.Run("happy path: the same operation several times", func(t *testing.T) {
+		comparison := diffPlans([]config.GoliacOperation{addMember}, []config.GoliacOperation{addMember, addMember})
+		assert.Equal(t, []config.GoliacOperation{addMember}, comparison.Added)
+		assert.Equal(t, 0, len(comparison.Removed))
+	})
+
+	t.Run("happy path: the operation of another organization or skipped differs", func(t *testing.T) {
+		otherOrg := createTeam
+		otherOrg.Organization = "other"
+		skipped := deleteRepo
+		skipped.Skipped = true
+		comparison := diffPlans([]config.GoliacOperation{createTeam, deleteRepo}, []config.GoliacOperation{otherOrg, skipped})
+		assert.Equal(t, []config.GoliacOperation{otherOrg, skipped}, comparison.Added)
+		assert.Equal(t, []config.GoliacOperation{createTeam, deleteRepo}, comparison.Removed)
+	})
+}
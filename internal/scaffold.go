@@ -6,12 +6,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/Alayacare/goliac/internal/config"
 	"github.com/Alayacare/goliac/internal/engine"
 	"github.com/Alayacare/goliac/internal/entity"
-	"github.com/Alayacare/goliac/internal/github"
 	"github.com/Alayacare/goliac/internal/observability"
 	"github.com/Alayacare/goliac/internal/utils"
 	"github.com/go-git/go-billy/v5"
@@ -30,8 +30,7 @@ type Scaffold struct {
 }
 
 func NewScaffold() (*Scaffold, error) {
-	githubClient, err := github.NewGitHubClientImpl(
-		config.Config.GithubServer,
+	githubClient, err := newGithubClient(
 		config.Config.GithubAppOrganization,
 		config.Config.GithubAppID,
 		config.Config.GithubAppPrivateKeyFile,
@@ -41,7 +40,7 @@ func NewScaffold() (*Scaffold, error) {
 		return nil, err
 	}
 
-	remote := engine.NewGoliacRemoteImpl(githubClient)
+	remote := engine.NewGoliacRemoteImpl(githubClient, config.Config.GithubAppOrganization)
 
 	loadUsersFromGithubOrgSaml := func(feedback observability.RemoteObservability) (map[string]*entity.User, error) {
 		ctx := context.Background()
@@ -110,6 +109,12 @@ func (s *Scaffold) generate(ctx context.Context, fs billy.Filesystem, adminteam
 		return fmt.Errorf("error creating the teams directory: %v", err)
 	}
 
+	if !usersOnly {
+		if err := s.generateArchived(ctx, fs, "archived"); err != nil {
+			return fmt.Errorf("error creating the archived directory: %v", err)
+		}
+	}
+
 	if err := s.generateRuleset(fs, "rulesets"); err != nil {
 		return fmt.Errorf("error creating the rulesets directory: %v", err)
 	}
@@ -148,8 +153,8 @@ func (s *Scaffold) generateTeams(ctx context.Context, fs billy.Filesystem, teams
 	repoAdmin := make(map[string]string)
 	teamsRepos := make(map[string][]string)
 	// to get all teams access per repo
-	repoWrite := make(map[string][]string)
-	repoRead := make(map[string][]string)
+	repoTeams := s.teamsRepositoriesPermissions(ctx)
+	rRepos := s.remote.Repositories(ctx)
 
 	// let's create the goliac admin team first
 	admins := []string{}
@@ -166,39 +171,31 @@ func (s *Scaffold) generateTeams(ctx context.Context, fs billy.Filesystem, teams
 	teamsSlugByName[adminteam] = adminteam
 	teamsNameBySlug[adminteam] = adminteam
 
-	// searching for ADMIN first
-	for team, tr := range teamsRepositories {
-		for reponame, repo := range tr {
-			if repo.Permission == "ADMIN" {
-				// if there is no admin attached yet to this repo
-				if _, ok := repoAdmin[reponame]; !ok {
-					repoAdmin[reponame] = team
-					teamsRepos[team] = append(teamsRepos[team], reponame)
+	// searching for ADMIN first, and WRITE second
+	for _, permission := range []string{"ADMIN", "WRITE"} {
+		for team, tr := range teamsRepositories {
+			for reponame, repo := range tr {
+				// archived repositories are not owned by a team
+				if rRepo, ok := rRepos[reponame]; ok && rRepo.BoolProperties["archived"] {
+					continue
 				}
-				repoWrite[reponame] = append(repoWrite[reponame], teamsNameBySlug[team])
-			}
-		}
-	}
-	// searching for WRITE second
-	for team, tr := range teamsRepositories {
-		for reponame, repo := range tr {
-			if repo.Permission == "WRITE" {
-				// if there is no admin attached yet to this repo
-				if _, ok := repoAdmin[reponame]; !ok {
-					repoAdmin[reponame] = team
-					teamsRepos[team] = append(teamsRepos[team], reponame)
+				if repo.Permission == permission {
+					// if there is no admin attached yet to this repo
+					if _, ok := repoAdmin[reponame]; !ok {
+						repoAdmin[reponame] = team
+						teamsRepos[team] = append(teamsRepos[team], reponame)
+					}
 				}
-				repoWrite[reponame] = append(repoWrite[reponame], teamsNameBySlug[team])
-			}
-			if repo.Permission != "ADMIN" && repo.Permission != "WRITE" {
-				repoRead[reponame] = append(repoRead[reponame], teamsNameBySlug[team])
 			}
 		}
 	}
 
 	countOrphaned := 0
 	// orphan repos should go to the admin team
-	for repo := range s.remote.Repositories(ctx) {
+	for repo, rRepo := range rRepos {
+		if rRepo.BoolProperties["archived"] {
+			continue
+		}
 		if _, ok := repoAdmin[repo]; !ok {
 			logrus.Debugf("repo %s is orphaned, attaching it to the admin (%s) team", repo, adminteam)
 			repoAdmin[repo] = adminteam
@@ -262,66 +259,14 @@ func (s *Scaffold) generateTeams(ctx context.Context, fs billy.Filesystem, teams
 			}
 
 			// write repos
-			rRepos := s.remote.Repositories(ctx)
 			for _, r := range repos {
 				lRepo := entity.Repository{}
 				lRepo.ApiVersion = "v1"
 				lRepo.Kind = "Repository"
 				lRepo.Name = r
-				lRepo.Spec.Writers = repoWrite[r]
-				lRepo.Spec.Readers = repoRead[r]
-
-				// scaffoldling repository rulesets
-				if rRepo, ok := rRepos[r]; ok {
-					rRulesets := rRepo.RuleSets
-					if rRulesets != nil {
-						lRepo.Spec.Rulesets = make([]entity.RepositoryRuleSet, 0, len(rRulesets))
-
-						for rRulesetname, rRuleset := range rRulesets {
-							lRuleset := entity.RepositoryRuleSet{
-								Name: rRulesetname,
-							}
-							lRuleset.Enforcement = rRuleset.Enforcement
-							for appname, mode := range rRuleset.BypassApps {
-								lRuleset.BypassApps = append(lRuleset.BypassApps, struct {
-									AppName string
-									Mode    string
-								}{
-									AppName: appname,
-									Mode:    mode,
-								})
-							}
-							lRuleset.Conditions.Include = rRuleset.OnInclude
-							lRuleset.Conditions.Exclude = rRuleset.OnExclude
-							for rulename, rulespec := range rRuleset.Rules {
-								lRuleset.Rules = append(lRuleset.Rules, struct {
-									Ruletype   string
-									Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
-								}{
-									Ruletype:   rulename,
-									Parameters: rulespec,
-								})
-							}
-
-							lRepo.Spec.Rulesets = append(lRepo.Spec.Rulesets, lRuleset)
-						}
-					}
-				}
 
-				// removing team name from writer
-				for i, t := range lRepo.Spec.Writers {
-					if teamsSlugByName[t] == team {
-						lRepo.Spec.Writers = append(lRepo.Spec.Writers[:i], lRepo.Spec.Writers[i+1:]...)
-						break
-					}
-				}
-				// removing team owner (especially for the special case teams repo)
-				for i, t := range lRepo.Spec.Writers {
-					if strings.HasSuffix(t, config.Config.GoliacTeamOwnerSuffix) {
-						lRepo.Spec.Writers = append(lRepo.Spec.Writers[:i], lRepo.Spec.Writers[i+1:]...)
-						break
-					}
-				}
+				s.fillRepositorySpec(&lRepo, rRepos[r], repoTeams[r], team, teamsNameBySlug)
+
 				if err := writeYamlFile(path.Join(teamspath, teamPath, r+".yaml"), &lRepo, fs); err != nil {
 					logrus.Errorf("not able to write repo file %s/%s.yaml: %v", team, r, err)
 				}
@@ -386,6 +331,179 @@ func (s *Scaffold) generateTeams(ctx context.Context, fs billy.Filesystem, teams
 	return nil
 }
 
+/*
+ * teamsRepositoriesPermissions returns a map[<reponame>]map[<teamslug>]<permission>
+ */
+func (s *Scaffold) teamsRepositoriesPermissions(ctx context.Context) map[string]map[string]string {
+	repoTeams := make(map[string]map[string]string)
+	for team, tr := range s.remote.TeamRepositories(ctx) {
+		for reponame, repo := range tr {
+			if _, ok := repoTeams[reponame]; !ok {
+				repoTeams[reponame] = make(map[string]string)
+			}
+			repoTeams[reponame][team] = repo.Permission
+		}
+	}
+	return repoTeams
+}
+
+/*
+ * fillRepositorySpec translates the Github repository (teams access, properties,
+ * collaborators and rulesets) into the repository spec, such as a reconciliation
+ * against the same Github state doesn't produce any change.
+ */
+func (s *Scaffold) fillRepositorySpec(lRepo *entity.Repository, rRepo *engine.GithubRepository, teamsPermissions map[string]string, owner string, teamsNameBySlug map[string]string) {
+	teamslugs := make([]string, 0, len(teamsPermissions))
+	for teamslug := range teamsPermissions {
+		teamslugs = append(teamslugs, teamslug)
+	}
+	sort.Strings(teamslugs)
+
+	for _, teamslug := range teamslugs {
+		permission := teamsPermissions[teamslug]
+		// the owner has an implicit write access
+		if teamslug == owner && permission == "WRITE" {
+			continue
+		}
+		// team owners are managed by Goliac (especially for the special case teams repo)
+		if strings.HasSuffix(teamslug, config.Config.GoliacTeamOwnerSuffix) {
+			continue
+		}
+		teamname := teamslug
+		if name, ok := teamsNameBySlug[teamslug]; ok {
+			teamname = name
+		}
+		switch permission {
+		case "ADMIN":
+			lRepo.Spec.Admins = append(lRepo.Spec.Admins, teamname)
+		case "MAINTAIN":
+			lRepo.Spec.Maintainers = append(lRepo.Spec.Maintainers, teamname)
+		case "WRITE":
+			lRepo.Spec.Writers = append(lRepo.Spec.Writers, teamname)
+		case "TRIAGE":
+			lRepo.Spec.Triagers = append(lRepo.Spec.Triagers, teamname)
+		default:
+			lRepo.Spec.Readers = append(lRepo.Spec.Readers, teamname)
+		}
+	}
+
+	if rRepo == nil {
+		return
+	}
+
+	if private, ok := rRepo.BoolProperties["private"]; ok {
+		lRepo.Spec.IsPublic = !private
+	}
+	lRepo.Spec.AllowAutoMerge = rRepo.BoolProperties["allow_auto_merge"]
+	lRepo.Spec.DeleteBranchOnMerge = rRepo.BoolProperties["delete_branch_on_merge"]
+	lRepo.Spec.AllowUpdateBranch = rRepo.BoolProperties["allow_update_branch"]
+
+	// outside collaborators (their user files are in the users/external directory)
+	for githubid, permission := range rRepo.ExternalUsers {
+		switch permission {
+		case "ADMIN":
+			lRepo.Spec.ExternalUserAdmins = append(lRepo.Spec.ExternalUserAdmins, githubid)
+		case "WRITE":
+			lRepo.Spec.ExternalUserWriters = append(lRepo.Spec.ExternalUserWriters, githubid)
+		default:
+			lRepo.Spec.ExternalUserReaders = append(lRepo.Spec.ExternalUserReaders, githubid)
+		}
+	}
+	sort.Strings(lRepo.Spec.ExternalUserAdmins)
+	sort.Strings(lRepo.Spec.ExternalUserWriters)
+	sort.Strings(lRepo.Spec.ExternalUserReaders)
+
+	// custom properties (only loaded for Enterprise)
+	if len(rRepo.CustomProperties) > 0 {
+		lRepo.Spec.CustomProperties = make(map[string]string)
+		for k, v := range rRepo.CustomProperties {
+			lRepo.Spec.CustomProperties[k] = v
+		}
+	}
+
+	// scaffoldling repository rulesets (sorted, so the generated files don't change from run to run)
+	rRulesets := rRepo.RuleSets
+	if rRulesets != nil {
+		lRepo.Spec.Rulesets = make([]entity.RepositoryRuleSet, 0, len(rRulesets))
+
+		rulesetnames := make([]string, 0, len(rRulesets))
+		for rRulesetname := range rRulesets {
+			rulesetnames = append(rulesetnames, rRulesetname)
+		}
+		sort.Strings(rulesetnames)
+
+		for _, rRulesetname := range rulesetnames {
+			rRuleset := rRulesets[rRulesetname]
+			lRuleset := entity.RepositoryRuleSet{
+				Name: rRulesetname,
+			}
+			lRuleset.Enforcement = rRuleset.Enforcement
+
+			appnames := make([]string, 0, len(rRuleset.BypassApps))
+			for appname := range rRuleset.BypassApps {
+				appnames = append(appnames, appname)
+			}
+			sort.Strings(appnames)
+			for _, appname := range appnames {
+				lRuleset.BypassApps = append(lRuleset.BypassApps, struct {
+					AppName string
+					Mode    string
+				}{
+					AppName: appname,
+					Mode:    rRuleset.BypassApps[appname],
+				})
+			}
+			lRuleset.Conditions.Include = rRuleset.OnInclude
+			lRuleset.Conditions.Exclude = rRuleset.OnExclude
+
+			rulenames := make([]string, 0, len(rRuleset.Rules))
+			for rulename := range rRuleset.Rules {
+				rulenames = append(rulenames, rulename)
+			}
+			sort.Strings(rulenames)
+			for _, rulename := range rulenames {
+				lRuleset.Rules = append(lRuleset.Rules, struct {
+					Ruletype   string
+					Parameters entity.RuleSetParameters `yaml:"parameters,omitempty"`
+				}{
+					Ruletype:   rulename,
+					Parameters: rRuleset.Rules[rulename],
+				})
+			}
+
+			lRepo.Spec.Rulesets = append(lRepo.Spec.Rulesets, lRuleset)
+		}
+	}
+}
+
+/*
+ * generateArchived writes the archived Github repositories into the archived directory
+ */
+func (s *Scaffold) generateArchived(ctx context.Context, fs billy.Filesystem, archivedpath string) error {
+	repoTeams := s.teamsRepositoriesPermissions(ctx)
+	teamsNameBySlug := make(map[string]string)
+	for k, v := range s.remote.TeamSlugByName(ctx) {
+		teamsNameBySlug[v] = k
+	}
+
+	for reponame, rRepo := range s.remote.Repositories(ctx) {
+		if !rRepo.BoolProperties["archived"] {
+			continue
+		}
+		lRepo := entity.Repository{}
+		lRepo.ApiVersion = "v1"
+		lRepo.Kind = "Repository"
+		lRepo.Name = reponame
+
+		s.fillRepositorySpec(&lRepo, rRepo, repoTeams[reponame], "", teamsNameBySlug)
+
+		if err := writeYamlFile(path.Join(archivedpath, reponame+".yaml"), &lRepo, fs); err != nil {
+			logrus.Errorf("not able to write repo file %s/%s.yaml: %v", archivedpath, reponame, err)
+		}
+	}
+	return nil
+}
+
 func buildTeamPath(teamIds map[int]*engine.GithubTeam, team *engine.GithubTeam) (string, error) {
 	maxRecursive := 100
 	fullpath := team.Name
@@ -418,6 +536,8 @@ func (s *Scaffold) generateUsers(ctx context.Context, fs billy.Filesystem, users
 	fs.MkdirAll(path.Join(userspath, "external"), 0755)
 
 	usermap := make(map[string]string)
+	// keep the current organization owners
+	orgRoles := s.remote.Users(ctx)
 	// test SAML integration
 	users, err := s.loadUsersFromGithubOrgSaml(s.feedback)
 
@@ -425,6 +545,9 @@ func (s *Scaffold) generateUsers(ctx context.Context, fs billy.Filesystem, users
 		logrus.Debug("SAML integration enabled")
 		for username, user := range users {
 			usermap[user.Spec.GithubID] = username
+			if orgRoles[user.Spec.GithubID] == "ADMIN" {
+				user.Spec.OrgRole = "admin"
+			}
 			if err := writeYamlFile(path.Join(userspath, "org", username+".yaml"), &user, fs); err != nil {
 				logrus.Errorf("Not able to write user file org/%s.yaml: %v", username, err)
 			}
@@ -432,13 +555,16 @@ func (s *Scaffold) generateUsers(ctx context.Context, fs billy.Filesystem, users
 	} else {
 		// fail back on github id
 		logrus.Debug("SAML integration disabled")
-		for githubid := range s.remote.Users(ctx) {
+		for githubid, role := range orgRoles {
 			usermap[githubid] = githubid
 			user := entity.User{}
 			user.ApiVersion = "v1"
 			user.Kind = "User"
 			user.Name = githubid
 			user.Spec.GithubID = githubid
+			if role == "ADMIN" {
+				user.Spec.OrgRole = "admin"
+			}
 
 			if err := writeYamlFile(path.Join(userspath, "org", githubid+".yaml"), user, fs); err != nil {
 				logrus.Errorf("Not able to write user file org/%s.yaml: %v", githubid, err)
@@ -446,26 +572,43 @@ func (s *Scaffold) generateUsers(ctx context.Context, fs billy.Filesystem, users
 		}
 	}
 
+	// outside collaborators
+	for _, repo := range s.remote.Repositories(ctx) {
+		for githubid := range repo.ExternalUsers {
+			user := entity.User{}
+			user.ApiVersion = "v1"
+			user.Kind = "User"
+			user.Name = githubid
+			user.Spec.GithubID = githubid
+
+			if err := writeYamlFile(path.Join(userspath, "external", githubid+".yaml"), user, fs); err != nil {
+				logrus.Errorf("Not able to write user file external/%s.yaml: %v", githubid, err)
+			}
+		}
+	}
+
 	return usermap, nil
 }
 
 func (s *Scaffold) generateRuleset(fs billy.Filesystem, rulesetspath string) error {
+	// (no Goliac app to bypass the ruleset with a personal access token)
+	bypassapps := "  bypassapps: []\n"
+	if s.githubappname != "" {
+		bypassapps = fmt.Sprintf("  bypassapps:\n    - appname: %s\n      mode: always\n", s.githubappname)
+	}
 	ruleset := fmt.Sprintf(`apiVersion: v1
 kind: Ruleset
 name: default
 spec:
   enforcement: active
-  bypassapps:
-    - appname: %s
-      mode: always
-  conditions:
+%s  conditions:
     include: 
     - "~DEFAULT_BRANCH"
   rules:
     - ruletype: pull_request
       parameters:
         requiredApprovingReviewCount: 1
-`, s.githubappname)
+`, bypassapps)
 	if err := writeFile(path.Join(rulesetspath, "default.yaml"), []byte(ruleset), fs); err != nil {
 		return err
 	}
@@ -607,8 +750,8 @@ spec:
   externallyManaged: true
 ` + "```" + `
 
-It will mean that the team is managed outside of Goliac, and that Goliac will not touch it.
-You can still "attach" repositories to this team, but you will have to manage the team members by yourself.
+It will mean that the team members are managed outside of Goliac (for example synchronized from your identity provider), and that Goliac will never add or remove them.
+Goliac still creates the team, and you can still "attach" repositories to this team.
 
 `
 	if err := writeFile(filepath.Join(rootpath, "README.md"), []byte(readme), fs); err != nil {
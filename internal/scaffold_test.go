@@ -54,6 +54,54 @@ func (s *ScaffoldGoliacRemoteMock) RuleSets(ctx context.Context) map[string]*eng
 func (s *ScaffoldGoliacRemoteMock) AppIds(ctx context.Context) map[string]int {
 	return nil
 }
+func (s *ScaffoldGoliacRemoteMock) OrgSettings(ctx context.Context) map[string]bool {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgActionsSettings(ctx context.Context) map[string]string {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgAllowedActions(ctx context.Context) *engine.GithubOrgAllowedActions {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgProfile(ctx context.Context) map[string]string {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgDefaultBranchName(ctx context.Context) string {
+	return ""
+}
+func (s *ScaffoldGoliacRemoteMock) OrgWebhooks(ctx context.Context) map[string]*engine.GithubOrgWebhook {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgInvitations(ctx context.Context) map[string]*engine.GithubOrgInvitation {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgSecurityManagerTeams(ctx context.Context) map[string]bool {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) OutsideCollaborators(ctx context.Context) map[string]bool {
+	return nil
+}
+func (s *ScaffoldGoliacRemoteMock) RepositoriesFile(ctx context.Context, reponames []string, filename string) (map[string]*engine.GithubFile, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) RepositoriesLabels(ctx context.Context, reponames []string) (map[string]map[string]*engine.GithubLabel, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) RepositoriesDependabot(ctx context.Context, reponames []string) (map[string]*engine.GithubRepositoryDependabot, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) OrgProjects(ctx context.Context) (map[string]*engine.GithubProject, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) RepositoriesProjects(ctx context.Context, reponames []string) (map[string]map[string]*engine.GithubProject, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) RepositoriesChecksIntegrations(ctx context.Context, reponames []string) (map[string]map[string]int, error) {
+	return nil, nil
+}
+func (s *ScaffoldGoliacRemoteMock) RefreshTeam(ctx context.Context, teamslug string) error {
+	return nil
+}
 func (s *ScaffoldGoliacRemoteMock) IsEnterprise() bool {
 	return true
 }
@@ -193,6 +241,74 @@ func NewScaffoldGoliacRemoteMockWithMaintainers() engine.GoliacRemote {
 	return &mock
 }
 
+func NewScaffoldGoliacRemoteMockWithPermissions() engine.GoliacRemote {
+	users := make(map[string]string)
+	teams := make(map[string]*engine.GithubTeam)
+	repos := make(map[string]*engine.GithubRepository)
+	teamsRepos := make(map[string]map[string]*engine.GithubTeamRepo)
+
+	users["githubid1"] = "githubid1"
+	users["githubid2"] = "githubid2"
+
+	teams["admin"] = &engine.GithubTeam{
+		Name:    "admin",
+		Slug:    "admin",
+		Members: []string{"githubid1"},
+	}
+	teams["regular"] = &engine.GithubTeam{
+		Name:    "regular",
+		Slug:    "regular",
+		Members: []string{"githubid2"},
+	}
+	teams["other"] = &engine.GithubTeam{
+		Name:    "other",
+		Slug:    "other",
+		Members: []string{"githubid2"},
+	}
+
+	repos["repo1"] = &engine.GithubRepository{
+		Name: "repo1",
+		BoolProperties: map[string]bool{
+			"private":                false,
+			"archived":               false,
+			"allow_auto_merge":       true,
+			"delete_branch_on_merge": true,
+			"allow_update_branch":    false,
+		},
+		ExternalUsers: map[string]string{
+			"outside1": "WRITE",
+			"outside2": "READ",
+		},
+	}
+	repos["repo2"] = &engine.GithubRepository{
+		Name: "repo2",
+		BoolProperties: map[string]bool{
+			"private":  true,
+			"archived": true,
+		},
+	}
+
+	teamsRepos["regular"] = map[string]*engine.GithubTeamRepo{
+		"repo1": {Name: "repo1", Permission: "ADMIN"},
+		"repo2": {Name: "repo2", Permission: "WRITE"},
+	}
+	teamsRepos["other"] = map[string]*engine.GithubTeamRepo{
+		"repo1": {Name: "repo1", Permission: "TRIAGE"},
+	}
+	teamsRepos["admin"] = map[string]*engine.GithubTeamRepo{
+		"repo1": {Name: "repo1", Permission: "MAINTAIN"},
+	}
+
+	mock := ScaffoldGoliacRemoteMock{
+		users:      users,
+		teams:      teams,
+		repos:      repos,
+		teamsRepos: teamsRepos,
+	}
+
+	return &mock
+}
+
 func LoadGithubSamlUsersMock(feedback observability.RemoteObservability) (map[string]*entity.User, error) {
 	users := make(map[string]*entity.User)
 	user1 := &entity.User{}
@@ -230,8 +346,11 @@ func TestScaffoldUnit(t *testing.T) {
 		fs := memfs.New()
 		// MockGithubClient doesn't support concurrent access
 
+		remote := NewScaffoldGoliacRemoteMock().(*ScaffoldGoliacRemoteMock)
+		// githubid1 is an owner of the organization
+		remote.users["githubid1"] = "ADMIN"
 		scaffold := &Scaffold{
-			remote:                     NewScaffoldGoliacRemoteMock(),
+			remote:                     remote,
 			loadUsersFromGithubOrgSaml: NoLoadGithubSamlUsersMock,
 		}
 
@@ -243,6 +362,11 @@ func TestScaffoldUnit(t *testing.T) {
 		found, err := utils.Exists(fs, "/users/org/githubid1.yaml")
 		assert.Nil(t, err)
 		assert.Equal(t, true, found)
+
+		orgUsers, errs, _ := entity.ReadUserDirectory(fs, "/users/org")
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, "admin", orgUsers["githubid1"].GetOrgRole())
+		assert.Equal(t, "member", orgUsers["githubid2"].GetOrgRole())
 	})
 
 	t.Run("happy path: test users SAML", func(t *testing.T) {
@@ -281,6 +405,80 @@ func TestScaffoldUnit(t *testing.T) {
 		assert.Equal(t, true, found)
 	})
 
+	t.Run("happy path: test rulesets bypass app", func(t *testing.T) {
+		for _, appname := range []string{"goliac-app", ""} {
+			fs := memfs.New()
+
+			scaffold := &Scaffold{
+				remote:                     NewScaffoldGoliacRemoteMock(),
+				loadUsersFromGithubOrgSaml: LoadGithubSamlUsersMock,
+				githubappname:              appname,
+			}
+
+			err := scaffold.generateRuleset(fs, "/rulesets")
+			assert.Nil(t, err)
+
+			ruleset, err := entity.NewRuleSet(fs, "/rulesets/default.yaml")
+			assert.Nil(t, err)
+			assert.Nil(t, ruleset.Validate("/rulesets/default.yaml"))
+			if appname == "" {
+				// authenticated with a personal access token: no Goliac app
+				assert.Equal(t, 0, len(ruleset.Spec.BypassApps))
+			} else {
+				assert.Equal(t, 1, len(ruleset.Spec.BypassApps))
+				assert.Equal(t, appname, ruleset.Spec.BypassApps[0].AppName)
+			}
+		}
+	})
+
+	t.Run("happy path: the repository rulesets are written in a stable order", func(t *testing.T) {
+		scaffold := &Scaffold{
+			remote:                     NewScaffoldGoliacRemoteMock(),
+			loadUsersFromGithubOrgSaml: LoadGithubSamlUsersMock,
+		}
+		rRepo := &engine.GithubRepository{
+			Name:           "repo1",
+			BoolProperties: map[string]bool{"private": true},
+			RuleSets: map[string]*engine.GithubRuleSet{
+				"release": {
+					Name:        "release",
+					Enforcement: "active",
+					BypassApps:  map[string]string{"deploy-app": "always", "ci-app": "pull_request"},
+					Rules: map[string]entity.RuleSetParameters{
+						"required_signatures": {},
+						"deletion":            {},
+						"non_fast_forward":    {},
+					},
+				},
+				"main": {
+					Name:        "main",
+					Enforcement: "evaluate",
+					Rules: map[string]entity.RuleSetParameters{
+						"pull_request": {RequiredApprovingReviewCount: 1},
+						"creation":     {},
+					},
+				},
+			},
+		}
+
+		var first []byte
+		for i := 0; i < 20; i++ {
+			lRepo := &entity.Repository{}
+			scaffold.fillRepositorySpec(lRepo, rRepo, map[string]string{}, "", map[string]string{})
+			content, err := yaml.Marshal(lRepo)
+			assert.Nil(t, err)
+			if first == nil {
+				first = content
+				assert.Equal(t, "main", lRepo.Spec.Rulesets[0].Name)
+				assert.Equal(t, "creation", lRepo.Spec.Rulesets[0].Rules[0].Ruletype)
+				assert.Equal(t, "ci-app", lRepo.Spec.Rulesets[1].BypassApps[0].AppName)
+				assert.Equal(t, "deletion", lRepo.Spec.Rulesets[1].Rules[0].Ruletype)
+				continue
+			}
+			assert.Equal(t, string(first), string(content))
+		}
+	})
+
 	t.Run("happy path: test goliac.conf", func(t *testing.T) {
 		fs := memfs.New()
 		// MockGithubClient doesn't support concurrent access
@@ -426,4 +624,51 @@ func TestScaffoldFull(t *testing.T) {
 		assert.Equal(t, 2, len(teamDefinition.Spec.Owners))
 		assert.Equal(t, 2, len(teamDefinition.Spec.Members))
 	})
+	t.Run("happy path: test repositories permissions and properties", func(t *testing.T) {
+		fs := memfs.New()
+
+		scaffold := &Scaffold{
+			remote:                     NewScaffoldGoliacRemoteMockWithPermissions(),
+			loadUsersFromGithubOrgSaml: NoLoadGithubSamlUsersMock,
+		}
+
+		ctx := context.TODO()
+		err := scaffold.generate(ctx, fs, "admin", false)
+		assert.Nil(t, err)
+
+		found, err := utils.Exists(fs, "/users/external/outside1.yaml")
+		assert.Nil(t, err)
+		assert.Equal(t, true, found)
+
+		repo1, err := utils.ReadFile(fs, "/teams/regular/repo1.yaml")
+		assert.Nil(t, err)
+
+		var r1 entity.Repository
+		err = yaml.Unmarshal(repo1, &r1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"regular"}, r1.Spec.Admins) // the owner keeps its admin access
+		assert.Equal(t, []string{"admin"}, r1.Spec.Maintainers)
+		assert.Equal(t, []string{"other"}, r1.Spec.Triagers)
+		assert.Equal(t, 0, len(r1.Spec.Writers))
+		assert.Equal(t, []string{"outside1"}, r1.Spec.ExternalUserWriters)
+		assert.Equal(t, []string{"outside2"}, r1.Spec.ExternalUserReaders)
+		assert.Equal(t, true, r1.Spec.IsPublic)
+		assert.Equal(t, true, r1.Spec.AllowAutoMerge)
+		assert.Equal(t, true, r1.Spec.DeleteBranchOnMerge)
+		assert.Equal(t, false, r1.Spec.AllowUpdateBranch)
+
+		// archived repositories are not owned by a team
+		found, err = utils.Exists(fs, "/teams/regular/repo2.yaml")
+		assert.Nil(t, err)
+		assert.Equal(t, false, found)
+
+		repo2, err := utils.ReadFile(fs, "/archived/repo2.yaml")
+		assert.Nil(t, err)
+
+		var r2 entity.Repository
+		err = yaml.Unmarshal(repo2, &r2)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"regular"}, r2.Spec.Writers)
+		assert.Equal(t, false, r2.Spec.IsPublic)
+	})
 }
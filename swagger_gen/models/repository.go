@@ -29,6 +29,9 @@ type Repository struct {
 	// delete branch on merge
 	DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
 
+	// immutable
+	Immutable bool `json:"immutable"`
+
 	// name
 	Name string `json:"name,omitempty"`
 
@@ -35,6 +35,12 @@ type RepositoryDetails struct {
 	// delete branch on merge
 	DeleteBranchOnMerge bool `json:"deleteBranchOnMerge"`
 
+	// drift
+	Drift *RepositoryDrift `json:"drift,omitempty"`
+
+	// immutable
+	Immutable bool `json:"immutable"`
+
 	// name
 	Name string `json:"name,omitempty"`
 
@@ -53,6 +59,10 @@ func (m *RepositoryDetails) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateDrift(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTeams(formats); err != nil {
 		res = append(res, err)
 	}
@@ -89,6 +99,25 @@ func (m *RepositoryDetails) validateCollaborators(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *RepositoryDetails) validateDrift(formats strfmt.Registry) error {
+	if swag.IsZero(m.Drift) { // not required
+		return nil
+	}
+
+	if m.Drift != nil {
+		if err := m.Drift.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("drift")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("drift")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *RepositoryDetails) validateTeams(formats strfmt.Registry) error {
 	if swag.IsZero(m.Teams) { // not required
 		return nil
@@ -123,6 +152,10 @@ func (m *RepositoryDetails) ContextValidate(ctx context.Context, formats strfmt.
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateDrift(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateTeams(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -158,6 +191,27 @@ func (m *RepositoryDetails) contextValidateCollaborators(ctx context.Context, fo
 	return nil
 }
 
+func (m *RepositoryDetails) contextValidateDrift(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Drift != nil {
+
+		if swag.IsZero(m.Drift) { // not required
+			return nil
+		}
+
+		if err := m.Drift.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("drift")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("drift")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *RepositoryDetails) contextValidateTeams(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Teams); i++ {
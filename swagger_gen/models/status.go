@@ -7,6 +7,7 @@ package models
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -19,15 +20,27 @@ import (
 // swagger:model status
 type Status struct {
 
+	// circuit open
+	CircuitOpen bool `json:"circuitOpen"`
+
 	// detailed errors
 	DetailedErrors []string `json:"detailedErrors"`
 
 	// detailed warnings
 	DetailedWarnings []string `json:"detailedWarnings"`
 
+	// last sync duration ms
+	LastSyncDurationMs int64 `json:"lastSyncDurationMs"`
+
 	// last sync error
 	LastSyncError string `json:"lastSyncError,omitempty"`
 
+	// last sync operations
+	LastSyncOperations int64 `json:"lastSyncOperations"`
+
+	// last sync operations breakdown
+	LastSyncOperationsBreakdown map[string]map[string]int64 `json:"lastSyncOperationsBreakdown,omitempty"`
+
 	// last sync time
 	// Min Length: 1
 	LastSyncTime string `json:"lastSyncTime,omitempty"`
@@ -44,6 +57,15 @@ type Status struct {
 	// nb users external
 	NbUsersExternal int64 `json:"nbUsersExternal"`
 
+	// observe only
+	ObserveOnly bool `json:"observeOnly"`
+
+	// organizations
+	Organizations []*StatusOrganization `json:"organizations"`
+
+	// paused
+	Paused bool `json:"paused"`
+
 	// version
 	Version string `json:"version,omitempty"`
 }
@@ -56,6 +78,10 @@ func (m *Status) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateOrganizations(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -74,8 +100,68 @@ func (m *Status) validateLastSyncTime(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this status based on context it is used
+func (m *Status) validateOrganizations(formats strfmt.Registry) error {
+	if swag.IsZero(m.Organizations) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Organizations); i++ {
+		if swag.IsZero(m.Organizations[i]) { // not required
+			continue
+		}
+
+		if m.Organizations[i] != nil {
+			if err := m.Organizations[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("organizations" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("organizations" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this status based on the context it is used
 func (m *Status) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateOrganizations(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Status) contextValidateOrganizations(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Organizations); i++ {
+
+		if m.Organizations[i] != nil {
+
+			if swag.IsZero(m.Organizations[i]) { // not required
+				return nil
+			}
+
+			if err := m.Organizations[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("organizations" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("organizations" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
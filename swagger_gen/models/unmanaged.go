@@ -23,6 +23,9 @@ type Unmanaged struct {
 	// externally managed teams
 	ExternallyManagedTeams []string `json:"externally_managed_teams"`
 
+	// outside collaborators
+	OutsideCollaborators []string `json:"outside_collaborators"`
+
 	// repos
 	Repos []string `json:"repos"`
 
@@ -44,6 +47,10 @@ func (m *Unmanaged) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateOutsideCollaborators(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateRepos(formats); err != nil {
 		res = append(res, err)
 	}
@@ -82,6 +89,22 @@ func (m *Unmanaged) validateExternallyManagedTeams(formats strfmt.Registry) erro
 	return nil
 }
 
+func (m *Unmanaged) validateOutsideCollaborators(formats strfmt.Registry) error {
+	if swag.IsZero(m.OutsideCollaborators) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.OutsideCollaborators); i++ {
+
+		if err := validate.MinLength("outside_collaborators"+"."+strconv.Itoa(i), "body", m.OutsideCollaborators[i], 1); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 func (m *Unmanaged) validateRepos(formats strfmt.Registry) error {
 	if swag.IsZero(m.Repos) { // not required
 		return nil
@@ -40,6 +40,8 @@ func configureAPI(api *operations.GoliacAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	api.TxtProducer = runtime.TextProducer()
+
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
 
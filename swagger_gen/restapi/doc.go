@@ -15,6 +15,7 @@
 //
 //	Produces:
 //	  - application/json
+//	  - text/plain
 //
 // swagger:meta
 package restapi
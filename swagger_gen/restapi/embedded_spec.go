@@ -35,6 +35,60 @@ func init() {
   },
   "basePath": "/api/v1",
   "paths": {
+    "/apply": {
+      "post": {
+        "description": "Apply against Github, and wait for the result (unlike /resync)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postApply",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "apply even outside the apply windows (GOLIAC_SERVER_APPLY_WINDOWS)",
+            "name": "force",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the apply is done, with the applied operations",
+            "schema": {
+              "$ref": "#/definitions/change"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/changes": {
+      "get": {
+        "description": "Get the changes applied by the last runs",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getLastChanges",
+        "responses": {
+          "200": {
+            "description": "get the list of changes applied by the last runs",
+            "schema": {
+              "$ref": "#/definitions/changes"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/collaborators": {
       "get": {
         "description": "Get all external collaborators",
@@ -91,6 +145,88 @@ func init() {
         }
       }
     },
+    "/compliance": {
+      "get": {
+        "description": "Get the required files missing in the repositories managed by Goliac",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getComplianceReport",
+        "responses": {
+          "200": {
+            "description": "get the compliance report",
+            "schema": {
+              "$ref": "#/definitions/complianceReport"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/drift": {
+      "get": {
+        "description": "Get the managed entities differing from the teams repository (reconciliation dry-run against the cached Github state)",
+        "produces": [
+          "application/json",
+          "text/plain"
+        ],
+        "tags": [
+          "app"
+        ],
+        "operationId": "getDrift",
+        "responses": {
+          "200": {
+            "description": "get the entities out of sync, with the operations to reconcile them (as a unified-diff-like text with Accept text/plain)",
+            "schema": {
+              "$ref": "#/definitions/drift"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/expiringaccesses": {
+      "get": {
+        "description": "Get the external users accesses to the repositories expiring within the window (and the expired ones still declared)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getExpiringAccesses",
+        "parameters": [
+          {
+            "type": "integer",
+            "default": 30,
+            "description": "expiration window in days",
+            "name": "days",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "get the expiring external users accesses",
+            "schema": {
+              "$ref": "#/definitions/expiringAccesses"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/flushcache": {
       "post": {
         "description": "Flush the Github remote cache",
@@ -111,6 +247,58 @@ func init() {
         }
       }
     },
+    "/health": {
+      "get": {
+        "description": "Report the status of each Goliac dependency (local state, teams git repository, Github API, last apply)",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "all the dependencies are healthy",
+            "schema": {
+              "$ref": "#/definitions/healthDetails"
+            }
+          },
+          "503": {
+            "description": "at least one dependency is not healthy",
+            "schema": {
+              "$ref": "#/definitions/healthDetails"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/history": {
+      "get": {
+        "description": "Get the history of the changes applied (persisted across restarts)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getHistory",
+        "responses": {
+          "200": {
+            "description": "get the history of the changes applied",
+            "schema": {
+              "$ref": "#/definitions/changes"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/liveness": {
       "get": {
         "description": "Check if Goliac is healthy",
@@ -134,6 +322,26 @@ func init() {
         }
       }
     },
+    "/pause": {
+      "post": {
+        "description": "Pause the reconciliation with Github (until resumed)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postPause",
+        "responses": {
+          "200": {
+            "description": "reconciliation paused"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/readiness": {
       "get": {
         "description": "Check if Goliac is ready to serve",
@@ -195,6 +403,12 @@ func init() {
             "name": "repositoryID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "include the differences between the repository definition and Github",
+            "name": "includeDrift",
+            "in": "query"
           }
         ],
         "responses": {
@@ -213,6 +427,26 @@ func init() {
         }
       }
     },
+    "/resume": {
+      "post": {
+        "description": "Resume the reconciliation with Github",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postResume",
+        "responses": {
+          "200": {
+            "description": "reconciliation resumed"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/resync": {
       "post": {
         "description": "Ask to sync again against Github",
@@ -233,6 +467,29 @@ func init() {
         }
       }
     },
+    "/seats": {
+      "get": {
+        "description": "Get the seats consumed by the users managed by Goliac",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getSeatReport",
+        "responses": {
+          "200": {
+            "description": "get the seats report",
+            "schema": {
+              "$ref": "#/definitions/seatReport"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/statistics": {
       "get": {
         "description": "Get different statistics on Goliac",
@@ -335,6 +592,59 @@ func init() {
         }
       }
     },
+    "/teams/{teamID}/resync": {
+      "post": {
+        "description": "Reload a team from Github, and sync it (members and repositories access)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postResyncTeam",
+        "parameters": [
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "team slug",
+            "name": "teamID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "team synced"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/teamstree": {
+      "get": {
+        "description": "Get all teams as a tree",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getTeamsTree",
+        "responses": {
+          "200": {
+            "description": "get the teams hierarchy",
+            "schema": {
+              "$ref": "#/definitions/teamsTree"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/unmanaged": {
       "get": {
         "description": "Get unmanaged resources metrics",
@@ -358,6 +668,29 @@ func init() {
         }
       }
     },
+    "/unusedteams": {
+      "get": {
+        "description": "Get the teams without any repository access (neither owner, reader nor writer) nor subteam",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getUnusedTeams",
+        "responses": {
+          "200": {
+            "description": "get list of unused teams",
+            "schema": {
+              "$ref": "#/definitions/teams"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/users": {
       "get": {
         "description": "Get all users",
@@ -413,10 +746,114 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "collaboratorDetails": {
+    },
+    "/users/{userID}/repositories/{repositoryID}": {
+      "get": {
+        "description": "Get the effective permission of a user on a repository (through its teams, and as an external user)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getUserRepositoryAccess",
+        "parameters": [
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "user (or external user) name",
+            "name": "userID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "repository slug name",
+            "name": "repositoryID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "get the strongest permission of the user, and the grants giving it",
+            "schema": {
+              "$ref": "#/definitions/userRepositoryAccess"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "change": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "dryrun": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/changeOperation"
+          }
+        },
+        "timestamp": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "changeOperation": {
+      "type": "object",
+      "properties": {
+        "changes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "command": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "detail": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "organization": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        },
+        "simulated": {
+          "type": "boolean"
+        },
+        "skipped": {
+          "type": "boolean"
+        },
+        "timestamp": {
+          "type": "string"
+        }
+      }
+    },
+    "changes": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/change"
+      }
+    },
+    "collaboratorDetails": {
       "type": "object",
       "properties": {
         "githubid": {
@@ -431,6 +868,61 @@ func init() {
         }
       }
     },
+    "complianceReport": {
+      "type": "object",
+      "properties": {
+        "lastCheckTime": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repositories": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/complianceRepository"
+          }
+        }
+      }
+    },
+    "complianceRepository": {
+      "type": "object",
+      "properties": {
+        "missingFiles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "drift": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/entityDrift"
+      }
+    },
+    "entityDrift": {
+      "type": "object",
+      "properties": {
+        "entityType": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "operations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/changeOperation"
+          }
+        }
+      }
+    },
     "error": {
       "type": "object",
       "required": [
@@ -443,6 +935,41 @@ func init() {
         }
       }
     },
+    "expiringAccess": {
+      "type": "object",
+      "properties": {
+        "expired": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "expiresAt": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "githubid": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "permission": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repository": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "username": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "expiringAccesses": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/expiringAccess"
+      }
+    },
     "health": {
       "type": "object",
       "properties": {
@@ -451,6 +978,39 @@ func init() {
         }
       }
     },
+    "healthCheck": {
+      "type": "object",
+      "properties": {
+        "message": {
+          "type": "string"
+        },
+        "status": {
+          "description": "OK, KO or UNKNOWN",
+          "type": "string"
+        }
+      }
+    },
+    "healthDetails": {
+      "type": "object",
+      "properties": {
+        "githubApi": {
+          "$ref": "#/definitions/healthCheck"
+        },
+        "lastApply": {
+          "$ref": "#/definitions/healthCheck"
+        },
+        "localState": {
+          "$ref": "#/definitions/healthCheck"
+        },
+        "status": {
+          "description": "OK if all the checks are OK, else KO",
+          "type": "string"
+        },
+        "teamsRepository": {
+          "$ref": "#/definitions/healthCheck"
+        }
+      }
+    },
     "repositories": {
       "type": "array",
       "items": {
@@ -480,6 +1040,11 @@ func init() {
           "x-isnullable": false,
           "x-omitempty": false
         },
+        "immutable": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
         "name": {
           "type": "string",
           "x-isnullable": false
@@ -530,6 +1095,14 @@ func init() {
           "x-isnullable": false,
           "x-omitempty": false
         },
+        "drift": {
+          "$ref": "#/definitions/repositoryDrift"
+        },
+        "immutable": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
         "name": {
           "type": "string",
           "x-isnullable": false
@@ -557,6 +1130,75 @@ func init() {
         }
       }
     },
+    "repositoryDrift": {
+      "type": "object",
+      "properties": {
+        "fields": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "local": {
+                "type": "string"
+              },
+              "name": {
+                "type": "string"
+              },
+              "remote": {
+                "type": "string"
+              }
+            }
+          }
+        },
+        "inSync": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        }
+      }
+    },
+    "seatReport": {
+      "type": "object",
+      "properties": {
+        "activeSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "inactiveSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "inactiveUsers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "teams": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/seatReportTeam"
+          }
+        },
+        "totalSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        }
+      }
+    },
+    "seatReportTeam": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "seats": {
+          "type": "integer",
+          "x-omitempty": false
+        }
+      }
+    },
     "statistics": {
       "properties": {
         "lastGithubApiCalls": {
@@ -588,6 +1230,10 @@ func init() {
     "status": {
       "type": "object",
       "properties": {
+        "circuitOpen": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
         "detailedErrors": {
           "type": "array",
           "items": {
@@ -600,9 +1246,26 @@ func init() {
             "type": "string"
           }
         },
+        "lastSyncDurationMs": {
+          "type": "integer",
+          "x-omitempty": false
+        },
         "lastSyncError": {
           "type": "string"
         },
+        "lastSyncOperations": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "lastSyncOperationsBreakdown": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "integer"
+            }
+          }
+        },
         "lastSyncTime": {
           "type": "string",
           "minLength": 1
@@ -623,11 +1286,41 @@ func init() {
           "type": "integer",
           "x-omitempty": false
         },
+        "observeOnly": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "organizations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/statusOrganization"
+          }
+        },
+        "paused": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
         "version": {
           "type": "string"
         }
       }
     },
+    "statusOrganization": {
+      "type": "object",
+      "properties": {
+        "lastSyncError": {
+          "type": "string"
+        },
+        "lastSyncOperations": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
     "team": {
       "type": "object",
       "properties": {
@@ -704,15 +1397,57 @@ func init() {
             }
           }
         },
-        "path": {
+        "path": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repositories": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/repository"
+          }
+        }
+      }
+    },
+    "teamTreeNode": {
+      "type": "object",
+      "properties": {
+        "children": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/teamTreeNode"
+          }
+        },
+        "externallyManaged": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
+        "members": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "name": {
           "type": "string",
           "x-isnullable": false
         },
-        "repositories": {
+        "owners": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/repository"
+            "type": "string",
+            "minLength": 1
           }
+        },
+        "ownersTeam": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "path": {
+          "type": "string",
+          "x-isnullable": false
         }
       }
     },
@@ -722,6 +1457,12 @@ func init() {
         "$ref": "#/definitions/team"
       }
     },
+    "teamsTree": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/teamTreeNode"
+      }
+    },
     "unmanaged": {
       "properties": {
         "externally_managed_teams": {
@@ -731,6 +1472,13 @@ func init() {
             "minLength": 1
           }
         },
+        "outside_collaborators": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
         "repos": {
           "type": "array",
           "items": {
@@ -795,6 +1543,46 @@ func init() {
         }
       }
     },
+    "userRepositoryAccess": {
+      "type": "object",
+      "properties": {
+        "grants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/userRepositoryGrant"
+          }
+        },
+        "permission": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repository": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "user": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "userRepositoryGrant": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "permission": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "source": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
     "users": {
       "type": "array",
       "items": {
@@ -841,6 +1629,60 @@ func init() {
   },
   "basePath": "/api/v1",
   "paths": {
+    "/apply": {
+      "post": {
+        "description": "Apply against Github, and wait for the result (unlike /resync)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postApply",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "apply even outside the apply windows (GOLIAC_SERVER_APPLY_WINDOWS)",
+            "name": "force",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the apply is done, with the applied operations",
+            "schema": {
+              "$ref": "#/definitions/change"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/changes": {
+      "get": {
+        "description": "Get the changes applied by the last runs",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getLastChanges",
+        "responses": {
+          "200": {
+            "description": "get the list of changes applied by the last runs",
+            "schema": {
+              "$ref": "#/definitions/changes"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/collaborators": {
       "get": {
         "description": "Get all external collaborators",
@@ -897,6 +1739,89 @@ func init() {
         }
       }
     },
+    "/compliance": {
+      "get": {
+        "description": "Get the required files missing in the repositories managed by Goliac",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getComplianceReport",
+        "responses": {
+          "200": {
+            "description": "get the compliance report",
+            "schema": {
+              "$ref": "#/definitions/complianceReport"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/drift": {
+      "get": {
+        "description": "Get the managed entities differing from the teams repository (reconciliation dry-run against the cached Github state)",
+        "produces": [
+          "application/json",
+          "text/plain"
+        ],
+        "tags": [
+          "app"
+        ],
+        "operationId": "getDrift",
+        "responses": {
+          "200": {
+            "description": "get the entities out of sync, with the operations to reconcile them (as a unified-diff-like text with Accept text/plain)",
+            "schema": {
+              "$ref": "#/definitions/drift"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/expiringaccesses": {
+      "get": {
+        "description": "Get the external users accesses to the repositories expiring within the window (and the expired ones still declared)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getExpiringAccesses",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 30,
+            "description": "expiration window in days",
+            "name": "days",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "get the expiring external users accesses",
+            "schema": {
+              "$ref": "#/definitions/expiringAccesses"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/flushcache": {
       "post": {
         "description": "Flush the Github remote cache",
@@ -917,6 +1842,58 @@ func init() {
         }
       }
     },
+    "/health": {
+      "get": {
+        "description": "Report the status of each Goliac dependency (local state, teams git repository, Github API, last apply)",
+        "tags": [
+          "health"
+        ],
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "all the dependencies are healthy",
+            "schema": {
+              "$ref": "#/definitions/healthDetails"
+            }
+          },
+          "503": {
+            "description": "at least one dependency is not healthy",
+            "schema": {
+              "$ref": "#/definitions/healthDetails"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/history": {
+      "get": {
+        "description": "Get the history of the changes applied (persisted across restarts)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getHistory",
+        "responses": {
+          "200": {
+            "description": "get the history of the changes applied",
+            "schema": {
+              "$ref": "#/definitions/changes"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/liveness": {
       "get": {
         "description": "Check if Goliac is healthy",
@@ -940,6 +1917,26 @@ func init() {
         }
       }
     },
+    "/pause": {
+      "post": {
+        "description": "Pause the reconciliation with Github (until resumed)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postPause",
+        "responses": {
+          "200": {
+            "description": "reconciliation paused"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/readiness": {
       "get": {
         "description": "Check if Goliac is ready to serve",
@@ -1001,14 +1998,60 @@ func init() {
             "name": "repositoryID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "include the differences between the repository definition and Github",
+            "name": "includeDrift",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "get repository details especially teams that have access",
+            "schema": {
+              "$ref": "#/definitions/repositoryDetails"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/resume": {
+      "post": {
+        "description": "Resume the reconciliation with Github",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postResume",
+        "responses": {
+          "200": {
+            "description": "reconciliation resumed"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
+        }
+      }
+    },
+    "/resync": {
+      "post": {
+        "description": "Ask to sync again against Github",
+        "tags": [
+          "app"
         ],
+        "operationId": "postResync",
         "responses": {
           "200": {
-            "description": "get repository details especially teams that have access",
-            "schema": {
-              "$ref": "#/definitions/repositoryDetails"
-            }
+            "description": "resync in progress"
           },
           "default": {
             "description": "generic error response",
@@ -1019,16 +2062,19 @@ func init() {
         }
       }
     },
-    "/resync": {
-      "post": {
-        "description": "Ask to sync again against Github",
+    "/seats": {
+      "get": {
+        "description": "Get the seats consumed by the users managed by Goliac",
         "tags": [
           "app"
         ],
-        "operationId": "postResync",
+        "operationId": "getSeatReport",
         "responses": {
           "200": {
-            "description": "resync in progress"
+            "description": "get the seats report",
+            "schema": {
+              "$ref": "#/definitions/seatReport"
+            }
           },
           "default": {
             "description": "generic error response",
@@ -1141,6 +2187,59 @@ func init() {
         }
       }
     },
+    "/teams/{teamID}/resync": {
+      "post": {
+        "description": "Reload a team from Github, and sync it (members and repositories access)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "postResyncTeam",
+        "parameters": [
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "team slug",
+            "name": "teamID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "team synced"
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/teamstree": {
+      "get": {
+        "description": "Get all teams as a tree",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getTeamsTree",
+        "responses": {
+          "200": {
+            "description": "get the teams hierarchy",
+            "schema": {
+              "$ref": "#/definitions/teamsTree"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/unmanaged": {
       "get": {
         "description": "Get unmanaged resources metrics",
@@ -1164,6 +2263,29 @@ func init() {
         }
       }
     },
+    "/unusedteams": {
+      "get": {
+        "description": "Get the teams without any repository access (neither owner, reader nor writer) nor subteam",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getUnusedTeams",
+        "responses": {
+          "200": {
+            "description": "get list of unused teams",
+            "schema": {
+              "$ref": "#/definitions/teams"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/users": {
       "get": {
         "description": "Get all users",
@@ -1219,103 +2341,344 @@ func init() {
           }
         }
       }
+    },
+    "/users/{userID}/repositories/{repositoryID}": {
+      "get": {
+        "description": "Get the effective permission of a user on a repository (through its teams, and as an external user)",
+        "tags": [
+          "app"
+        ],
+        "operationId": "getUserRepositoryAccess",
+        "parameters": [
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "user (or external user) name",
+            "name": "userID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "minLength": 1,
+            "type": "string",
+            "description": "repository slug name",
+            "name": "repositoryID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "get the strongest permission of the user, and the grants giving it",
+            "schema": {
+              "$ref": "#/definitions/userRepositoryAccess"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
     "RepositoryDetailsCollaboratorsItems0": {
       "type": "object",
       "properties": {
-        "access": {
-          "type": "string",
-          "minLength": 1
+        "access": {
+          "type": "string",
+          "minLength": 1
+        },
+        "name": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "RepositoryDetailsTeamsItems0": {
+      "type": "object",
+      "properties": {
+        "access": {
+          "type": "string",
+          "minLength": 1
+        },
+        "name": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "RepositoryDriftFieldsItems0": {
+      "type": "object",
+      "properties": {
+        "local": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "remote": {
+          "type": "string"
+        }
+      }
+    },
+    "TeamDetailsMembersItems0": {
+      "type": "object",
+      "properties": {
+        "external": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
+        "githubid": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "name": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "TeamDetailsOwnersItems0": {
+      "type": "object",
+      "properties": {
+        "external": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
+        "githubid": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "name": {
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "change": {
+      "type": "object",
+      "properties": {
+        "author": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "dryrun": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "operations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/changeOperation"
+          }
+        },
+        "timestamp": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "changeOperation": {
+      "type": "object",
+      "properties": {
+        "changes": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "command": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "detail": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "organization": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        },
+        "simulated": {
+          "type": "boolean"
+        },
+        "skipped": {
+          "type": "boolean"
+        },
+        "timestamp": {
+          "type": "string"
+        }
+      }
+    },
+    "changes": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/change"
+      }
+    },
+    "collaboratorDetails": {
+      "type": "object",
+      "properties": {
+        "githubid": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repositories": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/repository"
+          }
+        }
+      }
+    },
+    "complianceReport": {
+      "type": "object",
+      "properties": {
+        "lastCheckTime": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repositories": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/complianceRepository"
+          }
+        }
+      }
+    },
+    "complianceRepository": {
+      "type": "object",
+      "properties": {
+        "missingFiles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
         "name": {
           "type": "string",
-          "minLength": 1
+          "x-isnullable": false
         }
       }
     },
-    "RepositoryDetailsTeamsItems0": {
+    "drift": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/entityDrift"
+      }
+    },
+    "entityDrift": {
       "type": "object",
       "properties": {
-        "access": {
+        "entityType": {
           "type": "string",
-          "minLength": 1
+          "x-isnullable": false
         },
         "name": {
           "type": "string",
-          "minLength": 1
+          "x-isnullable": false
+        },
+        "operations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/changeOperation"
+          }
         }
       }
     },
-    "TeamDetailsMembersItems0": {
+    "error": {
       "type": "object",
+      "required": [
+        "message"
+      ],
       "properties": {
-        "external": {
-          "type": "boolean",
-          "x-isnullable": false,
-          "x-omitempty": false
-        },
-        "githubid": {
-          "type": "string",
-          "x-isnullable": false
-        },
-        "name": {
+        "message": {
           "type": "string",
           "minLength": 1
         }
       }
     },
-    "TeamDetailsOwnersItems0": {
+    "expiringAccess": {
       "type": "object",
       "properties": {
-        "external": {
+        "expired": {
           "type": "boolean",
-          "x-isnullable": false,
           "x-omitempty": false
         },
+        "expiresAt": {
+          "type": "string",
+          "x-isnullable": false
+        },
         "githubid": {
           "type": "string",
           "x-isnullable": false
         },
-        "name": {
+        "permission": {
           "type": "string",
-          "minLength": 1
+          "x-isnullable": false
+        },
+        "repository": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "username": {
+          "type": "string",
+          "x-isnullable": false
         }
       }
     },
-    "collaboratorDetails": {
+    "expiringAccesses": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/expiringAccess"
+      }
+    },
+    "health": {
       "type": "object",
       "properties": {
-        "githubid": {
-          "type": "string",
-          "x-isnullable": false
-        },
-        "repositories": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/repository"
-          }
+        "status": {
+          "type": "string"
         }
       }
     },
-    "error": {
+    "healthCheck": {
       "type": "object",
-      "required": [
-        "message"
-      ],
       "properties": {
         "message": {
-          "type": "string",
-          "minLength": 1
+          "type": "string"
+        },
+        "status": {
+          "description": "OK, KO or UNKNOWN",
+          "type": "string"
         }
       }
     },
-    "health": {
+    "healthDetails": {
       "type": "object",
       "properties": {
+        "githubApi": {
+          "$ref": "#/definitions/healthCheck"
+        },
+        "lastApply": {
+          "$ref": "#/definitions/healthCheck"
+        },
+        "localState": {
+          "$ref": "#/definitions/healthCheck"
+        },
         "status": {
+          "description": "OK if all the checks are OK, else KO",
           "type": "string"
+        },
+        "teamsRepository": {
+          "$ref": "#/definitions/healthCheck"
         }
       }
     },
@@ -1348,6 +2711,11 @@ func init() {
           "x-isnullable": false,
           "x-omitempty": false
         },
+        "immutable": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
         "name": {
           "type": "string",
           "x-isnullable": false
@@ -1388,6 +2756,14 @@ func init() {
           "x-isnullable": false,
           "x-omitempty": false
         },
+        "drift": {
+          "$ref": "#/definitions/repositoryDrift"
+        },
+        "immutable": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
         "name": {
           "type": "string",
           "x-isnullable": false
@@ -1405,6 +2781,64 @@ func init() {
         }
       }
     },
+    "repositoryDrift": {
+      "type": "object",
+      "properties": {
+        "fields": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RepositoryDriftFieldsItems0"
+          }
+        },
+        "inSync": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        }
+      }
+    },
+    "seatReport": {
+      "type": "object",
+      "properties": {
+        "activeSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "inactiveSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "inactiveUsers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "teams": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/seatReportTeam"
+          }
+        },
+        "totalSeats": {
+          "type": "integer",
+          "x-omitempty": false
+        }
+      }
+    },
+    "seatReportTeam": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "seats": {
+          "type": "integer",
+          "x-omitempty": false
+        }
+      }
+    },
     "statistics": {
       "properties": {
         "lastGithubApiCalls": {
@@ -1436,6 +2870,10 @@ func init() {
     "status": {
       "type": "object",
       "properties": {
+        "circuitOpen": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
         "detailedErrors": {
           "type": "array",
           "items": {
@@ -1448,9 +2886,26 @@ func init() {
             "type": "string"
           }
         },
+        "lastSyncDurationMs": {
+          "type": "integer",
+          "x-omitempty": false
+        },
         "lastSyncError": {
           "type": "string"
         },
+        "lastSyncOperations": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "lastSyncOperationsBreakdown": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "object",
+            "additionalProperties": {
+              "type": "integer"
+            }
+          }
+        },
         "lastSyncTime": {
           "type": "string",
           "minLength": 1
@@ -1471,11 +2926,41 @@ func init() {
           "type": "integer",
           "x-omitempty": false
         },
+        "observeOnly": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
+        "organizations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/statusOrganization"
+          }
+        },
+        "paused": {
+          "type": "boolean",
+          "x-omitempty": false
+        },
         "version": {
           "type": "string"
         }
       }
     },
+    "statusOrganization": {
+      "type": "object",
+      "properties": {
+        "lastSyncError": {
+          "type": "string"
+        },
+        "lastSyncOperations": {
+          "type": "integer",
+          "x-omitempty": false
+        },
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
     "team": {
       "type": "object",
       "properties": {
@@ -1534,12 +3019,60 @@ func init() {
         }
       }
     },
+    "teamTreeNode": {
+      "type": "object",
+      "properties": {
+        "children": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/teamTreeNode"
+          }
+        },
+        "externallyManaged": {
+          "type": "boolean",
+          "x-isnullable": false,
+          "x-omitempty": false
+        },
+        "members": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "owners": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "ownersTeam": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "path": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
     "teams": {
       "type": "array",
       "items": {
         "$ref": "#/definitions/team"
       }
     },
+    "teamsTree": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/teamTreeNode"
+      }
+    },
     "unmanaged": {
       "properties": {
         "externally_managed_teams": {
@@ -1549,6 +3082,13 @@ func init() {
             "minLength": 1
           }
         },
+        "outside_collaborators": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
         "repos": {
           "type": "array",
           "items": {
@@ -1613,6 +3153,46 @@ func init() {
         }
       }
     },
+    "userRepositoryAccess": {
+      "type": "object",
+      "properties": {
+        "grants": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/userRepositoryGrant"
+          }
+        },
+        "permission": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "repository": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "user": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
+    "userRepositoryGrant": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "permission": {
+          "type": "string",
+          "x-isnullable": false
+        },
+        "source": {
+          "type": "string",
+          "x-isnullable": false
+        }
+      }
+    },
     "users": {
       "type": "array",
       "items": {
@@ -9,8 +9,10 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -31,6 +33,10 @@ type GetRepositoryParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*include the differences between the repository definition and Github
+	  In: query
+	*/
+	IncludeDrift *bool
 	/*repository slug name
 	  Required: true
 	  Min Length: 1
@@ -48,6 +54,13 @@ func (o *GetRepositoryParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qIncludeDrift, qhkIncludeDrift, _ := qs.GetOK("includeDrift")
+	if err := o.bindIncludeDrift(qIncludeDrift, qhkIncludeDrift, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rRepositoryID, rhkRepositoryID, _ := route.Params.GetOK("repositoryID")
 	if err := o.bindRepositoryID(rRepositoryID, rhkRepositoryID, route.Formats); err != nil {
 		res = append(res, err)
@@ -58,6 +71,29 @@ func (o *GetRepositoryParams) BindRequest(r *http.Request, route *middleware.Mat
 	return nil
 }
 
+// bindIncludeDrift binds and validates parameter IncludeDrift from query.
+func (o *GetRepositoryParams) bindIncludeDrift(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeDrift", "query", "bool", raw)
+	}
+	o.IncludeDrift = &value
+
+	return nil
+}
+
 // bindRepositoryID binds and validates parameter RepositoryID from path.
 func (o *GetRepositoryParams) bindRepositoryID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -10,12 +10,16 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // GetRepositoryURL generates an URL for the get repository operation
 type GetRepositoryURL struct {
 	RepositoryID string
 
+	IncludeDrift *bool
+
 	_basePath string
 	// avoid unkeyed usage
 	_ struct{}
@@ -55,6 +59,18 @@ func (o *GetRepositoryURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var includeDriftQ string
+	if o.IncludeDrift != nil {
+		includeDriftQ = swag.FormatBool(*o.IncludeDrift)
+	}
+	if includeDriftQ != "" {
+		qs.Set("includeDrift", includeDriftQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -44,6 +44,7 @@ func NewGoliacAPI(spec *loads.Document) *GoliacAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		JSONProducer: runtime.JSONProducer(),
+		TxtProducer:  runtime.TextProducer(),
 
 		AppGetCollaboratorHandler: app.GetCollaboratorHandlerFunc(func(params app.GetCollaboratorParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetCollaborator has not yet been implemented")
@@ -51,6 +52,24 @@ func NewGoliacAPI(spec *loads.Document) *GoliacAPI {
 		AppGetCollaboratorsHandler: app.GetCollaboratorsHandlerFunc(func(params app.GetCollaboratorsParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetCollaborators has not yet been implemented")
 		}),
+		AppGetComplianceReportHandler: app.GetComplianceReportHandlerFunc(func(params app.GetComplianceReportParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetComplianceReport has not yet been implemented")
+		}),
+		AppGetDriftHandler: app.GetDriftHandlerFunc(func(params app.GetDriftParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetDrift has not yet been implemented")
+		}),
+		AppGetExpiringAccessesHandler: app.GetExpiringAccessesHandlerFunc(func(params app.GetExpiringAccessesParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetExpiringAccesses has not yet been implemented")
+		}),
+		HealthGetHealthHandler: health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
+			return middleware.NotImplemented("operation health.GetHealth has not yet been implemented")
+		}),
+		AppGetHistoryHandler: app.GetHistoryHandlerFunc(func(params app.GetHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetHistory has not yet been implemented")
+		}),
+		AppGetLastChangesHandler: app.GetLastChangesHandlerFunc(func(params app.GetLastChangesParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetLastChanges has not yet been implemented")
+		}),
 		HealthGetLivenessHandler: health.GetLivenessHandlerFunc(func(params health.GetLivenessParams) middleware.Responder {
 			return middleware.NotImplemented("operation health.GetLiveness has not yet been implemented")
 		}),
@@ -63,6 +82,9 @@ func NewGoliacAPI(spec *loads.Document) *GoliacAPI {
 		AppGetRepositoryHandler: app.GetRepositoryHandlerFunc(func(params app.GetRepositoryParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetRepository has not yet been implemented")
 		}),
+		AppGetSeatReportHandler: app.GetSeatReportHandlerFunc(func(params app.GetSeatReportParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetSeatReport has not yet been implemented")
+		}),
 		AppGetStatiticsHandler: app.GetStatiticsHandlerFunc(func(params app.GetStatiticsParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetStatitics has not yet been implemented")
 		}),
@@ -75,21 +97,42 @@ func NewGoliacAPI(spec *loads.Document) *GoliacAPI {
 		AppGetTeamsHandler: app.GetTeamsHandlerFunc(func(params app.GetTeamsParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetTeams has not yet been implemented")
 		}),
+		AppGetTeamsTreeHandler: app.GetTeamsTreeHandlerFunc(func(params app.GetTeamsTreeParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetTeamsTree has not yet been implemented")
+		}),
 		AppGetUnmanagedHandler: app.GetUnmanagedHandlerFunc(func(params app.GetUnmanagedParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetUnmanaged has not yet been implemented")
 		}),
+		AppGetUnusedTeamsHandler: app.GetUnusedTeamsHandlerFunc(func(params app.GetUnusedTeamsParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetUnusedTeams has not yet been implemented")
+		}),
 		AppGetUserHandler: app.GetUserHandlerFunc(func(params app.GetUserParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetUser has not yet been implemented")
 		}),
+		AppGetUserRepositoryAccessHandler: app.GetUserRepositoryAccessHandlerFunc(func(params app.GetUserRepositoryAccessParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.GetUserRepositoryAccess has not yet been implemented")
+		}),
 		AppGetUsersHandler: app.GetUsersHandlerFunc(func(params app.GetUsersParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.GetUsers has not yet been implemented")
 		}),
+		AppPostApplyHandler: app.PostApplyHandlerFunc(func(params app.PostApplyParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.PostApply has not yet been implemented")
+		}),
 		AppPostFlushCacheHandler: app.PostFlushCacheHandlerFunc(func(params app.PostFlushCacheParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.PostFlushCache has not yet been implemented")
 		}),
+		AppPostPauseHandler: app.PostPauseHandlerFunc(func(params app.PostPauseParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.PostPause has not yet been implemented")
+		}),
+		AppPostResumeHandler: app.PostResumeHandlerFunc(func(params app.PostResumeParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.PostResume has not yet been implemented")
+		}),
 		AppPostResyncHandler: app.PostResyncHandlerFunc(func(params app.PostResyncParams) middleware.Responder {
 			return middleware.NotImplemented("operation app.PostResync has not yet been implemented")
 		}),
+		AppPostResyncTeamHandler: app.PostResyncTeamHandlerFunc(func(params app.PostResyncTeamParams) middleware.Responder {
+			return middleware.NotImplemented("operation app.PostResyncTeam has not yet been implemented")
+		}),
 	}
 }
 
@@ -126,11 +169,26 @@ type GoliacAPI struct {
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
 
 	// AppGetCollaboratorHandler sets the operation handler for the get collaborator operation
 	AppGetCollaboratorHandler app.GetCollaboratorHandler
 	// AppGetCollaboratorsHandler sets the operation handler for the get collaborators operation
 	AppGetCollaboratorsHandler app.GetCollaboratorsHandler
+	// AppGetComplianceReportHandler sets the operation handler for the get compliance report operation
+	AppGetComplianceReportHandler app.GetComplianceReportHandler
+	// AppGetDriftHandler sets the operation handler for the get drift operation
+	AppGetDriftHandler app.GetDriftHandler
+	// AppGetExpiringAccessesHandler sets the operation handler for the get expiring accesses operation
+	AppGetExpiringAccessesHandler app.GetExpiringAccessesHandler
+	// HealthGetHealthHandler sets the operation handler for the get health operation
+	HealthGetHealthHandler health.GetHealthHandler
+	// AppGetHistoryHandler sets the operation handler for the get history operation
+	AppGetHistoryHandler app.GetHistoryHandler
+	// AppGetLastChangesHandler sets the operation handler for the get last changes operation
+	AppGetLastChangesHandler app.GetLastChangesHandler
 	// HealthGetLivenessHandler sets the operation handler for the get liveness operation
 	HealthGetLivenessHandler health.GetLivenessHandler
 	// HealthGetReadinessHandler sets the operation handler for the get readiness operation
@@ -139,6 +197,8 @@ type GoliacAPI struct {
 	AppGetRepositoriesHandler app.GetRepositoriesHandler
 	// AppGetRepositoryHandler sets the operation handler for the get repository operation
 	AppGetRepositoryHandler app.GetRepositoryHandler
+	// AppGetSeatReportHandler sets the operation handler for the get seat report operation
+	AppGetSeatReportHandler app.GetSeatReportHandler
 	// AppGetStatiticsHandler sets the operation handler for the get statitics operation
 	AppGetStatiticsHandler app.GetStatiticsHandler
 	// AppGetStatusHandler sets the operation handler for the get status operation
@@ -147,16 +207,30 @@ type GoliacAPI struct {
 	AppGetTeamHandler app.GetTeamHandler
 	// AppGetTeamsHandler sets the operation handler for the get teams operation
 	AppGetTeamsHandler app.GetTeamsHandler
+	// AppGetTeamsTreeHandler sets the operation handler for the get teams tree operation
+	AppGetTeamsTreeHandler app.GetTeamsTreeHandler
 	// AppGetUnmanagedHandler sets the operation handler for the get unmanaged operation
 	AppGetUnmanagedHandler app.GetUnmanagedHandler
+	// AppGetUnusedTeamsHandler sets the operation handler for the get unused teams operation
+	AppGetUnusedTeamsHandler app.GetUnusedTeamsHandler
 	// AppGetUserHandler sets the operation handler for the get user operation
 	AppGetUserHandler app.GetUserHandler
+	// AppGetUserRepositoryAccessHandler sets the operation handler for the get user repository access operation
+	AppGetUserRepositoryAccessHandler app.GetUserRepositoryAccessHandler
 	// AppGetUsersHandler sets the operation handler for the get users operation
 	AppGetUsersHandler app.GetUsersHandler
+	// AppPostApplyHandler sets the operation handler for the post apply operation
+	AppPostApplyHandler app.PostApplyHandler
 	// AppPostFlushCacheHandler sets the operation handler for the post flush cache operation
 	AppPostFlushCacheHandler app.PostFlushCacheHandler
+	// AppPostPauseHandler sets the operation handler for the post pause operation
+	AppPostPauseHandler app.PostPauseHandler
+	// AppPostResumeHandler sets the operation handler for the post resume operation
+	AppPostResumeHandler app.PostResumeHandler
 	// AppPostResyncHandler sets the operation handler for the post resync operation
 	AppPostResyncHandler app.PostResyncHandler
+	// AppPostResyncTeamHandler sets the operation handler for the post resync team operation
+	AppPostResyncTeamHandler app.PostResyncTeamHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -233,6 +307,9 @@ func (o *GoliacAPI) Validate() error {
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 
 	if o.AppGetCollaboratorHandler == nil {
 		unregistered = append(unregistered, "app.GetCollaboratorHandler")
@@ -240,6 +317,24 @@ func (o *GoliacAPI) Validate() error {
 	if o.AppGetCollaboratorsHandler == nil {
 		unregistered = append(unregistered, "app.GetCollaboratorsHandler")
 	}
+	if o.AppGetComplianceReportHandler == nil {
+		unregistered = append(unregistered, "app.GetComplianceReportHandler")
+	}
+	if o.AppGetDriftHandler == nil {
+		unregistered = append(unregistered, "app.GetDriftHandler")
+	}
+	if o.AppGetExpiringAccessesHandler == nil {
+		unregistered = append(unregistered, "app.GetExpiringAccessesHandler")
+	}
+	if o.HealthGetHealthHandler == nil {
+		unregistered = append(unregistered, "health.GetHealthHandler")
+	}
+	if o.AppGetHistoryHandler == nil {
+		unregistered = append(unregistered, "app.GetHistoryHandler")
+	}
+	if o.AppGetLastChangesHandler == nil {
+		unregistered = append(unregistered, "app.GetLastChangesHandler")
+	}
 	if o.HealthGetLivenessHandler == nil {
 		unregistered = append(unregistered, "health.GetLivenessHandler")
 	}
@@ -252,6 +347,9 @@ func (o *GoliacAPI) Validate() error {
 	if o.AppGetRepositoryHandler == nil {
 		unregistered = append(unregistered, "app.GetRepositoryHandler")
 	}
+	if o.AppGetSeatReportHandler == nil {
+		unregistered = append(unregistered, "app.GetSeatReportHandler")
+	}
 	if o.AppGetStatiticsHandler == nil {
 		unregistered = append(unregistered, "app.GetStatiticsHandler")
 	}
@@ -264,21 +362,42 @@ func (o *GoliacAPI) Validate() error {
 	if o.AppGetTeamsHandler == nil {
 		unregistered = append(unregistered, "app.GetTeamsHandler")
 	}
+	if o.AppGetTeamsTreeHandler == nil {
+		unregistered = append(unregistered, "app.GetTeamsTreeHandler")
+	}
 	if o.AppGetUnmanagedHandler == nil {
 		unregistered = append(unregistered, "app.GetUnmanagedHandler")
 	}
+	if o.AppGetUnusedTeamsHandler == nil {
+		unregistered = append(unregistered, "app.GetUnusedTeamsHandler")
+	}
 	if o.AppGetUserHandler == nil {
 		unregistered = append(unregistered, "app.GetUserHandler")
 	}
+	if o.AppGetUserRepositoryAccessHandler == nil {
+		unregistered = append(unregistered, "app.GetUserRepositoryAccessHandler")
+	}
 	if o.AppGetUsersHandler == nil {
 		unregistered = append(unregistered, "app.GetUsersHandler")
 	}
+	if o.AppPostApplyHandler == nil {
+		unregistered = append(unregistered, "app.PostApplyHandler")
+	}
 	if o.AppPostFlushCacheHandler == nil {
 		unregistered = append(unregistered, "app.PostFlushCacheHandler")
 	}
+	if o.AppPostPauseHandler == nil {
+		unregistered = append(unregistered, "app.PostPauseHandler")
+	}
+	if o.AppPostResumeHandler == nil {
+		unregistered = append(unregistered, "app.PostResumeHandler")
+	}
 	if o.AppPostResyncHandler == nil {
 		unregistered = append(unregistered, "app.PostResyncHandler")
 	}
+	if o.AppPostResyncTeamHandler == nil {
+		unregistered = append(unregistered, "app.PostResyncTeamHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -327,6 +446,8 @@ func (o *GoliacAPI) ProducersFor(mediaTypes []string) map[string]runtime.Produce
 		switch mt {
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -378,6 +499,30 @@ func (o *GoliacAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/compliance"] = app.NewGetComplianceReport(o.context, o.AppGetComplianceReportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/drift"] = app.NewGetDrift(o.context, o.AppGetDriftHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/expiringaccesses"] = app.NewGetExpiringAccesses(o.context, o.AppGetExpiringAccessesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/health"] = health.NewGetHealth(o.context, o.HealthGetHealthHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/history"] = app.NewGetHistory(o.context, o.AppGetHistoryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/changes"] = app.NewGetLastChanges(o.context, o.AppGetLastChangesHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/liveness"] = health.NewGetLiveness(o.context, o.HealthGetLivenessHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -394,6 +539,10 @@ func (o *GoliacAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/seats"] = app.NewGetSeatReport(o.context, o.AppGetSeatReportHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/statistics"] = app.NewGetStatitics(o.context, o.AppGetStatiticsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -410,23 +559,51 @@ func (o *GoliacAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/teamstree"] = app.NewGetTeamsTree(o.context, o.AppGetTeamsTreeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/unmanaged"] = app.NewGetUnmanaged(o.context, o.AppGetUnmanagedHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/unusedteams"] = app.NewGetUnusedTeams(o.context, o.AppGetUnusedTeamsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/users/{userID}"] = app.NewGetUser(o.context, o.AppGetUserHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/users/{userID}/repositories/{repositoryID}"] = app.NewGetUserRepositoryAccess(o.context, o.AppGetUserRepositoryAccessHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/users"] = app.NewGetUsers(o.context, o.AppGetUsersHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/apply"] = app.NewPostApply(o.context, o.AppPostApplyHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/flushcache"] = app.NewPostFlushCache(o.context, o.AppPostFlushCacheHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/pause"] = app.NewPostPause(o.context, o.AppPostPauseHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/resume"] = app.NewPostResume(o.context, o.AppPostResumeHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/resync"] = app.NewPostResync(o.context, o.AppPostResyncHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/teams/{teamID}/resync"] = app.NewPostResyncTeam(o.context, o.AppPostResyncTeamHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP